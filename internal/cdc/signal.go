@@ -0,0 +1,96 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Signal types that are written to and read from a signal table. A signal
+// table has the columns id, type and data, all of which are strings.
+const (
+	// SignalExecuteSnapshot requests an incremental snapshot of tables, and is
+	// inserted by users.
+	SignalExecuteSnapshot = "execute-snapshot"
+	// SignalSnapshotWindowOpen is the low watermark of a snapshot chunk, and
+	// is inserted by inputs.
+	SignalSnapshotWindowOpen = "snapshot-window-open"
+	// SignalSnapshotWindowClose is the high watermark of a snapshot chunk, and
+	// is inserted by inputs.
+	SignalSnapshotWindowClose = "snapshot-window-close"
+)
+
+// Signal is a row of a signal table.
+type Signal struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// SignalFromRow extracts a signal from the columns of a row that was inserted
+// into a signal table.
+func SignalFromRow(row map[string]any) (Signal, error) {
+	var s Signal
+	for _, f := range []struct {
+		name string
+		dst  *string
+	}{
+		{"id", &s.ID},
+		{"type", &s.Type},
+		{"data", &s.Data},
+	} {
+		switch v := row[f.name].(type) {
+		case string:
+			*f.dst = v
+		case []byte:
+			*f.dst = string(v)
+		case nil:
+		default:
+			return s, fmt.Errorf("expected signal column %v to be a string, got %T", f.name, v)
+		}
+	}
+	if s.Type == "" {
+		return s, errors.New("signal is missing a type")
+	}
+	return s, nil
+}
+
+// SnapshotRequest is the data of an execute-snapshot signal, which lists the
+// tables to snapshot and optional filters to apply to the rows of each table.
+type SnapshotRequest struct {
+	Tables  []string          `json:"tables"`
+	Filters map[string]string `json:"filters"`
+}
+
+// ParseSnapshotRequest parses the data of an execute-snapshot signal, e.g.
+// `{"tables":["foo","bar"],"filters":{"foo":"created_at > '2025-01-01'"}}`.
+func ParseSnapshotRequest(data string) (*SnapshotRequest, error) {
+	var req SnapshotRequest
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot request: %w", err)
+	}
+	if len(req.Tables) == 0 {
+		return nil, errors.New("snapshot request does not list any tables")
+	}
+	for table := range req.Filters {
+		if !slices.Contains(req.Tables, table) {
+			return nil, fmt.Errorf("snapshot request contains a filter for table %v, which is not listed", table)
+		}
+	}
+	return &req, nil
+}
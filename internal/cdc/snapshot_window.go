@@ -0,0 +1,141 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// SnapshotWindow deduplicates the rows of an incremental snapshot chunk against
+// changes that are streamed concurrently, following the watermark approach of
+// DBLog (https://arxiv.org/pdf/2010.12597).
+//
+// Before a chunk is queried a low watermark is written to the signal table,
+// and once the query completes a high watermark is written. Any row of the
+// chunk that is changed between the two watermarks in the stream is dropped
+// from the chunk, as the streamed change is more recent, and the remaining
+// rows are emitted by the stream when it reaches the high watermark.
+type SnapshotWindow struct {
+	mu sync.Mutex
+
+	id      string
+	table   string
+	keyCols []string
+
+	open   bool
+	seen   map[string]struct{}
+	rows   []map[string]any
+	closed chan struct{}
+}
+
+// Prepare resets the window for the next chunk of a table, identified by the
+// id of its watermarks. The returned channel is closed once the stream reaches
+// the high watermark of the chunk.
+func (w *SnapshotWindow) Prepare(id, table string, keyCols []string) <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.id = id
+	w.table = table
+	w.keyCols = keyCols
+	w.open = false
+	w.seen = map[string]struct{}{}
+	w.rows = nil
+	w.closed = make(chan struct{})
+	return w.closed
+}
+
+// SetRows sets the rows of the chunk, which must be called after the chunk is
+// queried and before the high watermark is written.
+func (w *SnapshotWindow) SetRows(rows []map[string]any) {
+	w.mu.Lock()
+	w.rows = rows
+	w.mu.Unlock()
+}
+
+// Open is called by the stream when it reaches a low watermark.
+func (w *SnapshotWindow) Open(id string) {
+	w.mu.Lock()
+	if id == w.id && w.closed != nil {
+		w.open = true
+	}
+	w.mu.Unlock()
+}
+
+// Observe is called by the stream for each change of a row, and drops the row
+// from the chunk when the window is open.
+func (w *SnapshotWindow) Observe(table string, row map[string]any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.open || table != w.table {
+		return
+	}
+	if key, ok := RowKey(w.keyCols, row); ok {
+		w.seen[key] = struct{}{}
+	}
+}
+
+// Close is called by the stream when it reaches a high watermark, and returns
+// the table of the chunk along with its rows that were not changed within the
+// window. The table is returned here as the window is prepared for the next
+// chunk as soon as it is closed. Nil rows are returned if the watermark does
+// not belong to the current chunk.
+func (w *SnapshotWindow) Close(id string) (table string, rows []map[string]any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if id != w.id || !w.open {
+		return "", nil
+	}
+
+	rows = make([]map[string]any, 0, len(w.rows))
+	for _, row := range w.rows {
+		if key, ok := RowKey(w.keyCols, row); ok {
+			if _, changed := w.seen[key]; changed {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	w.open = false
+	w.seen = nil
+	w.rows = nil
+	close(w.closed)
+	w.closed = nil
+	return w.table, rows
+}
+
+// RowKey returns a key that identifies a row by its primary key columns. The
+// values are JSON encoded so that values decoded from a snapshot query and
+// from a change stream compare equal despite differing in type, e.g. int32
+// and int64.
+func RowKey(keyCols []string, row map[string]any) (string, bool) {
+	values := make([]any, len(keyCols))
+	for i, col := range keyCols {
+		v, ok := row[col]
+		if !ok {
+			return "", false
+		}
+		values[i] = v
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
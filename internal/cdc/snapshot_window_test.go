@@ -0,0 +1,133 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotWindowDeduplicates(t *testing.T) {
+	var w SnapshotWindow
+	closed := w.Prepare("a", "foo", []string{"id"})
+
+	// Changes before the low watermark are older than the chunk.
+	w.Observe("foo", map[string]any{"id": int64(1)})
+	w.Open("a")
+	w.Observe("foo", map[string]any{"id": int32(2)})
+	w.Observe("bar", map[string]any{"id": int64(3)})
+	w.SetRows([]map[string]any{
+		{"id": 1, "v": "a"},
+		{"id": 2, "v": "b"},
+		{"id": 3, "v": "c"},
+	})
+
+	table, rows := w.Close("b")
+	assert.Empty(t, table)
+	assert.Nil(t, rows)
+	select {
+	case <-closed:
+		t.Fatal("window closed by the wrong watermark")
+	default:
+	}
+
+	table, rows = w.Close("a")
+	assert.Equal(t, "foo", table)
+	assert.Equal(t, []map[string]any{
+		{"id": 1, "v": "a"},
+		{"id": 3, "v": "c"},
+	}, rows)
+	select {
+	case <-closed:
+	default:
+		t.Fatal("window not closed")
+	}
+
+	// Watermarks replayed after the chunk are ignored.
+	w.Open("a")
+	_, rows = w.Close("a")
+	assert.Nil(t, rows)
+}
+
+func TestSnapshotWindowNotOpened(t *testing.T) {
+	var w SnapshotWindow
+	_ = w.Prepare("a", "foo", []string{"id"})
+	w.SetRows([]map[string]any{{"id": 1}})
+	_, rows := w.Close("a")
+	assert.Nil(t, rows)
+}
+
+func TestSnapshotWindowCloseRacesPrepare(t *testing.T) {
+	var w SnapshotWindow
+	for i := range 100 {
+		id := strconv.Itoa(i)
+		table := "table_" + id
+		closed := w.Prepare(id, table, []string{"id"})
+		w.Open(id)
+		w.SetRows([]map[string]any{{"id": i}})
+
+		// The snapshotter prepares the next chunk as soon as the window is
+		// closed, which must not change the table of the closed chunk.
+		next := make(chan struct{})
+		go func() {
+			defer close(next)
+			<-closed
+			_ = w.Prepare(id+"_next", "other", []string{"id"})
+		}()
+
+		closedTable, rows := w.Close(id)
+		<-next
+		assert.Equal(t, table, closedTable)
+		assert.Equal(t, []map[string]any{{"id": i}}, rows)
+	}
+}
+
+func TestRowKey(t *testing.T) {
+	a, ok := RowKey([]string{"a", "b"}, map[string]any{"a": int64(1), "b": "x", "c": true})
+	require.True(t, ok)
+	b, ok := RowKey([]string{"a", "b"}, map[string]any{"a": 1, "b": "x"})
+	require.True(t, ok)
+	assert.Equal(t, a, b)
+
+	_, ok = RowKey([]string{"a", "b"}, map[string]any{"a": 1})
+	assert.False(t, ok)
+}
+
+func TestParseSnapshotRequest(t *testing.T) {
+	req, err := ParseSnapshotRequest(`{"tables":["foo","bar"],"filters":{"foo":"id > 10"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, &SnapshotRequest{
+		Tables:  []string{"foo", "bar"},
+		Filters: map[string]string{"foo": "id > 10"},
+	}, req)
+
+	_, err = ParseSnapshotRequest(`{"tables":[]}`)
+	require.ErrorContains(t, err, "does not list any tables")
+
+	_, err = ParseSnapshotRequest(`{"tables":["foo"],"filters":{"bar":"id > 10"}}`)
+	require.ErrorContains(t, err, "table bar")
+}
+
+func TestSignalFromRow(t *testing.T) {
+	s, err := SignalFromRow(map[string]any{"id": "a", "type": []byte(SignalExecuteSnapshot), "data": nil})
+	require.NoError(t, err)
+	assert.Equal(t, Signal{ID: "a", Type: SignalExecuteSnapshot}, s)
+
+	_, err = SignalFromRow(map[string]any{"id": "a"})
+	require.Error(t, err)
+}
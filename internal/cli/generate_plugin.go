@@ -28,7 +28,7 @@ func pluginInit() *cli.Command {
 		},
 		&cli.StringFlag{
 			Name:  "component",
-			Usage: "The type of component to generate. Supported components are: input, output, processor.",
+			Usage: "The type of component to generate. Supported components are: input, output, processor, cache, rate_limit.",
 			Value: "processor",
 		},
 	}
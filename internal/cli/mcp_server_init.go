@@ -74,6 +74,22 @@ meta:
   mcp:
     enabled: true
     description: An example cache for saving information.
+    resource:
+      enabled: true
+`,
+	"prompts/example-prompt.yaml": `name: example-prompt
+description: An example prompt that asks for a summary of a topic.
+tags: [ example ]
+arguments:
+  - name: topic
+    type: string
+    description: The topic to summarise.
+    required: true
+  - name: words
+    type: number
+    description: The maximum number of words in the summary.
+mapping: |
+  root = "Summarise %v in no more than %v words.".format(this.topic, this.words.or(100))
 `,
 	"resources/processors/example-processor.yaml": `label: example-processor
 try:
@@ -0,0 +1,978 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/checkpoint"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/connect/v4/internal/impl/aws/config"
+)
+
+const (
+	ddbsiFieldTable            = "table"
+	ddbsiFieldStreamARN        = "stream_arn"
+	ddbsiFieldCheckpointTable  = "checkpoint_table"
+	ddbsiFieldCheckpointLimit  = "checkpoint_limit"
+	ddbsiFieldCommitPeriod     = "commit_period"
+	ddbsiFieldStealGracePeriod = "steal_grace_period"
+	ddbsiFieldLeasePeriod      = "lease_period"
+	ddbsiFieldRebalancePeriod  = "rebalance_period"
+	ddbsiFieldStartFromOldest  = "start_from_oldest"
+	ddbsiFieldBatching         = "batching"
+
+	// The sequence stored within the checkpoint of a shard that has been
+	// consumed in its entirety.
+	ddbsiShardEnd = "SHARD_END"
+
+	metricDynamoDBStreamsShardsPerClient = "dynamodb_streams_client_shards"
+)
+
+func dynamoDBStreamsInputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.73.0").
+		Categories("Services", "AWS").
+		Summary("Consumes the changes made to a DynamoDB table from its stream.").
+		Description(`
+Consumes the item level changes of a table from its DynamoDB stream, which must be enabled on the table. Shards of the stream are automatically balanced across instances of this input by coordinating through a <<table-schema,DynamoDB checkpoint table>>, where the latest sequence consumed from each shard is stored so that consumption resumes from the correct record after restarts.
+
+Redpanda Connect will not store a consumed sequence unless it is acknowledged at the output level, which ensures at-least-once delivery guarantees.
+
+== Shard Lineage
+
+The shards of a stream are periodically closed and replaced by child shards. In order to preserve the order of changes to each item a shard is only consumed once its parent shard has been consumed in its entirety, at which point the checkpoint of the parent is marked as finished.
+
+== Message Contents
+
+The body of each message is the new image of the item as plain JSON, or the old image for `+"`REMOVE`"+` events, depending on the `+"`StreamViewType`"+` of the stream. When the stream only contains the keys of items the body is the keys of the item.
+
+This input adds the following metadata fields to each message:
+
+- dynamodb_event_id
+- dynamodb_event_name (one of `+"`INSERT`, `MODIFY` or `REMOVE`"+`)
+- dynamodb_keys (the keys of the item as a structured object)
+- dynamodb_old_image (the old image of a modified item as a structured object, when available)
+- dynamodb_sequence_number
+- dynamodb_shard
+- dynamodb_stream
+- dynamodb_stream_view_type
+- dynamodb_approximate_creation_time
+- dynamodb_user_identity_type (for items removed by a TTL, when available)
+- dynamodb_user_identity_principal (for items removed by a TTL, when available)
+
+You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+== Table Schema
+
+It's possible to configure Redpanda Connect to create the DynamoDB table required for coordination if it does not already exist. However, if you wish to create this yourself (recommended) then create a table with a string HASH key `+"`StreamID`"+` and a string RANGE key `+"`ShardID`"+`. The same table can be shared with `+"`aws_kinesis`"+` inputs.
+
+== Batching
+
+Use the `+"`batching`"+` fields to configure an optional xref:configuration:batching.adoc#batch-policy[batching policy]. Each stream shard will be batched separately in order to ensure that acknowledgements aren't contaminated.
+`).
+		Fields(
+			service.NewStringField(ddbsiFieldTable).
+				Description("The name of the table to consume the stream of. Either this field or `stream_arn` must be set.").
+				Optional(),
+			service.NewStringField(ddbsiFieldStreamARN).
+				Description("The ARN of the stream to consume. Either this field or `table` must be set.").
+				Example("arn:aws:dynamodb:us-east-1:111122223333:table/Music/stream/2025-01-01T00:00:00.000").
+				Optional(),
+			service.NewObjectField(ddbsiFieldCheckpointTable, kinesisInputDynamoDBFields()...).
+				Description("Determines the table used for storing and accessing the latest consumed sequence for shards, and for coordinating consumers of the stream."),
+			service.NewIntField(ddbsiFieldCheckpointLimit).
+				Description("The maximum gap between the in flight sequence versus the latest acknowledged sequence at a given time. Increasing this limit enables parallel processing and batching at the output level to work on individual shards. Any given sequence will not be committed unless all messages under that offset are delivered in order to preserve at least once delivery guarantees.").
+				Default(1024),
+			service.NewAutoRetryNacksToggleField(),
+			service.NewDurationField(ddbsiFieldCommitPeriod).
+				Description("The period of time between each update to the checkpoint table.").
+				Default("5s"),
+			service.NewDurationField(ddbsiFieldStealGracePeriod).
+				Description("Determines how long beyond the next commit period a client will wait when stealing a shard for the current owner to store a checkpoint. A longer value increases the time taken to balance shards but reduces the likelihood of processing duplicate messages.").
+				Default("2s").
+				Advanced(),
+			service.NewDurationField(ddbsiFieldRebalancePeriod).
+				Description("The period of time between each attempt to discover new shards and rebalance shards across clients.").
+				Default("30s").
+				Advanced(),
+			service.NewDurationField(ddbsiFieldLeasePeriod).
+				Description("The period of time after which a client that has failed to update a shard checkpoint is assumed to be inactive.").
+				Default("30s").
+				Advanced(),
+			service.NewBoolField(ddbsiFieldStartFromOldest).
+				Description("Whether to consume from the oldest record of the stream when no checkpoints exist, otherwise only changes made after the input starts are consumed. Child shards of consumed shards are always consumed from their oldest record.").
+				Default(true),
+		).
+		Fields(config.SessionFields()...).
+		Field(service.NewBatchPolicyField(ddbsiFieldBatching))
+}
+
+func init() {
+	service.MustRegisterBatchInput("aws_dynamodb_streams", dynamoDBStreamsInputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+			r, err := newDynamoDBStreamsReaderFromParsed(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacksBatchedToggled(conf, r)
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type dynamoDBStreamsReader struct {
+	table           string
+	streamARN       string
+	ddbConf         kiddbConfig
+	checkpointLimit int
+	startFromOldest bool
+	clientID        string
+
+	commitPeriod     time.Duration
+	stealGracePeriod time.Duration
+	leasePeriod      time.Duration
+	rebalancePeriod  time.Duration
+
+	sess    aws.Config
+	ddbSess aws.Config
+	batcher service.BatchPolicy
+	log     *service.Logger
+	mgr     *service.Resources
+
+	svc          *dynamodbstreams.Client
+	checkpointer *awsKinesisCheckpointer
+
+	cMut    sync.Mutex
+	msgChan chan asyncMessage
+
+	// Signalled when a consumer finishes a shard, as its children can then be
+	// claimed without waiting for the rebalance period.
+	rebalanceChan chan struct{}
+
+	ctx  context.Context
+	done func()
+
+	closedChan chan struct{}
+
+	clientShardsMetric *service.MetricGauge
+}
+
+func newDynamoDBStreamsReaderFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*dynamoDBStreamsReader, error) {
+	r := &dynamoDBStreamsReader{
+		log:           mgr.Logger(),
+		mgr:           mgr,
+		rebalanceChan: make(chan struct{}, 1),
+		closedChan:    make(chan struct{}),
+	}
+
+	var err error
+	if pConf.Contains(ddbsiFieldTable) {
+		if r.table, err = pConf.FieldString(ddbsiFieldTable); err != nil {
+			return nil, err
+		}
+	}
+	if pConf.Contains(ddbsiFieldStreamARN) {
+		if r.streamARN, err = pConf.FieldString(ddbsiFieldStreamARN); err != nil {
+			return nil, err
+		}
+	}
+	if (r.table == "") == (r.streamARN == "") {
+		return nil, errors.New("exactly one of table or stream_arn must be set")
+	}
+
+	if r.ddbConf, err = kinesisInputDynamoDBConfigFromParsed(pConf.Namespace(ddbsiFieldCheckpointTable)); err != nil {
+		return nil, err
+	}
+	if r.ddbConf.Table == "" {
+		return nil, errors.New("a checkpoint table must be specified")
+	}
+	if r.checkpointLimit, err = pConf.FieldInt(ddbsiFieldCheckpointLimit); err != nil {
+		return nil, err
+	}
+	if r.commitPeriod, err = pConf.FieldDuration(ddbsiFieldCommitPeriod); err != nil {
+		return nil, err
+	}
+	if r.stealGracePeriod, err = pConf.FieldDuration(ddbsiFieldStealGracePeriod); err != nil {
+		return nil, err
+	}
+	if r.rebalancePeriod, err = pConf.FieldDuration(ddbsiFieldRebalancePeriod); err != nil {
+		return nil, err
+	}
+	if r.leasePeriod, err = pConf.FieldDuration(ddbsiFieldLeasePeriod); err != nil {
+		return nil, err
+	}
+	if r.startFromOldest, err = pConf.FieldBool(ddbsiFieldStartFromOldest); err != nil {
+		return nil, err
+	}
+	if r.batcher, err = pConf.FieldBatchPolicy(ddbsiFieldBatching); err != nil {
+		return nil, err
+	}
+	if r.batcher.IsNoop() {
+		r.batcher.Count = 1
+	}
+
+	if r.sess, err = GetSession(context.TODO(), pConf); err != nil {
+		return nil, err
+	}
+	ddbCredsConf := pConf.Namespace(ddbsiFieldCheckpointTable)
+	if ddbCredsConf.Contains("region") || ddbCredsConf.Contains("endpoint") || ddbCredsConf.Contains("credentials") {
+		if r.ddbSess, err = GetSession(context.TODO(), ddbCredsConf); err != nil {
+			return nil, err
+		}
+	} else {
+		r.ddbSess = r.sess
+	}
+
+	u4, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	r.clientID = u4.String()
+	r.ctx, r.done = context.WithCancel(context.Background())
+
+	r.clientShardsMetric = mgr.Metrics().NewGauge(metricDynamoDBStreamsShardsPerClient)
+	return r, nil
+}
+
+//------------------------------------------------------------------------------
+
+// ddbsiShard describes a shard that can be consumed, which is the case once
+// its parent has been consumed in its entirety.
+type ddbsiShard struct {
+	id string
+
+	// Whether the shard should be consumed from the latest record when there
+	// is no checkpoint sequence.
+	fromLatest bool
+
+	// The client holding a claim on the shard, which is empty when the shard
+	// is unclaimed.
+	claimedBy    string
+	leaseExpired bool
+}
+
+// ddbsiConsumableShards determines which shards of a stream can be consumed
+// given the checkpoints of the stream.
+func ddbsiConsumableShards(shards []types.Shard, checkpoints map[string]awsKinesisCheckpoint, startFromOldest bool, leasePeriod time.Duration) []ddbsiShard {
+	known := make(map[string]types.Shard, len(shards))
+	for _, s := range shards {
+		known[aws.ToString(s.ShardId)] = s
+	}
+
+	// When consuming from the latest records closed shards that have never
+	// been consumed are skipped, and are treated as finished so that their
+	// children can be consumed. A shard whose parent was consumed is never
+	// skipped, as otherwise falling behind by more than one generation of
+	// shards would lose the records of the shards in between.
+	skippedCache := map[string]bool{}
+	var skipped func(id string) bool
+	skipped = func(id string) bool {
+		if startFromOldest {
+			return false
+		}
+		if v, exists := skippedCache[id]; exists {
+			return v
+		}
+		skippedCache[id] = false
+
+		if _, exists := checkpoints[id]; exists {
+			return false
+		}
+		s, exists := known[id]
+		if !exists || s.SequenceNumberRange == nil || s.SequenceNumberRange.EndingSequenceNumber == nil {
+			return false
+		}
+		if parentID := aws.ToString(s.ParentShardId); parentID != "" {
+			if _, exists := known[parentID]; exists && !skipped(parentID) {
+				return false
+			}
+		}
+		skippedCache[id] = true
+		return true
+	}
+	finished := func(id string) bool {
+		if cp, exists := checkpoints[id]; exists && cp.SequenceNumber == ddbsiShardEnd {
+			return true
+		}
+		return skipped(id)
+	}
+
+	var consumable []ddbsiShard
+	for _, s := range shards {
+		id := aws.ToString(s.ShardId)
+		if finished(id) {
+			continue
+		}
+
+		shard := ddbsiShard{id: id, fromLatest: !startFromOldest}
+		if parentID := aws.ToString(s.ParentShardId); parentID != "" {
+			if _, exists := known[parentID]; exists {
+				if !finished(parentID) {
+					continue
+				}
+				shard.fromLatest = shard.fromLatest && skipped(parentID)
+			}
+		}
+
+		if cp, exists := checkpoints[id]; exists && cp.ClientID != nil {
+			shard.claimedBy = *cp.ClientID
+			shard.leaseExpired = cp.LeaseTimeout == nil || time.Since(*cp.LeaseTimeout) > leasePeriod*2
+		}
+		consumable = append(consumable, shard)
+	}
+	return consumable
+}
+
+func (r *dynamoDBStreamsReader) listShards(ctx context.Context) ([]types.Shard, error) {
+	var shards []types.Shard
+	input := &dynamodbstreams.DescribeStreamInput{
+		StreamArn: &r.streamARN,
+	}
+	for {
+		res, err := r.svc.DescribeStream(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, res.StreamDescription.Shards...)
+		if res.StreamDescription.LastEvaluatedShardId == nil {
+			return shards, nil
+		}
+		input.ExclusiveStartShardId = res.StreamDescription.LastEvaluatedShardId
+	}
+}
+
+func (r *dynamoDBStreamsReader) rebalance(wg *sync.WaitGroup) error {
+	shards, err := r.listShards(r.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list shards: %w", err)
+	}
+	checkpoints, err := r.checkpointer.AllCheckpoints(r.ctx, r.streamARN)
+	if err != nil {
+		return fmt.Errorf("failed to obtain checkpoints: %w", err)
+	}
+
+	consumable := ddbsiConsumableShards(shards, checkpoints, r.startFromOldest, r.leasePeriod)
+
+	clientClaims := map[string][]ddbsiShard{}
+	var unclaimed []ddbsiShard
+	for _, s := range consumable {
+		if s.claimedBy == "" || (s.leaseExpired && s.claimedBy != r.clientID) {
+			unclaimed = append(unclaimed, s)
+			continue
+		}
+		clientClaims[s.claimedBy] = append(clientClaims[s.claimedBy], s)
+	}
+	r.clientShardsMetric.Set(int64(len(clientClaims[r.clientID])))
+
+	if len(unclaimed) > 0 {
+		for _, s := range unclaimed {
+			sequence, err := r.checkpointer.Claim(r.ctx, r.streamARN, s.id, s.claimedBy)
+			if err != nil {
+				if r.ctx.Err() != nil {
+					return nil
+				}
+				if !errors.Is(err, ErrLeaseNotAcquired) {
+					r.log.Errorf("Failed to claim unclaimed shard '%v': %v", s.id, err)
+				}
+				continue
+			}
+			r.runConsumer(wg, s, sequence)
+		}
+		// If there are unclaimed shards then let's not resort to thievery
+		// just yet.
+		return nil
+	}
+
+	// Steal a shard from a client with at least two more shards than we have,
+	// so that we don't play hot potatoes with an odd shard.
+	selfClaims := len(clientClaims[r.clientID])
+	for clientID, claims := range clientClaims {
+		if clientID == r.clientID || len(claims) <= selfClaims+1 {
+			continue
+		}
+
+		s := claims[rand.Intn(len(claims))]
+		r.log.Debugf("Attempting to steal shard '%v' from client '%v' as client '%v'", s.id, clientID, r.clientID)
+
+		sequence, err := r.checkpointer.Claim(r.ctx, r.streamARN, s.id, clientID)
+		if err != nil {
+			if r.ctx.Err() != nil {
+				return nil
+			}
+			if !errors.Is(err, ErrLeaseNotAcquired) {
+				r.log.Errorf("Failed to steal shard '%v': %v", s.id, err)
+			}
+			continue
+		}
+		r.runConsumer(wg, s, sequence)
+		break
+	}
+	return nil
+}
+
+func (r *dynamoDBStreamsReader) runBalancedShards() {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(r.msgChan)
+		close(r.closedChan)
+	}()
+
+	for {
+		if err := r.rebalance(&wg); err != nil {
+			if r.ctx.Err() != nil {
+				return
+			}
+			r.log.Errorf("Failed to rebalance shards of stream '%v': %v", r.streamARN, err)
+		}
+
+		select {
+		case <-time.After(r.rebalancePeriod):
+		case <-r.rebalanceChan:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type ddbsiConsumerState int
+
+const (
+	ddbsiConsumerConsuming ddbsiConsumerState = iota
+	ddbsiConsumerYielding
+	ddbsiConsumerFinished
+	ddbsiConsumerClosing
+)
+
+// ddbsiShardConsumer consumes the records of a single shard, tracking the
+// latest acknowledged sequence for checkpointing.
+type ddbsiShardConsumer struct {
+	r     *dynamoDBStreamsReader
+	shard ddbsiShard
+
+	batchPolicy  *service.Batcher
+	checkpointer *checkpoint.Capped[string]
+	commitTicker *time.Ticker
+	state        ddbsiConsumerState
+
+	batchedSequence string
+
+	ackedSequence string
+	ackedMut      sync.Mutex
+	ackedWG       sync.WaitGroup
+}
+
+func (r *dynamoDBStreamsReader) runConsumer(wg *sync.WaitGroup, shard ddbsiShard, sequence string) {
+	release := func(seq string) {
+		if _, err := r.checkpointer.Checkpoint(context.Background(), r.streamARN, shard.id, seq, true); err != nil {
+			r.log.Errorf("Failed to gracefully yield checkpoint: %v", err)
+		}
+	}
+
+	if sequence == ddbsiShardEnd {
+		// The shard was finished by another client since it was listed.
+		release(sequence)
+		return
+	}
+
+	batchPolicy, err := r.batcher.NewBatcher(r.mgr)
+	if err != nil {
+		r.log.Errorf("Failed to initialize batch policy for shard consumer: %v", err)
+		release(sequence)
+		return
+	}
+
+	c := &ddbsiShardConsumer{
+		r:             r,
+		shard:         shard,
+		batchPolicy:   batchPolicy,
+		checkpointer:  checkpoint.NewCapped[string](int64(r.checkpointLimit)),
+		ackedSequence: sequence,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.run(sequence)
+	}()
+}
+
+func (c *ddbsiShardConsumer) getSequence() string {
+	c.ackedMut.Lock()
+	defer c.ackedMut.Unlock()
+	return c.ackedSequence
+}
+
+func (c *ddbsiShardConsumer) getIter(sequence string) (string, error) {
+	input := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         &c.r.streamARN,
+		ShardId:           &c.shard.id,
+		ShardIteratorType: types.ShardIteratorTypeTrimHorizon,
+	}
+	if sequence != "" {
+		input.ShardIteratorType = types.ShardIteratorTypeAfterSequenceNumber
+		input.SequenceNumber = &sequence
+	} else if c.shard.fromLatest {
+		input.ShardIteratorType = types.ShardIteratorTypeLatest
+	}
+
+	res, err := c.r.svc.GetShardIterator(c.r.ctx, input)
+	if err != nil {
+		return "", err
+	}
+	if res.ShardIterator == nil {
+		return "", errors.New("failed to obtain shard iterator")
+	}
+	return *res.ShardIterator, nil
+}
+
+// commit stores the latest acknowledged sequence, returning false if the shard
+// has been claimed by another client.
+func (c *ddbsiShardConsumer) commit() bool {
+	stillOwned, err := c.r.checkpointer.Checkpoint(c.r.ctx, c.r.streamARN, c.shard.id, c.getSequence(), false)
+	if err != nil {
+		c.r.log.Errorf("Failed to store checkpoint for DynamoDB stream '%v' shard '%v': %v", c.r.streamARN, c.shard.id, err)
+		return true
+	}
+	if !stillOwned {
+		c.state = ddbsiConsumerYielding
+	}
+	return stillOwned
+}
+
+// dispatch sends a batch to the input, returning false when the consumer should
+// stop.
+func (c *ddbsiShardConsumer) dispatch(batch service.MessageBatch) bool {
+	if len(batch) == 0 {
+		return true
+	}
+
+	var resolveFn func() *string
+	for {
+		// Tracking blocks whilst the checkpoint limit is reached, during which
+		// we must continue to commit in order to retain the shard.
+		trackCtx, done := context.WithTimeout(c.r.ctx, c.r.commitPeriod)
+		var err error
+		resolveFn, err = c.checkpointer.Track(trackCtx, c.batchedSequence, int64(len(batch)))
+		done()
+		if err == nil {
+			break
+		}
+		if c.r.ctx.Err() != nil {
+			c.state = ddbsiConsumerClosing
+			return false
+		}
+		if !c.commit() {
+			return false
+		}
+	}
+
+	c.ackedWG.Add(1)
+	msg := asyncMessage{
+		msg: batch,
+		ackFn: func(context.Context, error) error {
+			if topSequence := resolveFn(); topSequence != nil {
+				c.ackedMut.Lock()
+				c.ackedSequence = *topSequence
+				c.ackedMut.Unlock()
+			}
+			c.ackedWG.Done()
+			return nil
+		},
+	}
+
+	for {
+		select {
+		case c.r.msgChan <- msg:
+			return true
+		case <-c.commitTicker.C:
+			if !c.commit() {
+				return false
+			}
+		case <-c.r.ctx.Done():
+			c.state = ddbsiConsumerClosing
+			return false
+		}
+	}
+}
+
+func (c *ddbsiShardConsumer) flush() bool {
+	batch, err := c.batchPolicy.Flush(c.r.ctx)
+	if err != nil {
+		c.r.log.Errorf("Failed to flush batch: %v", err)
+	}
+	return c.dispatch(batch)
+}
+
+func (c *ddbsiShardConsumer) close() {
+	r := c.r
+	defer func() {
+		_ = c.batchPolicy.Close(context.Background())
+		select {
+		case r.rebalanceChan <- struct{}{}:
+		default:
+		}
+	}()
+
+	reason := ""
+	switch c.state {
+	case ddbsiConsumerFinished:
+		reason = " because the shard is closed"
+
+		acked := make(chan struct{})
+		go func() {
+			c.ackedWG.Wait()
+			close(acked)
+		}()
+		sequence := ddbsiShardEnd
+		select {
+		case <-acked:
+		case <-r.ctx.Done():
+			sequence = c.getSequence()
+		}
+		if _, err := r.checkpointer.Checkpoint(context.Background(), r.streamARN, c.shard.id, sequence, true); err != nil {
+			r.log.Errorf("Failed to store final checkpoint for stream '%v' shard '%v': %v", r.streamARN, c.shard.id, err)
+		}
+	case ddbsiConsumerYielding:
+		reason = " because the shard has been claimed by another client"
+		if err := r.checkpointer.Yield(r.ctx, r.streamARN, c.shard.id, c.getSequence()); err != nil {
+			r.log.Errorf("Failed to yield checkpoint for stolen stream '%v' shard '%v': %v", r.streamARN, c.shard.id, err)
+		}
+	default:
+		reason = " because the pipeline is shutting down"
+		if _, err := r.checkpointer.Checkpoint(context.Background(), r.streamARN, c.shard.id, c.getSequence(), true); err != nil {
+			r.log.Errorf("Failed to store final checkpoint for stream '%v' shard '%v': %v", r.streamARN, c.shard.id, err)
+		}
+	}
+	r.log.Debugf("Closing stream '%v' shard '%v' as client '%v'%v", r.streamARN, c.shard.id, r.clientID, reason)
+}
+
+func (c *ddbsiShardConsumer) run(sequence string) {
+	r := c.r
+	c.commitTicker = time.NewTicker(r.commitPeriod)
+	defer c.commitTicker.Stop()
+	defer c.close()
+
+	r.log.Debugf("Consuming stream '%v' shard '%v' as client '%v'", r.streamARN, c.shard.id, r.clientID)
+
+	iter, err := c.getIter(sequence)
+	if err != nil {
+		r.log.Errorf("Failed to obtain iterator for stream '%v' shard '%v': %v", r.streamARN, c.shard.id, err)
+		c.state = ddbsiConsumerClosing
+		return
+	}
+
+	boff := backoff.NewExponentialBackOff()
+	boff.InitialInterval = time.Millisecond * 300
+	boff.MaxInterval = time.Second * 2
+	boff.MaxElapsedTime = 0
+
+	pullNow := make(chan time.Time)
+	close(pullNow)
+	var nextPull <-chan time.Time = pullNow
+
+	for {
+		var nextTimedBatch <-chan time.Time
+		if tNext, exists := c.batchPolicy.UntilNext(); exists {
+			nextTimedBatch = time.After(tNext)
+		}
+
+		select {
+		case <-nextPull:
+		case <-nextTimedBatch:
+			if !c.flush() {
+				return
+			}
+			continue
+		case <-c.commitTicker.C:
+			if !c.commit() {
+				return
+			}
+			continue
+		case <-r.ctx.Done():
+			c.state = ddbsiConsumerClosing
+			return
+		}
+
+		res, err := r.svc.GetRecords(r.ctx, &dynamodbstreams.GetRecordsInput{
+			ShardIterator: &iter,
+		})
+		if err != nil {
+			if awsErrIsTimeout(err) {
+				continue
+			}
+			nextPull = time.After(boff.NextBackOff())
+
+			var expiredErr *types.ExpiredIteratorException
+			var trimmedErr *types.TrimmedDataAccessException
+			switch {
+			case errors.As(err, &expiredErr):
+				r.log.Warn("Shard iterator expired, attempting to refresh")
+				if newIter, err := c.getIter(c.getSequence()); err != nil {
+					r.log.Errorf("Failed to refresh shard iterator: %v", err)
+				} else {
+					iter = newIter
+				}
+			case errors.As(err, &trimmedErr):
+				r.log.Errorf("Records of stream '%v' shard '%v' were trimmed before being consumed, resuming from the oldest record", r.streamARN, c.shard.id)
+				if newIter, err := c.getIter(""); err != nil {
+					r.log.Errorf("Failed to refresh shard iterator: %v", err)
+				} else {
+					iter = newIter
+				}
+			default:
+				r.log.Errorf("Failed to pull DynamoDB stream records: %v", err)
+			}
+			continue
+		}
+
+		for _, rec := range res.Records {
+			msg, err := ddbsiRecordToMessage(rec)
+			if err != nil {
+				r.log.Errorf("Failed to convert record of stream '%v' shard '%v': %v", r.streamARN, c.shard.id, err)
+				continue
+			}
+			msg.MetaSetMut("dynamodb_shard", c.shard.id)
+			msg.MetaSetMut("dynamodb_stream", r.streamARN)
+
+			c.batchedSequence = aws.ToString(rec.Dynamodb.SequenceNumber)
+			if c.batchPolicy.Add(msg) && !c.flush() {
+				return
+			}
+		}
+
+		if res.NextShardIterator == nil {
+			if !c.flush() {
+				return
+			}
+			c.state = ddbsiConsumerFinished
+			return
+		}
+		iter = *res.NextShardIterator
+
+		if len(res.Records) == 0 {
+			nextPull = time.After(boff.NextBackOff())
+		} else {
+			boff.Reset()
+			nextPull = pullNow
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func ddbsiAttributeValueToAny(v types.AttributeValue) (any, error) {
+	switch t := v.(type) {
+	case *types.AttributeValueMemberS:
+		return t.Value, nil
+	case *types.AttributeValueMemberN:
+		return json.Number(t.Value), nil
+	case *types.AttributeValueMemberB:
+		return t.Value, nil
+	case *types.AttributeValueMemberBOOL:
+		return t.Value, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+	case *types.AttributeValueMemberM:
+		return ddbsiAttributeMapToAny(t.Value)
+	case *types.AttributeValueMemberL:
+		l := make([]any, 0, len(t.Value))
+		for i, e := range t.Value {
+			ev, err := ddbsiAttributeValueToAny(e)
+			if err != nil {
+				return nil, fmt.Errorf("index %v: %w", i, err)
+			}
+			l = append(l, ev)
+		}
+		return l, nil
+	case *types.AttributeValueMemberSS:
+		l := make([]any, 0, len(t.Value))
+		for _, e := range t.Value {
+			l = append(l, e)
+		}
+		return l, nil
+	case *types.AttributeValueMemberNS:
+		l := make([]any, 0, len(t.Value))
+		for _, e := range t.Value {
+			l = append(l, json.Number(e))
+		}
+		return l, nil
+	case *types.AttributeValueMemberBS:
+		l := make([]any, 0, len(t.Value))
+		for _, e := range t.Value {
+			l = append(l, e)
+		}
+		return l, nil
+	}
+	return nil, fmt.Errorf("unsupported attribute value type: %T", v)
+}
+
+func ddbsiAttributeMapToAny(m map[string]types.AttributeValue) (map[string]any, error) {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		av, err := ddbsiAttributeValueToAny(v)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %v: %w", k, err)
+		}
+		out[k] = av
+	}
+	return out, nil
+}
+
+func ddbsiRecordToMessage(rec types.Record) (*service.Message, error) {
+	if rec.Dynamodb == nil {
+		return nil, errors.New("record does not contain a stream record")
+	}
+
+	keys, err := ddbsiAttributeMapToAny(rec.Dynamodb.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("keys: %w", err)
+	}
+
+	var body map[string]any
+	switch {
+	case rec.Dynamodb.NewImage != nil:
+		if body, err = ddbsiAttributeMapToAny(rec.Dynamodb.NewImage); err != nil {
+			return nil, fmt.Errorf("new image: %w", err)
+		}
+	case rec.Dynamodb.OldImage != nil:
+		if body, err = ddbsiAttributeMapToAny(rec.Dynamodb.OldImage); err != nil {
+			return nil, fmt.Errorf("old image: %w", err)
+		}
+	default:
+		body = keys
+	}
+
+	msg := service.NewMessage(nil)
+	msg.SetStructuredMut(body)
+
+	msg.MetaSetMut("dynamodb_event_id", aws.ToString(rec.EventID))
+	msg.MetaSetMut("dynamodb_event_name", string(rec.EventName))
+	msg.MetaSetMut("dynamodb_keys", keys)
+	if rec.Dynamodb.NewImage != nil && rec.Dynamodb.OldImage != nil {
+		oldImage, err := ddbsiAttributeMapToAny(rec.Dynamodb.OldImage)
+		if err != nil {
+			return nil, fmt.Errorf("old image: %w", err)
+		}
+		msg.MetaSetMut("dynamodb_old_image", oldImage)
+	}
+	msg.MetaSetMut("dynamodb_sequence_number", aws.ToString(rec.Dynamodb.SequenceNumber))
+	msg.MetaSetMut("dynamodb_stream_view_type", string(rec.Dynamodb.StreamViewType))
+	if rec.Dynamodb.ApproximateCreationDateTime != nil {
+		msg.MetaSetMut("dynamodb_approximate_creation_time", rec.Dynamodb.ApproximateCreationDateTime.UTC().Format(time.RFC3339))
+	}
+	if rec.UserIdentity != nil {
+		if rec.UserIdentity.Type != nil {
+			msg.MetaSetMut("dynamodb_user_identity_type", *rec.UserIdentity.Type)
+		}
+		if rec.UserIdentity.PrincipalId != nil {
+			msg.MetaSetMut("dynamodb_user_identity_principal", *rec.UserIdentity.PrincipalId)
+		}
+	}
+	return msg, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Connect resolves the stream of the table and begins consuming its shards.
+func (r *dynamoDBStreamsReader) Connect(ctx context.Context) error {
+	r.cMut.Lock()
+	defer r.cMut.Unlock()
+	if r.msgChan != nil {
+		return nil
+	}
+
+	if r.streamARN == "" {
+		res, err := dynamodb.NewFromConfig(r.sess).DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: &r.table,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe table %v: %w", r.table, err)
+		}
+		if res.Table == nil || res.Table.LatestStreamArn == nil {
+			return fmt.Errorf("table %v does not have a stream enabled", r.table)
+		}
+		r.streamARN = *res.Table.LatestStreamArn
+	}
+
+	checkpointer, err := newAWSKinesisCheckpointer(ctx, r.ddbSess, r.clientID, r.ddbConf, r.leasePeriod, r.commitPeriod, r.stealGracePeriod)
+	if err != nil {
+		return err
+	}
+
+	r.svc = dynamodbstreams.NewFromConfig(r.sess)
+	r.checkpointer = checkpointer
+	r.msgChan = make(chan asyncMessage)
+
+	go r.runBalancedShards()
+	return nil
+}
+
+// ReadBatch attempts to read a batch of changes from the stream.
+func (r *dynamoDBStreamsReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	r.cMut.Lock()
+	msgChan := r.msgChan
+	r.cMut.Unlock()
+
+	if msgChan == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case m, open := <-msgChan:
+		if !open {
+			return nil, nil, service.ErrNotConnected
+		}
+		return m.msg, m.ackFn, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// Close shuts down the input and stops processing requests.
+func (r *dynamoDBStreamsReader) Close(ctx context.Context) error {
+	r.done()
+
+	r.cMut.Lock()
+	started := r.msgChan != nil
+	r.cMut.Unlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-r.closedChan:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestDynamoDBStreamsAttributeConversion(t *testing.T) {
+	v, err := ddbsiAttributeMapToAny(map[string]types.AttributeValue{
+		"s":    &types.AttributeValueMemberS{Value: "foo"},
+		"n":    &types.AttributeValueMemberN{Value: "12.5"},
+		"b":    &types.AttributeValueMemberB{Value: []byte("bar")},
+		"bool": &types.AttributeValueMemberBOOL{Value: true},
+		"null": &types.AttributeValueMemberNULL{Value: true},
+		"ss":   &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"ns":   &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "baz"},
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"n": &types.AttributeValueMemberN{Value: "3"},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"s":    "foo",
+		"n":    json.Number("12.5"),
+		"b":    []byte("bar"),
+		"bool": true,
+		"null": nil,
+		"ss":   []any{"a", "b"},
+		"ns":   []any{json.Number("1"), json.Number("2")},
+		"l": []any{
+			"baz",
+			map[string]any{"n": json.Number("3")},
+		},
+	}, v)
+}
+
+func TestDynamoDBStreamsRecordToMessage(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	keys := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "foo"},
+	}
+
+	tests := []struct {
+		name     string
+		record   types.Record
+		body     string
+		metadata map[string]any
+	}{
+		{
+			name: "modify",
+			record: types.Record{
+				EventID:   aws.String("a"),
+				EventName: types.OperationTypeModify,
+				Dynamodb: &types.StreamRecord{
+					ApproximateCreationDateTime: &created,
+					Keys:                        keys,
+					NewImage: map[string]types.AttributeValue{
+						"id":    &types.AttributeValueMemberS{Value: "foo"},
+						"count": &types.AttributeValueMemberN{Value: "2"},
+					},
+					OldImage: map[string]types.AttributeValue{
+						"id":    &types.AttributeValueMemberS{Value: "foo"},
+						"count": &types.AttributeValueMemberN{Value: "1"},
+					},
+					SequenceNumber: aws.String("100"),
+					StreamViewType: types.StreamViewTypeNewAndOldImages,
+				},
+			},
+			body: `{"count":2,"id":"foo"}`,
+			metadata: map[string]any{
+				"dynamodb_event_id":                  "a",
+				"dynamodb_event_name":                "MODIFY",
+				"dynamodb_keys":                      map[string]any{"id": "foo"},
+				"dynamodb_old_image":                 map[string]any{"id": "foo", "count": json.Number("1")},
+				"dynamodb_sequence_number":           "100",
+				"dynamodb_stream_view_type":          "NEW_AND_OLD_IMAGES",
+				"dynamodb_approximate_creation_time": "2025-01-02T03:04:05Z",
+			},
+		},
+		{
+			name: "ttl remove",
+			record: types.Record{
+				EventID:   aws.String("b"),
+				EventName: types.OperationTypeRemove,
+				Dynamodb: &types.StreamRecord{
+					Keys: keys,
+					OldImage: map[string]types.AttributeValue{
+						"id": &types.AttributeValueMemberS{Value: "foo"},
+					},
+					SequenceNumber: aws.String("101"),
+					StreamViewType: types.StreamViewTypeOldImage,
+				},
+				UserIdentity: &types.Identity{
+					PrincipalId: aws.String("dynamodb.amazonaws.com"),
+					Type:        aws.String("Service"),
+				},
+			},
+			body: `{"id":"foo"}`,
+			metadata: map[string]any{
+				"dynamodb_event_name":              "REMOVE",
+				"dynamodb_sequence_number":         "101",
+				"dynamodb_user_identity_principal": "dynamodb.amazonaws.com",
+				"dynamodb_user_identity_type":      "Service",
+			},
+		},
+		{
+			name: "keys only",
+			record: types.Record{
+				EventID:   aws.String("c"),
+				EventName: types.OperationTypeInsert,
+				Dynamodb: &types.StreamRecord{
+					Keys:           keys,
+					SequenceNumber: aws.String("102"),
+					StreamViewType: types.StreamViewTypeKeysOnly,
+				},
+			},
+			body: `{"id":"foo"}`,
+			metadata: map[string]any{
+				"dynamodb_event_name":       "INSERT",
+				"dynamodb_stream_view_type": "KEYS_ONLY",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg, err := ddbsiRecordToMessage(test.record)
+			require.NoError(t, err)
+
+			b, err := msg.AsBytes()
+			require.NoError(t, err)
+			assert.JSONEq(t, test.body, string(b))
+
+			for k, v := range test.metadata {
+				actual, exists := msg.MetaGetMut(k)
+				require.True(t, exists, k)
+				assert.Equal(t, v, actual, k)
+			}
+		})
+	}
+
+	_, err := ddbsiRecordToMessage(types.Record{})
+	require.Error(t, err)
+}
+
+func TestDynamoDBStreamsConsumableShards(t *testing.T) {
+	closedRange := &types.SequenceNumberRange{
+		StartingSequenceNumber: aws.String("1"),
+		EndingSequenceNumber:   aws.String("10"),
+	}
+	openRange := &types.SequenceNumberRange{
+		StartingSequenceNumber: aws.String("11"),
+	}
+	shards := []types.Shard{
+		{ShardId: aws.String("parent"), ParentShardId: aws.String("trimmed"), SequenceNumberRange: closedRange},
+		{ShardId: aws.String("child"), ParentShardId: aws.String("parent"), SequenceNumberRange: openRange},
+		{ShardId: aws.String("other"), SequenceNumberRange: openRange},
+	}
+
+	ids := func(shards []ddbsiShard) (ids []string) {
+		for _, s := range shards {
+			ids = append(ids, s.id)
+		}
+		return
+	}
+
+	// Only the parent and shards without parents are consumed initially.
+	consumable := ddbsiConsumableShards(shards, nil, true, time.Second)
+	assert.Equal(t, []string{"parent", "other"}, ids(consumable))
+	assert.False(t, consumable[0].fromLatest)
+
+	// The child is consumed once the parent is finished.
+	otherClient, myClient := "foo", "bar"
+	expired, active := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	consumable = ddbsiConsumableShards(shards, map[string]awsKinesisCheckpoint{
+		"parent": {SequenceNumber: ddbsiShardEnd},
+		"other":  {SequenceNumber: "15", ClientID: &otherClient, LeaseTimeout: &expired},
+	}, true, time.Second)
+	require.Equal(t, []string{"child", "other"}, ids(consumable))
+	assert.Empty(t, consumable[0].claimedBy)
+	assert.Equal(t, otherClient, consumable[1].claimedBy)
+	assert.True(t, consumable[1].leaseExpired)
+
+	consumable = ddbsiConsumableShards(shards, map[string]awsKinesisCheckpoint{
+		"parent": {SequenceNumber: "5", ClientID: &myClient, LeaseTimeout: &active},
+	}, true, time.Second)
+	require.Equal(t, []string{"parent", "other"}, ids(consumable))
+	assert.False(t, consumable[0].leaseExpired)
+
+	// Consuming from the latest records skips closed shards entirely.
+	consumable = ddbsiConsumableShards(shards, nil, false, time.Second)
+	require.Equal(t, []string{"child", "other"}, ids(consumable))
+	assert.True(t, consumable[0].fromLatest)
+	assert.True(t, consumable[1].fromLatest)
+
+	// Unless the closed shard has been partially consumed, in which case its
+	// children are consumed from their oldest record.
+	consumable = ddbsiConsumableShards(shards, map[string]awsKinesisCheckpoint{
+		"parent": {SequenceNumber: ddbsiShardEnd},
+	}, false, time.Second)
+	require.Equal(t, []string{"child", "other"}, ids(consumable))
+	assert.False(t, consumable[0].fromLatest)
+	assert.True(t, consumable[1].fromLatest)
+
+	// Falling behind by more than one generation of shards still consumes the
+	// closed children of a consumed shard in their entirety.
+	generations := []types.Shard{
+		{ShardId: aws.String("parent"), SequenceNumberRange: closedRange},
+		{ShardId: aws.String("child"), ParentShardId: aws.String("parent"), SequenceNumberRange: closedRange},
+		{ShardId: aws.String("grandchild"), ParentShardId: aws.String("child"), SequenceNumberRange: openRange},
+	}
+	consumable = ddbsiConsumableShards(generations, map[string]awsKinesisCheckpoint{
+		"parent": {SequenceNumber: ddbsiShardEnd},
+	}, false, time.Second)
+	require.Equal(t, []string{"child"}, ids(consumable))
+	assert.False(t, consumable[0].fromLatest)
+
+	consumable = ddbsiConsumableShards(generations, map[string]awsKinesisCheckpoint{
+		"parent": {SequenceNumber: ddbsiShardEnd},
+		"child":  {SequenceNumber: ddbsiShardEnd},
+	}, false, time.Second)
+	require.Equal(t, []string{"grandchild"}, ids(consumable))
+	assert.False(t, consumable[0].fromLatest)
+
+	// Whereas generations that were never consumed are all skipped.
+	consumable = ddbsiConsumableShards(generations, nil, false, time.Second)
+	require.Equal(t, []string{"grandchild"}, ids(consumable))
+	assert.True(t, consumable[0].fromLatest)
+}
+
+func TestDynamoDBStreamsConfigValidation(t *testing.T) {
+	for _, conf := range []string{
+		`
+checkpoint_table:
+  table: foo
+`,
+		`
+table: foo
+stream_arn: arn:aws:dynamodb:us-east-1:111122223333:table/foo/stream/2025-01-01T00:00:00.000
+checkpoint_table:
+  table: foo
+`,
+	} {
+		pConf, err := dynamoDBStreamsInputSpec().ParseYAML(conf, nil)
+		require.NoError(t, err)
+
+		_, err = newDynamoDBStreamsReaderFromParsed(pConf, service.MockResources())
+		require.ErrorContains(t, err, "exactly one of table or stream_arn must be set")
+	}
+
+	pConf, err := dynamoDBStreamsInputSpec().ParseYAML(`
+table: foo
+region: us-east-1
+checkpoint_table:
+  table: bar
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newDynamoDBStreamsReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	assert.Equal(t, "foo", r.table)
+	assert.Equal(t, "bar", r.ddbConf.Table)
+	assert.True(t, r.startFromOldest)
+}
@@ -51,6 +51,7 @@ const (
 	kiFieldRebalancePeriod  = "rebalance_period"
 	kiFieldStartFromOldest  = "start_from_oldest"
 	kiFieldBatching         = "batching"
+	kiFieldEnhancedFanOut   = "enhanced_fan_out"
 
 	// Kinesis metrics
 	metricShardsPerClient = "kinesis_client_shards"
@@ -66,6 +67,7 @@ type kiConfig struct {
 	LeasePeriod      string
 	RebalancePeriod  string
 	StartFromOldest  bool
+	EnhancedFanOut   kiEFOConfig
 }
 
 func kinesisInputConfigFromParsed(pConf *service.ParsedConfig) (conf kiConfig, err error) {
@@ -95,9 +97,39 @@ func kinesisInputConfigFromParsed(pConf *service.ParsedConfig) (conf kiConfig, e
 	if conf.StartFromOldest, err = pConf.FieldBool(kiFieldStartFromOldest); err != nil {
 		return
 	}
+	if conf.EnhancedFanOut, err = kinesisInputEFOConfigFromParsed(pConf.Namespace(kiFieldEnhancedFanOut)); err != nil {
+		return
+	}
 	return
 }
 
+// kinesisInputDynamoDBFields returns the fields of a DynamoDB table used for
+// checkpointing and coordinating consumers of shards.
+func kinesisInputDynamoDBFields() []*service.ConfigField {
+	return append([]*service.ConfigField{
+		service.NewStringField(kiddbFieldTable).
+			Description("The name of the table to access.").
+			Default(""),
+		service.NewBoolField(kiddbFieldCreate).
+			Description("Whether, if the table does not exist, it should be created.").
+			Default(false),
+		service.NewStringEnumField(kiddbFieldBillingMode, "PROVISIONED", "PAY_PER_REQUEST").
+			Description("When creating the table determines the billing mode.").
+			Default("PAY_PER_REQUEST").
+			Advanced(),
+		service.NewIntField(kiddbFieldReadCapacityUnits).
+			Description("Set the provisioned read capacity when creating the table with a `billing_mode` of `PROVISIONED`.").
+			Default(0).
+			Advanced(),
+		service.NewIntField(kiddbFieldWriteCapacityUnits).
+			Description("Set the provisioned write capacity when creating the table with a `billing_mode` of `PROVISIONED`.").
+			Default(0).
+			Advanced(),
+	},
+		config.SessionFields()...,
+	)
+}
+
 func kinesisInputSpec() *service.ConfigSpec {
 	spec := service.NewConfigSpec().
 		Stable().
@@ -113,6 +145,12 @@ Redpanda Connect will not store a consumed sequence unless it is acknowledged at
 
 By default messages of a shard can be processed in parallel, up to a limit determined by the field `+"`checkpoint_limit`"+`. However, if strict ordered processing is required then this value must be set to 1 in order to process shard messages in lock-step. When doing so it is recommended that you perform batching at this component for performance as it will not be possible to batch lock-stepped messages at the output level.
 
+== Enhanced Fan-Out
+
+By default shards are polled for records, where the read throughput of each shard is shared between all consumers of the stream. When `+"`enhanced_fan_out.enabled`"+` is set to `+"`true`"+` the input instead registers a stream consumer with the name `+"`enhanced_fan_out.consumer_name`"+` (if it does not already exist) and subscribes to each of its claimed shards, where records are pushed to the consumer with a dedicated throughput. This allows multiple pipelines to consume the same stream at full throughput, provided that each uses a distinct consumer name. Shards are balanced and checkpointed through the DynamoDB table in the same way in either mode.
+
+Registered consumers are not removed when the input is shut down, and must be deregistered manually once they are no longer needed.
+
 == Table schema
 
 It's possible to configure Redpanda Connect to create the DynamoDB table required for coordination if it does not already exist. However, if you wish to create this yourself (recommended) then create a table with a string HASH key `+"`StreamID`"+` and a string RANGE key `+"`ShardID`"+`.
@@ -124,30 +162,7 @@ Use the `+"`batching`"+` fields to configure an optional xref:configuration:batc
 		service.NewStringListField(kiFieldStreams).
 			Description("One or more Kinesis data streams to consume from. Streams can either be specified by their name or full ARN. Shards of a stream are automatically balanced across consumers by coordinating through the provided DynamoDB table. Multiple comma separated streams can be listed in a single element. Shards are automatically distributed across consumers of a stream by coordinating through the provided DynamoDB table. Alternatively, it's possible to specify an explicit shard to consume from with a colon after the stream name, e.g. `foo:0` would consume the shard `0` of the stream `foo`.").
 			Examples([]any{"foo", "arn:aws:kinesis:*:111122223333:stream/my-stream"}),
-		service.NewObjectField(kiFieldDynamoDB,
-			append([]*service.ConfigField{
-				service.NewStringField(kiddbFieldTable).
-					Description("The name of the table to access.").
-					Default(""),
-				service.NewBoolField(kiddbFieldCreate).
-					Description("Whether, if the table does not exist, it should be created.").
-					Default(false),
-				service.NewStringEnumField(kiddbFieldBillingMode, "PROVISIONED", "PAY_PER_REQUEST").
-					Description("When creating the table determines the billing mode.").
-					Default("PAY_PER_REQUEST").
-					Advanced(),
-				service.NewIntField(kiddbFieldReadCapacityUnits).
-					Description("Set the provisioned read capacity when creating the table with a `billing_mode` of `PROVISIONED`.").
-					Default(0).
-					Advanced(),
-				service.NewIntField(kiddbFieldWriteCapacityUnits).
-					Description("Set the provisioned write capacity when creating the table with a `billing_mode` of `PROVISIONED`.").
-					Default(0).
-					Advanced(),
-			},
-				config.SessionFields()...,
-			)...,
-		).
+		service.NewObjectField(kiFieldDynamoDB, kinesisInputDynamoDBFields()...).
 			Description("Determines the table used for storing and accessing the latest consumed sequence for shards, and for coordinating balanced consumers of streams."),
 		service.NewIntField(kiFieldCheckpointLimit).
 			Description("The maximum gap between the in flight sequence versus the latest acknowledged sequence at a given time. Increasing this limit enables parallel processing and batching at the output level to work on individual shards. Any given sequence will not be committed unless all messages under that offset are delivered in order to preserve at least once delivery guarantees.").
@@ -170,6 +185,7 @@ Use the `+"`batching`"+` fields to configure an optional xref:configuration:batc
 		service.NewBoolField(kiFieldStartFromOldest).
 			Description("Whether to consume from the oldest message when a sequence does not yet exist for the stream.").
 			Default(true),
+		kinesisInputEFOField(),
 	).
 		Fields(config.SessionFields()...).
 		Field(service.NewBatchPolicyField(kiFieldBatching))
@@ -200,6 +216,7 @@ type streamInfo struct {
 	explicitShards []string
 	id             string // Either a name or arn, extracted from config and used for balancing shards
 	arn            string
+	consumerARN    string // The registered consumer of the stream when using enhanced fan-out
 }
 
 type kinesisReader struct {
@@ -215,6 +232,7 @@ type kinesisReader struct {
 	boffPool sync.Pool
 
 	svc          *kinesis.Client
+	subscriber   kinesisSubscribeAPI
 	checkpointer *awsKinesisCheckpointer
 
 	streams []*streamInfo
@@ -289,6 +307,9 @@ func newKinesisReaderFromConfig(conf kiConfig, batcher service.BatchPolicy, sess
 	if batcher.IsNoop() {
 		batcher.Count = 1
 	}
+	if conf.EnhancedFanOut.Enabled && conf.EnhancedFanOut.ConsumerName == "" {
+		return nil, errors.New("a consumer_name must be specified when enhanced fan-out is enabled")
+	}
 
 	k := kinesisReader{
 		conf:       conf,
@@ -490,7 +511,13 @@ func (k *kinesisReader) runConsumer(wg *sync.WaitGroup, info streamInfo, shardID
 	// Stores consumed records that have yet to be added to the batcher.
 	var pending []types.Record
 	var iter string
-	if iter, initErr = k.getIter(info, shardID, startingSequence); initErr != nil {
+
+	// Records are either pushed to us by a subscription when using enhanced
+	// fan-out, or pulled with an iterator.
+	var subscription *kinesisShardSubscription
+	if k.conf.EnhancedFanOut.Enabled {
+		subscription = k.subscribeToShard(info, shardID, startingSequence)
+	} else if iter, initErr = k.getIter(info, shardID, startingSequence); initErr != nil {
 		return initErr
 	}
 
@@ -511,14 +538,23 @@ func (k *kinesisReader) runConsumer(wg *sync.WaitGroup, info streamInfo, shardID
 	//    is nil when our current batched message is a zero value (we don't have
 	//    one prepared).
 	// 4. Next commit, is "done" when the next commit is due.
+	// 5. Subscription records, this is nil unless we're using enhanced fan-out
+	//    and we run out of pending records, in which case pulling is disabled.
 	var nextTimedBatchChan <-chan time.Time
 	var nextPullChan <-chan time.Time = unblockedChan
 	var nextFlushChan chan<- asyncMessage
+	var nextRecordsChan <-chan []types.Record
+	if subscription != nil {
+		nextPullChan = nil
+	}
 	commitCtx, commitCtxClose := context.WithTimeout(k.ctx, k.commitPeriod)
 
 	go func() {
 		defer func() {
 			commitCtxClose()
+			if subscription != nil {
+				subscription.Close()
+			}
 			recordBatcher.Close(context.Background(), state == awsKinesisConsumerFinished)
 			boff.Reset()
 			k.boffPool.Put(boff)
@@ -559,7 +595,7 @@ func (k *kinesisReader) runConsumer(wg *sync.WaitGroup, info streamInfo, shardID
 
 		for {
 			var err error
-			if state == awsKinesisConsumerConsuming && len(pending) == 0 && nextPullChan == unblockedChan {
+			if subscription == nil && state == awsKinesisConsumerConsuming && len(pending) == 0 && nextPullChan == unblockedChan {
 				if pending, iter, err = k.getRecords(info, iter); err != nil {
 					if !awsErrIsTimeout(err) {
 						nextPullChan = time.After(boff.NextBackOff())
@@ -624,6 +660,11 @@ func (k *kinesisReader) runConsumer(wg *sync.WaitGroup, info streamInfo, shardID
 				}
 			}
 
+			nextRecordsChan = nil
+			if subscription != nil && state == awsKinesisConsumerConsuming && len(pending) == 0 {
+				nextRecordsChan = subscription.records
+			}
+
 			if pendingMsg.msg != nil {
 				nextFlushChan = k.msgChan
 			} else {
@@ -668,6 +709,12 @@ func (k *kinesisReader) runConsumer(wg *sync.WaitGroup, info streamInfo, shardID
 				pendingMsg = asyncMessage{}
 			case <-nextPullChan:
 				nextPullChan = unblockedChan
+			case records, open := <-nextRecordsChan:
+				if !open {
+					state = awsKinesisConsumerFinished
+				} else {
+					pending = records
+				}
 			case <-k.ctx.Done():
 				state = awsKinesisConsumerClosing
 				return
@@ -917,12 +964,20 @@ func (k *kinesisReader) Connect(ctx context.Context) error {
 	}
 
 	k.svc = svc
+	k.subscriber = kinesisClientSubscriber{svc: svc}
 	k.checkpointer = checkpointer
 	k.msgChan = make(chan asyncMessage)
 
 	if err = k.waitUntilStreamsExists(ctx); err != nil {
 		return err
 	}
+	if k.conf.EnhancedFanOut.Enabled {
+		for _, info := range k.streams {
+			if err = k.registerStreamConsumer(ctx, info); err != nil {
+				return err
+			}
+		}
+	}
 
 	if len(k.streams[0].explicitShards) > 0 {
 		go k.runExplicitShards()
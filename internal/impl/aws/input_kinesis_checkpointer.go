@@ -189,6 +189,57 @@ func (k *awsKinesisCheckpointer) getCheckpoint(ctx context.Context, streamID, sh
 	return &c, nil
 }
 
+// AllCheckpoints returns the checkpoints of all shards of a stream, including
+// those that are not currently claimed by a client, keyed by shard ID.
+func (k *awsKinesisCheckpointer) AllCheckpoints(ctx context.Context, streamID string) (map[string]awsKinesisCheckpoint, error) {
+	checkpoints := map[string]awsKinesisCheckpoint{}
+
+	var startKey map[string]types.AttributeValue
+	for {
+		scanRes, err := k.svc.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(k.conf.Table),
+			FilterExpression: aws.String("StreamID = :stream_id"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":stream_id": &types.AttributeValueMemberS{
+					Value: streamID,
+				},
+			},
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range scanRes.Items {
+			s, ok := i["ShardID"].(*types.AttributeValueMemberS)
+			if !ok || s.Value == "" {
+				return nil, errors.New("failed to extract shard id from checkpoint")
+			}
+
+			var c awsKinesisCheckpoint
+			if seq, ok := i["SequenceNumber"].(*types.AttributeValueMemberS); ok {
+				c.SequenceNumber = seq.Value
+			}
+			if clientID, ok := i["ClientID"].(*types.AttributeValueMemberS); ok {
+				c.ClientID = &clientID.Value
+			}
+			if lease, ok := i["LeaseTimeout"].(*types.AttributeValueMemberS); ok {
+				timeout, err := time.Parse(time.RFC3339Nano, lease.Value)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse claim lease: %w", err)
+				}
+				c.LeaseTimeout = &timeout
+			}
+			checkpoints[s.Value] = c
+		}
+
+		if len(scanRes.LastEvaluatedKey) == 0 {
+			return checkpoints, nil
+		}
+		startKey = scanRes.LastEvaluatedKey
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // awsKinesisClientClaim represents a shard claimed by a client.
@@ -0,0 +1,243 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Kinesis Input Enhanced Fan-Out Fields
+	kiefoFieldEnabled      = "enabled"
+	kiefoFieldConsumerName = "consumer_name"
+)
+
+type kiEFOConfig struct {
+	Enabled      bool
+	ConsumerName string
+}
+
+func kinesisInputEFOConfigFromParsed(pConf *service.ParsedConfig) (conf kiEFOConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(kiefoFieldEnabled); err != nil {
+		return
+	}
+	if conf.ConsumerName, err = pConf.FieldString(kiefoFieldConsumerName); err != nil {
+		return
+	}
+	return
+}
+
+func kinesisInputEFOField() *service.ConfigField {
+	return service.NewObjectField(kiFieldEnhancedFanOut,
+		service.NewBoolField(kiefoFieldEnabled).
+			Description("Whether to consume shards with enhanced fan-out.").
+			Default(false),
+		service.NewStringField(kiefoFieldConsumerName).
+			Description("The name of the stream consumer to register, which is required when enhanced fan-out is enabled. All instances of a pipeline must share the same name, whereas different pipelines consuming the same stream must each use a distinct name in order to receive their own throughput.").
+			Default(""),
+	).
+		Description("Configures the input to consume shards with enhanced fan-out, where records are pushed to a registered stream consumer with a dedicated throughput of 2MB/s per shard, rather than polled with a throughput shared between all consumers of the stream.").
+		Version("4.73.0")
+}
+
+// registerStreamConsumer obtains the ARN of the enhanced fan-out consumer of a
+// stream, registering the consumer if it does not yet exist and waiting until
+// it becomes active.
+func (k *kinesisReader) registerStreamConsumer(ctx context.Context, info *streamInfo) error {
+	name := k.conf.EnhancedFanOut.ConsumerName
+	for {
+		res, err := k.svc.DescribeStreamConsumer(ctx, &kinesis.DescribeStreamConsumerInput{
+			StreamARN:    &info.arn,
+			ConsumerName: &name,
+		})
+		if err != nil {
+			var nfErr *types.ResourceNotFoundException
+			if !errors.As(err, &nfErr) {
+				return fmt.Errorf("failed to describe stream '%v' consumer '%v': %w", info.id, name, err)
+			}
+
+			k.log.Infof("Registering stream '%v' consumer '%v'", info.id, name)
+			if _, err = k.svc.RegisterStreamConsumer(ctx, &kinesis.RegisterStreamConsumerInput{
+				StreamARN:    &info.arn,
+				ConsumerName: &name,
+			}); err != nil {
+				// Another instance might have registered the consumer since
+				// we described it.
+				var inUseErr *types.ResourceInUseException
+				if !errors.As(err, &inUseErr) {
+					return fmt.Errorf("failed to register stream '%v' consumer '%v': %w", info.id, name, err)
+				}
+			}
+		} else if desc := res.ConsumerDescription; desc != nil && desc.ConsumerStatus == types.ConsumerStatusActive {
+			info.consumerARN = *desc.ConsumerARN
+			return nil
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// kinesisSubscribeAPI opens the event stream of a subscription to a shard.
+type kinesisSubscribeAPI interface {
+	SubscribeToShard(ctx context.Context, params *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error)
+}
+
+type kinesisClientSubscriber struct {
+	svc *kinesis.Client
+}
+
+func (c kinesisClientSubscriber) SubscribeToShard(ctx context.Context, params *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error) {
+	res, err := c.svc.SubscribeToShard(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetStream(), nil
+}
+
+// kinesisShardSubscription consumes the records of a shard that are pushed to
+// an enhanced fan-out consumer, resubscribing each time a subscription expires.
+type kinesisShardSubscription struct {
+	// Receives the records of each event, and is closed once the shard has
+	// been consumed in its entirety.
+	records chan []types.Record
+
+	ctx      context.Context
+	done     func()
+	exitChan chan struct{}
+}
+
+func (k *kinesisReader) subscribeToShard(info streamInfo, shardID, startingSequence string) *kinesisShardSubscription {
+	s := &kinesisShardSubscription{
+		records:  make(chan []types.Record),
+		exitChan: make(chan struct{}),
+	}
+	s.ctx, s.done = context.WithCancel(k.ctx)
+
+	go func() {
+		defer close(s.exitChan)
+
+		boff := k.boffPool.Get().(backoff.BackOff)
+		defer func() {
+			boff.Reset()
+			k.boffPool.Put(boff)
+		}()
+
+		continuation := startingSequence
+		for {
+			position := &types.StartingPosition{
+				Type: types.ShardIteratorTypeTrimHorizon,
+			}
+			if continuation != "" {
+				position.Type = types.ShardIteratorTypeAfterSequenceNumber
+				position.SequenceNumber = &continuation
+			} else if !k.conf.StartFromOldest {
+				position.Type = types.ShardIteratorTypeLatest
+			}
+
+			stream, err := k.subscriber.SubscribeToShard(s.ctx, &kinesis.SubscribeToShardInput{
+				ConsumerARN:      &info.consumerARN,
+				ShardId:          &shardID,
+				StartingPosition: position,
+			})
+			if err == nil {
+				var finished bool
+				if finished, err = s.consume(stream, &continuation); finished {
+					close(s.records)
+					return
+				}
+				if err == nil {
+					// Subscriptions expire after five minutes, at which point
+					// we immediately resubscribe.
+					boff.Reset()
+					continue
+				}
+			}
+			if s.ctx.Err() != nil {
+				return
+			}
+
+			// A subscription to the shard by the previous owner might still be
+			// active, in which case we back off until it expires.
+			var inUseErr *types.ResourceInUseException
+			if errors.As(err, &inUseErr) {
+				k.log.Debugf("Subscription to stream '%v' shard '%v' is in use, retrying: %v", info.id, shardID, err)
+			} else if !awsErrIsTimeout(err) {
+				k.log.Errorf("Failed to consume Kinesis shard subscription: %v", err)
+			}
+
+			select {
+			case <-time.After(boff.NextBackOff()):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// consume reads the events of a subscription until it is closed, returning
+// true if the shard has been consumed in its entirety.
+func (s *kinesisShardSubscription) consume(stream *kinesis.SubscribeToShardEventStream, continuation *string) (bool, error) {
+	defer stream.Close()
+	for {
+		var e types.SubscribeToShardEventStream
+		var open bool
+		select {
+		case e, open = <-stream.Events():
+		case <-s.ctx.Done():
+			return false, s.ctx.Err()
+		}
+		if !open {
+			return false, stream.Err()
+		}
+
+		event, ok := e.(*types.SubscribeToShardEventStreamMemberSubscribeToShardEvent)
+		if !ok {
+			continue
+		}
+
+		if len(event.Value.Records) > 0 {
+			select {
+			case s.records <- event.Value.Records:
+			case <-s.ctx.Done():
+				return false, s.ctx.Err()
+			}
+		}
+
+		if event.Value.ContinuationSequenceNumber == nil || len(event.Value.ChildShards) > 0 {
+			return true, nil
+		}
+		*continuation = *event.Value.ContinuationSequenceNumber
+	}
+}
+
+// Close cancels the subscription and waits for it to exit.
+func (s *kinesisShardSubscription) Close() {
+	s.done()
+	<-s.exitChan
+}
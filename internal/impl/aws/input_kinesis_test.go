@@ -15,10 +15,18 @@
 package aws
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
 func TestStreamIDParser(t *testing.T) {
@@ -78,3 +86,185 @@ func TestStreamIDParser(t *testing.T) {
 		})
 	}
 }
+
+func TestKinesisEnhancedFanOutConfig(t *testing.T) {
+	pConf, err := kinesisInputSpec().ParseYAML(`
+streams: [ foo ]
+region: us-east-1
+enhanced_fan_out:
+  enabled: true
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newKinesisReaderFromParsed(pConf, service.MockResources())
+	require.ErrorContains(t, err, "a consumer_name must be specified")
+
+	pConf, err = kinesisInputSpec().ParseYAML(`
+streams: [ foo ]
+region: us-east-1
+enhanced_fan_out:
+  enabled: true
+  consumer_name: bar
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newKinesisReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	assert.True(t, r.conf.EnhancedFanOut.Enabled)
+	assert.Equal(t, "bar", r.conf.EnhancedFanOut.ConsumerName)
+}
+
+type mockKinesisEventReader struct {
+	events chan types.SubscribeToShardEventStream
+}
+
+func (m *mockKinesisEventReader) Events() <-chan types.SubscribeToShardEventStream {
+	return m.events
+}
+
+func (*mockKinesisEventReader) Close() error {
+	return nil
+}
+
+func (*mockKinesisEventReader) Err() error {
+	return nil
+}
+
+type mockKinesisSubscriber struct {
+	fn func(input *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error)
+}
+
+func (m *mockKinesisSubscriber) SubscribeToShard(_ context.Context, input *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error) {
+	return m.fn(input)
+}
+
+func kinesisSubscribeEvent(continuation string, childShards bool, seqs ...string) types.SubscribeToShardEventStream {
+	event := &types.SubscribeToShardEventStreamMemberSubscribeToShardEvent{}
+	if continuation != "" {
+		event.Value.ContinuationSequenceNumber = aws.String(continuation)
+	}
+	if childShards {
+		event.Value.ChildShards = []types.ChildShard{{ShardId: aws.String("shard-1")}}
+	}
+	for _, seq := range seqs {
+		event.Value.Records = append(event.Value.Records, types.Record{
+			SequenceNumber: aws.String(seq),
+			Data:           []byte(seq),
+		})
+	}
+	return event
+}
+
+func TestKinesisEnhancedFanOutSubscription(t *testing.T) {
+	pConf, err := kinesisInputSpec().ParseYAML(`
+streams: [ foo ]
+region: us-east-1
+start_from_oldest: false
+enhanced_fan_out:
+  enabled: true
+  consumer_name: bar
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newKinesisReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	// The first subscription expires after two events, and the second reaches
+	// the end of the shard.
+	subscriptions := [][]types.SubscribeToShardEventStream{
+		{
+			kinesisSubscribeEvent("2", false, "1", "2"),
+			kinesisSubscribeEvent("3", false),
+		},
+		{
+			kinesisSubscribeEvent("4", false, "4"),
+			kinesisSubscribeEvent("5", true, "5"),
+		},
+	}
+
+	var positionsMut sync.Mutex
+	var positions []string
+	r.subscriber = &mockKinesisSubscriber{
+		fn: func(input *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error) {
+			if exp, act := "bar-arn", aws.ToString(input.ConsumerARN); exp != act {
+				return nil, errors.New("unexpected consumer arn: " + act)
+			}
+
+			positionsMut.Lock()
+			defer positionsMut.Unlock()
+			positions = append(positions, string(input.StartingPosition.Type)+":"+aws.ToString(input.StartingPosition.SequenceNumber))
+			if len(positions) > len(subscriptions) {
+				return nil, errors.New("too many subscriptions")
+			}
+
+			events := make(chan types.SubscribeToShardEventStream, len(subscriptions[len(positions)-1]))
+			for _, e := range subscriptions[len(positions)-1] {
+				events <- e
+			}
+			close(events)
+			return kinesis.NewSubscribeToShardEventStream(func(es *kinesis.SubscribeToShardEventStream) {
+				es.Reader = &mockKinesisEventReader{events: events}
+			}), nil
+		},
+	}
+
+	s := r.subscribeToShard(streamInfo{id: "foo", consumerARN: "bar-arn"}, "shard-0", "")
+	t.Cleanup(s.Close)
+
+	// The records of each event are delivered in order, and are the records
+	// that are checkpointed by the consumer of the shard.
+	var seqs []string
+	for records := range s.records {
+		for _, rec := range records {
+			seqs = append(seqs, aws.ToString(rec.SequenceNumber))
+		}
+	}
+	assert.Equal(t, []string{"1", "2", "4", "5"}, seqs)
+
+	positionsMut.Lock()
+	defer positionsMut.Unlock()
+	assert.Equal(t, []string{
+		"LATEST:",
+		"AFTER_SEQUENCE_NUMBER:3",
+	}, positions)
+}
+
+func TestKinesisEnhancedFanOutSubscriptionResume(t *testing.T) {
+	pConf, err := kinesisInputSpec().ParseYAML(`
+streams: [ foo ]
+region: us-east-1
+enhanced_fan_out:
+  enabled: true
+  consumer_name: bar
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newKinesisReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	inputs := make(chan *kinesis.SubscribeToShardInput, 1)
+	r.subscriber = &mockKinesisSubscriber{
+		fn: func(input *kinesis.SubscribeToShardInput) (*kinesis.SubscribeToShardEventStream, error) {
+			inputs <- input
+			events := make(chan types.SubscribeToShardEventStream, 1)
+			events <- kinesisSubscribeEvent("", false)
+			close(events)
+			return kinesis.NewSubscribeToShardEventStream(func(es *kinesis.SubscribeToShardEventStream) {
+				es.Reader = &mockKinesisEventReader{events: events}
+			}), nil
+		},
+	}
+
+	// A shard with a checkpoint resumes from it, and a missing continuation
+	// marks the end of the shard.
+	s := r.subscribeToShard(streamInfo{id: "foo", consumerARN: "bar-arn"}, "shard-0", "10")
+	t.Cleanup(s.Close)
+
+	_, open := <-s.records
+	assert.False(t, open)
+
+	input := <-inputs
+	assert.Equal(t, "shard-0", aws.ToString(input.ShardId))
+	assert.Equal(t, types.ShardIteratorTypeAfterSequenceNumber, input.StartingPosition.Type)
+	assert.Equal(t, "10", aws.ToString(input.StartingPosition.SequenceNumber))
+}
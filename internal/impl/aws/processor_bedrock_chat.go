@@ -16,26 +16,54 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
 	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
 	"github.com/redpanda-data/benthos/v4/public/service"
 
 	"github.com/redpanda-data/connect/v4/internal/impl/aws/config"
 )
 
 const (
-	bedcpFieldModel        = "model"
-	bedcpFieldUserPrompt   = "prompt"
-	bedcpFieldSystemPrompt = "system_prompt"
-	bedcpFieldMaxTokens    = "max_tokens"
-	bedcpFieldStop         = "stop"
-	bedcpFieldTemp         = "temperature"
-	bedcpFieldTopP         = "top_p"
+	bedcpFieldModel          = "model"
+	bedcpFieldUserPrompt     = "prompt"
+	bedcpFieldSystemPrompt   = "system_prompt"
+	bedcpFieldHistory        = "history"
+	bedcpFieldImage          = "image"
+	bedcpFieldMaxTokens      = "max_tokens"
+	bedcpFieldStop           = "stop"
+	bedcpFieldTemp           = "temperature"
+	bedcpFieldTopP           = "top_p"
+	bedcpFieldResponseFormat = "response_format"
+	bedcpFieldJSONSchema     = "json_schema"
+	bedcpFieldMaxToolCalls   = "max_tool_calls"
+
+	// Tool options
+	bedcpFieldTools                    = "tools"
+	bedcpToolFieldName                 = "name"
+	bedcpToolFieldDesc                 = "description"
+	bedcpToolFieldParams               = "parameters"
+	bedcpToolParamFieldRequired        = "required"
+	bedcpToolParamFieldProps           = "properties"
+	bedcpToolParamPropFieldType        = "type"
+	bedcpToolParamPropFieldDescription = "description"
+	bedcpToolParamPropFieldEnum        = "enum"
+	bedcpToolFieldPipeline             = "processors"
+
+	// The name of the tool that the model is asked to invoke in order to
+	// produce structured output, as the Converse API has no native JSON mode.
+	bedcpStructuredOutputTool = "structured_output"
 )
 
 func init() {
@@ -46,7 +74,11 @@ func newBedrockChatConfigSpec() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Summary("Generates responses to messages in a chat conversation, using the AWS Bedrock API.").
 		Description(`This processor sends prompts to your chosen large language model (LLM) and generates text from the responses, using the AWS Bedrock API.
-For more information, see the https://docs.aws.amazon.com/bedrock/latest/userguide[AWS Bedrock documentation^].`).
+For more information, see the https://docs.aws.amazon.com/bedrock/latest/userguide[AWS Bedrock documentation^].
+
+== Structured output
+
+The Converse API does not natively support a JSON response format, so when `+"`"+bedcpFieldResponseFormat+"`"+` is set to `+"`json`"+` or `+"`json_schema`"+` the model is given an additional tool named `+"`"+bedcpStructuredOutputTool+"`"+` and is required to respond by invoking it, the arguments of that invocation become the output message. This requires a model that supports forcing tool use, such as the Anthropic Claude, Amazon Nova and Mistral Large models.`).
 		Categories("AI").
 		Version("4.34.0").
 		Fields(config.SessionFields()...).
@@ -59,6 +91,15 @@ For more information, see the https://docs.aws.amazon.com/bedrock/latest/usergui
 		Field(service.NewStringField(bedcpFieldSystemPrompt).
 			Optional().
 			Description("The system prompt to submit to the AWS Bedrock LLM.")).
+		Field(service.NewBloblangField(bedcpFieldHistory).
+			Description(`The history of the prior conversation. A bloblang query that should result in an array of objects of the form: [{"role": "user", "content": "<text>"}, {"role":"assistant", "content":"<text>"}]`).
+			Version("4.73.0").
+			Optional()).
+		Field(service.NewBloblangField(bedcpFieldImage).
+			Description("An image to send along with the prompt. The mapping result must be a byte array, and the image format (PNG, JPEG, GIF or WebP) is automatically detected.").
+			Version("4.73.0").
+			Example(`root = this.image.decode("base64") # decode base64 encoded image`).
+			Optional()).
 		Field(service.NewIntField(bedcpFieldMaxTokens).
 			Optional().
 			Description("The maximum number of tokens to allow in the generated response.").
@@ -75,7 +116,74 @@ For more information, see the https://docs.aws.amazon.com/bedrock/latest/usergui
 			Optional().
 			Advanced().
 			Description("The percentage of most-likely candidates that the model considers for the next token. For example, if you choose a value of 0.8, the model selects from the top 80% of the probability distribution of tokens that could be next in the sequence. ").
-			LintRule(`root = if this < 0 || this > 1 { ["field must be between 0.0-1.0"] }`))
+			LintRule(`root = if this < 0 || this > 1 { ["field must be between 0.0-1.0"] }`)).
+		Field(service.NewStringEnumField(bedcpFieldResponseFormat, "text", "json", "json_schema").
+			Default("text").
+			Version("4.73.0").
+			Description("Specify the model's output format. If `json_schema` is specified, then additionally a `json_schema` must be configured.")).
+		Field(service.NewStringField(bedcpFieldJSONSchema).
+			Optional().
+			Version("4.73.0").
+			Description("The JSON schema to use when responding in `json_schema` format. The schema must describe a JSON object.")).
+		Field(service.NewIntField(bedcpFieldMaxToolCalls).
+			Default(10).
+			Advanced().
+			Version("4.73.0").
+			Description("The maximum number of sequential tool calls.").
+			LintRule(`root = if this <= 0 { ["field must be greater than zero"] }`)).
+		Field(service.NewObjectListField(
+			bedcpFieldTools,
+			service.NewStringField(bedcpToolFieldName).Description("The name of this tool."),
+			service.NewStringField(bedcpToolFieldDesc).Description("A description of this tool, the LLM uses this to decide if the tool should be used."),
+			service.NewObjectField(
+				bedcpToolFieldParams,
+				service.NewStringListField(bedcpToolParamFieldRequired).Default([]string{}).Description("The required parameters for this pipeline."),
+				service.NewObjectMapField(
+					bedcpToolParamFieldProps,
+					service.NewStringField(bedcpToolParamPropFieldType).Description("The type of this parameter."),
+					service.NewStringField(bedcpToolParamPropFieldDescription).Description("A description of this parameter."),
+					service.NewStringListField(bedcpToolParamPropFieldEnum).Default([]string{}).Description("Specifies that this parameter is an enum and only these specific values should be used."),
+				).Description("The properties for the processor's input data"),
+			).Description("The parameters the LLM needs to provide to invoke this tool."),
+			service.NewProcessorListField(bedcpToolFieldPipeline).Description("The pipeline to execute when the LLM uses this tool.").Optional(),
+		).
+			Description("The tools to allow the LLM to invoke. This allows building subpipelines that the LLM can choose to invoke to execute agentic-like actions.").
+			Version("4.73.0").
+			Default([]any{})).
+		LintRule(`root = if this.response_format == "json_schema" && !this.exists("`+bedcpFieldJSONSchema+`") { ["`+"`"+bedcpFieldJSONSchema+"`"+` must be specified when `+"`"+bedcpFieldResponseFormat+"`"+` is json_schema"] }`).
+		Example(
+			"Use subpipelines as tool calls",
+			"This example allows Claude to execute a subpipeline as a tool call to get more data.",
+			`
+input:
+  generate:
+    count: 1
+    mapping: |
+      root = "What is the weather like in Chicago?"
+pipeline:
+  processors:
+    - aws_bedrock_chat:
+        model: anthropic.claude-3-5-sonnet-20240620-v1:0
+        prompt: "${!content().string()}"
+        tools:
+          - name: GetWeather
+            description: "Retrieve the weather for a specific city"
+            parameters:
+              required: ["city"]
+              properties:
+                city:
+                  type: string
+                  description: the city to lookup the weather for
+            processors:
+              - http:
+                  verb: GET
+                  url: 'https://wttr.in/${!this.city}?T'
+                  headers:
+                    # Spoof curl user-ageent to get a plaintext text
+                    User-Agent: curl/8.11.1
+output:
+  stdout: {}
+`)
 }
 
 func newBedrockChatProcessor(conf *service.ParsedConfig, _ *service.Resources) (service.Processor, error) {
@@ -83,7 +191,10 @@ func newBedrockChatProcessor(conf *service.ParsedConfig, _ *service.Resources) (
 	if err != nil {
 		return nil, err
 	}
-	client := bedrockruntime.NewFromConfig(aconf)
+	return newBedrockChatProcessorFromConfig(conf, bedrockruntime.NewFromConfig(aconf))
+}
+
+func newBedrockChatProcessorFromConfig(conf *service.ParsedConfig, client bedrockChatAPI) (*bedrockChatProcessor, error) {
 	model, err := conf.FieldString(bedcpFieldModel)
 	if err != nil {
 		return nil, err
@@ -106,6 +217,20 @@ func newBedrockChatProcessor(conf *service.ParsedConfig, _ *service.Resources) (
 		}
 		p.systemPrompt = pf
 	}
+	if conf.Contains(bedcpFieldHistory) {
+		h, err := conf.FieldBloblang(bedcpFieldHistory)
+		if err != nil {
+			return nil, err
+		}
+		p.history = h
+	}
+	if conf.Contains(bedcpFieldImage) {
+		i, err := conf.FieldBloblang(bedcpFieldImage)
+		if err != nil {
+			return nil, err
+		}
+		p.image = i
+	}
 	if conf.Contains(bedcpFieldMaxTokens) {
 		v, err := conf.FieldInt(bedcpFieldMaxTokens)
 		if err != nil {
@@ -137,37 +262,136 @@ func newBedrockChatProcessor(conf *service.ParsedConfig, _ *service.Resources) (
 		tp := float32(v)
 		p.topP = &tp
 	}
+	format, err := conf.FieldString(bedcpFieldResponseFormat)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "text":
+	case "json":
+		p.outputSchema = map[string]any{"type": "object"}
+	case "json_schema":
+		if !conf.Contains(bedcpFieldJSONSchema) {
+			return nil, fmt.Errorf("using %s %q, but did not specify %s", bedcpFieldResponseFormat, format, bedcpFieldJSONSchema)
+		}
+		raw, err := conf.FieldString(bedcpFieldJSONSchema)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(raw), &p.outputSchema); err != nil {
+			return nil, fmt.Errorf("unable to parse `%s`: %w", bedcpFieldJSONSchema, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown %s: %q", bedcpFieldResponseFormat, format)
+	}
+	if p.maxToolCalls, err = conf.FieldInt(bedcpFieldMaxToolCalls); err != nil {
+		return nil, err
+	}
+	toolConfs, err := conf.FieldObjectList(bedcpFieldTools)
+	if err != nil {
+		return nil, err
+	}
+	for _, toolConf := range toolConfs {
+		t, err := newBedrockChatTool(toolConf)
+		if err != nil {
+			return nil, err
+		}
+		if *t.spec.Name == bedcpStructuredOutputTool {
+			return nil, fmt.Errorf("tool name %q is reserved", bedcpStructuredOutputTool)
+		}
+		p.tools = append(p.tools, t)
+	}
 	return p, nil
 }
 
+func newBedrockChatTool(conf *service.ParsedConfig) (bedrockChatTool, error) {
+	name, err := conf.FieldString(bedcpToolFieldName)
+	if err != nil {
+		return bedrockChatTool{}, err
+	}
+	desc, err := conf.FieldString(bedcpToolFieldDesc)
+	if err != nil {
+		return bedrockChatTool{}, err
+	}
+	required, err := conf.FieldStringList(bedcpToolFieldParams, bedcpToolParamFieldRequired)
+	if err != nil {
+		return bedrockChatTool{}, err
+	}
+	propsConf, err := conf.FieldObjectMap(bedcpToolFieldParams, bedcpToolParamFieldProps)
+	if err != nil {
+		return bedrockChatTool{}, err
+	}
+	props := map[string]any{}
+	for propName, propConf := range propsConf {
+		propType, err := propConf.FieldString(bedcpToolParamPropFieldType)
+		if err != nil {
+			return bedrockChatTool{}, err
+		}
+		prop := map[string]any{"type": propType}
+		propDesc, err := propConf.FieldString(bedcpToolParamPropFieldDescription)
+		if err != nil {
+			return bedrockChatTool{}, err
+		}
+		if propDesc != "" {
+			prop["description"] = propDesc
+		}
+		enum, err := propConf.FieldStringList(bedcpToolParamPropFieldEnum)
+		if err != nil {
+			return bedrockChatTool{}, err
+		}
+		if len(enum) > 0 {
+			prop["enum"] = enum
+		}
+		props[propName] = prop
+	}
+	processors, err := conf.FieldProcessorList(bedcpToolFieldPipeline)
+	if err != nil {
+		return bedrockChatTool{}, err
+	}
+	return bedrockChatTool{
+		spec: bedrocktypes.ToolSpecification{
+			Name:        &name,
+			Description: &desc,
+			InputSchema: &bedrocktypes.ToolInputSchemaMemberJson{
+				Value: document.NewLazyDocument(map[string]any{
+					"type":       "object",
+					"required":   required,
+					"properties": props,
+				}),
+			},
+		},
+		processors: processors,
+	}, nil
+}
+
+type bedrockChatAPI interface {
+	Converse(context.Context, *bedrockruntime.ConverseInput, ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error)
+}
+
+type bedrockChatTool struct {
+	spec       bedrocktypes.ToolSpecification
+	processors []*service.OwnedProcessor
+}
+
 type bedrockChatProcessor struct {
-	client *bedrockruntime.Client
+	client bedrockChatAPI
 	model  string
 
 	userPrompt   *service.InterpolatedString
 	systemPrompt *service.InterpolatedString
+	history      *bloblang.Executor
+	image        *bloblang.Executor
 	maxTokens    *int32
 	stop         []string
 	temp         *float32
 	topP         *float32
+	outputSchema map[string]any
+	maxToolCalls int
+	tools        []bedrockChatTool
 }
 
 func (b *bedrockChatProcessor) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
-	prompt, err := b.computePrompt(msg)
-	if err != nil {
-		return nil, err
-	}
 	input := &bedrockruntime.ConverseInput{
-		Messages: []bedrocktypes.Message{
-			{
-				Role: bedrocktypes.ConversationRoleUser,
-				Content: []bedrocktypes.ContentBlock{
-					&bedrocktypes.ContentBlockMemberText{
-						Value: prompt,
-					},
-				},
-			},
-		},
 		ModelId: &b.model,
 		InferenceConfig: &bedrocktypes.InferenceConfiguration{
 			MaxTokens:     b.maxTokens,
@@ -185,28 +409,260 @@ func (b *bedrockChatProcessor) Process(ctx context.Context, msg *service.Message
 			&bedrocktypes.SystemContentBlockMemberText{Value: prompt},
 		}
 	}
-	resp, err := b.client.Converse(ctx, input)
+	history, err := b.computeHistory(msg)
 	if err != nil {
 		return nil, err
 	}
-	respOut, ok := resp.Output.(*bedrocktypes.ConverseOutputMemberMessage)
-	if !ok {
-		return nil, fmt.Errorf("unexpected output: %T", resp)
+	input.Messages = history
+	userMsg, err := b.computeUserMessage(msg)
+	if err != nil {
+		return nil, err
 	}
-	content := respOut.Value.Content
-	if len(content) != 1 {
-		return nil, fmt.Errorf("unexpected number of response content: %d", len(content))
+	input.Messages = append(input.Messages, userMsg)
+	toolConfig := b.toolConfig()
+	input.ToolConfig = toolConfig
+
+	// Each round of tool calls is followed by another turn of the model, where
+	// the turn after the last round must give the answer.
+	for i := 0; ; i++ {
+		if i == b.maxToolCalls && b.outputSchema != nil {
+			// Force the model to give an answer instead of calling more tools.
+			forced := *toolConfig
+			forced.ToolChoice = &bedrocktypes.ToolChoiceMemberTool{
+				Value: bedrocktypes.SpecificToolChoice{Name: aws.String(bedcpStructuredOutputTool)},
+			}
+			input.ToolConfig = &forced
+		}
+		resp, err := b.client.Converse(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		respOut, ok := resp.Output.(*bedrocktypes.ConverseOutputMemberMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected output: %T", resp.Output)
+		}
+		content := respOut.Value.Content
+		var toolUses []*bedrocktypes.ToolUseBlock
+		for _, c := range content {
+			if tu, ok := c.(*bedrocktypes.ContentBlockMemberToolUse); ok {
+				toolUses = append(toolUses, &tu.Value)
+			}
+		}
+		if len(toolUses) == 0 {
+			if b.outputSchema != nil {
+				return nil, errors.New("model did not respond with structured output")
+			}
+			return b.textResponse(msg, content)
+		}
+		if b.outputSchema != nil {
+			for _, tu := range toolUses {
+				if aws.ToString(tu.Name) == bedcpStructuredOutputTool {
+					return b.structuredResponse(msg, tu)
+				}
+			}
+		}
+		if i == b.maxToolCalls {
+			return nil, fmt.Errorf("model did not finish after %d tool calls", b.maxToolCalls)
+		}
+		input.Messages = append(input.Messages, respOut.Value)
+		results := make([]bedrocktypes.ContentBlock, 0, len(toolUses))
+		for _, tu := range toolUses {
+			result, err := b.callTool(ctx, tu)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, &bedrocktypes.ContentBlockMemberToolResult{Value: *result})
+		}
+		input.Messages = append(input.Messages, bedrocktypes.Message{
+			Role:    bedrocktypes.ConversationRoleUser,
+			Content: results,
+		})
+	}
+}
+
+func (b *bedrockChatProcessor) toolConfig() *bedrocktypes.ToolConfiguration {
+	if len(b.tools) == 0 && b.outputSchema == nil {
+		return nil
+	}
+	cfg := &bedrocktypes.ToolConfiguration{}
+	for _, t := range b.tools {
+		cfg.Tools = append(cfg.Tools, &bedrocktypes.ToolMemberToolSpec{Value: t.spec})
+	}
+	if b.outputSchema != nil {
+		cfg.Tools = append(cfg.Tools, &bedrocktypes.ToolMemberToolSpec{
+			Value: bedrocktypes.ToolSpecification{
+				Name:        aws.String(bedcpStructuredOutputTool),
+				Description: aws.String("Respond to the user with the final answer. This tool must be used to give the final answer."),
+				InputSchema: &bedrocktypes.ToolInputSchemaMemberJson{
+					Value: document.NewLazyDocument(b.outputSchema),
+				},
+			},
+		})
+		// The model must always call a tool, eventually it will call the
+		// structured output tool with the answer.
+		cfg.ToolChoice = &bedrocktypes.ToolChoiceMemberAny{}
+	}
+	return cfg
+}
+
+func (b *bedrockChatProcessor) callTool(ctx context.Context, tu *bedrocktypes.ToolUseBlock) (*bedrocktypes.ToolResultBlock, error) {
+	name := aws.ToString(tu.Name)
+	idx := slices.IndexFunc(b.tools, func(t bedrockChatTool) bool { return *t.spec.Name == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("unknown tool call requested: %q", name)
+	}
+	toolCallMsg := service.NewMessage(nil)
+	if tu.Input != nil {
+		args, err := tu.Input.MarshalSmithyDocument()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tool %q arguments: %w", name, err)
+		}
+		toolCallMsg.SetBytes(args)
+	}
+	batches, err := service.ExecuteProcessors(ctx, b.tools[idx].processors, service.MessageBatch{toolCallMsg})
+	if err != nil {
+		return nil, fmt.Errorf("error executing tool %q: %w", name, err)
+	}
+	result := &bedrocktypes.ToolResultBlock{ToolUseId: tu.ToolUseId}
+	for _, m := range slices.Concat(batches...) {
+		if err := m.GetError(); err != nil {
+			return nil, fmt.Errorf("error executing tool %q: %w", name, err)
+		}
+		v, err := m.AsBytes()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tool %q output: %w", name, err)
+		}
+		if !utf8.Valid(v) {
+			return nil, fmt.Errorf("tool %q output is not valid UTF-8", name)
+		}
+		result.Content = append(result.Content, &bedrocktypes.ToolResultContentBlockMemberText{Value: string(v)})
+	}
+	if len(result.Content) == 0 {
+		// The API rejects empty tool results.
+		result.Content = append(result.Content, &bedrocktypes.ToolResultContentBlockMemberText{Value: "(no output)"})
+	}
+	return result, nil
+}
+
+func (*bedrockChatProcessor) textResponse(msg *service.Message, content []bedrocktypes.ContentBlock) (service.MessageBatch, error) {
+	var text strings.Builder
+	for _, c := range content {
+		switch c := c.(type) {
+		case *bedrocktypes.ContentBlockMemberText:
+			_, _ = text.WriteString(c.Value)
+		case *bedrocktypes.ContentBlockMemberReasoningContent:
+			// Reasoning is not part of the response.
+		default:
+			return nil, fmt.Errorf("unsupported response content type: %T", c)
+		}
 	}
 	out := msg.Copy()
-	switch c := content[0].(type) {
-	case *bedrocktypes.ContentBlockMemberText:
-		out.SetStructured(c.Value)
-	default:
-		return nil, fmt.Errorf("unsupported response content type: %T", content[0])
+	out.SetStructured(text.String())
+	return service.MessageBatch{out}, nil
+}
+
+func (*bedrockChatProcessor) structuredResponse(msg *service.Message, tu *bedrocktypes.ToolUseBlock) (service.MessageBatch, error) {
+	if tu.Input == nil {
+		return nil, errors.New("model responded with empty structured output")
+	}
+	b, err := tu.Input.MarshalSmithyDocument()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read structured output: %w", err)
 	}
+	out := msg.Copy()
+	out.SetBytes(b)
 	return service.MessageBatch{out}, nil
 }
 
+func (b *bedrockChatProcessor) computeHistory(msg *service.Message) ([]bedrocktypes.Message, error) {
+	if b.history == nil {
+		return nil, nil
+	}
+	h, err := msg.BloblangQuery(b.history)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute bloblang for `%s`: %w", bedcpFieldHistory, err)
+	}
+	raw, err := h.AsBytes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert `%s` result to bytes: %w", bedcpFieldHistory, err)
+	}
+	var entries []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("unable to parse `%s`: %w", bedcpFieldHistory, err)
+	}
+	history := make([]bedrocktypes.Message, 0, len(entries))
+	for _, e := range entries {
+		var role bedrocktypes.ConversationRole
+		switch e.Role {
+		case "user":
+			role = bedrocktypes.ConversationRoleUser
+		case "assistant":
+			role = bedrocktypes.ConversationRoleAssistant
+		default:
+			return nil, fmt.Errorf("invalid role %q in `%s`, expected user or assistant", e.Role, bedcpFieldHistory)
+		}
+		history = append(history, bedrocktypes.Message{
+			Role:    role,
+			Content: []bedrocktypes.ContentBlock{&bedrocktypes.ContentBlockMemberText{Value: e.Content}},
+		})
+	}
+	return history, nil
+}
+
+func (b *bedrockChatProcessor) computeUserMessage(msg *service.Message) (bedrocktypes.Message, error) {
+	prompt, err := b.computePrompt(msg)
+	if err != nil {
+		return bedrocktypes.Message{}, err
+	}
+	userMsg := bedrocktypes.Message{
+		Role: bedrocktypes.ConversationRoleUser,
+		Content: []bedrocktypes.ContentBlock{
+			&bedrocktypes.ContentBlockMemberText{
+				Value: prompt,
+			},
+		},
+	}
+	if b.image != nil {
+		i, err := msg.BloblangQuery(b.image)
+		if err != nil {
+			return bedrocktypes.Message{}, fmt.Errorf("unable to execute bloblang for `%s`: %w", bedcpFieldImage, err)
+		}
+		img, err := i.AsBytes()
+		if err != nil {
+			return bedrocktypes.Message{}, fmt.Errorf("unable to convert `%s` result to a byte array: %w", bedcpFieldImage, err)
+		}
+		format, err := detectBedrockImageFormat(img)
+		if err != nil {
+			return bedrocktypes.Message{}, err
+		}
+		userMsg.Content = append(userMsg.Content, &bedrocktypes.ContentBlockMemberImage{
+			Value: bedrocktypes.ImageBlock{
+				Format: format,
+				Source: &bedrocktypes.ImageSourceMemberBytes{Value: img},
+			},
+		})
+	}
+	return userMsg, nil
+}
+
+func detectBedrockImageFormat(img []byte) (bedrocktypes.ImageFormat, error) {
+	switch contentType := http.DetectContentType(img); contentType {
+	case "image/png":
+		return bedrocktypes.ImageFormatPng, nil
+	case "image/jpeg":
+		return bedrocktypes.ImageFormatJpeg, nil
+	case "image/gif":
+		return bedrocktypes.ImageFormatGif, nil
+	case "image/webp":
+		return bedrocktypes.ImageFormatWebp, nil
+	default:
+		return "", fmt.Errorf("unsupported `%s` content type: %s", bedcpFieldImage, contentType)
+	}
+}
+
 func (b *bedrockChatProcessor) computePrompt(msg *service.Message) (string, error) {
 	if b.userPrompt != nil {
 		return b.userPrompt.TryString(msg)
@@ -221,6 +677,13 @@ func (b *bedrockChatProcessor) computePrompt(msg *service.Message) (string, erro
 	return string(buf), nil
 }
 
-func (*bedrockChatProcessor) Close(context.Context) error {
+func (b *bedrockChatProcessor) Close(ctx context.Context) error {
+	for _, t := range b.tools {
+		for _, p := range t.processors {
+			if err := p.Close(ctx); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
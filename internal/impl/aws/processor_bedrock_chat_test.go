@@ -0,0 +1,243 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+type mockBedrockChat struct {
+	inputs []*bedrockruntime.ConverseInput
+	fn     func(*bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error)
+}
+
+func (m *mockBedrockChat) Converse(_ context.Context, in *bedrockruntime.ConverseInput, _ ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error) {
+	// Capture a copy of the messages so far as the processor appends to them.
+	cpy := *in
+	cpy.Messages = append([]bedrocktypes.Message(nil), in.Messages...)
+	m.inputs = append(m.inputs, &cpy)
+	return m.fn(in)
+}
+
+func bedrockChatOutput(content ...bedrocktypes.ContentBlock) *bedrockruntime.ConverseOutput {
+	return &bedrockruntime.ConverseOutput{
+		Output: &bedrocktypes.ConverseOutputMemberMessage{
+			Value: bedrocktypes.Message{
+				Role:    bedrocktypes.ConversationRoleAssistant,
+				Content: content,
+			},
+		},
+	}
+}
+
+func bedrockToolUse(id, name string, input any) bedrocktypes.ContentBlock {
+	return &bedrocktypes.ContentBlockMemberToolUse{
+		Value: bedrocktypes.ToolUseBlock{
+			ToolUseId: aws.String(id),
+			Name:      aws.String(name),
+			Input:     document.NewLazyDocument(input),
+		},
+	}
+}
+
+func newTestBedrockChat(t *testing.T, yaml string, client bedrockChatAPI) *bedrockChatProcessor {
+	t.Helper()
+	conf, err := newBedrockChatConfigSpec().ParseYAML(yaml, nil)
+	require.NoError(t, err)
+	p, err := newBedrockChatProcessorFromConfig(conf, client)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, p.Close(context.Background())) })
+	return p
+}
+
+func TestBedrockChatToolCalls(t *testing.T) {
+	mock := &mockBedrockChat{}
+	mock.fn = func(*bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+		if len(mock.inputs) == 1 {
+			return bedrockChatOutput(bedrockToolUse("call1", "get_weather", map[string]any{"city": "Chicago"})), nil
+		}
+		return bedrockChatOutput(&bedrocktypes.ContentBlockMemberText{Value: "It is sunny"}), nil
+	}
+	p := newTestBedrockChat(t, `
+model: foo
+history: 'root = [{"role": "user", "content": "hi"}, {"role": "assistant", "content": "hello"}]'
+tools:
+  - name: get_weather
+    description: Get the weather
+    parameters:
+      required: [city]
+      properties:
+        city:
+          type: string
+          description: The city
+    processors:
+      - mapping: 'root = "sunny in " + this.city'
+`, mock)
+
+	batch, err := p.Process(t.Context(), service.NewMessage([]byte("what is the weather?")))
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	v, err := batch[0].AsStructured()
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny", v)
+
+	require.Len(t, mock.inputs, 2)
+	first := mock.inputs[0]
+	require.Len(t, first.Messages, 3)
+	assert.Equal(t, bedrocktypes.ConversationRoleUser, first.Messages[0].Role)
+	assert.Equal(t, bedrocktypes.ConversationRoleAssistant, first.Messages[1].Role)
+	assert.Equal(t, &bedrocktypes.ContentBlockMemberText{Value: "what is the weather?"}, first.Messages[2].Content[0])
+	require.NotNil(t, first.ToolConfig)
+	require.Len(t, first.ToolConfig.Tools, 1)
+	assert.Nil(t, first.ToolConfig.ToolChoice)
+
+	second := mock.inputs[1]
+	require.Len(t, second.Messages, 5)
+	result, ok := second.Messages[4].Content[0].(*bedrocktypes.ContentBlockMemberToolResult)
+	require.True(t, ok)
+	assert.Equal(t, "call1", aws.ToString(result.Value.ToolUseId))
+	assert.Equal(t, []bedrocktypes.ToolResultContentBlock{
+		&bedrocktypes.ToolResultContentBlockMemberText{Value: "sunny in Chicago"},
+	}, result.Value.Content)
+}
+
+func TestBedrockChatMaxToolCalls(t *testing.T) {
+	mock := &mockBedrockChat{}
+	mock.fn = func(*bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+		return bedrockChatOutput(bedrockToolUse("call", "noop", map[string]any{})), nil
+	}
+	p := newTestBedrockChat(t, `
+model: foo
+max_tool_calls: 2
+tools:
+  - name: noop
+    description: Does nothing
+    parameters: {}
+    processors:
+      - mapping: 'root = "ok"'
+`, mock)
+
+	_, err := p.Process(t.Context(), service.NewMessage([]byte("hello")))
+	require.ErrorContains(t, err, "model did not finish after 2 tool calls")
+
+	// Tools are run exactly twice, and the model is given one more turn to
+	// answer after the last results.
+	require.Len(t, mock.inputs, 3)
+	var toolRuns int
+	for _, m := range mock.inputs[2].Messages {
+		for _, c := range m.Content {
+			if _, ok := c.(*bedrocktypes.ContentBlockMemberToolResult); ok {
+				toolRuns++
+			}
+		}
+	}
+	assert.Equal(t, 2, toolRuns)
+}
+
+func TestBedrockChatMaxToolCallsStructuredOutput(t *testing.T) {
+	mock := &mockBedrockChat{}
+	mock.fn = func(in *bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+		if _, forced := in.ToolConfig.ToolChoice.(*bedrocktypes.ToolChoiceMemberTool); forced {
+			return bedrockChatOutput(bedrockToolUse("answer", bedcpStructuredOutputTool, map[string]any{"answer": "yes"})), nil
+		}
+		return bedrockChatOutput(bedrockToolUse("call", "noop", map[string]any{})), nil
+	}
+	p := newTestBedrockChat(t, `
+model: foo
+max_tool_calls: 1
+response_format: json_schema
+json_schema: '{"type": "object", "properties": {"answer": {"type": "string"}}}'
+tools:
+  - name: noop
+    description: Does nothing
+    parameters: {}
+    processors:
+      - mapping: 'root = "ok"'
+`, mock)
+
+	batch, err := p.Process(t.Context(), service.NewMessage([]byte("hello")))
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	b, err := batch[0].AsBytes()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"answer":"yes"}`, string(b))
+	assert.Len(t, mock.inputs, 2)
+}
+
+func TestBedrockChatStructuredOutput(t *testing.T) {
+	mock := &mockBedrockChat{}
+	mock.fn = func(*bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+		return bedrockChatOutput(bedrockToolUse("call1", bedcpStructuredOutputTool, map[string]any{"answer": "yes"})), nil
+	}
+	p := newTestBedrockChat(t, `
+model: foo
+prompt: 'is it ${! content() }?'
+response_format: json_schema
+json_schema: '{"type": "object", "properties": {"answer": {"type": "string"}}}'
+`, mock)
+
+	batch, err := p.Process(t.Context(), service.NewMessage([]byte("sunny")))
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	b, err := batch[0].AsBytes()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"answer":"yes"}`, string(b))
+
+	require.Len(t, mock.inputs, 1)
+	require.NotNil(t, mock.inputs[0].ToolConfig)
+	require.Len(t, mock.inputs[0].ToolConfig.Tools, 1)
+	assert.Equal(t, &bedrocktypes.ToolChoiceMemberAny{}, mock.inputs[0].ToolConfig.ToolChoice)
+	assert.Equal(t, &bedrocktypes.ContentBlockMemberText{Value: "is it sunny?"}, mock.inputs[0].Messages[0].Content[0])
+}
+
+func TestBedrockChatImage(t *testing.T) {
+	mock := &mockBedrockChat{}
+	mock.fn = func(*bedrockruntime.ConverseInput) (*bedrockruntime.ConverseOutput, error) {
+		return bedrockChatOutput(&bedrocktypes.ContentBlockMemberText{Value: "a cat"}), nil
+	}
+	p := newTestBedrockChat(t, `
+model: foo
+prompt: describe this image
+image: 'root = content()'
+`, mock)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	_, err := p.Process(t.Context(), service.NewMessage(png))
+	require.NoError(t, err)
+
+	require.Len(t, mock.inputs, 1)
+	content := mock.inputs[0].Messages[0].Content
+	require.Len(t, content, 2)
+	assert.Equal(t, &bedrocktypes.ContentBlockMemberImage{
+		Value: bedrocktypes.ImageBlock{
+			Format: bedrocktypes.ImageFormatPng,
+			Source: &bedrocktypes.ImageSourceMemberBytes{Value: png},
+		},
+	}, content[1])
+
+	_, err = p.Process(t.Context(), service.NewMessage([]byte("not an image")))
+	require.ErrorContains(t, err, "unsupported `image` content type")
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/v2"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Common fields for AMQP based components
+	amqpFieldConnectionString = "connection_string"
+	amqpFieldNamespace        = "namespace"
+)
+
+func amqpNamespaceFields(entityDescription string) []*service.ConfigField {
+	return []*service.ConfigField{
+		service.NewStringField(amqpFieldConnectionString).
+			Description("A connection string of the namespace containing a shared access key or signature. The connection string can optionally include the `EntityPath` of the " + entityDescription + ". Either this field or `" + amqpFieldNamespace + "` must be set.").
+			Example("Endpoint=sb://foo.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=bar").
+			Secret().
+			Default(""),
+		service.NewStringField(amqpFieldNamespace).
+			Description("The fully qualified namespace to connect to using the default Azure credentials, which are obtained from the environment. This field is ignored if `" + amqpFieldConnectionString + "` is set.").
+			Example("foo.servicebus.windows.net").
+			Default(""),
+	}
+}
+
+// amqpNamespaceConfig describes how the clients of the Event Hubs and Service
+// Bus SDKs connect and authenticate to a namespace.
+type amqpNamespaceConfig struct {
+	connectionString string
+	entityPath       string
+
+	namespace string
+	cred      azcore.TokenCredential
+}
+
+func amqpNamespaceConfigFromParsed(pConf *service.ParsedConfig) (conf amqpNamespaceConfig, err error) {
+	if conf.connectionString, err = pConf.FieldString(amqpFieldConnectionString); err != nil {
+		return
+	}
+	if conf.connectionString != "" {
+		// Event Hubs and Service Bus share the same connection string format.
+		var props azeventhubs.ConnectionStringProperties
+		if props, err = azeventhubs.ParseConnectionString(conf.connectionString); err != nil {
+			err = fmt.Errorf("parsing connection string: %w", err)
+			return
+		}
+		if props.EntityPath != nil {
+			conf.entityPath = *props.EntityPath
+		}
+		return
+	}
+
+	if conf.namespace, err = pConf.FieldString(amqpFieldNamespace); err != nil {
+		return
+	}
+	if conf.namespace == "" {
+		err = fmt.Errorf("either %v or %v must be set", amqpFieldConnectionString, amqpFieldNamespace)
+		return
+	}
+	conf.namespace = strings.TrimSuffix(strings.TrimPrefix(conf.namespace, "sb://"), "/")
+
+	if conf.cred, err = azidentity.NewDefaultAzureCredential(nil); err != nil {
+		err = fmt.Errorf("getting default Azure credentials: %w", err)
+	}
+	return
+}
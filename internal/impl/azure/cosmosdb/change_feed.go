@@ -0,0 +1,309 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosmosdb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+// The change feed isn't exposed by azcosmos, and so it is read with requests
+// to the REST API of the container.
+// https://learn.microsoft.com/en-us/rest/api/cosmos-db/list-documents
+
+const (
+	changeFeedAPIVersion = "2018-12-31"
+
+	// The sub-status of a 410 response indicating that a partition key range
+	// has been split or merged.
+	subStatusPartitionKeyRangeGone = 1002
+)
+
+// ErrPartitionKeyRangeGone is returned when reading the change feed of a
+// partition key range that no longer exists, in which case the change feed must
+// be continued from the ranges that replaced it.
+var ErrPartitionKeyRangeGone = errors.New("partition key range is gone")
+
+// PartitionKeyRange describes a range of the partition keys of a container.
+type PartitionKeyRange struct {
+	ID           string   `json:"id"`
+	MinInclusive string   `json:"minInclusive"`
+	MaxExclusive string   `json:"maxExclusive"`
+	Parents      []string `json:"parents"`
+}
+
+// ChangeFeedPage is a page of changes of a partition key range.
+type ChangeFeedPage struct {
+	// The documents that changed, which is empty when there were no changes.
+	Documents []json.RawMessage
+	// The continuation from which to read the next page.
+	Continuation  string
+	ActivityID    string
+	RequestCharge float64
+}
+
+// ChangeFeedStart describes where to begin reading the change feed of a
+// partition key range that has no continuation.
+type ChangeFeedStart struct {
+	// FromNow begins with changes made after the first read.
+	FromNow bool
+	// FromTime begins with changes made after the time, unless it is zero.
+	FromTime time.Time
+}
+
+// ChangeFeedClient reads the change feed of a container.
+type ChangeFeedClient struct {
+	endpoint     string
+	resourceLink string
+	key          []byte
+	cred         azcore.TokenCredential
+	scope        string
+	httpClient   *http.Client
+}
+
+// ChangeFeedClientFromParsed creates a change feed client from a parsed config
+// containing the container client config fields.
+func ChangeFeedClientFromParsed(conf *service.ParsedConfig) (*ChangeFeedClient, error) {
+	var endpoint, accountKey, connectionString string
+	var err error
+	if conf.Contains(fieldEndpoint) {
+		if endpoint, err = conf.FieldString(fieldEndpoint); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(fieldAccountKey) {
+		if accountKey, err = conf.FieldString(fieldAccountKey); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(fieldConnectionString) {
+		if connectionString, err = conf.FieldString(fieldConnectionString); err != nil {
+			return nil, err
+		}
+	}
+	if endpoint == "" && connectionString != "" {
+		if endpoint, accountKey, err = parseConnectionString(connectionString); err != nil {
+			return nil, err
+		}
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("either %s or %s must be set", fieldEndpoint, fieldConnectionString)
+	}
+
+	database, err := conf.FieldString(fieldDatabase)
+	if err != nil {
+		return nil, err
+	}
+	container, err := conf.FieldString(fieldContainer)
+	if err != nil {
+		return nil, err
+	}
+	return NewChangeFeedClient(endpoint, accountKey, database, container)
+}
+
+// NewChangeFeedClient creates a change feed client for a container, which
+// authenticates with the account key when provided and otherwise with the
+// default Azure credentials.
+func NewChangeFeedClient(endpoint, accountKey, database, container string) (*ChangeFeedClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid %s: %s", fieldEndpoint, endpoint)
+	}
+
+	c := &ChangeFeedClient{
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		resourceLink: "dbs/" + database + "/colls/" + container,
+		httpClient:   &http.Client{},
+	}
+	if accountKey != "" {
+		if c.key, err = base64.StdEncoding.DecodeString(accountKey); err != nil {
+			return nil, fmt.Errorf("failed to deserialise %s: %s", fieldAccountKey, err)
+		}
+	} else {
+		if c.cred, err = azidentity.NewDefaultAzureCredential(nil); err != nil {
+			return nil, fmt.Errorf("error getting default Azure credentials: %s", err)
+		}
+		c.scope = u.Scheme + "://" + u.Host + "/.default"
+	}
+	return c, nil
+}
+
+func parseConnectionString(connectionString string) (endpoint, accountKey string, err error) {
+	for part := range strings.SplitSeq(connectionString, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "AccountEndpoint":
+			endpoint = value
+		case "AccountKey":
+			accountKey = value
+		}
+	}
+	if endpoint == "" || accountKey == "" {
+		return "", "", fmt.Errorf("failed parsing %s: AccountEndpoint and AccountKey are required", fieldConnectionString)
+	}
+	return
+}
+
+// authorization returns the value of the authorization header of a request.
+// https://learn.microsoft.com/en-us/rest/api/cosmos-db/access-control-on-cosmosdb-resources
+func (c *ChangeFeedClient) authorization(ctx context.Context, method, resourceType, date string) (string, error) {
+	if c.cred != nil {
+		t, err := c.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{c.scope}})
+		if err != nil {
+			return "", err
+		}
+		return url.QueryEscape("type=aad&ver=1.0&sig=" + t.Token), nil
+	}
+
+	stringToSign := strings.ToLower(method) + "\n" + resourceType + "\n" + c.resourceLink + "\n" + strings.ToLower(date) + "\n\n"
+	mac := hmac.New(sha256.New, c.key)
+	_, _ = mac.Write([]byte(stringToSign))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return url.QueryEscape("type=master&ver=1.0&sig=" + sig), nil
+}
+
+func (c *ChangeFeedClient) do(ctx context.Context, resourceType string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/"+c.resourceLink+"/"+resourceType, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	date := time.Now().UTC().Format(http.TimeFormat)
+	auth, err := c.authorization(ctx, http.MethodGet, resourceType, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize request: %w", err)
+	}
+	req.Header.Set("x-ms-date", date)
+	req.Header.Set("x-ms-version", changeFeedAPIVersion)
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Accept", "application/json")
+	return c.httpClient.Do(req)
+}
+
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	return fmt.Errorf("unexpected status %v (sub-status %v): %s", res.StatusCode, res.Header.Get("x-ms-substatus"), body)
+}
+
+// PartitionKeyRanges returns the current partition key ranges of the
+// container.
+func (c *ChangeFeedClient) PartitionKeyRanges(ctx context.Context) ([]PartitionKeyRange, error) {
+	var ranges []PartitionKeyRange
+	var continuation string
+	for {
+		header := http.Header{}
+		if continuation != "" {
+			header.Set("x-ms-continuation", continuation)
+		}
+		res, err := c.do(ctx, "pkranges", header)
+		if err != nil {
+			return nil, err
+		}
+
+		var body struct {
+			PartitionKeyRanges []PartitionKeyRange `json:"PartitionKeyRanges"`
+		}
+		if res.StatusCode != http.StatusOK {
+			err = responseError(res)
+		} else {
+			err = json.NewDecoder(res.Body).Decode(&body)
+		}
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list partition key ranges: %w", err)
+		}
+
+		ranges = append(ranges, body.PartitionKeyRanges...)
+		if continuation = res.Header.Get("x-ms-continuation"); continuation == "" {
+			return ranges, nil
+		}
+	}
+}
+
+// ReadChanges reads the next page of changes of a partition key range. The
+// continuation of the returned page is set even when there are no changes,
+// and ErrPartitionKeyRangeGone is returned when the range has been split.
+func (c *ChangeFeedClient) ReadChanges(ctx context.Context, rangeID, continuation string, start ChangeFeedStart, maxItemCount int) (*ChangeFeedPage, error) {
+	header := http.Header{}
+	header.Set("A-IM", "Incremental feed")
+	header.Set("x-ms-documentdb-partitionkeyrangeid", rangeID)
+	header.Set("x-ms-max-item-count", strconv.Itoa(maxItemCount))
+	switch {
+	case continuation != "":
+		header.Set("If-None-Match", continuation)
+	case start.FromNow:
+		header.Set("If-None-Match", "*")
+	case !start.FromTime.IsZero():
+		header.Set("If-Modified-Since", start.FromTime.UTC().Format(http.TimeFormat))
+	}
+
+	res, err := c.do(ctx, "docs", header)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	page := &ChangeFeedPage{
+		Continuation: res.Header.Get("etag"),
+		ActivityID:   res.Header.Get("x-ms-activity-id"),
+	}
+	page.RequestCharge, _ = strconv.ParseFloat(res.Header.Get("x-ms-request-charge"), 64)
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var body struct {
+			Documents []json.RawMessage `json:"Documents"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("failed to decode change feed: %w", err)
+		}
+		page.Documents = body.Documents
+	case http.StatusNotModified:
+		if page.Continuation == "" {
+			page.Continuation = continuation
+		}
+	case http.StatusGone:
+		if subStatus, _ := strconv.Atoi(res.Header.Get("x-ms-substatus")); subStatus == subStatusPartitionKeyRangeGone {
+			return nil, ErrPartitionKeyRangeGone
+		}
+		return nil, responseError(res)
+	default:
+		return nil, responseError(res)
+	}
+	return page, nil
+}
@@ -240,6 +240,16 @@ func CRUDFields(hasReadOperation bool) []*service.ConfigField {
 
 // ContainerClientFromParsed creates the container client from a parsed config.
 func ContainerClientFromParsed(conf *service.ParsedConfig) (*azcosmos.ContainerClient, error) {
+	container, err := conf.FieldString(fieldContainer)
+	if err != nil {
+		return nil, err
+	}
+	return NamedContainerClientFromParsed(conf, container)
+}
+
+// NamedContainerClientFromParsed creates a client for a container of the
+// configured database other than the configured container.
+func NamedContainerClientFromParsed(conf *service.ParsedConfig, container string) (*azcosmos.ContainerClient, error) {
 	var endpoint string
 	var err error
 	if conf.Contains(fieldEndpoint) {
@@ -295,11 +305,6 @@ func ContainerClientFromParsed(conf *service.ParsedConfig) (*azcosmos.ContainerC
 		return nil, err
 	}
 
-	container, err := conf.FieldString(fieldContainer)
-	if err != nil {
-		return nil, err
-	}
-
 	containerClient, err := client.NewContainer(database, container)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container client: %s", err)
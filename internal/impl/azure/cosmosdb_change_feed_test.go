@@ -0,0 +1,134 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/connect/v4/internal/impl/azure/cosmosdb"
+)
+
+const testCosmosDBAccountKey = "C2y6yDjf5/R+ob0N8A7Cgv30VRDJIWEHLM+4QDU5DE2nQ9nDuVTqobD4b8mGGyPMbIZnqyMsEcaGQy67XIw/Jw=="
+
+func TestCosmosDBChangeFeedClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "type%3Dmaster"))
+		assert.NotEmpty(t, r.Header.Get("x-ms-date"))
+
+		switch r.URL.Path {
+		case "/dbs/foo/colls/bar/pkranges":
+			if r.Header.Get("x-ms-continuation") == "" {
+				w.Header().Set("x-ms-continuation", "next")
+				_, _ = w.Write([]byte(`{"PartitionKeyRanges":[{"id":"0","minInclusive":"","maxExclusive":"80"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"PartitionKeyRanges":[{"id":"1","minInclusive":"80","maxExclusive":"FF","parents":["a"]}]}`))
+		case "/dbs/foo/colls/bar/docs":
+			assert.Equal(t, "Incremental feed", r.Header.Get("A-IM"))
+			switch r.Header.Get("x-ms-documentdb-partitionkeyrangeid") {
+			case "0":
+				assert.Empty(t, r.Header.Get("If-None-Match"))
+				assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", r.Header.Get("If-Modified-Since"))
+				w.Header().Set("etag", `"10"`)
+				w.Header().Set("x-ms-request-charge", "2.5")
+				_, _ = w.Write([]byte(`{"Documents":[{"id":"a"},{"id":"b"}]}`))
+			case "1":
+				assert.Equal(t, `"10"`, r.Header.Get("If-None-Match"))
+				w.Header().Set("etag", `"10"`)
+				w.WriteHeader(http.StatusNotModified)
+			default:
+				w.Header().Set("x-ms-substatus", "1002")
+				w.WriteHeader(http.StatusGone)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := cosmosdb.NewChangeFeedClient(srv.URL, testCosmosDBAccountKey, "foo", "bar")
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	ranges, err := client.PartitionKeyRanges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []cosmosdb.PartitionKeyRange{
+		{ID: "0", MinInclusive: "", MaxExclusive: "80"},
+		{ID: "1", MinInclusive: "80", MaxExclusive: "FF", Parents: []string{"a"}},
+	}, ranges)
+
+	start := cosmosdb.ChangeFeedStart{FromTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	page, err := client.ReadChanges(ctx, "0", "", start, 10)
+	require.NoError(t, err)
+	assert.Equal(t, `"10"`, page.Continuation)
+	assert.InDelta(t, 2.5, page.RequestCharge, 0.001)
+	require.Len(t, page.Documents, 2)
+	assert.JSONEq(t, `{"id":"a"}`, string(page.Documents[0]))
+
+	page, err = client.ReadChanges(ctx, "1", `"10"`, start, 10)
+	require.NoError(t, err)
+	assert.Equal(t, `"10"`, page.Continuation)
+	assert.Empty(t, page.Documents)
+
+	_, err = client.ReadChanges(ctx, "2", `"10"`, start, 10)
+	require.ErrorIs(t, err, cosmosdb.ErrPartitionKeyRangeGone)
+}
+
+type memCheckpointStore map[string]string
+
+func (m memCheckpointStore) get(_ context.Context, rangeID string) (string, error) {
+	return m[rangeID], nil
+}
+
+func (m memCheckpointStore) set(_ context.Context, rangeID, continuation string) error {
+	m[rangeID] = continuation
+	return nil
+}
+
+func TestCosmosDBChangeFeedStartingContinuation(t *testing.T) {
+	r := &cosmosDBChangeFeedReader{
+		store: memCheckpointStore{
+			"0": `"5"`,
+			"1": `"7"`,
+			"2": `"9"`,
+		},
+	}
+
+	for _, test := range []struct {
+		name     string
+		pkr      cosmosdb.PartitionKeyRange
+		expected string
+	}{
+		{name: "own checkpoint", pkr: cosmosdb.PartitionKeyRange{ID: "2", Parents: []string{"0"}}, expected: `"9"`},
+		{name: "latest parent", pkr: cosmosdb.PartitionKeyRange{ID: "3", Parents: []string{"0", "1"}}, expected: `"7"`},
+		{name: "no checkpoint", pkr: cosmosdb.PartitionKeyRange{ID: "4", Parents: []string{"x"}}, expected: ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			continuation, err := r.startingContinuation(t.Context(), test.pkr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, continuation)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestAMQPNamespaceConfig(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(amqpNamespaceFields("event hub")...)
+
+	pConf, err := spec.ParseYAML(`
+connection_string: Endpoint=sb://foo.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=bar=;EntityPath=baz
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := amqpNamespaceConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.Equal(t, "baz", conf.entityPath)
+	assert.Nil(t, conf.cred)
+
+	pConf, err = spec.ParseYAML(`
+connection_string: SharedAccessKeyName=a;SharedAccessKey=b
+`, nil)
+	require.NoError(t, err)
+
+	_, err = amqpNamespaceConfigFromParsed(pConf)
+	require.Error(t, err)
+
+	pConf, err = spec.ParseYAML(`{}`, nil)
+	require.NoError(t, err)
+
+	_, err = amqpNamespaceConfigFromParsed(pConf)
+	require.ErrorContains(t, err, "either connection_string or namespace must be set")
+}
+
+func TestEventHubsEventToMessage(t *testing.T) {
+	enqueued := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	partitionKey := "foo"
+	msg := ehEventToMessage(&azeventhubs.ReceivedEventData{
+		EventData: azeventhubs.EventData{
+			Body: []byte("hello world"),
+			Properties: map[string]any{
+				"bar": "baz",
+			},
+		},
+		EnqueuedTime:   &enqueued,
+		PartitionKey:   &partitionKey,
+		Offset:         "1024",
+		SequenceNumber: 42,
+	})
+
+	body, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(body))
+
+	for k, v := range map[string]any{
+		"event_hubs_enqueued_time":   "2025-01-02T03:04:05Z",
+		"event_hubs_sequence_number": int64(42),
+		"event_hubs_offset":          "1024",
+		"event_hubs_partition_key":   "foo",
+		"bar":                        "baz",
+	} {
+		actual, exists := msg.MetaGetMut(k)
+		require.True(t, exists, k)
+		assert.Equal(t, v, actual, k)
+	}
+}
+
+func TestEventHubsMessageToEvent(t *testing.T) {
+	spec := service.NewConfigSpec().Field(service.NewMetadataExcludeFilterField(ehoFieldMetadata))
+	pConf, err := spec.ParseYAML(`
+metadata:
+  exclude_prefixes: [ "skip_" ]
+`, nil)
+	require.NoError(t, err)
+
+	filter, err := pConf.FieldMetadataExcludeFilter(ehoFieldMetadata)
+	require.NoError(t, err)
+
+	msg := service.NewMessage([]byte("hello"))
+	msg.MetaSetMut("foo", "bar")
+	msg.MetaSetMut("skip_me", "baz")
+
+	event, err := ehMessageToEvent(msg, filter)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(event.Body))
+	assert.Equal(t, map[string]any{"foo": "bar"}, event.Properties)
+}
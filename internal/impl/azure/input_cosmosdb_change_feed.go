@@ -0,0 +1,665 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/Jeffail/checkpoint"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+
+	"github.com/redpanda-data/connect/v4/internal/impl/azure/cosmosdb"
+)
+
+const (
+	cdbcfFieldStartFrom       = "start_from"
+	cdbcfFieldStartTime       = "start_time"
+	cdbcfFieldLeaseContainer  = "lease_container"
+	cdbcfFieldCheckpointCache = "checkpoint_cache"
+	cdbcfFieldCheckpointKey   = "checkpoint_key"
+	cdbcfFieldMaxItemCount    = "max_item_count"
+	cdbcfFieldPollInterval    = "poll_interval"
+	cdbcfFieldCheckpointLimit = "checkpoint_limit"
+	cdbcfFieldCommitPeriod    = "commit_period"
+
+	cdbcfStartFromBeginning = "beginning"
+	cdbcfStartFromNow       = "now"
+	cdbcfStartFromTime      = "time"
+)
+
+func cosmosDBChangeFeedInputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Azure").
+		Version("4.73.0").
+		Summary(`Consumes the change feed of an https://learn.microsoft.com/en-us/azure/cosmos-db/introduction[Azure CosmosDB^] container, creating a batch of messages from each page of changed items.`).
+		Description(`
+The change feed of each partition key range of the container is consumed in parallel, where the continuation of each range is checkpointed once the changes preceding it have been acknowledged at the output level, which ensures at-least-once delivery guarantees. Checkpoints are stored either as items of a `+"`lease_container`"+` within the same database, which must be partitioned by `+"`/id`"+`, or within a `+"`checkpoint_cache`"+`.
+
+When a partition key range is split the consumption of the change feed continues from the checkpoint of the range within the ranges that replaced it.
+
+Only a single instance of this input should consume a container with a given `+"`checkpoint_key`"+` at any given time. The change feed only contains the latest version of inserted and updated items, and does not contain deletions.
+`+cosmosdb.CredentialsDocs+`
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```"+`
+- activity_id
+- request_charge
+- partition_key_range_id
+`+"```"+`
+
+You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+`).
+		Footnotes(cosmosdb.EmulatorDocs).
+		Fields(cosmosdb.ContainerClientConfigFields()...).
+		Fields(
+			service.NewStringAnnotatedEnumField(cdbcfFieldStartFrom, map[string]string{
+				cdbcfStartFromBeginning: "Consume all changes of the container from the beginning of its history.",
+				cdbcfStartFromNow:       "Consume only changes made after the input starts.",
+				cdbcfStartFromTime:      "Consume changes made after the `" + cdbcfFieldStartTime + "`.",
+			}).
+				Description("Where to begin consuming the change feed of partition key ranges without a checkpoint.").
+				Default(cdbcfStartFromBeginning),
+			service.NewStringField(cdbcfFieldStartTime).
+				Description("An RFC 3339 timestamp from which to consume changes when `"+cdbcfFieldStartFrom+"` is `"+cdbcfStartFromTime+"`.").
+				Example("2025-01-01T00:00:00Z").
+				Optional(),
+			service.NewStringField(cdbcfFieldLeaseContainer).
+				Description("The name of a container within the database in which to store checkpoints, which must be partitioned by `/id`. Either this field or `"+cdbcfFieldCheckpointCache+"` must be set.").
+				Optional(),
+			service.NewStringField(cdbcfFieldCheckpointCache).
+				Description("The name of a xref:components:caches/about.adoc[cache resource] in which to store checkpoints. Either this field or `"+cdbcfFieldLeaseContainer+"` must be set.").
+				Optional(),
+			service.NewStringField(cdbcfFieldCheckpointKey).
+				Description("A prefix of the keys under which checkpoints are stored. Defaults to the database and container names.").
+				Optional().
+				Advanced(),
+			service.NewIntField(cdbcfFieldMaxItemCount).
+				Description("The maximum number of items to read in each page of changes.").
+				Default(100).
+				Advanced(),
+			service.NewDurationField(cdbcfFieldPollInterval).
+				Description("The period of time to wait before polling a partition key range that had no changes.").
+				Default("1s").
+				Advanced(),
+			service.NewIntField(cdbcfFieldCheckpointLimit).
+				Description("The maximum number of items of a partition key range that can be in flight at a given time.").
+				Default(1024).
+				Advanced(),
+			service.NewDurationField(cdbcfFieldCommitPeriod).
+				Description("The period of time between each checkpoint of a partition key range.").
+				Default("5s").
+				Advanced(),
+			service.NewAutoRetryNacksToggleField(),
+		).
+		LintRule(`root = []`+cosmosdb.CommonLintRules+`
+root."-" = if this.lease_container.or("") == "" && this.checkpoint_cache.or("") == "" {
+  "Either `+"`lease_container`"+` or `+"`checkpoint_cache`"+` must be set."
+}
+
+root."-" = if this.start_from.or("") == "time" && this.start_time.or("") == "" {
+  "The `+"`start_time`"+` field must be set when `+"`start_from`"+` is `+"`time`"+`."
+}
+`).
+		Example("Replicate a container", "Consume the change feed of a container with checkpoints stored in a lease container.", `
+input:
+  azure_cosmosdb_change_feed:
+    endpoint: https://blobfish.documents.azure.com:443/
+    account_key: ${COSMOSDB_ACCOUNT_KEY}
+    database: blobbase
+    container: blobfish
+    lease_container: leases
+`)
+}
+
+func init() {
+	service.MustRegisterBatchInput("azure_cosmosdb_change_feed", cosmosDBChangeFeedInputSpec(), func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+		r, err := newCosmosDBChangeFeedReaderFromParsed(conf, mgr)
+		if err != nil {
+			return nil, err
+		}
+		return service.AutoRetryNacksBatchedToggled(conf, r)
+	})
+}
+
+//------------------------------------------------------------------------------
+
+// cdbcfCheckpointStore stores the continuation of each partition key range.
+type cdbcfCheckpointStore interface {
+	// get returns the continuation of a range, or an empty string if the
+	// range has no checkpoint.
+	get(ctx context.Context, rangeID string) (string, error)
+	set(ctx context.Context, rangeID, continuation string) error
+}
+
+type cdbcfCacheStore struct {
+	mgr    *service.Resources
+	cache  string
+	prefix string
+}
+
+func (s *cdbcfCacheStore) get(ctx context.Context, rangeID string) (continuation string, err error) {
+	if cerr := s.mgr.AccessCache(ctx, s.cache, func(c service.Cache) {
+		var b []byte
+		if b, err = c.Get(ctx, s.prefix+"/"+rangeID); errors.Is(err, service.ErrKeyNotFound) {
+			err = nil
+		}
+		continuation = string(b)
+	}); cerr != nil {
+		return "", cerr
+	}
+	return
+}
+
+func (s *cdbcfCacheStore) set(ctx context.Context, rangeID, continuation string) (err error) {
+	if cerr := s.mgr.AccessCache(ctx, s.cache, func(c service.Cache) {
+		err = c.Set(ctx, s.prefix+"/"+rangeID, []byte(continuation), nil)
+	}); cerr != nil {
+		return cerr
+	}
+	return
+}
+
+// cdbcfLease is an item of a lease container.
+type cdbcfLease struct {
+	ID                  string `json:"id"`
+	PartitionKeyRangeID string `json:"partitionKeyRangeId"`
+	ContinuationToken   string `json:"continuationToken"`
+	Timestamp           string `json:"timestamp"`
+}
+
+type cdbcfLeaseStore struct {
+	client *azcosmos.ContainerClient
+	prefix string
+}
+
+func (s *cdbcfLeaseStore) leaseID(rangeID string) string {
+	// Item IDs cannot contain slashes.
+	return strings.ReplaceAll(s.prefix, "/", ".") + ".." + rangeID
+}
+
+func (s *cdbcfLeaseStore) get(ctx context.Context, rangeID string) (string, error) {
+	id := s.leaseID(rangeID)
+	res, err := s.client.ReadItem(ctx, azcosmos.NewPartitionKeyString(id), id, nil)
+	if err != nil {
+		var resErr *azcore.ResponseError
+		if errors.As(err, &resErr) && resErr.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var lease cdbcfLease
+	if err := json.Unmarshal(res.Value, &lease); err != nil {
+		return "", fmt.Errorf("failed to parse lease %v: %w", id, err)
+	}
+	return lease.ContinuationToken, nil
+}
+
+func (s *cdbcfLeaseStore) set(ctx context.Context, rangeID, continuation string) error {
+	lease := cdbcfLease{
+		ID:                  s.leaseID(rangeID),
+		PartitionKeyRangeID: rangeID,
+		ContinuationToken:   continuation,
+		Timestamp:           time.Now().UTC().Format(time.RFC3339),
+	}
+	b, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.UpsertItem(ctx, azcosmos.NewPartitionKeyString(lease.ID), b, nil)
+	return err
+}
+
+//------------------------------------------------------------------------------
+
+type cdbcfConfig struct {
+	Start           cosmosdb.ChangeFeedStart
+	MaxItemCount    int
+	PollInterval    time.Duration
+	CheckpointLimit int
+	CommitPeriod    time.Duration
+}
+
+type cdbcfAsyncMessage struct {
+	msg   service.MessageBatch
+	ackFn service.AckFunc
+}
+
+// cdbcfGoneRange is emitted when a partition key range has been split, along
+// with the continuation from which the ranges that replaced it are consumed.
+type cdbcfGoneRange struct {
+	id           string
+	continuation string
+}
+
+type cosmosDBChangeFeedReader struct {
+	conf   cdbcfConfig
+	client *cosmosdb.ChangeFeedClient
+	store  cdbcfCheckpointStore
+	log    *service.Logger
+
+	cMut    sync.Mutex
+	msgChan chan cdbcfAsyncMessage
+	runDone chan struct{}
+
+	shutCtx  context.Context
+	shutdown func()
+}
+
+func newCosmosDBChangeFeedReaderFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*cosmosDBChangeFeedReader, error) {
+	r := &cosmosDBChangeFeedReader{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if r.client, err = cosmosdb.ChangeFeedClientFromParsed(conf); err != nil {
+		return nil, err
+	}
+
+	startFrom, err := conf.FieldString(cdbcfFieldStartFrom)
+	if err != nil {
+		return nil, err
+	}
+	switch startFrom {
+	case cdbcfStartFromNow:
+		r.conf.Start.FromNow = true
+	case cdbcfStartFromTime:
+		startTime, err := conf.FieldString(cdbcfFieldStartTime)
+		if err != nil {
+			return nil, err
+		}
+		if r.conf.Start.FromTime, err = time.Parse(time.RFC3339Nano, startTime); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", cdbcfFieldStartTime, err)
+		}
+	}
+
+	if r.conf.MaxItemCount, err = conf.FieldInt(cdbcfFieldMaxItemCount); err != nil {
+		return nil, err
+	}
+	if r.conf.PollInterval, err = conf.FieldDuration(cdbcfFieldPollInterval); err != nil {
+		return nil, err
+	}
+	if r.conf.CheckpointLimit, err = conf.FieldInt(cdbcfFieldCheckpointLimit); err != nil {
+		return nil, err
+	}
+	if r.conf.CommitPeriod, err = conf.FieldDuration(cdbcfFieldCommitPeriod); err != nil {
+		return nil, err
+	}
+
+	database, err := conf.FieldString("database")
+	if err != nil {
+		return nil, err
+	}
+	container, err := conf.FieldString("container")
+	if err != nil {
+		return nil, err
+	}
+	prefix := database + "/" + container
+	if conf.Contains(cdbcfFieldCheckpointKey) {
+		if prefix, err = conf.FieldString(cdbcfFieldCheckpointKey); err != nil {
+			return nil, err
+		}
+	}
+
+	var leaseContainer, cache string
+	if conf.Contains(cdbcfFieldLeaseContainer) {
+		if leaseContainer, err = conf.FieldString(cdbcfFieldLeaseContainer); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(cdbcfFieldCheckpointCache) {
+		if cache, err = conf.FieldString(cdbcfFieldCheckpointCache); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case leaseContainer != "" && cache != "":
+		return nil, fmt.Errorf("only one of %s or %s may be set", cdbcfFieldLeaseContainer, cdbcfFieldCheckpointCache)
+	case leaseContainer != "":
+		leaseClient, err := cosmosdb.NamedContainerClientFromParsed(conf, leaseContainer)
+		if err != nil {
+			return nil, err
+		}
+		r.store = &cdbcfLeaseStore{client: leaseClient, prefix: prefix}
+	case cache != "":
+		if !mgr.HasCache(cache) {
+			return nil, fmt.Errorf("cache resource '%s' was not found", cache)
+		}
+		r.store = &cdbcfCacheStore{mgr: mgr, cache: cache, prefix: prefix}
+	default:
+		return nil, fmt.Errorf("either %s or %s must be set", cdbcfFieldLeaseContainer, cdbcfFieldCheckpointCache)
+	}
+
+	r.shutCtx, r.shutdown = context.WithCancel(context.Background())
+	return r, nil
+}
+
+// Connect lists the partition key ranges of the container and begins
+// consuming their change feeds.
+func (r *cosmosDBChangeFeedReader) Connect(ctx context.Context) error {
+	r.cMut.Lock()
+	defer r.cMut.Unlock()
+	if r.msgChan != nil {
+		return nil
+	}
+
+	ranges, err := r.client.PartitionKeyRanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	runCtx, runDone := context.WithCancel(r.shutCtx)
+	r.msgChan = make(chan cdbcfAsyncMessage)
+	r.runDone = make(chan struct{})
+	go r.run(runCtx, runDone, ranges, r.msgChan, r.runDone)
+	return nil
+}
+
+// startingContinuation returns the continuation from which to consume a range,
+// which falls back to the checkpoints of its parents when the range was created
+// by a split that happened before they were consumed entirely.
+func (r *cosmosDBChangeFeedReader) startingContinuation(ctx context.Context, pkr cosmosdb.PartitionKeyRange) (string, error) {
+	continuation, err := r.store.get(ctx, pkr.ID)
+	if err != nil || continuation != "" {
+		return continuation, err
+	}
+	for i := len(pkr.Parents) - 1; i >= 0; i-- {
+		if continuation, err = r.store.get(ctx, pkr.Parents[i]); err != nil || continuation != "" {
+			return continuation, err
+		}
+	}
+	return "", nil
+}
+
+func (r *cosmosDBChangeFeedReader) run(ctx context.Context, cancel func(), ranges []cosmosdb.PartitionKeyRange, msgChan chan cdbcfAsyncMessage, runDone chan struct{}) {
+	var wg sync.WaitGroup
+	goneChan := make(chan cdbcfGoneRange)
+	defer func() {
+		cancel()
+		wg.Wait()
+		close(msgChan)
+		close(runDone)
+	}()
+
+	consume := func(pkr cosmosdb.PartitionKeyRange, continuation string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.consumeRange(ctx, cancel, pkr.ID, continuation, msgChan, goneChan)
+		}()
+	}
+
+	for _, pkr := range ranges {
+		continuation, err := r.startingContinuation(ctx, pkr)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Errorf("Failed to read checkpoint of partition key range %v: %v", pkr.ID, err)
+			}
+			return
+		}
+		consume(pkr, continuation)
+	}
+
+	for {
+		var gone cdbcfGoneRange
+		select {
+		case gone = <-goneChan:
+		case <-ctx.Done():
+			return
+		}
+		r.log.Infof("Partition key range %v was split, continuing from the ranges that replaced it", gone.id)
+
+		ranges, err := r.client.PartitionKeyRanges(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Errorf("Failed to list partition key ranges: %v", err)
+			}
+			return
+		}
+
+		var children int
+		for _, pkr := range ranges {
+			for _, parent := range pkr.Parents {
+				if parent != gone.id {
+					continue
+				}
+				continuation, err := r.store.get(ctx, pkr.ID)
+				if err != nil {
+					if ctx.Err() == nil {
+						r.log.Errorf("Failed to read checkpoint of partition key range %v: %v", pkr.ID, err)
+					}
+					return
+				}
+				if continuation == "" {
+					continuation = gone.continuation
+				}
+				consume(pkr, continuation)
+				children++
+				break
+			}
+		}
+		if children == 0 {
+			r.log.Errorf("Failed to find the partition key ranges that replaced range %v", gone.id)
+			return
+		}
+	}
+}
+
+func (r *cosmosDBChangeFeedReader) consumeRange(ctx context.Context, cancel func(), rangeID, continuation string, msgChan chan cdbcfAsyncMessage, goneChan chan cdbcfGoneRange) {
+	tracker := checkpoint.NewCapped[string](int64(r.conf.CheckpointLimit))
+
+	var ackedMut sync.Mutex
+	acked, committed := continuation, continuation
+
+	commit := func(ctx context.Context) error {
+		ackedMut.Lock()
+		cp := acked
+		ackedMut.Unlock()
+		if cp == committed {
+			return nil
+		}
+		if err := r.store.set(ctx, rangeID, cp); err != nil {
+			return fmt.Errorf("failed to checkpoint partition key range %v: %w", rangeID, err)
+		}
+		committed = cp
+		return nil
+	}
+	defer func() {
+		commitCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+		if err := commit(commitCtx); err != nil {
+			r.log.Errorf("%v", err)
+		}
+		done()
+	}()
+
+	nextCommit := time.Now().Add(r.conf.CommitPeriod)
+	for {
+		if time.Now().After(nextCommit) {
+			if err := commit(ctx); err != nil {
+				r.log.Errorf("%v", err)
+			}
+			nextCommit = time.Now().Add(r.conf.CommitPeriod)
+		}
+
+		page, err := r.client.ReadChanges(ctx, rangeID, continuation, r.conf.Start, r.conf.MaxItemCount)
+		if errors.Is(err, cosmosdb.ErrPartitionKeyRangeGone) {
+			r.handOffGoneRange(ctx, cancel, rangeID, tracker, &ackedMut, &acked, commit, goneChan)
+			return
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.log.Errorf("Failed to read change feed of partition key range %v: %v", rangeID, err)
+			select {
+			case <-time.After(r.conf.PollInterval):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		if page.Continuation != "" {
+			continuation = page.Continuation
+		}
+		if len(page.Documents) == 0 {
+			// Without any changes in flight the continuation can be
+			// checkpointed immediately.
+			ackedMut.Lock()
+			if tracker.Pending() == 0 {
+				acked = continuation
+			}
+			ackedMut.Unlock()
+
+			select {
+			case <-time.After(r.conf.PollInterval):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		batch := make(service.MessageBatch, 0, len(page.Documents))
+		for _, doc := range page.Documents {
+			m := service.NewMessage(doc)
+			m.MetaSetMut("activity_id", page.ActivityID)
+			m.MetaSetMut("request_charge", page.RequestCharge)
+			m.MetaSetMut("partition_key_range_id", rangeID)
+			batch = append(batch, m)
+		}
+
+		resolveFn, err := tracker.Track(ctx, continuation, int64(len(batch)))
+		if err != nil {
+			return
+		}
+		select {
+		case msgChan <- cdbcfAsyncMessage{
+			msg: batch,
+			ackFn: func(context.Context, error) error {
+				ackedMut.Lock()
+				if cp := resolveFn(); cp != nil {
+					acked = *cp
+				}
+				ackedMut.Unlock()
+				return nil
+			},
+		}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handOffGoneRange waits for all changes of a range that was split to be
+// acknowledged before checkpointing it and handing its continuation to the
+// ranges that replaced it.
+func (r *cosmosDBChangeFeedReader) handOffGoneRange(
+	ctx context.Context,
+	cancel func(),
+	rangeID string,
+	tracker *checkpoint.Capped[string],
+	ackedMut *sync.Mutex,
+	acked *string,
+	commit func(context.Context) error,
+	goneChan chan cdbcfGoneRange,
+) {
+	for {
+		ackedMut.Lock()
+		pending := tracker.Pending()
+		ackedMut.Unlock()
+		if pending == 0 {
+			break
+		}
+		select {
+		case <-time.After(r.conf.PollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if err := commit(ctx); err != nil {
+		if ctx.Err() == nil {
+			r.log.Errorf("%v", err)
+			cancel()
+		}
+		return
+	}
+
+	ackedMut.Lock()
+	gone := cdbcfGoneRange{id: rangeID, continuation: *acked}
+	ackedMut.Unlock()
+	select {
+	case goneChan <- gone:
+	case <-ctx.Done():
+	}
+}
+
+// ReadBatch attempts to read a page of changes.
+func (r *cosmosDBChangeFeedReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	r.cMut.Lock()
+	msgChan := r.msgChan
+	r.cMut.Unlock()
+
+	if msgChan == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case m, open := <-msgChan:
+		if !open {
+			r.cMut.Lock()
+			if r.msgChan == msgChan {
+				r.msgChan = nil
+			}
+			r.cMut.Unlock()
+			return nil, nil, service.ErrNotConnected
+		}
+		return m.msg, m.ackFn, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// Close stops consuming the change feed and checkpoints all ranges.
+func (r *cosmosDBChangeFeedReader) Close(ctx context.Context) error {
+	r.shutdown()
+
+	r.cMut.Lock()
+	runDone := r.runDone
+	r.cMut.Unlock()
+	if runDone == nil {
+		return nil
+	}
+
+	select {
+	case <-runDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,519 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/v2/checkpoints"
+	"github.com/Jeffail/checkpoint"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Event Hubs Input Fields
+	ehiFieldEventHub            = "event_hub"
+	ehiFieldConsumerGroup       = "consumer_group"
+	ehiFieldCheckpointContainer = "checkpoint_container"
+	ehiFieldStartFromOldest     = "start_from_oldest"
+	ehiFieldPrefetch            = "prefetch"
+	ehiFieldCheckpointLimit     = "checkpoint_limit"
+	ehiFieldCommitPeriod        = "commit_period"
+	ehiFieldRebalancePeriod     = "rebalance_period"
+	ehiFieldOwnershipExpiration = "ownership_expiration"
+	ehiFieldBatching            = "batching"
+)
+
+type ehiConfig struct {
+	EventHub            string
+	ConsumerGroup       string
+	CheckpointContainer string
+	StartFromOldest     bool
+	Prefetch            int
+	CheckpointLimit     int
+	CommitPeriod        time.Duration
+	RebalancePeriod     time.Duration
+	OwnershipExpiration time.Duration
+}
+
+func ehiConfigFromParsed(pConf *service.ParsedConfig) (conf ehiConfig, err error) {
+	if conf.EventHub, err = pConf.FieldString(ehiFieldEventHub); err != nil {
+		return
+	}
+	if conf.ConsumerGroup, err = pConf.FieldString(ehiFieldConsumerGroup); err != nil {
+		return
+	}
+	if conf.CheckpointContainer, err = pConf.FieldString(ehiFieldCheckpointContainer); err != nil {
+		return
+	}
+	if conf.StartFromOldest, err = pConf.FieldBool(ehiFieldStartFromOldest); err != nil {
+		return
+	}
+	if conf.Prefetch, err = pConf.FieldInt(ehiFieldPrefetch); err != nil {
+		return
+	}
+	if conf.CheckpointLimit, err = pConf.FieldInt(ehiFieldCheckpointLimit); err != nil {
+		return
+	}
+	if conf.CommitPeriod, err = pConf.FieldDuration(ehiFieldCommitPeriod); err != nil {
+		return
+	}
+	if conf.RebalancePeriod, err = pConf.FieldDuration(ehiFieldRebalancePeriod); err != nil {
+		return
+	}
+	if conf.OwnershipExpiration, err = pConf.FieldDuration(ehiFieldOwnershipExpiration); err != nil {
+		return
+	}
+	return
+}
+
+func ehiSpec() *service.ConfigSpec {
+	return azureComponentSpec().
+		Beta().
+		Version("4.73.0").
+		Summary(`Consumes events from an Azure Event Hub, balancing partitions across instances of the input with a checkpoint store in Azure Blob Storage.`).
+		Description(`
+Partitions of the event hub are balanced evenly across all instances of this input that share a consumer group and checkpoint container. Ownership of each partition, along with the offset of the latest event consumed from it, is stored as blobs within the container, where the layout of blobs is compatible with the checkpoint stores of the Azure SDKs. The storage account is configured with the `+"`storage_*`"+` fields.
+
+Redpanda Connect will not checkpoint an event unless it has been acknowledged at the output level, which ensures at-least-once delivery guarantees. When an instance shuts down its partitions are relinquished so that other instances can claim them immediately.
+
+== Authentication
+
+Either a `+"`connection_string`"+` containing a shared access key or signature, or the fully qualified `+"`namespace`"+` of the event hub must be provided. When only the namespace is provided the default Azure credentials are obtained from the environment.
+
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```"+`
+- event_hubs_enqueued_time
+- event_hubs_sequence_number
+- event_hubs_offset
+- event_hubs_partition_key
+- event_hubs_partition_id
+- event_hubs_event_hub
+- event_hubs_consumer_group
+- All application properties of the event
+`+"```"+`
+
+You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+== Batching
+
+Use the `+"`batching`"+` fields to configure an optional xref:configuration:batching.adoc#batch-policy[batching policy]. Each partition is batched separately in order to ensure that acknowledgements aren't contaminated.`).
+		Fields(amqpNamespaceFields("event hub")...).
+		Fields(
+			service.NewStringField(ehiFieldEventHub).
+				Description("The name of the event hub to consume from. This field is required unless the `"+amqpFieldConnectionString+"` contains an `EntityPath`.").
+				Default(""),
+			service.NewStringField(ehiFieldConsumerGroup).
+				Description("The consumer group to consume within.").
+				Default("$Default"),
+			service.NewStringField(ehiFieldCheckpointContainer).
+				Description("The name of the blob storage container used for storing partition ownerships and checkpoints."),
+			service.NewBoolField(ehiFieldStartFromOldest).
+				Description("Whether to consume from the oldest available event of a partition when it has no checkpoint, otherwise only events enqueued after the partition is claimed are consumed.").
+				Default(true),
+			service.NewIntField(ehiFieldPrefetch).
+				Description("The maximum number of events of each partition to receive ahead of processing.").
+				Default(300).
+				Advanced(),
+			service.NewIntField(ehiFieldCheckpointLimit).
+				Description("The maximum number of events of a partition that can be in flight at a given time. Increasing this limit enables parallel processing and batching at the output level to work on individual partitions. Any given offset will not be checkpointed unless all events under that offset are delivered in order to preserve at least once delivery guarantees.").
+				Default(1024),
+			service.NewAutoRetryNacksToggleField(),
+			service.NewDurationField(ehiFieldCommitPeriod).
+				Description("The period of time between each update to the checkpoint of a partition.").
+				Default("5s"),
+			service.NewDurationField(ehiFieldRebalancePeriod).
+				Description("The period of time between each attempt to renew ownership of claimed partitions and to rebalance partitions across instances.").
+				Default("10s").
+				Advanced(),
+			service.NewDurationField(ehiFieldOwnershipExpiration).
+				Description("The period of time after which the ownership of a partition that has not been renewed expires, allowing other instances to claim it.").
+				Default("1m").
+				Advanced(),
+			service.NewBatchPolicyField(ehiFieldBatching),
+		).
+		Example("Consume events", "Consume events from an event hub with checkpoints stored in a storage account.", `
+input:
+  azure_event_hubs:
+    connection_string: ${EVENT_HUBS_CONNECTION_STRING}
+    event_hub: foo
+    storage_connection_string: ${STORAGE_CONNECTION_STRING}
+    checkpoint_container: checkpoints
+`)
+}
+
+func init() {
+	service.MustRegisterBatchInput("azure_event_hubs", ehiSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+			r, err := newEventHubsReaderFromParsed(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacksBatchedToggled(conf, r)
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type ehAsyncMessage struct {
+	msg   service.MessageBatch
+	ackFn service.AckFunc
+}
+
+type eventHubsReader struct {
+	conf     ehiConfig
+	consumer *azeventhubs.ConsumerClient
+	store    azeventhubs.CheckpointStore
+	batcher  service.BatchPolicy
+	log      *service.Logger
+	mgr      *service.Resources
+
+	cMut    sync.Mutex
+	msgChan chan ehAsyncMessage
+	runDone chan struct{}
+
+	shutCtx  context.Context
+	shutdown func()
+}
+
+func newEventHubsReaderFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*eventHubsReader, error) {
+	r := &eventHubsReader{
+		log: mgr.Logger(),
+		mgr: mgr,
+	}
+
+	var err error
+	if r.conf, err = ehiConfigFromParsed(pConf); err != nil {
+		return nil, err
+	}
+	ns, err := amqpNamespaceConfigFromParsed(pConf)
+	if err != nil {
+		return nil, err
+	}
+	if r.conf.EventHub == "" {
+		r.conf.EventHub = ns.entityPath
+	}
+	if r.conf.EventHub == "" {
+		return nil, errors.New("an event_hub must be specified")
+	}
+	if r.conf.CheckpointContainer == "" {
+		return nil, errors.New("a checkpoint_container must be specified")
+	}
+
+	if r.batcher, err = pConf.FieldBatchPolicy(ehiFieldBatching); err != nil {
+		return nil, err
+	}
+	if r.batcher.IsNoop() {
+		r.batcher.Count = 1
+	}
+
+	container, err := service.NewInterpolatedString(r.conf.CheckpointContainer)
+	if err != nil {
+		return nil, err
+	}
+	client, containerSASToken, err := blobStorageClientFromParsed(pConf, container)
+	if err != nil {
+		return nil, err
+	}
+	containerName := r.conf.CheckpointContainer
+	if containerSASToken {
+		// if using a container SAS token, the container is already implicit
+		containerName = ""
+	}
+	if r.store, err = checkpoints.NewBlobStore(client.ServiceClient().NewContainerClient(containerName), nil); err != nil {
+		return nil, err
+	}
+
+	if ns.connectionString != "" {
+		r.consumer, err = azeventhubs.NewConsumerClientFromConnectionString(ns.connectionString, r.conf.EventHub, r.conf.ConsumerGroup, nil)
+	} else {
+		r.consumer, err = azeventhubs.NewConsumerClient(ns.namespace, r.conf.EventHub, r.conf.ConsumerGroup, ns.cred, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.shutCtx, r.shutdown = context.WithCancel(context.Background())
+	return r, nil
+}
+
+// Connect to the event hub and begin balancing partitions.
+func (r *eventHubsReader) Connect(ctx context.Context) error {
+	r.cMut.Lock()
+	defer r.cMut.Unlock()
+	if r.msgChan != nil {
+		return nil
+	}
+
+	// Surface misconfigurations on connect rather than within the processor.
+	if _, err := r.consumer.GetEventHubProperties(ctx, nil); err != nil {
+		return err
+	}
+
+	startPosition := azeventhubs.StartPosition{Latest: to.Ptr(true)}
+	if r.conf.StartFromOldest {
+		startPosition = azeventhubs.StartPosition{Earliest: to.Ptr(true)}
+	}
+
+	// A processor can't be restarted once it stops running, and so a new one
+	// is created for each connection.
+	processor, err := azeventhubs.NewProcessor(r.consumer, r.store, &azeventhubs.ProcessorOptions{
+		UpdateInterval:              r.conf.RebalancePeriod,
+		PartitionExpirationDuration: r.conf.OwnershipExpiration,
+		StartPositions:              azeventhubs.StartPositions{Default: startPosition},
+		Prefetch:                    int32(r.conf.Prefetch),
+	})
+	if err != nil {
+		return err
+	}
+
+	r.msgChan = make(chan ehAsyncMessage)
+	r.runDone = make(chan struct{})
+	go r.run(processor, r.msgChan, r.runDone)
+	return nil
+}
+
+func (r *eventHubsReader) run(processor *azeventhubs.Processor, msgChan chan ehAsyncMessage, runDone chan struct{}) {
+	ctx, cancel := context.WithCancel(r.shutCtx)
+
+	var consumersWG sync.WaitGroup
+	defer func() {
+		cancel()
+		consumersWG.Wait()
+		close(msgChan)
+		close(runDone)
+	}()
+
+	consumersWG.Add(1)
+	go func() {
+		defer consumersWG.Done()
+		for {
+			pc := processor.NextPartitionClient(ctx)
+			if pc == nil {
+				return
+			}
+			consumersWG.Add(1)
+			go func() {
+				defer consumersWG.Done()
+				r.consumePartition(ctx, pc, msgChan)
+			}()
+		}
+	}()
+
+	// Partitions owned by the processor are relinquished when it stops
+	// running so that other instances can claim them without waiting for the
+	// ownership to expire.
+	if err := processor.Run(ctx); err != nil {
+		r.log.Errorf("Failed to balance partitions of event hub %v: %v", r.conf.EventHub, err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// ehEventToMessage converts an event to a message.
+func ehEventToMessage(event *azeventhubs.ReceivedEventData) *service.Message {
+	msg := service.NewMessage(event.Body)
+	for k, v := range event.Properties {
+		msg.MetaSetMut(k, v)
+	}
+
+	if event.EnqueuedTime != nil {
+		msg.MetaSetMut("event_hubs_enqueued_time", event.EnqueuedTime.UTC().Format(time.RFC3339Nano))
+	}
+	msg.MetaSetMut("event_hubs_sequence_number", event.SequenceNumber)
+	msg.MetaSetMut("event_hubs_offset", event.Offset)
+	if event.PartitionKey != nil {
+		msg.MetaSetMut("event_hubs_partition_key", *event.PartitionKey)
+	}
+	return msg
+}
+
+func (r *eventHubsReader) consumePartition(ctx context.Context, pc *azeventhubs.ProcessorPartitionClient, msgChan chan ehAsyncMessage) {
+	partitionID := pc.PartitionID()
+
+	// Closing the client allows the processor to claim the partition again
+	// should we stop consuming it whilst it is still owned.
+	defer func() {
+		_ = pc.Close(context.Background())
+	}()
+
+	batcher, err := r.batcher.NewBatcher(r.mgr)
+	if err != nil {
+		r.log.Errorf("Failed to initialize batch policy for partition %v: %v", partitionID, err)
+		return
+	}
+	defer batcher.Close(context.Background())
+
+	r.log.Debugf("Consuming partition %v of event hub %v", partitionID, r.conf.EventHub)
+
+	var ackedMut sync.Mutex
+	var acked, committed *azeventhubs.ReceivedEventData
+
+	commit := func(ctx context.Context) {
+		ackedMut.Lock()
+		event := acked
+		ackedMut.Unlock()
+		if event == committed {
+			return
+		}
+		if err := pc.UpdateCheckpoint(ctx, event, nil); err != nil {
+			r.log.Errorf("Failed to checkpoint partition %v: %v", partitionID, err)
+			return
+		}
+		committed = event
+	}
+	defer func() {
+		commitCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+		commit(commitCtx)
+		done()
+		r.log.Debugf("Stopped consuming partition %v of event hub %v", partitionID, r.conf.EventHub)
+	}()
+
+	tracker := checkpoint.NewCapped[*azeventhubs.ReceivedEventData](int64(r.conf.CheckpointLimit))
+	var latest *azeventhubs.ReceivedEventData
+	flush := func() bool {
+		batch, err := batcher.Flush(ctx)
+		if err != nil {
+			r.log.Errorf("Failed to flush batch: %v", err)
+		}
+		if len(batch) == 0 {
+			return true
+		}
+
+		resolveFn, err := tracker.Track(ctx, latest, int64(len(batch)))
+		if err != nil {
+			return false
+		}
+		select {
+		case msgChan <- ehAsyncMessage{
+			msg: batch,
+			ackFn: func(context.Context, error) error {
+				if event := resolveFn(); event != nil {
+					ackedMut.Lock()
+					acked = *event
+					ackedMut.Unlock()
+				}
+				return nil
+			},
+		}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	nextCommit := time.Now().Add(r.conf.CommitPeriod)
+	for {
+		wait := time.Until(nextCommit)
+		if tNext, exists := batcher.UntilNext(); exists && tNext < wait {
+			wait = tNext
+		}
+
+		// Events are received individually from the prefetched events of the
+		// partition so that batching periods are respected.
+		recvCtx, recvDone := context.WithTimeout(ctx, max(wait, time.Millisecond))
+		events, err := pc.ReceiveEvents(recvCtx, 1, nil)
+		recvDone()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			var ehErr *azeventhubs.Error
+			if errors.As(err, &ehErr) && ehErr.Code == azeventhubs.ErrorCodeOwnershipLost {
+				r.log.Debugf("Ownership of partition %v was lost", partitionID)
+				return
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				r.log.Errorf("Failed to receive from partition %v: %v", partitionID, err)
+				return
+			}
+		}
+		for _, event := range events {
+			msg := ehEventToMessage(event)
+			msg.MetaSetMut("event_hubs_partition_id", partitionID)
+			msg.MetaSetMut("event_hubs_event_hub", r.conf.EventHub)
+			msg.MetaSetMut("event_hubs_consumer_group", r.conf.ConsumerGroup)
+
+			latest = event
+			if batcher.Add(msg) && !flush() {
+				return
+			}
+		}
+
+		if tNext, exists := batcher.UntilNext(); exists && tNext <= 0 && !flush() {
+			return
+		}
+		if time.Now().After(nextCommit) {
+			commit(ctx)
+			nextCommit = time.Now().Add(r.conf.CommitPeriod)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// ReadBatch attempts to read a batch of events from the event hub.
+func (r *eventHubsReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	r.cMut.Lock()
+	msgChan := r.msgChan
+	r.cMut.Unlock()
+
+	if msgChan == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case m, open := <-msgChan:
+		if !open {
+			r.cMut.Lock()
+			if r.msgChan == msgChan {
+				r.msgChan = nil
+			}
+			r.cMut.Unlock()
+			return nil, nil, service.ErrNotConnected
+		}
+		return m.msg, m.ackFn, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// Close relinquishes all partitions and closes the connection.
+func (r *eventHubsReader) Close(ctx context.Context) error {
+	r.shutdown()
+
+	r.cMut.Lock()
+	runDone := r.runDone
+	r.cMut.Unlock()
+
+	if runDone != nil {
+		select {
+		case <-runDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return r.consumer.Close(ctx)
+}
@@ -0,0 +1,700 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Service Bus Input Fields
+	sbiFieldQueue            = "queue"
+	sbiFieldTopic            = "topic"
+	sbiFieldSubscription     = "subscription"
+	sbiFieldPrefetch         = "prefetch"
+	sbiFieldRenewLock        = "renew_lock"
+	sbiFieldNackAction       = "nack_action"
+	sbiFieldDeadLetterReason = "dead_letter_reason"
+	sbiFieldSessions         = "sessions"
+
+	// Service Bus Input Session Fields
+	sbisFieldEnabled               = "enabled"
+	sbisFieldSessionID             = "session_id"
+	sbisFieldMaxConcurrentSessions = "max_concurrent_sessions"
+	sbisFieldIdleTimeout           = "idle_timeout"
+
+	sbNackActionAbandon    = "abandon"
+	sbNackActionDeadLetter = "dead_letter"
+)
+
+type sbiSessionsConfig struct {
+	Enabled               bool
+	SessionID             string
+	MaxConcurrentSessions int
+	IdleTimeout           time.Duration
+}
+
+type sbiConfig struct {
+	Queue            string
+	Topic            string
+	Subscription     string
+	Prefetch         int
+	RenewLock        bool
+	NackAction       string
+	DeadLetterReason string
+	Sessions         sbiSessionsConfig
+}
+
+func sbiConfigFromParsed(pConf *service.ParsedConfig) (conf sbiConfig, err error) {
+	if conf.Queue, err = pConf.FieldString(sbiFieldQueue); err != nil {
+		return
+	}
+	if conf.Topic, err = pConf.FieldString(sbiFieldTopic); err != nil {
+		return
+	}
+	if conf.Subscription, err = pConf.FieldString(sbiFieldSubscription); err != nil {
+		return
+	}
+	if conf.Prefetch, err = pConf.FieldInt(sbiFieldPrefetch); err != nil {
+		return
+	}
+	if conf.RenewLock, err = pConf.FieldBool(sbiFieldRenewLock); err != nil {
+		return
+	}
+	if conf.NackAction, err = pConf.FieldString(sbiFieldNackAction); err != nil {
+		return
+	}
+	if conf.DeadLetterReason, err = pConf.FieldString(sbiFieldDeadLetterReason); err != nil {
+		return
+	}
+
+	sConf := pConf.Namespace(sbiFieldSessions)
+	if conf.Sessions.Enabled, err = sConf.FieldBool(sbisFieldEnabled); err != nil {
+		return
+	}
+	if conf.Sessions.SessionID, err = sConf.FieldString(sbisFieldSessionID); err != nil {
+		return
+	}
+	if conf.Sessions.MaxConcurrentSessions, err = sConf.FieldInt(sbisFieldMaxConcurrentSessions); err != nil {
+		return
+	}
+	if conf.Sessions.IdleTimeout, err = sConf.FieldDuration(sbisFieldIdleTimeout); err != nil {
+		return
+	}
+	return
+}
+
+func sbiSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Categories("Services", "Azure").
+		Beta().
+		Version("4.73.0").
+		Summary(`Consumes messages from an Azure Service Bus queue or topic subscription.`).
+		Description(`
+Messages are received in peek-lock mode, where a message is locked for the duration of its processing and completed once it has been acknowledged at the output level. Locks are renewed in the background until then when `+"`renew_lock`"+` is enabled, allowing the processing of a message to exceed the lock duration of the entity.
+
+When a message is rejected it is either abandoned, in which case it is unlocked and redelivered by Service Bus until it exceeds the maximum delivery count of the entity, or it is dead-lettered with the reason `+"`dead_letter_reason`"+` and a description containing the error, depending on the `+"`nack_action`"+`.
+
+== Authentication
+
+Either a `+"`connection_string`"+` containing a shared access key or signature, or the fully qualified `+"`namespace`"+` must be provided. When only the namespace is provided the default Azure credentials are obtained from the environment.
+
+== Sessions
+
+Entities that require sessions can only be consumed when `+"`sessions.enabled`"+` is set. Each session is locked by a single receiver at a time and its messages are received in order, where up to `+"`sessions.max_concurrent_sessions`"+` sessions are consumed in parallel. A session is released once no messages have been received from it within `+"`sessions.idle_timeout`"+`, allowing the next available session to be accepted, unless a specific `+"`sessions.session_id`"+` is consumed.
+
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```"+`
+- service_bus_message_id
+- service_bus_sequence_number
+- service_bus_enqueued_time
+- service_bus_locked_until
+- service_bus_delivery_count
+- service_bus_session_id
+- service_bus_correlation_id
+- service_bus_content_type
+- service_bus_subject
+- service_bus_reply_to
+- service_bus_to
+- service_bus_partition_key
+- service_bus_scheduled_enqueue_time
+- service_bus_dead_letter_reason
+- service_bus_dead_letter_error_description
+- All application properties of the message
+`+"```"+`
+
+You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].`).
+		Fields(amqpNamespaceFields("queue or topic")...).
+		Fields(
+			service.NewStringField(sbiFieldQueue).
+				Description("The name of the queue to consume from. Either this field or `"+sbiFieldTopic+"` must be set unless the `"+amqpFieldConnectionString+"` contains an `EntityPath`.").
+				Default(""),
+			service.NewStringField(sbiFieldTopic).
+				Description("The name of the topic to consume from, which requires a `"+sbiFieldSubscription+"`.").
+				Default(""),
+			service.NewStringField(sbiFieldSubscription).
+				Description("The name of the topic subscription to consume from.").
+				Default(""),
+			service.NewIntField(sbiFieldPrefetch).
+				Description("The maximum number of messages to receive and lock ahead of processing, which limits the number of unacknowledged messages of each receiver.").
+				Default(10),
+			service.NewBoolField(sbiFieldRenewLock).
+				Description("Whether to renew the locks of messages, or the locks of sessions when `"+sbiFieldSessions+"."+sbisFieldEnabled+"` is set, until they are acknowledged.").
+				Default(true),
+			service.NewStringAnnotatedEnumField(sbiFieldNackAction, map[string]string{
+				sbNackActionAbandon:    "Abandon the message, which makes it available for redelivery and increments its delivery count.",
+				sbNackActionDeadLetter: "Move the message to the dead-letter queue of the entity, along with the `" + sbiFieldDeadLetterReason + "` and the error that caused the rejection.",
+			}).
+				Description("The action taken when a message is rejected.").
+				Default(sbNackActionAbandon),
+			service.NewStringField(sbiFieldDeadLetterReason).
+				Description("The reason given when dead-lettering a message.").
+				Default("ProcessingFailed").
+				Advanced(),
+			service.NewObjectField(sbiFieldSessions,
+				service.NewBoolField(sbisFieldEnabled).
+					Description("Whether to consume the entity with session receivers, which is required when the entity has sessions enabled.").
+					Default(false),
+				service.NewStringField(sbisFieldSessionID).
+					Description("An optional ID of the session to consume. When empty the next available sessions are accepted.").
+					Default(""),
+				service.NewIntField(sbisFieldMaxConcurrentSessions).
+					Description("The maximum number of sessions to consume in parallel. This field is ignored when a `"+sbisFieldSessionID+"` is set.").
+					Default(8),
+				service.NewDurationField(sbisFieldIdleTimeout).
+					Description("The period of time without receiving a message after which a session is released in order to accept the next available session.").
+					Default("1m"),
+			).
+				Description("Configures the consumption of sessions, where the messages of each session are received in order.").
+				Advanced(),
+		).
+		Example("Consume a topic subscription", "Consume messages from a topic subscription, dead-lettering messages that fail processing.", `
+input:
+  azure_service_bus:
+    connection_string: ${SERVICE_BUS_CONNECTION_STRING}
+    topic: orders
+    subscription: fulfilment
+    nack_action: dead_letter
+`)
+}
+
+func init() {
+	service.MustRegisterInput("azure_service_bus", sbiSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Input, error) {
+			return newServiceBusReaderFromParsed(conf, mgr)
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type sbAsyncMessage struct {
+	msg   *service.Message
+	ackFn service.AckFunc
+}
+
+// sbEntity identifies the queue or topic subscription to consume.
+type sbEntity struct {
+	queue        string
+	topic        string
+	subscription string
+}
+
+func (e sbEntity) String() string {
+	if e.queue != "" {
+		return e.queue
+	}
+	return e.topic + "/Subscriptions/" + e.subscription
+}
+
+// sbReceiver is implemented by both receivers and session receivers, which
+// settle messages in the same way.
+type sbReceiver interface {
+	ReceiveMessages(ctx context.Context, maxMessages int, options *azservicebus.ReceiveMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+	CompleteMessage(ctx context.Context, msg *azservicebus.ReceivedMessage, options *azservicebus.CompleteMessageOptions) error
+	AbandonMessage(ctx context.Context, msg *azservicebus.ReceivedMessage, options *azservicebus.AbandonMessageOptions) error
+	DeadLetterMessage(ctx context.Context, msg *azservicebus.ReceivedMessage, options *azservicebus.DeadLetterOptions) error
+	Close(ctx context.Context) error
+}
+
+type serviceBusReader struct {
+	conf   sbiConfig
+	entity sbEntity
+	client *azservicebus.Client
+	log    *service.Logger
+
+	cMut    sync.Mutex
+	msgChan chan sbAsyncMessage
+	runDone chan struct{}
+
+	shutCtx  context.Context
+	shutdown func()
+}
+
+func newServiceBusReaderFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*serviceBusReader, error) {
+	r := &serviceBusReader{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if r.conf, err = sbiConfigFromParsed(pConf); err != nil {
+		return nil, err
+	}
+	ns, err := amqpNamespaceConfigFromParsed(pConf)
+	if err != nil {
+		return nil, err
+	}
+	if r.entity, err = sbReceiverEntity(r.conf, ns.entityPath); err != nil {
+		return nil, err
+	}
+	if r.conf.Prefetch < 1 {
+		return nil, fmt.Errorf("%v must be greater than zero", sbiFieldPrefetch)
+	}
+	if r.conf.Sessions.MaxConcurrentSessions < 1 || r.conf.Sessions.SessionID != "" {
+		r.conf.Sessions.MaxConcurrentSessions = 1
+	}
+
+	if r.client, err = newServiceBusClient(ns); err != nil {
+		return nil, err
+	}
+
+	r.shutCtx, r.shutdown = context.WithCancel(context.Background())
+	return r, nil
+}
+
+func newServiceBusClient(ns amqpNamespaceConfig) (*azservicebus.Client, error) {
+	if ns.connectionString != "" {
+		return azservicebus.NewClientFromConnectionString(ns.connectionString, nil)
+	}
+	return azservicebus.NewClient(ns.namespace, ns.cred, nil)
+}
+
+// sbReceiverEntity returns the queue or topic subscription to consume.
+func sbReceiverEntity(conf sbiConfig, connStrEntityPath string) (sbEntity, error) {
+	switch {
+	case conf.Queue != "" && conf.Topic != "":
+		return sbEntity{}, fmt.Errorf("only one of %v or %v may be set", sbiFieldQueue, sbiFieldTopic)
+	case conf.Queue != "":
+		return sbEntity{queue: conf.Queue}, nil
+	case conf.Topic != "" || conf.Subscription != "":
+		topic := conf.Topic
+		if topic == "" {
+			topic = connStrEntityPath
+		}
+		if topic == "" || conf.Subscription == "" {
+			return sbEntity{}, fmt.Errorf("both %v and %v must be set in order to consume a topic", sbiFieldTopic, sbiFieldSubscription)
+		}
+		return sbEntity{topic: topic, subscription: conf.Subscription}, nil
+	case connStrEntityPath != "":
+		return sbEntity{queue: connStrEntityPath}, nil
+	}
+	return sbEntity{}, fmt.Errorf("either a %v or %v must be specified", sbiFieldQueue, sbiFieldTopic)
+}
+
+// Connect to the namespace and begin receiving messages.
+func (r *serviceBusReader) Connect(ctx context.Context) error {
+	r.cMut.Lock()
+	defer r.cMut.Unlock()
+	if r.msgChan != nil {
+		return nil
+	}
+
+	var receiver *azservicebus.Receiver
+	if !r.conf.Sessions.Enabled {
+		var err error
+		opts := &azservicebus.ReceiverOptions{ReceiveMode: azservicebus.ReceiveModePeekLock}
+		if r.entity.queue != "" {
+			receiver, err = r.client.NewReceiverForQueue(r.entity.queue, opts)
+		} else {
+			receiver, err = r.client.NewReceiverForSubscription(r.entity.topic, r.entity.subscription, opts)
+		}
+		if err != nil {
+			return err
+		}
+
+		// The receiver is opened lazily, and so a message is peeked in order
+		// that misconfigurations are surfaced on connect.
+		if _, err := receiver.PeekMessages(ctx, 1, nil); err != nil {
+			_ = receiver.Close(ctx)
+			return err
+		}
+	}
+
+	r.msgChan = make(chan sbAsyncMessage)
+	r.runDone = make(chan struct{})
+	go r.run(receiver, r.msgChan, r.runDone)
+	return nil
+}
+
+func (r *serviceBusReader) run(receiver *azservicebus.Receiver, msgChan chan sbAsyncMessage, runDone chan struct{}) {
+	ctx, cancel := context.WithCancel(r.shutCtx)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+		close(msgChan)
+		close(runDone)
+	}()
+
+	if receiver != nil {
+		err := r.consume(ctx, receiver, "", msgChan)
+		_ = receiver.Close(context.Background())
+		if err != nil && ctx.Err() == nil {
+			r.log.Errorf("Failed to receive from %v: %v", r.entity, err)
+		}
+		return
+	}
+
+	for range r.conf.Sessions.MaxConcurrentSessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.consumeSessions(ctx, msgChan)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *serviceBusReader) acceptSession(ctx context.Context) (*azservicebus.SessionReceiver, error) {
+	opts := &azservicebus.SessionReceiverOptions{ReceiveMode: azservicebus.ReceiveModePeekLock}
+	switch {
+	case r.conf.Sessions.SessionID != "" && r.entity.queue != "":
+		return r.client.AcceptSessionForQueue(ctx, r.entity.queue, r.conf.Sessions.SessionID, opts)
+	case r.conf.Sessions.SessionID != "":
+		return r.client.AcceptSessionForSubscription(ctx, r.entity.topic, r.entity.subscription, r.conf.Sessions.SessionID, opts)
+	case r.entity.queue != "":
+		return r.client.AcceptNextSessionForQueue(ctx, r.entity.queue, opts)
+	}
+	return r.client.AcceptNextSessionForSubscription(ctx, r.entity.topic, r.entity.subscription, opts)
+}
+
+// consumeSessions repeatedly accepts sessions and consumes them until they are
+// idle.
+func (r *serviceBusReader) consumeSessions(ctx context.Context, msgChan chan sbAsyncMessage) {
+	for ctx.Err() == nil {
+		receiver, err := r.acceptSession(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Attempting to accept the next available session times out when
+			// there are none available.
+			var sbErr *azservicebus.Error
+			if errors.As(err, &sbErr) && sbErr.Code == azservicebus.CodeTimeout {
+				r.log.Tracef("No sessions available on %v", r.entity)
+				continue
+			}
+			r.log.Errorf("Failed to accept session of %v: %v", r.entity, err)
+
+			select {
+			case <-time.After(time.Second * 5):
+			case <-ctx.Done():
+			}
+			continue
+		}
+
+		sessionID := receiver.SessionID()
+		r.log.Debugf("Accepted session %v of %v", sessionID, r.entity)
+
+		// The session lock is renewed until the session is closed, which
+		// happens only once all of its messages have been settled.
+		sessCtx, sessDone := context.WithCancel(ctx)
+		renewCtx, renewDone := context.WithCancel(context.Background())
+		if r.conf.RenewLock {
+			go r.renewSessionLock(renewCtx, sessDone, receiver)
+		}
+
+		err = r.consume(sessCtx, receiver, sessionID, msgChan)
+		renewDone()
+		sessDone()
+		_ = receiver.Close(context.Background())
+		if err != nil && ctx.Err() == nil {
+			r.log.Errorf("Failed to receive from session %v of %v: %v", sessionID, r.entity, err)
+		} else {
+			r.log.Debugf("Released session %v of %v", sessionID, r.entity)
+		}
+	}
+}
+
+// consume receives messages from a receiver and dispatches them until the
+// context is cancelled, the receiver fails, or a session becomes idle, and then
+// waits for the dispatched messages to be settled, as messages can only be
+// settled by the receiver that they were received from.
+func (r *serviceBusReader) consume(ctx context.Context, receiver sbReceiver, sessionID string, msgChan chan sbAsyncMessage) error {
+	var pending sync.WaitGroup
+	defer func() {
+		settled := make(chan struct{})
+		go func() {
+			pending.Wait()
+			close(settled)
+		}()
+		select {
+		case <-settled:
+		case <-r.shutCtx.Done():
+			// On shutdown in flight messages are given a chance to be
+			// acknowledged before the receiver is closed.
+			select {
+			case <-settled:
+			case <-time.After(time.Second * 30):
+			}
+		}
+	}()
+
+	// Each unsettled message holds a slot, which limits the number of
+	// messages received ahead of their settlement to the prefetch.
+	slots := make(chan struct{}, r.conf.Prefetch)
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+		n := 1
+	acquire:
+		for n < r.conf.Prefetch {
+			select {
+			case slots <- struct{}{}:
+				n++
+			default:
+				break acquire
+			}
+		}
+
+		recvCtx, recvDone := ctx, func() {}
+		if sessionID != "" && r.conf.Sessions.SessionID == "" {
+			recvCtx, recvDone = context.WithTimeout(ctx, r.conf.Sessions.IdleTimeout)
+		}
+		msgs, err := receiver.ReceiveMessages(recvCtx, n, nil)
+		recvDone()
+		for range n - len(msgs) {
+			<-slots
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				// The session is idle.
+				return nil
+			}
+			return err
+		}
+
+		for i, msg := range msgs {
+			part := sbMessageToMessage(msg)
+
+			renewCtx, renewDone := context.WithCancel(r.shutCtx)
+			if plain, ok := receiver.(*azservicebus.Receiver); ok && r.conf.RenewLock {
+				go r.renewMessageLock(renewCtx, plain, msg)
+			}
+
+			pending.Add(1)
+			var settleOnce sync.Once
+			ackFn := func(ctx context.Context, res error) (err error) {
+				settleOnce.Do(func() {
+					defer func() {
+						<-slots
+						pending.Done()
+					}()
+					renewDone()
+					err = r.settle(ctx, receiver, msg, res)
+				})
+				return
+			}
+
+			select {
+			case msgChan <- sbAsyncMessage{msg: part, ackFn: ackFn}:
+			case <-ctx.Done():
+				// The remaining messages are unlocked once the receiver is
+				// closed.
+				renewDone()
+				pending.Done()
+				for range len(msgs) - i {
+					<-slots
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// settle completes a message when it was delivered successfully, otherwise it
+// is either abandoned or dead-lettered.
+func (r *serviceBusReader) settle(ctx context.Context, receiver sbReceiver, msg *azservicebus.ReceivedMessage, res error) error {
+	if res == nil {
+		return receiver.CompleteMessage(ctx, msg, nil)
+	}
+	if r.conf.NackAction == sbNackActionDeadLetter {
+		return receiver.DeadLetterMessage(ctx, msg, &azservicebus.DeadLetterOptions{
+			Reason:           &r.conf.DeadLetterReason,
+			ErrorDescription: to.Ptr(res.Error()),
+		})
+	}
+	return receiver.AbandonMessage(ctx, msg, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// sbRenewIn returns how long to wait before renewing a lock.
+func sbRenewIn(lockedUntil time.Time) time.Duration {
+	return max(time.Until(lockedUntil)/2, time.Second)
+}
+
+func (r *serviceBusReader) renewMessageLock(ctx context.Context, receiver *azservicebus.Receiver, msg *azservicebus.ReceivedMessage) {
+	var lockedUntil time.Time
+	if msg.LockedUntil != nil {
+		lockedUntil = *msg.LockedUntil
+	}
+
+	for {
+		select {
+		case <-time.After(sbRenewIn(lockedUntil)):
+		case <-ctx.Done():
+			return
+		}
+
+		if err := receiver.RenewMessageLock(ctx, msg, nil); err != nil {
+			if ctx.Err() == nil {
+				r.log.Errorf("Unable to renew message lock: %v", err)
+			}
+			return
+		}
+		if msg.LockedUntil != nil {
+			lockedUntil = *msg.LockedUntil
+		}
+		r.log.Tracef("Renewed message lock until %v", lockedUntil)
+	}
+}
+
+func (r *serviceBusReader) renewSessionLock(ctx context.Context, cancel func(), receiver *azservicebus.SessionReceiver) {
+	for {
+		select {
+		case <-time.After(sbRenewIn(receiver.LockedUntil())):
+		case <-ctx.Done():
+			return
+		}
+
+		if err := receiver.RenewSessionLock(ctx, nil); err != nil {
+			if ctx.Err() == nil {
+				// Without a lock the session can no longer be settled, and so
+				// we release it.
+				r.log.Errorf("Unable to renew lock of session %v: %v", receiver.SessionID(), err)
+				cancel()
+			}
+			return
+		}
+		r.log.Tracef("Renewed lock of session %v until %v", receiver.SessionID(), receiver.LockedUntil())
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// sbMessageToMessage converts a received message into a message with metadata.
+func sbMessageToMessage(m *azservicebus.ReceivedMessage) *service.Message {
+	msg := service.NewMessage(m.Body)
+	for k, v := range m.ApplicationProperties {
+		msg.MetaSetMut(k, v)
+	}
+
+	msg.MetaSetMut("service_bus_delivery_count", int64(m.DeliveryCount))
+	if m.MessageID != "" {
+		msg.MetaSetMut("service_bus_message_id", m.MessageID)
+	}
+	if m.SequenceNumber != nil {
+		msg.MetaSetMut("service_bus_sequence_number", *m.SequenceNumber)
+	}
+	for k, v := range map[string]*string{
+		"service_bus_session_id":                    m.SessionID,
+		"service_bus_correlation_id":                m.CorrelationID,
+		"service_bus_content_type":                  m.ContentType,
+		"service_bus_subject":                       m.Subject,
+		"service_bus_reply_to":                      m.ReplyTo,
+		"service_bus_to":                            m.To,
+		"service_bus_partition_key":                 m.PartitionKey,
+		"service_bus_dead_letter_reason":            m.DeadLetterReason,
+		"service_bus_dead_letter_error_description": m.DeadLetterErrorDescription,
+	} {
+		if v != nil {
+			msg.MetaSetMut(k, *v)
+		}
+	}
+	for k, v := range map[string]*time.Time{
+		"service_bus_enqueued_time":          m.EnqueuedTime,
+		"service_bus_locked_until":           m.LockedUntil,
+		"service_bus_scheduled_enqueue_time": m.ScheduledEnqueueTime,
+	} {
+		if v != nil {
+			msg.MetaSetMut(k, v.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	return msg
+}
+
+// Read attempts to read a message from the queue or topic subscription.
+func (r *serviceBusReader) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
+	r.cMut.Lock()
+	msgChan := r.msgChan
+	r.cMut.Unlock()
+
+	if msgChan == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case m, open := <-msgChan:
+		if !open {
+			r.cMut.Lock()
+			if r.msgChan == msgChan {
+				r.msgChan = nil
+			}
+			r.cMut.Unlock()
+			return nil, nil, service.ErrNotConnected
+		}
+		return m.msg, m.ackFn, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// Close the connection to the namespace.
+func (r *serviceBusReader) Close(ctx context.Context) error {
+	r.shutdown()
+
+	r.cMut.Lock()
+	runDone := r.runDone
+	r.cMut.Unlock()
+
+	if runDone != nil {
+		select {
+		case <-runDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return r.client.Close(ctx)
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs/v2"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Event Hubs Output Fields
+	ehoFieldEventHub     = "event_hub"
+	ehoFieldPartitionKey = "partition_key"
+	ehoFieldPartitionID  = "partition_id"
+	ehoFieldMetadata     = "metadata"
+	ehoFieldBatching     = "batching"
+)
+
+type ehoConfig struct {
+	EventHub     string
+	PartitionKey *service.InterpolatedString
+	PartitionID  *service.InterpolatedString
+	MetaFilter   *service.MetadataExcludeFilter
+}
+
+func ehoConfigFromParsed(pConf *service.ParsedConfig) (conf ehoConfig, err error) {
+	if conf.EventHub, err = pConf.FieldString(ehoFieldEventHub); err != nil {
+		return
+	}
+	if pConf.Contains(ehoFieldPartitionKey) {
+		if conf.PartitionKey, err = pConf.FieldInterpolatedString(ehoFieldPartitionKey); err != nil {
+			return
+		}
+	}
+	if pConf.Contains(ehoFieldPartitionID) {
+		if conf.PartitionID, err = pConf.FieldInterpolatedString(ehoFieldPartitionID); err != nil {
+			return
+		}
+	}
+	if conf.MetaFilter, err = pConf.FieldMetadataExcludeFilter(ehoFieldMetadata); err != nil {
+		return
+	}
+	return
+}
+
+func ehoSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Categories("Services", "Azure").
+		Beta().
+		Version("4.73.0").
+		Summary(`Sends messages to an Azure Event Hub.`).
+		Description(`
+Either a `+"`connection_string`"+` containing a shared access key or signature, or the fully qualified `+"`namespace`"+` of the event hub must be provided. When only the namespace is provided the default Azure credentials are obtained from the environment.
+
+Events are distributed across partitions by the event hub unless a `+"`partition_key`"+` or `+"`partition_id`"+` is specified, both of which support xref:configuration:interpolation.adoc#bloblang-queries[function interpolation] calculated per message of a batch. Messages of a batch that share a partition are sent as a single batch of events, which is split when it exceeds the maximum message size of the event hub.
+
+Metadata fields of each message that aren't excluded by the `+"`metadata`"+` field are sent as application properties of the event.`+service.OutputPerformanceDocs(true, true)).
+		Fields(amqpNamespaceFields("event hub")...).
+		Fields(
+			service.NewStringField(ehoFieldEventHub).
+				Description("The name of the event hub to send to. This field is required unless the `"+amqpFieldConnectionString+"` contains an `EntityPath`.").
+				Default(""),
+			service.NewInterpolatedStringField(ehoFieldPartitionKey).
+				Description("An optional key that is hashed by the event hub in order to determine the partition of an event, ensuring that events sharing a key are delivered to the same partition.").
+				Example(`${! @user_id }`).
+				Optional(),
+			service.NewInterpolatedStringField(ehoFieldPartitionID).
+				Description("An optional ID of the partition to send an event to, which takes precedence over the `"+ehoFieldPartitionKey+"`.").
+				Example(`${! @event_hubs_partition_id }`).
+				Optional().
+				Advanced(),
+			service.NewMetadataExcludeFilterField(ehoFieldMetadata).
+				Description("Specify criteria for which metadata values are sent as application properties of events."),
+			service.NewOutputMaxInFlightField(),
+			service.NewBatchPolicyField(ehoFieldBatching),
+		)
+}
+
+func init() {
+	service.MustRegisterBatchOutput("azure_event_hubs", ehoSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batcher service.BatchPolicy, mif int, err error) {
+			if batcher, err = conf.FieldBatchPolicy(ehoFieldBatching); err != nil {
+				return
+			}
+			if mif, err = conf.FieldMaxInFlight(); err != nil {
+				return
+			}
+			out, err = newEventHubsWriterFromParsed(conf, mgr)
+			return
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type eventHubsWriter struct {
+	conf ehoConfig
+	ns   amqpNamespaceConfig
+	log  *service.Logger
+
+	connMut  sync.RWMutex
+	producer *azeventhubs.ProducerClient
+}
+
+func newEventHubsWriterFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*eventHubsWriter, error) {
+	w := &eventHubsWriter{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if w.conf, err = ehoConfigFromParsed(pConf); err != nil {
+		return nil, err
+	}
+	if w.ns, err = amqpNamespaceConfigFromParsed(pConf); err != nil {
+		return nil, err
+	}
+	if w.conf.EventHub == "" {
+		w.conf.EventHub = w.ns.entityPath
+	}
+	if w.conf.EventHub == "" {
+		return nil, errors.New("an event_hub must be specified")
+	}
+	return w, nil
+}
+
+// Connect to the event hub.
+func (w *eventHubsWriter) Connect(ctx context.Context) error {
+	w.connMut.Lock()
+	defer w.connMut.Unlock()
+	if w.producer != nil {
+		return nil
+	}
+
+	var producer *azeventhubs.ProducerClient
+	var err error
+	if w.ns.connectionString != "" {
+		producer, err = azeventhubs.NewProducerClientFromConnectionString(w.ns.connectionString, w.conf.EventHub, nil)
+	} else {
+		producer, err = azeventhubs.NewProducerClient(w.ns.namespace, w.conf.EventHub, w.ns.cred, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The connection is opened lazily, and so the properties of the event hub
+	// are obtained in order that misconfigurations are surfaced on connect.
+	if _, err := producer.GetEventHubProperties(ctx, nil); err != nil {
+		_ = producer.Close(ctx)
+		return err
+	}
+
+	w.producer = producer
+	return nil
+}
+
+func (w *eventHubsWriter) disconnect(ctx context.Context) {
+	w.connMut.Lock()
+	defer w.connMut.Unlock()
+	if w.producer == nil {
+		return
+	}
+	_ = w.producer.Close(ctx)
+	w.producer = nil
+}
+
+// ehMessageToEvent converts a message into an event.
+func ehMessageToEvent(msg *service.Message, metaFilter *service.MetadataExcludeFilter) (*azeventhubs.EventData, error) {
+	body, err := msg.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &azeventhubs.EventData{Body: body}
+	_ = metaFilter.Walk(msg, func(k, v string) error {
+		if event.Properties == nil {
+			event.Properties = map[string]any{}
+		}
+		event.Properties[k] = v
+		return nil
+	})
+	return event, nil
+}
+
+type ehEventGroup struct {
+	partitionID  string
+	partitionKey string
+	events       []*azeventhubs.EventData
+	indexes      []int
+}
+
+// WriteBatch sends a batch of messages to the event hub.
+func (w *eventHubsWriter) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	w.connMut.RLock()
+	producer := w.producer
+	w.connMut.RUnlock()
+	if producer == nil {
+		return service.ErrNotConnected
+	}
+
+	var groups []*ehEventGroup
+	groupIndex := map[[2]string]*ehEventGroup{}
+
+	for i, msg := range batch {
+		var partitionKey, partitionID string
+		var err error
+		if w.conf.PartitionKey != nil {
+			if partitionKey, err = batch.TryInterpolatedString(i, w.conf.PartitionKey); err != nil {
+				return fmt.Errorf("partition key interpolation error: %w", err)
+			}
+		}
+		if w.conf.PartitionID != nil {
+			if partitionID, err = batch.TryInterpolatedString(i, w.conf.PartitionID); err != nil {
+				return fmt.Errorf("partition id interpolation error: %w", err)
+			}
+		}
+		if partitionID != "" {
+			// A batch can't target both a partition and a partition key.
+			partitionKey = ""
+		}
+
+		event, err := ehMessageToEvent(msg, w.conf.MetaFilter)
+		if err != nil {
+			return err
+		}
+
+		key := [2]string{partitionID, partitionKey}
+		g, exists := groupIndex[key]
+		if !exists {
+			g = &ehEventGroup{partitionID: partitionID, partitionKey: partitionKey}
+			groupIndex[key] = g
+			groups = append(groups, g)
+		}
+		g.events = append(g.events, event)
+		g.indexes = append(g.indexes, i)
+	}
+
+	var batchErr *service.BatchError
+	for _, g := range groups {
+		err := w.sendGroup(ctx, producer, g)
+		if err == nil {
+			continue
+		}
+
+		var ehErr *azeventhubs.Error
+		if errors.As(err, &ehErr) && ehErr.Code == azeventhubs.ErrorCodeConnectionLost {
+			w.log.Errorf("Lost connection to event hub %v: %v", w.conf.EventHub, err)
+			w.disconnect(ctx)
+			return service.ErrNotConnected
+		}
+
+		if batchErr == nil {
+			batchErr = service.NewBatchError(batch, err)
+		}
+		for _, i := range g.indexes {
+			batchErr.Failed(i, err)
+		}
+	}
+	if batchErr != nil {
+		return batchErr
+	}
+	return nil
+}
+
+// sendGroup sends the events of a group in as few batches as possible without
+// exceeding the maximum message size of the event hub.
+func (w *eventHubsWriter) sendGroup(ctx context.Context, producer *azeventhubs.ProducerClient, g *ehEventGroup) error {
+	var opts azeventhubs.EventDataBatchOptions
+	if g.partitionID != "" {
+		opts.PartitionID = &g.partitionID
+	} else if g.partitionKey != "" {
+		opts.PartitionKey = &g.partitionKey
+	}
+
+	eventBatch, err := producer.NewEventDataBatch(ctx, &opts)
+	if err != nil {
+		return err
+	}
+	for _, event := range g.events {
+		err := eventBatch.AddEventData(event, nil)
+		if errors.Is(err, azeventhubs.ErrEventDataTooLarge) && eventBatch.NumEvents() > 0 {
+			if err = producer.SendEventDataBatch(ctx, eventBatch, nil); err != nil {
+				return err
+			}
+			if eventBatch, err = producer.NewEventDataBatch(ctx, &opts); err != nil {
+				return err
+			}
+			err = eventBatch.AddEventData(event, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return producer.SendEventDataBatch(ctx, eventBatch, nil)
+}
+
+// Close the connection to the event hub.
+func (w *eventHubsWriter) Close(ctx context.Context) error {
+	w.disconnect(ctx)
+	return nil
+}
@@ -0,0 +1,411 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	// Service Bus Output Fields
+	sboFieldQueue                = "queue"
+	sboFieldTopic                = "topic"
+	sboFieldMessageID            = "message_id"
+	sboFieldSessionID            = "session_id"
+	sboFieldCorrelationID        = "correlation_id"
+	sboFieldSubject              = "subject"
+	sboFieldContentType          = "content_type"
+	sboFieldPartitionKey         = "partition_key"
+	sboFieldScheduledEnqueueTime = "scheduled_enqueue_time"
+	sboFieldMetadata             = "metadata"
+	sboFieldBatching             = "batching"
+)
+
+type sboConfig struct {
+	Queue                string
+	Topic                string
+	MessageID            *service.InterpolatedString
+	SessionID            *service.InterpolatedString
+	CorrelationID        *service.InterpolatedString
+	Subject              *service.InterpolatedString
+	ContentType          *service.InterpolatedString
+	PartitionKey         *service.InterpolatedString
+	ScheduledEnqueueTime *service.InterpolatedString
+	MetaFilter           *service.MetadataExcludeFilter
+}
+
+func sboConfigFromParsed(pConf *service.ParsedConfig) (conf sboConfig, err error) {
+	if conf.Queue, err = pConf.FieldString(sboFieldQueue); err != nil {
+		return
+	}
+	if conf.Topic, err = pConf.FieldString(sboFieldTopic); err != nil {
+		return
+	}
+	for _, f := range []struct {
+		name string
+		dst  **service.InterpolatedString
+	}{
+		{sboFieldMessageID, &conf.MessageID},
+		{sboFieldSessionID, &conf.SessionID},
+		{sboFieldCorrelationID, &conf.CorrelationID},
+		{sboFieldSubject, &conf.Subject},
+		{sboFieldContentType, &conf.ContentType},
+		{sboFieldPartitionKey, &conf.PartitionKey},
+		{sboFieldScheduledEnqueueTime, &conf.ScheduledEnqueueTime},
+	} {
+		if !pConf.Contains(f.name) {
+			continue
+		}
+		if *f.dst, err = pConf.FieldInterpolatedString(f.name); err != nil {
+			return
+		}
+	}
+	if conf.MetaFilter, err = pConf.FieldMetadataExcludeFilter(sboFieldMetadata); err != nil {
+		return
+	}
+	return
+}
+
+func sboSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Categories("Services", "Azure").
+		Beta().
+		Version("4.73.0").
+		Summary(`Sends messages to an Azure Service Bus queue or topic.`).
+		Description(`
+Either a `+"`connection_string`"+` containing a shared access key or signature, or the fully qualified `+"`namespace`"+` must be provided. When only the namespace is provided the default Azure credentials are obtained from the environment.
+
+Messages can be sent to a session by setting a `+"`session_id`"+`, which is required when the entity has sessions enabled, and their delivery can be deferred by setting a `+"`scheduled_enqueue_time`"+`. All of these fields support xref:configuration:interpolation.adoc#bloblang-queries[function interpolation] calculated per message of a batch.
+
+Messages of a batch that share a session and partition key are sent as a single batch, which is split when it exceeds the maximum message size of the entity. Metadata fields of each message that aren't excluded by the `+"`metadata`"+` field are sent as application properties.`+service.OutputPerformanceDocs(true, true)).
+		Fields(amqpNamespaceFields("queue or topic")...).
+		Fields(
+			service.NewStringField(sboFieldQueue).
+				Description("The name of the queue to send to. Either this field or `"+sboFieldTopic+"` must be set unless the `"+amqpFieldConnectionString+"` contains an `EntityPath`.").
+				Default(""),
+			service.NewStringField(sboFieldTopic).
+				Description("The name of the topic to send to.").
+				Default(""),
+			service.NewInterpolatedStringField(sboFieldMessageID).
+				Description("An optional ID of each message, which is used by entities with duplicate detection enabled.").
+				Example(`${! @id }`).
+				Optional(),
+			service.NewInterpolatedStringField(sboFieldSessionID).
+				Description("An optional ID of the session that each message belongs to.").
+				Example(`${! @customer_id }`).
+				Optional(),
+			service.NewInterpolatedStringField(sboFieldCorrelationID).
+				Description("An optional correlation ID of each message.").
+				Optional().
+				Advanced(),
+			service.NewInterpolatedStringField(sboFieldSubject).
+				Description("An optional subject of each message, which can be used by subscription filters.").
+				Optional().
+				Advanced(),
+			service.NewInterpolatedStringField(sboFieldContentType).
+				Description("An optional content type of each message.").
+				Example("application/json").
+				Optional().
+				Advanced(),
+			service.NewInterpolatedStringField(sboFieldPartitionKey).
+				Description("An optional key that determines the partition of each message when sent to a partitioned entity. When a `"+sboFieldSessionID+"` is set the partition key must match it.").
+				Optional().
+				Advanced(),
+			service.NewInterpolatedStringField(sboFieldScheduledEnqueueTime).
+				Description("An optional RFC 3339 timestamp at which each message becomes available to receivers. Messages with an empty or past timestamp are enqueued immediately.").
+				Example(`${! now().ts_add_iso8601("PT1H") }`).
+				Optional(),
+			service.NewMetadataExcludeFilterField(sboFieldMetadata).
+				Description("Specify criteria for which metadata values are sent as application properties of messages."),
+			service.NewOutputMaxInFlightField(),
+			service.NewBatchPolicyField(sboFieldBatching),
+		)
+}
+
+func init() {
+	service.MustRegisterBatchOutput("azure_service_bus", sboSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batcher service.BatchPolicy, mif int, err error) {
+			if batcher, err = conf.FieldBatchPolicy(sboFieldBatching); err != nil {
+				return
+			}
+			if mif, err = conf.FieldMaxInFlight(); err != nil {
+				return
+			}
+			out, err = newServiceBusWriterFromParsed(conf, mgr)
+			return
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type serviceBusWriter struct {
+	conf   sboConfig
+	entity string
+	client *azservicebus.Client
+	log    *service.Logger
+
+	connMut sync.RWMutex
+	sender  *azservicebus.Sender
+}
+
+func newServiceBusWriterFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*serviceBusWriter, error) {
+	w := &serviceBusWriter{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if w.conf, err = sboConfigFromParsed(pConf); err != nil {
+		return nil, err
+	}
+	ns, err := amqpNamespaceConfigFromParsed(pConf)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case w.conf.Queue != "" && w.conf.Topic != "":
+		return nil, fmt.Errorf("only one of %v or %v may be set", sboFieldQueue, sboFieldTopic)
+	case w.conf.Queue != "":
+		w.entity = w.conf.Queue
+	case w.conf.Topic != "":
+		w.entity = w.conf.Topic
+	default:
+		w.entity = ns.entityPath
+	}
+	if w.entity == "" {
+		return nil, fmt.Errorf("either a %v or %v must be specified", sboFieldQueue, sboFieldTopic)
+	}
+
+	if w.client, err = newServiceBusClient(ns); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Connect to the queue or topic.
+func (w *serviceBusWriter) Connect(ctx context.Context) error {
+	w.connMut.Lock()
+	defer w.connMut.Unlock()
+	if w.sender != nil {
+		return nil
+	}
+
+	sender, err := w.client.NewSender(w.entity, nil)
+	if err != nil {
+		return err
+	}
+
+	// The sender is opened lazily, and so a batch is created, which obtains
+	// the maximum message size of the entity, in order that
+	// misconfigurations are surfaced on connect.
+	if _, err := sender.NewMessageBatch(ctx, nil); err != nil {
+		_ = sender.Close(ctx)
+		return err
+	}
+
+	w.sender = sender
+	return nil
+}
+
+func (w *serviceBusWriter) disconnect(ctx context.Context) {
+	w.connMut.Lock()
+	defer w.connMut.Unlock()
+	if w.sender == nil {
+		return
+	}
+	_ = w.sender.Close(ctx)
+	w.sender = nil
+}
+
+// sbBatchToMessages converts each message of a batch into a Service Bus
+// message.
+func (w *serviceBusWriter) sbBatchToMessages(batch service.MessageBatch) ([]*azservicebus.Message, error) {
+	msgs := make([]*azservicebus.Message, 0, len(batch))
+	for i, msg := range batch {
+		body, err := msg.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		m := &azservicebus.Message{Body: body}
+
+		for _, p := range []struct {
+			field string
+			is    *service.InterpolatedString
+			dst   **string
+		}{
+			{sboFieldMessageID, w.conf.MessageID, &m.MessageID},
+			{sboFieldSessionID, w.conf.SessionID, &m.SessionID},
+			{sboFieldCorrelationID, w.conf.CorrelationID, &m.CorrelationID},
+			{sboFieldSubject, w.conf.Subject, &m.Subject},
+			{sboFieldContentType, w.conf.ContentType, &m.ContentType},
+			{sboFieldPartitionKey, w.conf.PartitionKey, &m.PartitionKey},
+		} {
+			if p.is == nil {
+				continue
+			}
+			v, err := batch.TryInterpolatedString(i, p.is)
+			if err != nil {
+				return nil, fmt.Errorf("%v interpolation error: %w", p.field, err)
+			}
+			if v != "" {
+				*p.dst = &v
+			}
+		}
+
+		if w.conf.ScheduledEnqueueTime != nil {
+			v, err := batch.TryInterpolatedString(i, w.conf.ScheduledEnqueueTime)
+			if err != nil {
+				return nil, fmt.Errorf("%v interpolation error: %w", sboFieldScheduledEnqueueTime, err)
+			}
+			if v != "" {
+				t, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %v: %w", sboFieldScheduledEnqueueTime, err)
+				}
+				m.ScheduledEnqueueTime = &t
+			}
+		}
+
+		_ = w.conf.MetaFilter.Walk(msg, func(k, v string) error {
+			if m.ApplicationProperties == nil {
+				m.ApplicationProperties = map[string]any{}
+			}
+			m.ApplicationProperties[k] = v
+			return nil
+		})
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
+
+// sbBatchGroupKey returns the session and partition key of a message, which
+// must be shared by all messages of a batch.
+func sbBatchGroupKey(m *azservicebus.Message) (key [2]string) {
+	if m.SessionID != nil {
+		key[0] = *m.SessionID
+	}
+	if m.PartitionKey != nil {
+		key[1] = *m.PartitionKey
+	}
+	return
+}
+
+// WriteBatch sends a batch of messages to the queue or topic.
+func (w *serviceBusWriter) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	w.connMut.RLock()
+	sender := w.sender
+	w.connMut.RUnlock()
+	if sender == nil {
+		return service.ErrNotConnected
+	}
+
+	msgs, err := w.sbBatchToMessages(batch)
+	if err != nil {
+		return err
+	}
+
+	// Messages with a scheduled enqueue time are scheduled together with the
+	// other messages of the same time, and the remaining messages are sent in
+	// batches of the same session and partition key.
+	var groups, scheduled [][]*azservicebus.Message
+	groupIndex := map[[2]string]int{}
+	scheduledIndex := map[time.Time]int{}
+	for _, m := range msgs {
+		if m.ScheduledEnqueueTime != nil {
+			i, exists := scheduledIndex[*m.ScheduledEnqueueTime]
+			if !exists {
+				i = len(scheduled)
+				scheduledIndex[*m.ScheduledEnqueueTime] = i
+				scheduled = append(scheduled, nil)
+			}
+			scheduled[i] = append(scheduled[i], m)
+			continue
+		}
+
+		key := sbBatchGroupKey(m)
+		i, exists := groupIndex[key]
+		if !exists {
+			i = len(groups)
+			groupIndex[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], m)
+	}
+
+	for _, g := range groups {
+		if err = w.sendGroup(ctx, sender, g); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		for _, g := range scheduled {
+			if _, err = sender.ScheduleMessages(ctx, g, *g[0].ScheduledEnqueueTime, nil); err != nil {
+				break
+			}
+		}
+	}
+
+	var sbErr *azservicebus.Error
+	if errors.As(err, &sbErr) && sbErr.Code == azservicebus.CodeConnectionLost {
+		w.log.Errorf("Lost connection to %v: %v", w.entity, err)
+		w.disconnect(ctx)
+		return service.ErrNotConnected
+	}
+	return err
+}
+
+// sendGroup sends messages of the same session and partition key in as few
+// batches as possible without exceeding the maximum message size of the
+// entity.
+func (w *serviceBusWriter) sendGroup(ctx context.Context, sender *azservicebus.Sender, msgs []*azservicebus.Message) error {
+	if len(msgs) == 1 {
+		return sender.SendMessage(ctx, msgs[0], nil)
+	}
+
+	mb, err := sender.NewMessageBatch(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		err := mb.AddMessage(m, nil)
+		if errors.Is(err, azservicebus.ErrMessageTooLarge) && mb.NumMessages() > 0 {
+			if err = sender.SendMessageBatch(ctx, mb, nil); err != nil {
+				return err
+			}
+			if mb, err = sender.NewMessageBatch(ctx, nil); err != nil {
+				return err
+			}
+			err = mb.AddMessage(m, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return sender.SendMessageBatch(ctx, mb, nil)
+}
+
+// Close the connection to the queue or topic.
+func (w *serviceBusWriter) Close(ctx context.Context) error {
+	w.disconnect(ctx)
+	return w.client.Close(ctx)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceBusReceiverEntityPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       sbiConfig
+		connStrEP  string
+		expected   sbEntity
+		errContain string
+	}{
+		{name: "queue", conf: sbiConfig{Queue: "foo"}, expected: sbEntity{queue: "foo"}},
+		{name: "subscription", conf: sbiConfig{Topic: "foo", Subscription: "bar"}, expected: sbEntity{topic: "foo", subscription: "bar"}},
+		{name: "connection string queue", connStrEP: "foo", expected: sbEntity{queue: "foo"}},
+		{name: "connection string topic", conf: sbiConfig{Subscription: "bar"}, connStrEP: "foo", expected: sbEntity{topic: "foo", subscription: "bar"}},
+		{name: "topic without subscription", conf: sbiConfig{Topic: "foo"}, errContain: "must be set"},
+		{name: "queue and topic", conf: sbiConfig{Queue: "foo", Topic: "bar"}, errContain: "only one of"},
+		{name: "nothing", errContain: "must be specified"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entity, err := sbReceiverEntity(test.conf, test.connStrEP)
+			if test.errContain != "" {
+				require.ErrorContains(t, err, test.errContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, entity)
+		})
+	}
+}
+
+func TestServiceBusMessageToMessage(t *testing.T) {
+	enqueued := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	msg := sbMessageToMessage(&azservicebus.ReceivedMessage{
+		MessageID:        "foo",
+		SessionID:        to.Ptr("bar"),
+		Subject:          to.Ptr("baz"),
+		DeliveryCount:    3,
+		SequenceNumber:   to.Ptr(int64(7)),
+		EnqueuedTime:     &enqueued,
+		DeadLetterReason: to.Ptr("ProcessingFailed"),
+		ApplicationProperties: map[string]any{
+			"qux": "quz",
+		},
+		Body: []byte("hello world"),
+	})
+
+	body, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(body))
+
+	for k, v := range map[string]any{
+		"service_bus_message_id":         "foo",
+		"service_bus_session_id":         "bar",
+		"service_bus_subject":            "baz",
+		"service_bus_delivery_count":     int64(3),
+		"service_bus_sequence_number":    int64(7),
+		"service_bus_enqueued_time":      "2025-01-02T03:04:05Z",
+		"service_bus_dead_letter_reason": "ProcessingFailed",
+		"qux":                            "quz",
+	} {
+		actual, exists := msg.MetaGetMut(k)
+		require.True(t, exists, k)
+		assert.Equal(t, v, actual, k)
+	}
+}
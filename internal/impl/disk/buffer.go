@@ -0,0 +1,786 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Jeffail/checkpoint"
+	"github.com/Jeffail/shutdown"
+	"github.com/dustin/go-humanize"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	dbFieldDirectory      = "directory"
+	dbFieldMaxDiskSize    = "max_disk_size"
+	dbFieldSegmentSize    = "segment_size"
+	dbFieldSyncPolicy     = "sync_policy"
+	dbFieldSyncInterval   = "sync_interval"
+	dbFieldPreProcessors  = "pre_processors"
+	dbFieldPostProcessors = "post_processors"
+)
+
+const (
+	syncPolicyAlways   = "always"
+	syncPolicyInterval = "interval"
+	syncPolicyNone     = "none"
+)
+
+func diskBufferSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.73.0").
+		Categories("Utility").
+		Summary("Stores messages in append-only segment files on the local disk and acknowledges them at the input level.").
+		Description(`
+Messages are appended to segment files within a directory and are then consumed as a stream in the order they were written. Once the active segment reaches `+"`segment_size`"+` a new segment is started, and segments are deleted once every message they contain has been successfully sent at the output level.
+
+This buffer is intended for absorbing long periods of downstream unavailability at high throughput. When the total size of all segments reaches `+"`max_disk_size`"+` writes are blocked, which applies back pressure to the input, until older segments are delivered and deleted.
+
+== Delivery guarantees
+
+Messages are not acknowledged at the input level until they have been written to the active segment, and segments are not deleted until all of their messages have been delivered. The progress of delivered messages is periodically persisted to a checkpoint file within the directory, and when the service is restarted consumption resumes from that checkpoint. This means at-least-once delivery guarantees are preserved in cases where the service is shut down unexpectedly, although messages delivered since the last checkpoint may be duplicated.
+
+How resilient written messages are to a machine crash (rather than a process crash) depends on the `+"`sync_policy`"+`:
+
+- `+"`always`"+`: Segment files are synced to disk before each write is acknowledged. This is the safest option but has the lowest throughput.
+- `+"`interval`"+`: Segment files are synced to disk periodically according to `+"`sync_interval`"+`. A machine crash could lose writes made since the last sync.
+- `+"`none`"+`: Syncing is left to the operating system.
+
+== Batching
+
+Messages that are logically batched at the point where they are added to the buffer will continue to be associated with that batch when they are consumed. Each batch is stored as a single record and therefore it is recommended to use batching at the input level in high-throughput use cases even if they are not required for processing.
+`).
+		Fields(
+			service.NewStringField(dbFieldDirectory).
+				Description("The path of a directory to store segment files within, which will be created if it does not already exist. The directory must not be shared with other buffers."),
+			service.NewStringField(dbFieldMaxDiskSize).
+				Description("The maximum total size of all segment files. Once reached, writes to the buffer are blocked until older segments are delivered.").
+				Default("10GiB"),
+			service.NewStringField(dbFieldSegmentSize).
+				Description("The size at which the active segment is closed and a new segment is started. Smaller segments allow disk space to be reclaimed sooner at the cost of more files. Must not be larger than half of `max_disk_size`.").
+				Default("64MiB").
+				Advanced(),
+			service.NewStringEnumField(dbFieldSyncPolicy, syncPolicyAlways, syncPolicyInterval, syncPolicyNone).
+				Description("Determines when segment files are synced to disk.").
+				Default(syncPolicyInterval),
+			service.NewDurationField(dbFieldSyncInterval).
+				Description("The period at which segment files are synced to disk when the `sync_policy` is `interval`, and the period at which delivery progress is checkpointed regardless of the `sync_policy`.").
+				Default("1s").
+				Advanced(),
+			service.NewProcessorListField(dbFieldPreProcessors).
+				Description(`An optional list of processors to apply to messages before they are stored within the buffer. These processors are useful for compressing, archiving or otherwise reducing the data in size before it's stored on disk.`).
+				Optional(),
+			service.NewProcessorListField(dbFieldPostProcessors).
+				Description("An optional list of processors to apply to messages after they are consumed from the buffer. These processors are useful for undoing any compression, archiving, etc that may have been done by your `pre_processors`.").
+				Optional(),
+		).
+		Example("Absorbing outages", "Batching at the input level greatly increases the throughput of this buffer. If logical batches aren't needed for processing add a xref:components:processors/split.adoc[`split` processor] to the `post_processors`.", `
+input:
+  kafka_franz:
+    seed_brokers: [ localhost:9092 ]
+    topics: [ foo ]
+    consumer_group: bar
+    batching:
+      count: 1000
+      period: 100ms
+
+buffer:
+  disk:
+    directory: /var/lib/connect/buffer
+    max_disk_size: 100GiB
+    post_processors:
+      - split: {}
+`)
+}
+
+func init() {
+	service.MustRegisterBatchBuffer(
+		"disk", diskBufferSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchBuffer, error) {
+			return newDiskBufferFromParsed(conf, mgr)
+		})
+}
+
+func newDiskBufferFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*diskBuffer, error) {
+	dir, err := conf.FieldString(dbFieldDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	parseBytes := func(name string) (int64, error) {
+		str, err := conf.FieldString(name)
+		if err != nil {
+			return 0, err
+		}
+		v, err := humanize.ParseBytes(str)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %v: %w", name, err)
+		}
+		return int64(v), nil
+	}
+
+	maxDiskSize, err := parseBytes(dbFieldMaxDiskSize)
+	if err != nil {
+		return nil, err
+	}
+	segmentSize, err := parseBytes(dbFieldSegmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if segmentSize > maxDiskSize/2 {
+		// At least two segments must fit within the disk size so that a new
+		// segment can be started while an older one is still being delivered.
+		return nil, fmt.Errorf("%v must not be larger than half of %v", dbFieldSegmentSize, dbFieldMaxDiskSize)
+	}
+
+	syncPolicy, err := conf.FieldString(dbFieldSyncPolicy)
+	if err != nil {
+		return nil, err
+	}
+	syncInterval, err := conf.FieldDuration(dbFieldSyncInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	var preProcs, postProcs []*service.OwnedProcessor
+	if conf.Contains(dbFieldPreProcessors) {
+		if preProcs, err = conf.FieldProcessorList(dbFieldPreProcessors); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(dbFieldPostProcessors) {
+		if postProcs, err = conf.FieldProcessorList(dbFieldPostProcessors); err != nil {
+			return nil, err
+		}
+	}
+
+	return newDiskBuffer(diskBufferConfig{
+		dir:          dir,
+		maxDiskSize:  maxDiskSize,
+		segmentSize:  segmentSize,
+		syncPolicy:   syncPolicy,
+		syncInterval: syncInterval,
+		preProcs:     preProcs,
+		postProcs:    postProcs,
+	}, mgr.Logger())
+}
+
+//------------------------------------------------------------------------------
+
+const (
+	segmentSuffix  = ".seg"
+	checkpointFile = "checkpoint"
+)
+
+// position marks the end of a record within a segment.
+type position struct {
+	Segment uint64 `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+// covers returns true if every record of a segment of a given size is at or
+// before this position.
+func (p position) covers(id uint64, size int64) bool {
+	return p.Segment > id || (p.Segment == id && p.Offset >= size)
+}
+
+type segment struct {
+	id   uint64
+	size int64
+}
+
+type pendingRecord struct {
+	data    []byte
+	resolve func() *position
+}
+
+type ackableBatch struct {
+	b   service.MessageBatch
+	aFn service.AckFunc
+}
+
+type diskBufferConfig struct {
+	dir          string
+	maxDiskSize  int64
+	segmentSize  int64
+	syncPolicy   string
+	syncInterval time.Duration
+	preProcs     []*service.OwnedProcessor
+	postProcs    []*service.OwnedProcessor
+}
+
+type diskBuffer struct {
+	conf diskBufferConfig
+	log  *service.Logger
+
+	cond *sync.Cond
+
+	// Ordered by ID, the last segment is always the one being written to.
+	segments  []segment
+	diskSize  int64
+	writeFile *os.File
+	unsynced  bool
+
+	readSeg    uint64
+	readOffset int64
+	readFile   *os.File
+	readBuf    *bufio.Reader
+
+	pending   []ackableBatch
+	requeued  []pendingRecord
+	tracker   *checkpoint.Uncapped[position]
+	acked     position
+	persisted position
+
+	endOfInput bool
+	closed     bool
+
+	shutSig *shutdown.Signaller
+}
+
+func newDiskBuffer(conf diskBufferConfig, log *service.Logger) (*diskBuffer, error) {
+	if err := os.MkdirAll(conf.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	d := &diskBuffer{
+		conf:    conf,
+		log:     log,
+		cond:    sync.NewCond(&sync.Mutex{}),
+		tracker: checkpoint.NewUncapped[position](),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	if cBytes, err := os.ReadFile(filepath.Join(conf.dir, checkpointFile)); err == nil {
+		if err := json.Unmarshal(cBytes, &d.acked); err != nil {
+			return nil, fmt.Errorf("failed to parse checkpoint file: %w", err)
+		}
+		d.persisted = d.acked
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(conf.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		idStr, isSegment := strings.CutSuffix(e.Name(), segmentSuffix)
+		if !isSegment || e.IsDir() {
+			continue
+		}
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		if d.acked.covers(id, info.Size()) {
+			// Fully delivered before the last shutdown but not yet removed.
+			if err := os.Remove(d.segmentPath(id)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		d.segments = append(d.segments, segment{id: id, size: info.Size()})
+		d.diskSize += info.Size()
+	}
+	sort.Slice(d.segments, func(i, j int) bool {
+		return d.segments[i].id < d.segments[j].id
+	})
+
+	// New segment IDs must always be beyond the checkpoint, otherwise they
+	// would be considered delivered.
+	nextID := d.acked.Segment + 1
+	if len(d.segments) > 0 {
+		d.readSeg = d.segments[0].id
+		if d.readSeg == d.acked.Segment {
+			d.readOffset = d.acked.Offset
+		}
+		if last := d.segments[len(d.segments)-1].id; last >= nextID {
+			nextID = last + 1
+		}
+	} else {
+		d.readSeg = nextID
+	}
+
+	// Always start writing to a fresh segment so that we never append to a
+	// segment with a partially written tail.
+	if err := d.openSegment(nextID); err != nil {
+		return nil, err
+	}
+
+	go d.loop()
+	return d, nil
+}
+
+func (d *diskBuffer) segmentPath(id uint64) string {
+	return filepath.Join(d.conf.dir, fmt.Sprintf("%020d%v", id, segmentSuffix))
+}
+
+func (d *diskBuffer) openSegment(id uint64) error {
+	f, err := os.OpenFile(d.segmentPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	d.writeFile = f
+	d.segments = append(d.segments, segment{id: id})
+	return nil
+}
+
+func (d *diskBuffer) rotate() error {
+	if d.conf.syncPolicy != syncPolicyNone {
+		if err := d.writeFile.Sync(); err != nil {
+			return err
+		}
+		d.unsynced = false
+	}
+	if err := d.writeFile.Close(); err != nil {
+		return err
+	}
+	if err := d.openSegment(d.segments[len(d.segments)-1].id + 1); err != nil {
+		return err
+	}
+	d.cleanup()
+	return nil
+}
+
+// cleanup removes all segments, other than the active one, where every record
+// has been delivered.
+func (d *diskBuffer) cleanup() {
+	for len(d.segments) > 1 && d.acked.covers(d.segments[0].id, d.segments[0].size) {
+		seg := d.segments[0]
+		if d.readFile != nil && d.readSeg == seg.id {
+			d.closeReader()
+		}
+		if err := os.Remove(d.segmentPath(seg.id)); err != nil {
+			d.log.Errorf("Failed to remove delivered segment %v: %v", d.segmentPath(seg.id), err)
+		}
+		d.diskSize -= seg.size
+		d.segments = d.segments[1:]
+	}
+	d.cond.Broadcast()
+}
+
+func (d *diskBuffer) persistCheckpoint() error {
+	if d.acked == d.persisted {
+		return nil
+	}
+
+	cBytes, err := json.Marshal(d.acked)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(d.conf.dir, checkpointFile+".tmp")
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(cBytes); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if d.conf.syncPolicy != syncPolicyNone {
+		if err := f.Sync(); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(d.conf.dir, checkpointFile)); err != nil {
+		return err
+	}
+	d.persisted = d.acked
+	return nil
+}
+
+func (d *diskBuffer) sync() error {
+	if d.unsynced {
+		if err := d.writeFile.Sync(); err != nil {
+			return err
+		}
+		d.unsynced = false
+	}
+	return d.persistCheckpoint()
+}
+
+func (d *diskBuffer) loop() {
+	defer d.shutSig.TriggerHasStopped()
+
+	ticker := time.NewTicker(d.conf.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.shutSig.SoftStopChan():
+			return
+		}
+
+		d.cond.L.Lock()
+		if !d.closed {
+			if err := d.sync(); err != nil {
+				d.log.Errorf("Failed to sync buffer: %v", err)
+			}
+		}
+		d.cond.L.Unlock()
+	}
+}
+
+// broadcast wakes all waiters on the buffer. The lock is held so that the wake
+// up cannot be missed by a waiter that is between checking its exit conditions
+// and waiting.
+func (d *diskBuffer) broadcast() {
+	d.cond.L.Lock()
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+}
+
+//------------------------------------------------------------------------------
+
+func (d *diskBuffer) closeReader() {
+	if d.readFile != nil {
+		_ = d.readFile.Close()
+	}
+	d.readFile, d.readBuf = nil, nil
+}
+
+func (d *diskBuffer) readRecord(seg segment) ([]byte, error) {
+	if d.readFile == nil {
+		f, err := os.Open(d.segmentPath(seg.id))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(d.readOffset, io.SeekStart); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		d.readFile, d.readBuf = f, bufio.NewReader(f)
+	}
+
+	data, err := readRecord(d.readBuf, seg.size-d.readOffset)
+	if err != nil {
+		// The reader is no longer aligned with a record boundary.
+		d.closeReader()
+		return nil, err
+	}
+	d.readOffset += int64(recordHeaderLen + len(data))
+	return data, nil
+}
+
+// nextRecord returns the next record to be consumed, preferring those that
+// were rejected and need to be redelivered. Returns false if there are no
+// records available.
+func (d *diskBuffer) nextRecord() (pendingRecord, bool, error) {
+	if len(d.requeued) > 0 {
+		rec := d.requeued[0]
+		d.requeued = d.requeued[1:]
+		return rec, true, nil
+	}
+
+	for i, seg := range d.segments {
+		if seg.id < d.readSeg {
+			continue
+		}
+		if seg.id > d.readSeg {
+			// The segment we were reading has been removed.
+			d.closeReader()
+			d.readSeg, d.readOffset = seg.id, 0
+		}
+
+		active := i == len(d.segments)-1
+		if d.readOffset < seg.size {
+			data, err := d.readRecord(seg)
+			if err != nil {
+				if active {
+					return pendingRecord{}, false, err
+				}
+				d.log.Errorf("Skipping the remainder of segment %v: %v", d.segmentPath(seg.id), err)
+				d.readOffset = seg.size
+				continue
+			}
+			return pendingRecord{
+				data:    data,
+				resolve: d.tracker.Track(position{Segment: seg.id, Offset: d.readOffset}, 1),
+			}, true, nil
+		}
+		if active {
+			break
+		}
+
+		d.closeReader()
+		d.readSeg, d.readOffset = d.segments[i+1].id, 0
+	}
+	return pendingRecord{}, false, nil
+}
+
+func (d *diskBuffer) resolveRecord(rec pendingRecord, err error) {
+	if err != nil {
+		d.requeued = append(d.requeued, rec)
+	} else if p := rec.resolve(); p != nil {
+		d.acked = *p
+		d.cleanup()
+	}
+	d.cond.Broadcast()
+}
+
+func (d *diskBuffer) toAckableBatches(batches []service.MessageBatch, rec pendingRecord) []ackableBatch {
+	endAckFn := func(_ context.Context, err error) error {
+		d.cond.L.Lock()
+		defer d.cond.L.Unlock()
+		d.resolveRecord(rec, err)
+		return nil
+	}
+
+	if len(batches) == 1 {
+		return []ackableBatch{
+			{b: batches[0], aFn: endAckFn},
+		}
+	}
+
+	pendingResponses := int64(len(batches))
+	aBatches := make([]ackableBatch, len(batches))
+	var ackOnce sync.Once
+	for i := range batches {
+		aBatches[i] = ackableBatch{b: batches[i], aFn: func(ctx context.Context, err error) error {
+			if atomic.AddInt64(&pendingResponses, -1) == 0 || err != nil {
+				var ackErr error
+				ackOnce.Do(func() {
+					ackErr = endAckFn(ctx, err)
+				})
+				return ackErr
+			}
+			return nil
+		}}
+	}
+	return aBatches
+}
+
+func processBatches(ctx context.Context, procs []*service.OwnedProcessor, batches []service.MessageBatch) ([]service.MessageBatch, error) {
+	for _, proc := range procs {
+		var tmpResBatch []service.MessageBatch
+		for _, batch := range batches {
+			resBatches, err := proc.ProcessBatch(ctx, batch)
+			if err != nil {
+				return nil, err
+			}
+			tmpResBatch = append(tmpResBatch, resBatches...)
+		}
+		batches = tmpResBatch
+	}
+	return batches, nil
+}
+
+// ReadBatch attempts to read the next record from disk.
+func (d *diskBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		d.broadcast()
+	}()
+
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	for len(d.pending) == 0 {
+		if d.closed {
+			return nil, nil, service.ErrEndOfBuffer
+		}
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+
+		rec, ok, err := d.nextRecord()
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			batch, err := decodeBatch(rec.data)
+			if err != nil {
+				return nil, nil, err
+			}
+			resBatches, err := processBatches(ctx, d.conf.postProcs, []service.MessageBatch{batch})
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(resBatches) == 0 {
+				// Everything was filtered by the post processors.
+				d.resolveRecord(rec, nil)
+				continue
+			}
+			d.pending = d.toAckableBatches(resBatches, rec)
+			continue
+		}
+		if d.endOfInput && d.tracker.Pending() == 0 {
+			return nil, nil, service.ErrEndOfBuffer
+		}
+
+		// None of our exit conditions triggered, so wait for a change
+		d.cond.Wait()
+	}
+
+	tmp := d.pending[0]
+	d.pending = d.pending[1:]
+	return tmp.b, tmp.aFn, nil
+}
+
+// WriteBatch appends a batch to the active segment.
+func (d *diskBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
+	msgBatches, err := processBatches(ctx, d.conf.preProcs, []service.MessageBatch{msgBatch})
+	if err != nil {
+		return err
+	}
+
+	records := make([][]byte, 0, len(msgBatches))
+	for _, batch := range msgBatches {
+		rec, err := appendRecord(nil, batch)
+		if err != nil {
+			return err
+		}
+		if int64(len(rec)) > d.conf.maxDiskSize {
+			return fmt.Errorf("batch of size %v exceeds the %v", len(rec), dbFieldMaxDiskSize)
+		}
+		records = append(records, rec)
+	}
+
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		d.broadcast()
+	}()
+
+	if err := d.writeRecords(ctx, records); err != nil {
+		return err
+	}
+	return aFn(ctx, nil)
+}
+
+func (d *diskBuffer) writeRecords(ctx context.Context, records [][]byte) error {
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	for _, rec := range records {
+		recLen := int64(len(rec))
+		for {
+			if d.closed {
+				return service.ErrEndOfBuffer
+			}
+
+			// The active segment is never removed by cleanup, and so it must
+			// be rotated before waiting on disk space whenever it is full or
+			// already delivered, otherwise its space would never be reclaimed.
+			active := d.segments[len(d.segments)-1]
+			if active.size > 0 && (active.size+recLen > d.conf.segmentSize ||
+				(d.diskSize+recLen > d.conf.maxDiskSize && d.acked.covers(active.id, active.size))) {
+				if err := d.rotate(); err != nil {
+					return err
+				}
+			}
+
+			if d.diskSize+recLen <= d.conf.maxDiskSize {
+				break
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			d.cond.Wait()
+		}
+
+		active := &d.segments[len(d.segments)-1]
+		if _, err := d.writeFile.Write(rec); err != nil {
+			return err
+		}
+		active.size += recLen
+		d.diskSize += recLen
+	}
+
+	switch d.conf.syncPolicy {
+	case syncPolicyAlways:
+		if err := d.writeFile.Sync(); err != nil {
+			return err
+		}
+	case syncPolicyInterval:
+		d.unsynced = true
+	}
+
+	d.cond.Broadcast()
+	return nil
+}
+
+// EndOfInput signals to the buffer that the input is finished and therefore
+// once the segments are drained it should close.
+func (d *diskBuffer) EndOfInput() {
+	go func() {
+		d.cond.L.Lock()
+		defer d.cond.L.Unlock()
+
+		d.endOfInput = true
+		d.cond.Broadcast()
+	}()
+}
+
+// Close syncs and closes all segment files and persists the latest checkpoint.
+func (d *diskBuffer) Close(ctx context.Context) error {
+	d.cond.L.Lock()
+	if d.closed {
+		d.cond.L.Unlock()
+		return nil
+	}
+	d.closed = true
+
+	if d.conf.syncPolicy != syncPolicyNone {
+		d.unsynced = true
+	}
+	err := d.sync()
+	if cErr := d.writeFile.Close(); err == nil {
+		err = cErr
+	}
+	d.closeReader()
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+
+	d.shutSig.TriggerSoftStop()
+	select {
+	case <-d.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,368 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func newTestBuffer(t testing.TB, confStr string, args ...any) *diskBuffer {
+	t.Helper()
+
+	conf, err := diskBufferSpec().ParseYAML(fmt.Sprintf(confStr, args...), nil)
+	require.NoError(t, err)
+
+	b, err := newDiskBufferFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+	return b
+}
+
+func writeTestBatch(t testing.TB, b *diskBuffer, contents ...string) {
+	t.Helper()
+
+	var batch service.MessageBatch
+	for _, c := range contents {
+		msg := service.NewMessage([]byte(c))
+		msg.MetaSetMut("content", c)
+		batch = append(batch, msg)
+	}
+	require.NoError(t, b.WriteBatch(t.Context(), batch, func(context.Context, error) error { return nil }))
+}
+
+func readTestBatch(t testing.TB, b *diskBuffer) ([]string, service.AckFunc) {
+	t.Helper()
+
+	ctx, done := context.WithTimeout(t.Context(), time.Second*5)
+	defer done()
+
+	batch, aFn, err := b.ReadBatch(ctx)
+	require.NoError(t, err)
+
+	var contents []string
+	for _, msg := range batch {
+		mBytes, err := msg.AsBytes()
+		require.NoError(t, err)
+		meta, _ := msg.MetaGetMut("content")
+		assert.Equal(t, string(mBytes), meta)
+		contents = append(contents, string(mBytes))
+	}
+	return contents, aFn
+}
+
+func segmentFiles(t testing.TB, dir string) (names []string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), segmentSuffix) {
+			names = append(names, e.Name())
+		}
+	}
+	return
+}
+
+func TestDiskBufferWriteRead(t *testing.T) {
+	b := newTestBuffer(t, `directory: %v`, t.TempDir())
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	writeTestBatch(t, b, "foo", "bar")
+	writeTestBatch(t, b, "baz")
+
+	contents, aFn := readTestBatch(t, b)
+	assert.Equal(t, []string{"foo", "bar"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"baz"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	ctx, done := context.WithTimeout(t.Context(), time.Millisecond*50)
+	defer done()
+	_, _, err := b.ReadBatch(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	b.EndOfInput()
+	_, _, err = b.ReadBatch(t.Context())
+	require.ErrorIs(t, err, service.ErrEndOfBuffer)
+}
+
+func TestDiskBufferNackRequeue(t *testing.T) {
+	b := newTestBuffer(t, `directory: %v`, t.TempDir())
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	writeTestBatch(t, b, "foo")
+	writeTestBatch(t, b, "bar")
+
+	contents, aFn := readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	require.NoError(t, aFn(t.Context(), errors.New("nope")))
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"bar"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+}
+
+func TestDiskBufferResume(t *testing.T) {
+	dir := t.TempDir()
+
+	b := newTestBuffer(t, `directory: %v`, dir)
+	writeTestBatch(t, b, "foo")
+	writeTestBatch(t, b, "bar")
+	writeTestBatch(t, b, "baz")
+
+	contents, aFn := readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	// Read but never acknowledged, and therefore redelivered.
+	contents, _ = readTestBatch(t, b)
+	assert.Equal(t, []string{"bar"}, contents)
+	require.NoError(t, b.Close(t.Context()))
+
+	b = newTestBuffer(t, `directory: %v`, dir)
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"bar"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	writeTestBatch(t, b, "buz")
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"baz"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"buz"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	// Only the active segment remains once everything is delivered.
+	assert.Len(t, segmentFiles(t, dir), 1)
+}
+
+func TestDiskBufferSegmentRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	b := newTestBuffer(t, `
+directory: %v
+segment_size: 100B
+max_disk_size: 10KB
+sync_policy: always
+`, dir)
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	for i := range 10 {
+		writeTestBatch(t, b, fmt.Sprintf("message-%v", strings.Repeat("x", 20+i)))
+	}
+	assert.Greater(t, len(segmentFiles(t, dir)), 2)
+
+	var aFns []service.AckFunc
+	for i := range 10 {
+		contents, aFn := readTestBatch(t, b)
+		assert.Equal(t, []string{fmt.Sprintf("message-%v", strings.Repeat("x", 20+i))}, contents)
+		aFns = append(aFns, aFn)
+	}
+
+	// Acknowledging out of order only removes segments once all prior records
+	// are delivered.
+	for i := len(aFns) - 1; i >= 0; i-- {
+		require.NoError(t, aFns[i](t.Context(), nil))
+	}
+	assert.Len(t, segmentFiles(t, dir), 1)
+}
+
+func TestDiskBufferBackPressure(t *testing.T) {
+	dir := t.TempDir()
+
+	b := newTestBuffer(t, `
+directory: %v
+segment_size: 100B
+max_disk_size: 250B
+`, dir)
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	payload := strings.Repeat("x", 40)
+	writeTestBatch(t, b, payload)
+	writeTestBatch(t, b, payload)
+
+	ctx, done := context.WithTimeout(t.Context(), time.Millisecond*50)
+	defer done()
+	err := b.WriteBatch(ctx, service.MessageBatch{service.NewMessage([]byte(payload))}, func(context.Context, error) error {
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- b.WriteBatch(t.Context(), service.MessageBatch{service.NewMessage([]byte(payload))}, func(context.Context, error) error {
+			return nil
+		})
+	}()
+
+	for range 2 {
+		_, aFn := readTestBatch(t, b)
+		require.NoError(t, aFn(t.Context(), nil))
+	}
+
+	select {
+	case err := <-writeErr:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for blocked write")
+	}
+}
+
+func TestDiskBufferSegmentSizeValidation(t *testing.T) {
+	conf, err := diskBufferSpec().ParseYAML(fmt.Sprintf(`
+directory: %v
+segment_size: 101B
+max_disk_size: 200B
+`, t.TempDir()), nil)
+	require.NoError(t, err)
+
+	_, err = newDiskBufferFromParsed(conf, service.MockResources())
+	require.ErrorContains(t, err, "must not be larger than half of max_disk_size")
+}
+
+func TestDiskBufferFullActiveSegment(t *testing.T) {
+	dir := t.TempDir()
+
+	payload := strings.Repeat("x", 40)
+	msg := service.NewMessage([]byte(payload))
+	msg.MetaSetMut("content", payload)
+	rec, err := appendRecord(nil, service.MessageBatch{msg})
+	require.NoError(t, err)
+
+	// A segment the size of the whole disk can't be configured, but would
+	// previously block writes forever once the active segment was full.
+	b, err := newDiskBuffer(diskBufferConfig{
+		dir:          dir,
+		maxDiskSize:  int64(len(rec)) * 2,
+		segmentSize:  int64(len(rec)) * 2,
+		syncPolicy:   syncPolicyNone,
+		syncInterval: time.Second,
+	}, service.MockResources().Logger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	writeTestBatch(t, b, payload)
+	writeTestBatch(t, b, payload)
+
+	for range 2 {
+		contents, aFn := readTestBatch(t, b)
+		assert.Equal(t, []string{payload}, contents)
+		require.NoError(t, aFn(t.Context(), nil))
+	}
+
+	ctx, done := context.WithTimeout(t.Context(), time.Second*5)
+	defer done()
+	for range 2 {
+		require.NoError(t, b.WriteBatch(ctx, service.MessageBatch{msg.Copy()}, func(context.Context, error) error {
+			return nil
+		}))
+	}
+	assert.Len(t, segmentFiles(t, dir), 1)
+
+	contents, _ := readTestBatch(t, b)
+	assert.Equal(t, []string{payload}, contents)
+}
+
+func TestDiskBufferCorruptSegment(t *testing.T) {
+	dir := t.TempDir()
+
+	b := newTestBuffer(t, `directory: %v`, dir)
+	writeTestBatch(t, b, "foo")
+	writeTestBatch(t, b, "bar")
+	require.NoError(t, b.Close(t.Context()))
+
+	// Simulate a torn write by truncating the tail of the segment.
+	segs := segmentFiles(t, dir)
+	require.Len(t, segs, 1)
+	path := filepath.Join(dir, segs[0])
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-2))
+
+	b = newTestBuffer(t, `directory: %v`, dir)
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+	writeTestBatch(t, b, "baz")
+
+	contents, aFn := readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+
+	contents, aFn = readTestBatch(t, b)
+	assert.Equal(t, []string{"baz"}, contents)
+	require.NoError(t, aFn(t.Context(), nil))
+}
+
+func TestDiskBufferPostProcessors(t *testing.T) {
+	b := newTestBuffer(t, `
+directory: %v
+post_processors:
+  - split: {}
+`, t.TempDir())
+	t.Cleanup(func() {
+		require.NoError(t, b.Close(context.Background()))
+	})
+
+	writeTestBatch(t, b, "foo", "bar")
+
+	contents, aFnFoo := readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	contents, aFnBar := readTestBatch(t, b)
+	assert.Equal(t, []string{"bar"}, contents)
+
+	require.NoError(t, aFnFoo(t.Context(), nil))
+	require.NoError(t, aFnBar(t.Context(), errors.New("nope")))
+
+	// The whole batch is redelivered.
+	contents, _ = readTestBatch(t, b)
+	assert.Equal(t, []string{"foo"}, contents)
+	contents, _ = readTestBatch(t, b)
+	assert.Equal(t, []string{"bar"}, contents)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+// Each record is framed by the length of its payload followed by a CRC32
+// (Castagnoli) checksum of the payload.
+const recordHeaderLen = 8
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptRecord = errors.New("the record appears to be corrupt")
+)
+
+// appendRecord appends a framed record containing a batch to a buffer.
+func appendRecord(buffer []byte, batch service.MessageBatch) ([]byte, error) {
+	start := len(buffer)
+	buffer = append(buffer, make([]byte, recordHeaderLen)...)
+
+	// The payload starts with the number of messages in the batch.
+	buffer = binary.BigEndian.AppendUint32(buffer, uint32(len(batch)))
+	for _, msg := range batch {
+		metaObj := map[string]any{}
+		_ = msg.MetaWalkMut(func(key string, value any) error {
+			metaObj[key] = value
+			return nil
+		})
+
+		metaBytes, err := msgpack.Marshal(metaObj)
+		if err != nil {
+			return nil, err
+		}
+
+		msgBytes, err := msg.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		buffer = binary.BigEndian.AppendUint32(buffer, uint32(len(metaBytes)))
+		buffer = append(buffer, metaBytes...)
+		buffer = binary.BigEndian.AppendUint32(buffer, uint32(len(msgBytes)))
+		buffer = append(buffer, msgBytes...)
+	}
+
+	payload := buffer[start+recordHeaderLen:]
+	binary.BigEndian.PutUint32(buffer[start:], uint32(len(payload)))
+	binary.BigEndian.PutUint32(buffer[start+4:], crc32.Checksum(payload, crcTable))
+	return buffer, nil
+}
+
+// readRecord reads the payload of the next framed record from a reader, where
+// remaining is the number of bytes known to be written beyond the current
+// position.
+func readRecord(r io.Reader, remaining int64) ([]byte, error) {
+	if remaining < recordHeaderLen {
+		return nil, errCorruptRecord
+	}
+
+	var header [recordHeaderLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	payloadLen := binary.BigEndian.Uint32(header[:])
+	if int64(payloadLen) > remaining-recordHeaderLen {
+		return nil, errCorruptRecord
+	}
+
+	payload := make([]byte, payloadLen)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, errCorruptRecord
+	}
+	return payload, nil
+}
+
+func readUint32(b []byte) (uint32, []byte, error) {
+	if len(b) < 4 {
+		return 0, nil, errCorruptRecord
+	}
+	return binary.BigEndian.Uint32(b), b[4:], nil
+}
+
+func readBytes(b []byte) ([]byte, []byte, error) {
+	l, b, err := readUint32(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint32(len(b)) < l {
+		return nil, nil, errCorruptRecord
+	}
+	return b[:l], b[l:], nil
+}
+
+// decodeBatch decodes the payload of a record into a batch.
+func decodeBatch(b []byte) (service.MessageBatch, error) {
+	parts, b, err := readUint32(b)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make(service.MessageBatch, 0, parts)
+	for range parts {
+		var metaBytes, contentBytes []byte
+		if metaBytes, b, err = readBytes(b); err != nil {
+			return nil, err
+		}
+		if contentBytes, b, err = readBytes(b); err != nil {
+			return nil, err
+		}
+
+		msg := service.NewMessage(contentBytes)
+
+		metaObj := map[string]any{}
+		if err := msgpack.Unmarshal(metaBytes, &metaObj); err != nil {
+			return nil, err
+		}
+		for k, v := range metaObj {
+			msg.MetaSetMut(k, v)
+		}
+		batch = append(batch, msg)
+	}
+	return batch, nil
+}
@@ -0,0 +1,389 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/checkpoint"
+	"github.com/elastic/go-elasticsearch/v8"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	esiFieldQuery           = "query"
+	esiFieldSort            = "sort"
+	esiFieldBatchSize       = "batch_size"
+	esiFieldKeepAlive       = "keep_alive"
+	esiFieldCheckpointCache = "checkpoint_cache"
+	esiFieldCheckpointKey   = "checkpoint_key"
+)
+
+func elasticsearchInputConfigSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.73.0").
+		Categories("Services").
+		Summary(`Executes a query against an Elasticsearch index and creates a message for each document found.`).
+		Description(`
+Results are paged through using a https://www.elastic.co/docs/reference/elasticsearch/rest-apis/point-in-time-api[point in time^] and `+"`search_after`"+`, which gives a consistent view of the index for the duration of the export. Once all documents have been consumed the input shuts down, allowing the pipeline to gracefully terminate (or the next input in a xref:components:inputs/sequence.adoc[sequence] to execute).
+
+== Checkpointing
+
+When a `+"`"+esiFieldCheckpointCache+"`"+` is configured the sort values of the latest document to be delivered are stored in the cache once all prior documents have been acknowledged. When the input is restarted the export resumes after the stored sort values instead of starting from the beginning.
+
+Since a new point in time is opened on each restart, resuming is only reliable when the `+"`"+esiFieldSort+"`"+` fields uniquely and consistently identify each document, such as a timestamp followed by a unique ID field. The default `+"`_shard_doc`"+` sort is the most efficient for a single pass export but its values are only meaningful within the point in time that produced them.
+
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```text"+`
+- elasticsearch_index
+- elasticsearch_id
+- elasticsearch_sort
+`+"```"+`
+
+The `+"`elasticsearch_sort`"+` field contains the sort values of the document as a JSON array.`).
+		Fields(
+			service.NewStringListField(esFieldURLs).
+				Description("A list of URLs to connect to. If an item of the list contains commas it will be expanded into multiple URLs.").
+				Example([]string{"http://localhost:9200"}),
+			service.NewStringField(esFieldIndex).
+				Description("The index to read documents from. Multiple comma separated indexes, aliases and wildcard expressions are supported.").
+				Example("things").
+				Example("logs-*"),
+			service.NewBloblangField(esiFieldQuery).
+				Description("A xref:guides:bloblang/about.adoc[Bloblang mapping] that produces the https://www.elastic.co/docs/explore-analyze/query-filter/languages/querydsl[query DSL^] object used to select documents. The mapping is executed once without an input message.").
+				Default(`root.match_all = {}`).
+				Example(`root.range.timestamp.gte = "now-1d/d"`).
+				Example(`root.bool.filter = [{"term": {"status": "active"}}]`),
+			service.NewAnyListField(esiFieldSort).
+				Description("The sort to apply to the search, which determines the order of documents and the values used for `search_after` pagination.").
+				Default([]any{map[string]any{"_shard_doc": "asc"}}).
+				Example([]any{map[string]any{"timestamp": "asc"}, map[string]any{"id": "asc"}}).
+				Advanced(),
+			service.NewIntField(esiFieldBatchSize).
+				Description("The maximum number of documents to fetch in each search request, each page of documents is emitted as a batch.").
+				Default(1000).
+				LintRule(`root = if this < 1 { ["field must be greater than zero"] }`),
+			service.NewDurationField(esiFieldKeepAlive).
+				Description("The period of time for which the point in time is kept alive between each search request. If it expires a new point in time is opened, which continues after the last document read but also sees changes made since the first one was opened.").
+				Default("5m").
+				Advanced(),
+			service.NewStringField(esiFieldCheckpointCache).
+				Description("An optional xref:components:caches/about.adoc[cache resource] used to store the sort values of the latest acknowledged document, allowing interrupted exports to resume.").
+				Optional(),
+			service.NewStringField(esiFieldCheckpointKey).
+				Description("The key to store the checkpoint under within the `"+esiFieldCheckpointCache+"`.").
+				Default("elasticsearch_search_after").
+				Advanced(),
+			service.NewTLSToggledField(esFieldTLS),
+			service.NewObjectField(esFieldAuth,
+				service.NewBoolField(esFieldAuthEnabled).
+					Description("Whether to use basic authentication in requests.").
+					Default(false),
+				service.NewStringField(esFieldAuthUsername).
+					Description("A username to authenticate as.").
+					Default(""),
+				service.NewStringField(esFieldAuthPassword).
+					Description("A password to authenticate with.").
+					Default("").Secret(),
+			).Description("Allows you to specify basic authentication.").
+				Advanced().
+				Optional(),
+			service.NewAutoRetryNacksToggleField(),
+		).
+		Example("Re-indexing documents", "Here we copy all documents from one Elasticsearch index to another, keeping their IDs.", `
+input:
+  elasticsearch_v8:
+    urls: ['http://localhost:9200']
+    index: things
+    query: |
+      root.range.updated_at.gte = "now-7d/d"
+output:
+  elasticsearch_v8:
+    urls: ['http://localhost:9200']
+    index: things_v2
+    action: index
+    id: ${! @elasticsearch_id }
+`)
+}
+
+func init() {
+	service.MustRegisterBatchInput("elasticsearch_v8", elasticsearchInputConfigSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+			in, err := inputFromParsed(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacksBatchedToggled(conf, in)
+		})
+}
+
+type esInput struct {
+	log *service.Logger
+	mgr *service.Resources
+
+	clientOpts      elasticsearch.Config
+	index           string
+	query           any
+	sort            []any
+	batchSize       int
+	keepAlive       string
+	checkpointCache string
+	checkpointKey   string
+
+	checkpointer *checkpoint.Uncapped[json.RawMessage]
+
+	mut         sync.Mutex
+	client      *elasticsearch.TypedClient
+	pitID       string
+	searchAfter json.RawMessage
+	done        bool
+}
+
+func inputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*esInput, error) {
+	e := &esInput{
+		log:          mgr.Logger(),
+		mgr:          mgr,
+		checkpointer: checkpoint.NewUncapped[json.RawMessage](),
+	}
+	var err error
+	if e.clientOpts, err = esClientOptsFromParsed(conf); err != nil {
+		return nil, err
+	}
+	if e.index, err = conf.FieldString(esFieldIndex); err != nil {
+		return nil, err
+	}
+	queryMapping, err := conf.FieldBloblang(esiFieldQuery)
+	if err != nil {
+		return nil, err
+	}
+	if e.query, err = queryMapping.Query(nil); err != nil {
+		return nil, fmt.Errorf("executing %s mapping: %w", esiFieldQuery, err)
+	}
+	if e.sort, err = conf.FieldAnyList(esiFieldSort); err != nil {
+		return nil, err
+	}
+	if e.batchSize, err = conf.FieldInt(esiFieldBatchSize); err != nil {
+		return nil, err
+	}
+	keepAlive, err := conf.FieldDuration(esiFieldKeepAlive)
+	if err != nil {
+		return nil, err
+	}
+	e.keepAlive = strconv.FormatInt(keepAlive.Milliseconds(), 10) + "ms"
+	if conf.Contains(esiFieldCheckpointCache) {
+		if e.checkpointCache, err = conf.FieldString(esiFieldCheckpointCache); err != nil {
+			return nil, err
+		}
+		if !mgr.HasCache(e.checkpointCache) {
+			return nil, fmt.Errorf("cache resource %q was not found", e.checkpointCache)
+		}
+	}
+	if e.checkpointKey, err = conf.FieldString(esiFieldCheckpointKey); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *esInput) Connect(ctx context.Context) error {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client != nil {
+		return nil
+	}
+
+	client, err := elasticsearch.NewTypedClient(e.clientOpts)
+	if err != nil {
+		return err
+	}
+
+	// When reconnecting after the point in time was lost the search resumes
+	// after the last sort values that were read rather than the checkpoint.
+	if e.checkpointCache != "" && e.searchAfter == nil {
+		var cacheErr error
+		if err := e.mgr.AccessCache(ctx, e.checkpointCache, func(c service.Cache) {
+			var v []byte
+			if v, cacheErr = c.Get(ctx, e.checkpointKey); errors.Is(cacheErr, service.ErrKeyNotFound) {
+				cacheErr = nil
+			}
+			if len(v) > 0 {
+				e.searchAfter = v
+			}
+		}); err != nil {
+			return err
+		}
+		if cacheErr != nil {
+			return fmt.Errorf("reading checkpoint: %w", cacheErr)
+		}
+		if e.searchAfter != nil {
+			e.log.Infof("Resuming search after sort values %s", e.searchAfter)
+		}
+	}
+
+	pit, err := client.OpenPointInTime(e.index).KeepAlive(e.keepAlive).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("opening point in time: %w", err)
+	}
+
+	e.client = client
+	e.pitID = pit.Id
+	return nil
+}
+
+// searchHit is decoded manually rather than with the typed API so that the
+// sort values retain their exact representation, large integers such as
+// `_shard_doc` values lose precision when decoded as floats.
+type searchHit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	Sort   json.RawMessage `json:"sort"`
+}
+
+// errPointInTimeMissing is returned by a search when its point in time has
+// expired or no longer exists.
+var errPointInTimeMissing = errors.New("point in time is missing")
+
+type searchResponse struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+func (e *esInput) search(ctx context.Context) (*searchResponse, error) {
+	body := map[string]any{
+		"size":             e.batchSize,
+		"query":            e.query,
+		"sort":             e.sort,
+		"track_total_hits": false,
+		"pit": map[string]any{
+			"id":         e.pitID,
+			"keep_alive": e.keepAlive,
+		},
+	}
+	if e.searchAfter != nil {
+		body["search_after"] = e.searchAfter
+	}
+	reqBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding search request: %w", err)
+	}
+
+	res, err := e.client.Search().Raw(bytes.NewReader(reqBytes)).Perform(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sending search request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading search response: %w", err)
+	}
+	if res.StatusCode >= 300 {
+		if bytes.Contains(resBytes, []byte("search_context_missing_exception")) {
+			return nil, fmt.Errorf("%w: %s", errPointInTimeMissing, resBytes)
+		}
+		return nil, fmt.Errorf("search request failed with status %v: %s", res.StatusCode, resBytes)
+	}
+
+	var result searchResponse
+	if err := json.Unmarshal(resBytes, &result); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+	return &result, nil
+}
+
+func (e *esInput) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+	if e.done {
+		return nil, nil, service.ErrEndOfInput
+	}
+
+	res, err := e.search(ctx)
+	if errors.Is(err, errPointInTimeMissing) {
+		// The point in time expired, most likely because the keep alive
+		// lapsed between reads, and so a new one is opened by reconnecting.
+		e.log.Warnf("Reopening point in time: %v", err)
+		e.client = nil
+		e.pitID = ""
+		return nil, nil, service.ErrNotConnected
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.PitID != "" {
+		e.pitID = res.PitID
+	}
+	if len(res.Hits.Hits) == 0 {
+		e.done = true
+		return nil, nil, service.ErrEndOfInput
+	}
+
+	batch := make(service.MessageBatch, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		msg := service.NewMessage(hit.Source)
+		msg.MetaSetMut("elasticsearch_index", hit.Index)
+		msg.MetaSetMut("elasticsearch_id", hit.ID)
+		msg.MetaSetMut("elasticsearch_sort", string(hit.Sort))
+		batch = append(batch, msg)
+	}
+	e.searchAfter = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+
+	release := e.checkpointer.Track(e.searchAfter, int64(len(batch)))
+	return batch, func(ctx context.Context, _ error) error {
+		highest := release()
+		if highest == nil || e.checkpointCache == "" {
+			return nil
+		}
+		var setErr error
+		if err := e.mgr.AccessCache(ctx, e.checkpointCache, func(c service.Cache) {
+			setErr = c.Set(ctx, e.checkpointKey, *highest, nil)
+		}); err != nil {
+			return err
+		}
+		return setErr
+	}, nil
+}
+
+func (e *esInput) Close(ctx context.Context) error {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client == nil || e.pitID == "" {
+		return nil
+	}
+	closeCtx, done := context.WithTimeout(ctx, 10*time.Second)
+	defer done()
+	if _, err := e.client.ClosePointInTime().Id(e.pitID).Do(closeCtx); err != nil {
+		e.log.Warnf("Failed to close point in time: %v", err)
+	}
+	e.pitID = ""
+	return nil
+}
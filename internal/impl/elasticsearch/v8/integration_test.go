@@ -14,8 +14,10 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
@@ -182,4 +184,31 @@ elasticsearch_v8:
 		require.True(t, resp.Found)
 		require.Equal(t, string(upsertUpdateMsgBytes), string(resp.Source_))
 	})
+
+	t.Run("read", func(t *testing.T) {
+		_, err := client.Indices.Refresh().Index("things").Do(ctx)
+		require.NoError(t, err)
+
+		inBuilder := service.NewStreamBuilder()
+		require.NoError(t, inBuilder.AddInputYAML(fmt.Sprintf(`
+elasticsearch_v8:
+  urls: ['%s']
+  index: "things"
+  batch_size: 1
+`, url)))
+
+		var ids []string
+		require.NoError(t, inBuilder.AddConsumerFunc(func(_ context.Context, msg *service.Message) error {
+			id, _ := msg.MetaGet("elasticsearch_id")
+			ids = append(ids, id)
+			return nil
+		}))
+
+		inStream, err := inBuilder.Build()
+		require.NoError(t, err)
+		require.NoError(t, inStream.Run(ctx))
+
+		sort.Strings(ids)
+		require.Equal(t, []string{"2", "3"}, ids)
+	})
 }
@@ -58,11 +58,9 @@ type esConfig struct {
 	retryOnConflict int
 }
 
-func esConfigFromParsed(pConf *service.ParsedConfig) (*esConfig, error) {
-	conf := &esConfig{}
-
+func esClientOptsFromParsed(pConf *service.ParsedConfig) (opts elasticsearch.Config, err error) {
 	if os.Getenv("REDPANDA_CONNECT_ELASTICSEARCH_DEBUG") != "" {
-		conf.clientOpts.Logger = &elastictransport.CurlLogger{
+		opts.Logger = &elastictransport.CurlLogger{
 			Output:             os.Stdout,
 			EnableRequestBody:  true,
 			EnableResponseBody: true,
@@ -71,35 +69,45 @@ func esConfigFromParsed(pConf *service.ParsedConfig) (*esConfig, error) {
 
 	urlStrs, err := pConf.FieldStringList(esFieldURLs)
 	if err != nil {
-		return nil, err
+		return opts, err
 	}
 	for _, u := range urlStrs {
 		for urlStr := range strings.SplitSeq(u, ",") {
 			if urlStr != "" {
-				conf.clientOpts.Addresses = append(conf.clientOpts.Addresses, urlStr)
+				opts.Addresses = append(opts.Addresses, urlStr)
 			}
 		}
 	}
 
 	authConf := pConf.Namespace(esFieldAuth)
 	if enabled, _ := authConf.FieldBool(esFieldAuthEnabled); enabled {
-		if conf.clientOpts.Username, err = authConf.FieldString(esFieldAuthUsername); err != nil {
-			return nil, err
+		if opts.Username, err = authConf.FieldString(esFieldAuthUsername); err != nil {
+			return opts, err
 		}
-		if conf.clientOpts.Password, err = authConf.FieldString(esFieldAuthPassword); err != nil {
-			return nil, err
+		if opts.Password, err = authConf.FieldString(esFieldAuthPassword); err != nil {
+			return opts, err
 		}
 	}
 
 	tlsConf, tlsEnabled, err := pConf.FieldTLSToggled(esFieldTLS)
 	if err != nil {
-		return nil, err
+		return opts, err
 	}
 	if tlsEnabled {
-		conf.clientOpts.Transport = &http.Transport{
+		opts.Transport = &http.Transport{
 			TLSClientConfig: tlsConf,
 		}
 	}
+	return opts, nil
+}
+
+func esConfigFromParsed(pConf *service.ParsedConfig) (*esConfig, error) {
+	conf := &esConfig{}
+
+	var err error
+	if conf.clientOpts, err = esClientOptsFromParsed(pConf); err != nil {
+		return nil, err
+	}
 
 	if conf.action, err = pConf.FieldInterpolatedString(esFieldAction); err != nil {
 		return nil, err
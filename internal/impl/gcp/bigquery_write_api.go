@@ -0,0 +1,326 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"cloud.google.com/go/bigquery/storage/managedwriter/adapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+// bqwRowEncoder converts structured messages into protobuf rows of a table
+// schema for the Storage Write API.
+type bqwRowEncoder struct {
+	schema          bigquery.Schema
+	descriptor      protoreflect.MessageDescriptor
+	descriptorProto *descriptorpb.DescriptorProto
+}
+
+// Civil and numeric types are written as strings, which the Storage Write API
+// parses into the column type, as it saves us from their packed encodings.
+var bqwStringMappedTypes = []storagepb.TableFieldSchema_Type{
+	storagepb.TableFieldSchema_DATE,
+	storagepb.TableFieldSchema_DATETIME,
+	storagepb.TableFieldSchema_TIME,
+	storagepb.TableFieldSchema_NUMERIC,
+	storagepb.TableFieldSchema_BIGNUMERIC,
+}
+
+func newBQWRowEncoder(schema bigquery.Schema) (*bqwRowEncoder, error) {
+	tableSchema, err := adapt.BQSchemaToStorageTableSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert table schema: %w", err)
+	}
+
+	opts := make([]adapt.ProtoConversionOption, 0, len(bqwStringMappedTypes))
+	for _, t := range bqwStringMappedTypes {
+		opts = append(opts, adapt.WithProtoMapping(adapt.ProtoMapping{
+			FieldType: t,
+			Type:      descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		}))
+	}
+	desc, err := adapt.StorageSchemaToProtoDescriptorWithOptions(tableSchema, "root", opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert table schema to protobuf: %w", err)
+	}
+	msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unexpected protobuf descriptor type %T", desc)
+	}
+	descProto, err := adapt.NormalizeDescriptor(msgDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalise protobuf descriptor: %w", err)
+	}
+	return &bqwRowEncoder{
+		schema:          schema,
+		descriptor:      msgDesc,
+		descriptorProto: descProto,
+	}, nil
+}
+
+// unknownFields returns the top level fields of a row that are not columns of
+// the table, ignoring null values.
+func (e *bqwRowEncoder) unknownFields(row map[string]any) []string {
+	var unknown []string
+	for k, v := range row {
+		if v == nil {
+			continue
+		}
+		if bqwFieldByName(e.schema, k) == nil {
+			unknown = append(unknown, k)
+		}
+	}
+	return unknown
+}
+
+// encode converts a row into a protobuf message of the table schema.
+func (e *bqwRowEncoder) encode(row map[string]any) (*dynamicpb.Message, error) {
+	msg := dynamicpb.NewMessage(e.descriptor)
+	if err := bqwSetFields(msg, e.schema, row, ""); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func bqwFieldByName(schema bigquery.Schema, name string) *bigquery.FieldSchema {
+	for _, f := range schema {
+		// Column names are case insensitive.
+		if strings.EqualFold(f.Name, name) {
+			return f
+		}
+	}
+	return nil
+}
+
+func bqwSetFields(msg *dynamicpb.Message, schema bigquery.Schema, row map[string]any, path string) error {
+	for k, v := range row {
+		if v == nil {
+			continue
+		}
+		field := bqwFieldByName(schema, k)
+		if field == nil {
+			return fmt.Errorf("field %v%v is not a column of the table", path, k)
+		}
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(field.Name))
+		if fd == nil {
+			return fmt.Errorf("column %v%v cannot be written with the Storage Write API", path, field.Name)
+		}
+
+		if field.Repeated {
+			arr, ok := v.([]any)
+			if !ok {
+				return fmt.Errorf("field %v%v: expected array, got %T", path, k, v)
+			}
+			list := msg.Mutable(fd).List()
+			for i, elem := range arr {
+				if elem == nil {
+					return fmt.Errorf("field %v%v: array elements cannot be null", path, k)
+				}
+				pv, err := bqwFieldValue(msg, fd, field, elem, fmt.Sprintf("%v%v[%v].", path, k, i))
+				if err != nil {
+					return err
+				}
+				list.Append(pv)
+			}
+			continue
+		}
+
+		pv, err := bqwFieldValue(msg, fd, field, v, path+k+".")
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, pv)
+	}
+	return nil
+}
+
+func bqwFieldValue(msg *dynamicpb.Message, fd protoreflect.FieldDescriptor, field *bigquery.FieldSchema, v any, path string) (protoreflect.Value, error) {
+	if field.Type == bigquery.RecordFieldType {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("field %v: expected object, got %T", strings.TrimSuffix(path, "."), v)
+		}
+		var nested protoreflect.Message
+		if fd.IsList() {
+			nested = msg.Mutable(fd).List().NewElement().Message()
+		} else {
+			nested = msg.NewField(fd).Message()
+		}
+		nestedMsg, ok := nested.(*dynamicpb.Message)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("field %v: unexpected message type %T", strings.TrimSuffix(path, "."), nested)
+		}
+		if err := bqwSetFields(nestedMsg, field.Schema, obj, path); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfMessage(nestedMsg), nil
+	}
+
+	pv, err := bqwScalarValue(field.Type, v)
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("field %v: %w", strings.TrimSuffix(path, "."), err)
+	}
+	return pv, nil
+}
+
+func bqwScalarValue(t bigquery.FieldType, v any) (protoreflect.Value, error) {
+	switch t {
+	case bigquery.StringFieldType, bigquery.GeographyFieldType:
+		s, err := bqwString(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfString(s), nil
+	case bigquery.JSONFieldType:
+		if s, ok := v.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfString(string(b)), nil
+	case bigquery.BytesFieldType:
+		switch b := v.(type) {
+		case []byte:
+			return protoreflect.ValueOfBytes(b), nil
+		case string:
+			return protoreflect.ValueOfBytes([]byte(b)), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("expected bytes, got %T", v)
+	case bigquery.IntegerFieldType:
+		i, err := bloblang.ValueAsInt64(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt64(i), nil
+	case bigquery.FloatFieldType:
+		f, err := bloblang.ValueAsFloat64(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case bigquery.BooleanFieldType:
+		b, ok := v.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected boolean, got %T", v)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case bigquery.TimestampFieldType:
+		// Timestamps are written as microseconds since the unix epoch.
+		switch ts := v.(type) {
+		case time.Time:
+			return protoreflect.ValueOfInt64(ts.UnixMicro()), nil
+		case string:
+			parsed, err := time.Parse(time.RFC3339Nano, ts)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfInt64(parsed.UnixMicro()), nil
+		}
+		i, err := bloblang.ValueAsInt64(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt64(i), nil
+	case bigquery.DateFieldType, bigquery.DateTimeFieldType, bigquery.TimeFieldType:
+		if ts, ok := v.(time.Time); ok {
+			switch t {
+			case bigquery.DateFieldType:
+				return protoreflect.ValueOfString(ts.Format(time.DateOnly)), nil
+			case bigquery.TimeFieldType:
+				return protoreflect.ValueOfString(ts.Format("15:04:05.999999")), nil
+			default:
+				return protoreflect.ValueOfString(ts.Format("2006-01-02 15:04:05.999999")), nil
+			}
+		}
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected string, got %T", v)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case bigquery.NumericFieldType, bigquery.BigNumericFieldType:
+		s, err := bqwString(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfString(s), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("column type %v is not supported", t)
+}
+
+func bqwString(v any) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	case json.Number:
+		return s.String(), nil
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprintf("%d", s), nil
+	case float32:
+		return strconv.FormatFloat(float64(s), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(s), nil
+	}
+	return "", fmt.Errorf("expected string, got %T", v)
+}
+
+var errBQWCannotInferType = errors.New("cannot infer column type")
+
+// bqwInferField infers the schema of a new nullable column from a value.
+func bqwInferField(name string, v any) (*bigquery.FieldSchema, error) {
+	field := &bigquery.FieldSchema{Name: name}
+	switch t := v.(type) {
+	case string:
+		field.Type = bigquery.StringFieldType
+	case bool:
+		field.Type = bigquery.BooleanFieldType
+	case []byte:
+		field.Type = bigquery.BytesFieldType
+	case time.Time:
+		field.Type = bigquery.TimestampFieldType
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			field.Type = bigquery.IntegerFieldType
+		} else {
+			field.Type = bigquery.FloatFieldType
+		}
+	case int, int32, int64, uint, uint32, uint64:
+		field.Type = bigquery.IntegerFieldType
+	case float32, float64:
+		field.Type = bigquery.FloatFieldType
+	case map[string]any, []any:
+		field.Type = bigquery.JSONFieldType
+	default:
+		return nil, fmt.Errorf("field %v: %w from %T", name, errBQWCannotInferType, v)
+	}
+	return field, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func testBQWSchema() bigquery.Schema {
+	return bigquery.Schema{
+		{Name: "id", Type: bigquery.IntegerFieldType, Required: true},
+		{Name: "name", Type: bigquery.StringFieldType},
+		{Name: "score", Type: bigquery.FloatFieldType},
+		{Name: "created_at", Type: bigquery.TimestampFieldType},
+		{Name: "day", Type: bigquery.DateFieldType},
+		{Name: "amount", Type: bigquery.NumericFieldType},
+		{Name: "attrs", Type: bigquery.JSONFieldType},
+		{Name: "tags", Type: bigquery.StringFieldType, Repeated: true},
+		{Name: "owner", Type: bigquery.RecordFieldType, Schema: bigquery.Schema{
+			{Name: "email", Type: bigquery.StringFieldType},
+			{Name: "active", Type: bigquery.BooleanFieldType},
+		}},
+	}
+}
+
+func TestBigQueryWriteAPIEncode(t *testing.T) {
+	encoder, err := newBQWRowEncoder(testBQWSchema())
+	require.NoError(t, err)
+
+	msg, err := encoder.encode(map[string]any{
+		"id":         json.Number("7"),
+		"Name":       "foo",
+		"score":      1.5,
+		"created_at": "2025-01-02T03:04:05Z",
+		"day":        "2025-01-02",
+		"amount":     json.Number("12.34"),
+		"attrs":      map[string]any{"a": "b"},
+		"tags":       []any{"x", "y"},
+		"owner":      map[string]any{"email": "a@b.c", "active": true},
+		"missing":    nil,
+	})
+	require.NoError(t, err)
+
+	b, err := protojson.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "id": "7",
+  "name": "foo",
+  "score": 1.5,
+  "createdAt": "1735787045000000",
+  "day": "2025-01-02",
+  "amount": "12.34",
+  "attrs": "{\"a\":\"b\"}",
+  "tags": ["x", "y"],
+  "owner": {"email": "a@b.c", "active": true}
+}`, string(b))
+}
+
+func TestBigQueryWriteAPIEncodeErrors(t *testing.T) {
+	encoder, err := newBQWRowEncoder(testBQWSchema())
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name       string
+		row        map[string]any
+		errContain string
+	}{
+		{name: "unknown field", row: map[string]any{"nope": 1}, errContain: "nope is not a column"},
+		{name: "wrong type", row: map[string]any{"owner": map[string]any{"active": "yes"}}, errContain: "owner.active: expected boolean"},
+		{name: "not an array", row: map[string]any{"tags": "x"}, errContain: "expected array"},
+		{name: "bad timestamp", row: map[string]any{"created_at": "yesterday"}, errContain: "created_at"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encoder.encode(test.row)
+			require.ErrorContains(t, err, test.errContain)
+		})
+	}
+}
+
+func TestBigQueryWriteAPIUnknownFields(t *testing.T) {
+	encoder, err := newBQWRowEncoder(testBQWSchema())
+	require.NoError(t, err)
+
+	assert.Empty(t, encoder.unknownFields(map[string]any{"ID": 1, "nope": nil}))
+	assert.Equal(t, []string{"extra"}, encoder.unknownFields(map[string]any{"id": 1, "extra": "foo"}))
+}
+
+func TestBigQueryWriteAPIInferField(t *testing.T) {
+	for _, test := range []struct {
+		value    any
+		expected bigquery.FieldType
+	}{
+		{value: "foo", expected: bigquery.StringFieldType},
+		{value: true, expected: bigquery.BooleanFieldType},
+		{value: json.Number("5"), expected: bigquery.IntegerFieldType},
+		{value: json.Number("5.5"), expected: bigquery.FloatFieldType},
+		{value: int64(5), expected: bigquery.IntegerFieldType},
+		{value: 5.5, expected: bigquery.FloatFieldType},
+		{value: time.Now(), expected: bigquery.TimestampFieldType},
+		{value: map[string]any{"a": "b"}, expected: bigquery.JSONFieldType},
+		{value: []any{"a"}, expected: bigquery.JSONFieldType},
+	} {
+		field, err := bqwInferField("foo", test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, field.Type, test.value)
+		assert.False(t, field.Required)
+	}
+
+	_, err := bqwInferField("foo", struct{}{})
+	require.ErrorIs(t, err, errBQWCannotInferType)
+}
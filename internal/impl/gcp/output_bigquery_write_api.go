@@ -0,0 +1,478 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"cloud.google.com/go/bigquery/storage/managedwriter"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	bqwFieldProject         = "project"
+	bqwFieldDataset         = "dataset"
+	bqwFieldTable           = "table"
+	bqwFieldStreamType      = "stream_type"
+	bqwFieldSchemaEvolution = "schema_evolution"
+	bqwFieldCredentialsJSON = "credentials_json"
+	bqwFieldMaxInFlight     = "max_in_flight"
+	bqwFieldBatching        = "batching"
+
+	bqwStreamTypeCommitted = "committed"
+	bqwStreamTypePending   = "pending"
+)
+
+type bqwConfig struct {
+	ProjectID       string
+	DatasetID       string
+	TableID         string
+	StreamType      string
+	SchemaEvolution bool
+	CredentialsJSON string
+}
+
+func bqwConfigFromParsed(conf *service.ParsedConfig) (bconf bqwConfig, err error) {
+	if bconf.ProjectID, err = conf.FieldString(bqwFieldProject); err != nil {
+		return
+	}
+	if bconf.ProjectID == "" {
+		bconf.ProjectID = bigquery.DetectProjectID
+	}
+	if bconf.DatasetID, err = conf.FieldString(bqwFieldDataset); err != nil {
+		return
+	}
+	if bconf.TableID, err = conf.FieldString(bqwFieldTable); err != nil {
+		return
+	}
+	if bconf.StreamType, err = conf.FieldString(bqwFieldStreamType); err != nil {
+		return
+	}
+	if bconf.SchemaEvolution, err = conf.FieldBool(bqwFieldSchemaEvolution); err != nil {
+		return
+	}
+	if bconf.CredentialsJSON, err = conf.FieldString(bqwFieldCredentialsJSON); err != nil {
+		return
+	}
+	return
+}
+
+func gcpBigQueryWriteAPIConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("GCP", "Services").
+		Version("4.73.0").
+		Summary(`Sends messages as new rows to a Google Cloud BigQuery table using the Storage Write API.`).
+		Description(`
+Messages must be JSON objects, which are converted into protobuf rows from the schema of the table that is read when the output connects. Nested objects are written to `+"`RECORD`"+` columns and arrays to `+"`REPEATED`"+` columns. `+"`TIMESTAMP`"+` columns accept RFC 3339 strings or integers of microseconds since the unix epoch, and `+"`DATE`, `DATETIME`, `TIME`, `NUMERIC` and `BIGNUMERIC`"+` columns accept values in their canonical BigQuery string formats.
+
+== Credentials
+
+By default Redpanda Connect will use a shared credentials file when connecting to GCP services. You can find out more in xref:guides:cloud/gcp.adoc[].
+
+== Stream types
+
+With the `+"`committed`"+` stream type rows are written to a single stream and are visible as soon as they are written. Each batch is written at an explicit offset of the stream so that a batch that is retried after it was written is not written again. A new stream is created whenever a write fails, and therefore a batch may be duplicated when a write fails after it was persisted.
+
+With the `+"`pending`"+` stream type each batch is written to its own stream that is committed once all of its rows are written, and so the rows of a batch become visible atomically.
+
+== Schema evolution
+
+When `+"`schema_evolution`"+` is enabled, fields of messages that are not columns of the table are added to the table schema as `+"`NULLABLE`"+` columns, with a type inferred from their values. Objects and arrays are added as `+"`JSON`"+` columns. When disabled, messages with such fields are rejected.
+
+`+service.OutputPerformanceDocs(true, true)).
+		Fields(
+			service.NewStringField(bqwFieldProject).
+				Description("The project ID of the dataset to insert data to. If not set, it will be inferred from the credentials or read from the GOOGLE_CLOUD_PROJECT environment variable.").
+				Default(""),
+			service.NewStringField(bqwFieldDataset).
+				Description("The BigQuery Dataset ID."),
+			service.NewStringField(bqwFieldTable).
+				Description("The table to insert messages to."),
+			service.NewStringAnnotatedEnumField(bqwFieldStreamType, map[string]string{
+				bqwStreamTypeCommitted: "Rows are visible immediately and batches are written at tracked offsets of a single stream.",
+				bqwStreamTypePending:   "Each batch is written to a pending stream that is committed atomically once all of its rows are written.",
+			}).
+				Description("The type of write streams to use.").
+				Default(bqwStreamTypeCommitted),
+			service.NewBoolField(bqwFieldSchemaEvolution).
+				Description("Whether to add columns to the table for fields of messages that are not in its schema.").
+				Default(false),
+			service.NewStringField(bqwFieldCredentialsJSON).
+				Description("An optional field to set Google Service Account Credentials json.").
+				Secret().
+				Default(""),
+			service.NewIntField(bqwFieldMaxInFlight).
+				Description("The maximum number of message batches to have in flight at a given time. Increase this to improve throughput at the cost of the ordering of rows.").
+				Default(1),
+			service.NewBatchPolicyField(bqwFieldBatching),
+		).
+		Example("Stream rows", "Write JSON documents to a table as they arrive, adding columns for new fields.", `
+output:
+  gcp_bigquery_write_api:
+    project: foo
+    dataset: bar
+    table: baz
+    schema_evolution: true
+    batching:
+      count: 500
+      period: 1s
+`)
+}
+
+func init() {
+	service.MustRegisterBatchOutput(
+		"gcp_bigquery_write_api", gcpBigQueryWriteAPIConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (output service.BatchOutput, batchPol service.BatchPolicy, maxInFlight int, err error) {
+			if batchPol, err = conf.FieldBatchPolicy(bqwFieldBatching); err != nil {
+				return
+			}
+			if maxInFlight, err = conf.FieldInt(bqwFieldMaxInFlight); err != nil {
+				return
+			}
+			var bconf bqwConfig
+			if bconf, err = bqwConfigFromParsed(conf); err != nil {
+				return
+			}
+			output = newGCPBigQueryWriteAPIOutput(bconf, mgr.Logger())
+			return
+		})
+}
+
+type gcpBigQueryWriteAPIOutput struct {
+	conf bqwConfig
+	log  *service.Logger
+
+	connMut sync.RWMutex
+	client  *bigquery.Client
+	writer  *managedwriter.Client
+	encoder *bqwRowEncoder
+
+	// The committed stream and the offset of its next batch.
+	streamMut sync.Mutex
+	stream    *managedwriter.ManagedStream
+	offset    int64
+}
+
+func newGCPBigQueryWriteAPIOutput(conf bqwConfig, log *service.Logger) *gcpBigQueryWriteAPIOutput {
+	return &gcpBigQueryWriteAPIOutput{
+		conf: conf,
+		log:  log,
+	}
+}
+
+func (g *gcpBigQueryWriteAPIOutput) table() *bigquery.Table {
+	return g.client.DatasetInProject(g.conf.ProjectID, g.conf.DatasetID).Table(g.conf.TableID)
+}
+
+func (g *gcpBigQueryWriteAPIOutput) Connect(ctx context.Context) (err error) {
+	g.connMut.Lock()
+	defer g.connMut.Unlock()
+
+	var opt []option.ClientOption
+	if opt, err = getClientOptionWithCredential(g.conf.CredentialsJSON, opt); err != nil {
+		return
+	}
+
+	var client *bigquery.Client
+	if client, err = bigquery.NewClient(context.Background(), g.conf.ProjectID, opt...); err != nil {
+		return fmt.Errorf("error creating big query client: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			client.Close()
+		}
+	}()
+
+	md, err := client.DatasetInProject(g.conf.ProjectID, g.conf.DatasetID).Table(g.conf.TableID).Metadata(ctx)
+	if err != nil {
+		if hasStatusCode(err, http.StatusNotFound) {
+			return fmt.Errorf("table does not exist: %v", g.conf.TableID)
+		}
+		return fmt.Errorf("error reading table metadata: %w", err)
+	}
+
+	var encoder *bqwRowEncoder
+	if encoder, err = newBQWRowEncoder(md.Schema); err != nil {
+		return
+	}
+
+	var writer *managedwriter.Client
+	if writer, err = managedwriter.NewClient(context.Background(), client.Project(), opt...); err != nil {
+		return fmt.Errorf("error creating storage write client: %w", err)
+	}
+
+	g.client, g.writer, g.encoder = client, writer, encoder
+	return nil
+}
+
+func (g *gcpBigQueryWriteAPIOutput) newStream(ctx context.Context, streamType managedwriter.StreamType, encoder *bqwRowEncoder) (*managedwriter.ManagedStream, error) {
+	return g.writer.NewManagedStream(ctx,
+		managedwriter.WithDestinationTable(managedwriter.TableParentFromParts(g.client.Project(), g.conf.DatasetID, g.conf.TableID)),
+		managedwriter.WithType(streamType),
+		managedwriter.WithSchemaDescriptor(encoder.descriptorProto),
+		managedwriter.EnableWriteRetries(true),
+	)
+}
+
+// evolveSchema adds columns to the table for the unknown fields of rows, and
+// returns an encoder of the updated schema.
+func (g *gcpBigQueryWriteAPIOutput) evolveSchema(ctx context.Context, rows []map[string]any) (*bqwRowEncoder, error) {
+	g.connMut.Lock()
+	defer g.connMut.Unlock()
+	if g.client == nil {
+		return nil, service.ErrNotConnected
+	}
+
+	md, err := g.table().Metadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading table metadata: %w", err)
+	}
+
+	schema := slices.Clone(md.Schema)
+	for _, row := range rows {
+		for k, v := range row {
+			if v == nil || bqwFieldByName(schema, k) != nil {
+				continue
+			}
+			field, err := bqwInferField(k, v)
+			if err != nil {
+				return nil, err
+			}
+			schema = append(schema, field)
+		}
+	}
+
+	if len(schema) > len(md.Schema) {
+		g.log.Infof("Adding %v columns to table %v", len(schema)-len(md.Schema), g.conf.TableID)
+		if md, err = g.table().Update(ctx, bigquery.TableMetadataToUpdate{Schema: schema}, md.ETag); err != nil {
+			return nil, fmt.Errorf("error updating table schema: %w", err)
+		}
+	}
+
+	encoder, err := newBQWRowEncoder(md.Schema)
+	if err != nil {
+		return nil, err
+	}
+	g.encoder = encoder
+
+	// Streams only write the columns that existed when they were created.
+	g.streamMut.Lock()
+	g.resetStreamLocked(nil)
+	g.streamMut.Unlock()
+	return encoder, nil
+}
+
+func (g *gcpBigQueryWriteAPIOutput) resetStreamLocked(stream *managedwriter.ManagedStream) {
+	if g.stream == nil || (stream != nil && g.stream != stream) {
+		return
+	}
+	_ = g.stream.Close()
+	g.stream = nil
+	g.offset = 0
+}
+
+func (g *gcpBigQueryWriteAPIOutput) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	g.connMut.RLock()
+	encoder := g.encoder
+	g.connMut.RUnlock()
+	if encoder == nil {
+		return service.ErrNotConnected
+	}
+
+	var batchErr *service.BatchError
+	setErr := func(idx int, err error) {
+		if batchErr == nil {
+			batchErr = service.NewBatchError(batch, err)
+		}
+		batchErr = batchErr.Failed(idx, err)
+	}
+
+	parsed := make([]map[string]any, len(batch))
+	var evolve bool
+	for i, msg := range batch {
+		v, err := msg.AsStructured()
+		if err != nil {
+			setErr(i, err)
+			continue
+		}
+		row, ok := v.(map[string]any)
+		if !ok {
+			setErr(i, fmt.Errorf("expected message to be an object, got %T", v))
+			continue
+		}
+		if unknown := encoder.unknownFields(row); len(unknown) > 0 {
+			if !g.conf.SchemaEvolution {
+				setErr(i, fmt.Errorf("fields %v are not columns of the table", unknown))
+				continue
+			}
+			evolve = true
+		}
+		parsed[i] = row
+	}
+
+	if evolve {
+		var err error
+		if encoder, err = g.evolveSchema(ctx, parsed); err != nil {
+			return err
+		}
+	}
+
+	indexes := make([]int, 0, len(batch))
+	rows := make([][]byte, 0, len(batch))
+	for i, row := range parsed {
+		if row == nil {
+			continue
+		}
+		msg, err := encoder.encode(row)
+		if err != nil {
+			setErr(i, err)
+			continue
+		}
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			setErr(i, err)
+			continue
+		}
+		indexes = append(indexes, i)
+		rows = append(rows, b)
+	}
+
+	if len(rows) > 0 {
+		var err error
+		if g.conf.StreamType == bqwStreamTypePending {
+			err = g.writePending(ctx, encoder, rows)
+		} else {
+			err = g.writeCommitted(ctx, encoder, rows)
+		}
+		if err != nil {
+			if batchErr == nil {
+				return err
+			}
+			for _, i := range indexes {
+				setErr(i, err)
+			}
+		}
+	}
+
+	if batchErr != nil {
+		return batchErr
+	}
+	return nil
+}
+
+func (g *gcpBigQueryWriteAPIOutput) writeCommitted(ctx context.Context, encoder *bqwRowEncoder, rows [][]byte) error {
+	g.streamMut.Lock()
+	if g.stream == nil {
+		stream, err := g.newStream(ctx, managedwriter.CommittedStream, encoder)
+		if err != nil {
+			g.streamMut.Unlock()
+			return fmt.Errorf("error creating write stream: %w", err)
+		}
+		g.stream, g.offset = stream, 0
+	}
+	stream, offset := g.stream, g.offset
+
+	// Appends are ordered by the stream, and so offsets must be allocated in
+	// the same order as they are sent.
+	res, err := stream.AppendRows(ctx, rows, managedwriter.WithOffset(offset))
+	if err != nil {
+		g.resetStreamLocked(stream)
+		g.streamMut.Unlock()
+		return fmt.Errorf("error appending rows: %w", err)
+	}
+	g.offset += int64(len(rows))
+	g.streamMut.Unlock()
+
+	if _, err := res.GetResult(ctx); err != nil {
+		// The rows of a batch retried at its original offset have already
+		// been written.
+		if status.Code(err) == codes.AlreadyExists {
+			return nil
+		}
+		g.streamMut.Lock()
+		g.resetStreamLocked(stream)
+		g.streamMut.Unlock()
+		return fmt.Errorf("error appending rows: %w", err)
+	}
+	return nil
+}
+
+func (g *gcpBigQueryWriteAPIOutput) writePending(ctx context.Context, encoder *bqwRowEncoder, rows [][]byte) error {
+	stream, err := g.newStream(ctx, managedwriter.PendingStream, encoder)
+	if err != nil {
+		return fmt.Errorf("error creating write stream: %w", err)
+	}
+	defer stream.Close()
+
+	res, err := stream.AppendRows(ctx, rows, managedwriter.WithOffset(0))
+	if err != nil {
+		return fmt.Errorf("error appending rows: %w", err)
+	}
+	if _, err := res.GetResult(ctx); err != nil {
+		return fmt.Errorf("error appending rows: %w", err)
+	}
+	if _, err := stream.Finalize(ctx); err != nil {
+		return fmt.Errorf("error finalizing write stream: %w", err)
+	}
+
+	resp, err := g.writer.BatchCommitWriteStreams(ctx, &storagepb.BatchCommitWriteStreamsRequest{
+		Parent:       managedwriter.TableParentFromStreamName(stream.StreamName()),
+		WriteStreams: []string{stream.StreamName()},
+	})
+	if err != nil {
+		return fmt.Errorf("error committing write stream: %w", err)
+	}
+	if streamErrs := resp.GetStreamErrors(); len(streamErrs) > 0 {
+		errs := make([]error, 0, len(streamErrs))
+		for _, e := range streamErrs {
+			errs = append(errs, errors.New(e.GetErrorMessage()))
+		}
+		return fmt.Errorf("error committing write stream: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (g *gcpBigQueryWriteAPIOutput) Close(context.Context) error {
+	g.connMut.Lock()
+	defer g.connMut.Unlock()
+
+	g.streamMut.Lock()
+	g.resetStreamLocked(nil)
+	g.streamMut.Unlock()
+
+	if g.writer != nil {
+		g.writer.Close()
+		g.writer = nil
+	}
+	if g.client != nil {
+		g.client.Close()
+		g.client = nil
+	}
+	g.encoder = nil
+	return nil
+}
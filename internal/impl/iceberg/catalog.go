@@ -0,0 +1,299 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	errTableNotFound      = errors.New("table does not exist")
+	errCommitConflict     = errors.New("table was modified concurrently")
+	errCommitStateUnknown = errors.New("commit state is unknown")
+)
+
+// catalogError is an error response from a REST catalog.
+type catalogError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *catalogError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("catalog responded with status %v: %v", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("catalog responded with status %v (%v): %v", e.StatusCode, e.Type, e.Message)
+}
+
+type tableIdentifier struct {
+	Namespace []string `json:"namespace"`
+	Name      string   `json:"name"`
+}
+
+func (t tableIdentifier) String() string {
+	return strings.Join(t.Namespace, ".") + "." + t.Name
+}
+
+type loadTableResult struct {
+	MetadataLocation string            `json:"metadata-location"`
+	Metadata         *tableMetadata    `json:"metadata"`
+	Config           map[string]string `json:"config,omitempty"`
+}
+
+type createTableRequest struct {
+	Name          string            `json:"name"`
+	Location      string            `json:"location,omitempty"`
+	Schema        *schema           `json:"schema"`
+	PartitionSpec *partitionSpec    `json:"partition-spec,omitempty"`
+	Properties    map[string]string `json:"properties,omitempty"`
+}
+
+// Requirements and updates of a commit are kept as loosely typed objects as
+// some fields must be explicitly null.
+type (
+	tableRequirement map[string]any
+	tableUpdate      map[string]any
+)
+
+type commitTableRequest struct {
+	Identifier   tableIdentifier    `json:"identifier"`
+	Requirements []tableRequirement `json:"requirements"`
+	Updates      []tableUpdate      `json:"updates"`
+}
+
+// restCatalog is a client for the subset of the Iceberg REST catalog API that
+// is needed for creating tables and committing appends to them. The API is
+// specified at https://github.com/apache/iceberg/blob/main/open-api/rest-catalog-open-api.yaml.
+type restCatalog struct {
+	baseURL   string
+	warehouse string
+	prefix    string
+	token     string
+	headers   map[string]string
+	client    *http.Client
+}
+
+func newRESTCatalog(baseURL, warehouse, token string, headers map[string]string, client *http.Client) *restCatalog {
+	return &restCatalog{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		warehouse: warehouse,
+		token:     token,
+		headers:   headers,
+		client:    client,
+	}
+}
+
+func (c *restCatalog) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Iceberg-Access-Delegation", "vended-credentials")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		cErr := &catalogError{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(resBytes))}
+		var errRes struct {
+			Error struct {
+				Message string `json:"message"`
+				Type    string `json:"type"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(resBytes, &errRes) == nil && errRes.Error.Message != "" {
+			cErr.Message = errRes.Error.Message
+			cErr.Type = errRes.Error.Type
+		}
+		return cErr
+	}
+
+	if out == nil || len(resBytes) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resBytes, out)
+}
+
+// loadConfig obtains the catalog configuration, which may include a prefix
+// that must be added to all subsequent requests.
+func (c *restCatalog) loadConfig(ctx context.Context) error {
+	query := url.Values{}
+	if c.warehouse != "" {
+		query.Set("warehouse", c.warehouse)
+	}
+
+	var res struct {
+		Defaults  map[string]string `json:"defaults"`
+		Overrides map[string]string `json:"overrides"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/config", query, nil, &res); err != nil {
+		return fmt.Errorf("loading catalog config: %w", err)
+	}
+
+	c.prefix = res.Defaults["prefix"]
+	if p, exists := res.Overrides["prefix"]; exists {
+		c.prefix = p
+	}
+	return nil
+}
+
+func (c *restCatalog) namespacePath(namespace []string) string {
+	path := "/v1/"
+	if c.prefix != "" {
+		path += url.PathEscape(c.prefix) + "/"
+	}
+	// Multiple level namespaces are separated by the unit separator character.
+	return path + "namespaces/" + url.PathEscape(strings.Join(namespace, "\x1F"))
+}
+
+func (c *restCatalog) tablePath(ident tableIdentifier) string {
+	return c.namespacePath(ident.Namespace) + "/tables/" + url.PathEscape(ident.Name)
+}
+
+func (c *restCatalog) loadTable(ctx context.Context, ident tableIdentifier) (*loadTableResult, error) {
+	var res loadTableResult
+	if err := c.do(ctx, http.MethodGet, c.tablePath(ident), nil, nil, &res); err != nil {
+		var cErr *catalogError
+		if errors.As(err, &cErr) && cErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %v", errTableNotFound, cErr)
+		}
+		return nil, fmt.Errorf("loading table %v: %w", ident, err)
+	}
+	if res.Metadata == nil {
+		return nil, fmt.Errorf("loading table %v: response is missing table metadata", ident)
+	}
+	return &res, nil
+}
+
+func (c *restCatalog) createTable(ctx context.Context, ident tableIdentifier, req createTableRequest) (*loadTableResult, error) {
+	req.Name = ident.Name
+
+	var res loadTableResult
+	if err := c.do(ctx, http.MethodPost, c.namespacePath(ident.Namespace)+"/tables", nil, req, &res); err != nil {
+		return nil, fmt.Errorf("creating table %v: %w", ident, err)
+	}
+	if res.Metadata == nil {
+		return nil, fmt.Errorf("creating table %v: response is missing table metadata", ident)
+	}
+	return &res, nil
+}
+
+// commitTable atomically applies a series of updates to a table as long as all
+// requirements are met. A conflict with another writer results in an error
+// wrapping errCommitConflict, and an error wrapping errCommitStateUnknown is
+// returned when it isn't possible to know whether the commit was applied.
+func (c *restCatalog) commitTable(ctx context.Context, ident tableIdentifier, requirements []tableRequirement, updates []tableUpdate) (*loadTableResult, error) {
+	var res loadTableResult
+	err := c.do(ctx, http.MethodPost, c.tablePath(ident), nil, commitTableRequest{
+		Identifier:   ident,
+		Requirements: requirements,
+		Updates:      updates,
+	}, &res)
+	if err != nil {
+		var cErr *catalogError
+		if !errors.As(err, &cErr) {
+			return nil, fmt.Errorf("%w: %v", errCommitStateUnknown, err)
+		}
+		switch cErr.StatusCode {
+		case http.StatusConflict:
+			return nil, fmt.Errorf("%w: %v", errCommitConflict, cErr)
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return nil, fmt.Errorf("%w: %v", errCommitStateUnknown, cErr)
+		}
+		return nil, fmt.Errorf("committing to table %v: %w", ident, err)
+	}
+	if res.Metadata == nil {
+		return nil, fmt.Errorf("committing to table %v: response is missing table metadata", ident)
+	}
+	return &res, nil
+}
+
+//------------------------------------------------------------------------------
+
+func assertTableUUID(uuid string) tableRequirement {
+	return tableRequirement{"type": "assert-table-uuid", "uuid": uuid}
+}
+
+// assertRefSnapshotID requires a ref to point to a given snapshot, or to not
+// exist when the snapshot ID is nil.
+func assertRefSnapshotID(ref string, snapshotID *int64) tableRequirement {
+	return tableRequirement{"type": "assert-ref-snapshot-id", "ref": ref, "snapshot-id": snapshotID}
+}
+
+func assertCurrentSchemaID(id int) tableRequirement {
+	return tableRequirement{"type": "assert-current-schema-id", "current-schema-id": id}
+}
+
+func assertLastAssignedFieldID(id int) tableRequirement {
+	return tableRequirement{"type": "assert-last-assigned-field-id", "last-assigned-field-id": id}
+}
+
+func addSchemaUpdate(s *schema, lastColumnID int) tableUpdate {
+	return tableUpdate{"action": "add-schema", "schema": s, "last-column-id": lastColumnID}
+}
+
+// setCurrentSchemaUpdate sets the current schema, where an ID of -1 refers to
+// the last schema added within the same commit.
+func setCurrentSchemaUpdate(id int) tableUpdate {
+	return tableUpdate{"action": "set-current-schema", "schema-id": id}
+}
+
+func addSnapshotUpdate(s *snapshot) tableUpdate {
+	return tableUpdate{"action": "add-snapshot", "snapshot": s}
+}
+
+func setBranchUpdate(branch string, snapshotID int64) tableUpdate {
+	return tableUpdate{"action": "set-snapshot-ref", "ref-name": branch, "type": "branch", "snapshot-id": snapshotID}
+}
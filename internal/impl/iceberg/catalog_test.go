@@ -0,0 +1,331 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// testCatalog is an in-process stand-in for a REST catalog that keeps table
+// metadata in memory and uses a local directory as its warehouse.
+type testCatalog struct {
+	warehouse string
+	server    *httptest.Server
+
+	mut    sync.Mutex
+	tables map[string]*tableMetadata
+
+	// Status codes to respond to subsequent commits with. A conflict is
+	// rejected, whereas any other status is applied before responding, as if
+	// the response was lost.
+	commitFailures []int
+	commits        int
+}
+
+func newTestCatalog(t testing.TB) *testCatalog {
+	t.Helper()
+
+	c := &testCatalog{
+		warehouse: t.TempDir(),
+		tables:    map[string]*tableMetadata{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/config", func(w http.ResponseWriter, _ *http.Request) {
+		c.respond(w, http.StatusOK, map[string]any{"defaults": map[string]string{}, "overrides": map[string]string{}})
+	})
+	mux.HandleFunc("GET /v1/namespaces/{ns}/tables/{table}", c.handleLoad)
+	mux.HandleFunc("POST /v1/namespaces/{ns}/tables", c.handleCreate)
+	mux.HandleFunc("POST /v1/namespaces/{ns}/tables/{table}", c.handleCommit)
+
+	c.server = httptest.NewServer(mux)
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+func (*testCatalog) respond(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (c *testCatalog) respondErr(w http.ResponseWriter, status int, errType, msg string) {
+	c.respond(w, status, map[string]any{"error": map[string]any{"message": msg, "type": errType, "code": status}})
+}
+
+func (c *testCatalog) table(ns, name string) *tableMetadata {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.tables[ns+"\x1F"+name]
+}
+
+// writeMetadata requires the lock to be held.
+func (c *testCatalog) writeMetadata(m *tableMetadata) (string, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(m.Location, "metadata", fmt.Sprintf("%05d-%v.metadata.json", m.LastSequenceNumber, uuid.Must(uuid.NewV4())))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, b, 0o644)
+}
+
+func (c *testCatalog) handleLoad(w http.ResponseWriter, r *http.Request) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	m, exists := c.tables[r.PathValue("ns")+"\x1F"+r.PathValue("table")]
+	if !exists {
+		c.respondErr(w, http.StatusNotFound, "NoSuchTableException", "table does not exist")
+		return
+	}
+	c.respond(w, http.StatusOK, loadTableResult{Metadata: m})
+}
+
+func maxFieldID(fields []*schemaField) (id int) {
+	for _, f := range fields {
+		id = max(id, f.ID)
+		switch {
+		case f.Type.Struct != nil:
+			id = max(id, maxFieldID(f.Type.Struct.Fields))
+		case f.Type.List != nil:
+			id = max(id, f.Type.List.ElementID)
+			if f.Type.List.Element.Struct != nil {
+				id = max(id, maxFieldID(f.Type.List.Element.Struct.Fields))
+			}
+		}
+	}
+	return
+}
+
+func (c *testCatalog) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createTableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondErr(w, http.StatusBadRequest, "BadRequestException", err.Error())
+		return
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	ns := r.PathValue("ns")
+	key := ns + "\x1F" + req.Name
+	if _, exists := c.tables[key]; exists {
+		c.respondErr(w, http.StatusConflict, "AlreadyExistsException", "table already exists")
+		return
+	}
+
+	req.Schema.ID = 0
+	spec := req.PartitionSpec
+	if spec == nil {
+		spec = &partitionSpec{Fields: []partitionField{}}
+	}
+	lastPartitionID := firstPartitionFieldID - 1
+	for _, f := range spec.Fields {
+		lastPartitionID = max(lastPartitionID, f.FieldID)
+	}
+
+	m := &tableMetadata{
+		FormatVersion:  2,
+		TableUUID:      uuid.Must(uuid.NewV4()).String(),
+		Location:       filepath.Join(c.warehouse, strings.ReplaceAll(ns, "\x1F", "/"), req.Name),
+		LastColumnID:   maxFieldID(req.Schema.Fields),
+		Schemas:        []*schema{req.Schema},
+		PartitionSpecs: []*partitionSpec{spec},
+		Properties:     req.Properties,
+		Refs:           map[string]snapshotRef{},
+		SortOrders:     []sortOrder{{Fields: []json.RawMessage{}}},
+
+		LastPartitionID: lastPartitionID,
+	}
+	metadataLocation, err := c.writeMetadata(m)
+	if err != nil {
+		c.respondErr(w, http.StatusInternalServerError, "ServerError", err.Error())
+		return
+	}
+	c.tables[key] = m
+	c.respond(w, http.StatusOK, loadTableResult{MetadataLocation: metadataLocation, Metadata: m})
+}
+
+func (c *testCatalog) checkRequirement(m *tableMetadata, req tableRequirement) error {
+	switch req["type"] {
+	case "assert-table-uuid":
+		if req["uuid"] != m.TableUUID {
+			return fmt.Errorf("table uuid mismatch")
+		}
+	case "assert-ref-snapshot-id":
+		ref, exists := m.Refs[req["ref"].(string)]
+		if id, isNum := req["snapshot-id"].(float64); isNum {
+			if !exists || ref.SnapshotID != int64(id) {
+				return fmt.Errorf("ref %v has changed", req["ref"])
+			}
+		} else if exists {
+			return fmt.Errorf("ref %v was created", req["ref"])
+		}
+	case "assert-current-schema-id":
+		if int(req["current-schema-id"].(float64)) != m.CurrentSchemaID {
+			return fmt.Errorf("current schema has changed")
+		}
+	case "assert-last-assigned-field-id":
+		if int(req["last-assigned-field-id"].(float64)) != m.LastColumnID {
+			return fmt.Errorf("last assigned field id has changed")
+		}
+	default:
+		return fmt.Errorf("unsupported requirement: %v", req["type"])
+	}
+	return nil
+}
+
+func (*testCatalog) applyUpdate(m *tableMetadata, update json.RawMessage, lastAddedSchemaID *int) error {
+	var action struct {
+		Action       string    `json:"action"`
+		Schema       *schema   `json:"schema"`
+		LastColumnID int       `json:"last-column-id"`
+		SchemaID     int       `json:"schema-id"`
+		Snapshot     *snapshot `json:"snapshot"`
+		RefName      string    `json:"ref-name"`
+		Type         string    `json:"type"`
+		SnapshotID   int64     `json:"snapshot-id"`
+	}
+	if err := json.Unmarshal(update, &action); err != nil {
+		return err
+	}
+
+	switch action.Action {
+	case "add-schema":
+		for _, s := range m.Schemas {
+			action.Schema.ID = max(action.Schema.ID, s.ID+1)
+		}
+		m.Schemas = append(m.Schemas, action.Schema)
+		m.LastColumnID = action.LastColumnID
+		*lastAddedSchemaID = action.Schema.ID
+	case "set-current-schema":
+		if action.SchemaID == -1 {
+			action.SchemaID = *lastAddedSchemaID
+		}
+		m.CurrentSchemaID = action.SchemaID
+	case "add-snapshot":
+		if action.Snapshot.SequenceNumber != m.LastSequenceNumber+1 {
+			return fmt.Errorf("sequence number %v is not the next sequence number", action.Snapshot.SequenceNumber)
+		}
+		m.Snapshots = append(m.Snapshots, action.Snapshot)
+		m.LastSequenceNumber = action.Snapshot.SequenceNumber
+	case "set-snapshot-ref":
+		m.Refs[action.RefName] = snapshotRef{SnapshotID: action.SnapshotID, Type: action.Type}
+		if action.RefName == mainBranch {
+			m.CurrentSnapshotID = &action.SnapshotID
+		}
+	default:
+		return fmt.Errorf("unsupported update: %v", action.Action)
+	}
+	return nil
+}
+
+func (c *testCatalog) handleCommit(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Requirements []tableRequirement `json:"requirements"`
+		Updates      []json.RawMessage  `json:"updates"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondErr(w, http.StatusBadRequest, "BadRequestException", err.Error())
+		return
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.commits++
+
+	key := r.PathValue("ns") + "\x1F" + r.PathValue("table")
+	current, exists := c.tables[key]
+	if !exists {
+		c.respondErr(w, http.StatusNotFound, "NoSuchTableException", "table does not exist")
+		return
+	}
+
+	var failWith int
+	if len(c.commitFailures) > 0 {
+		failWith, c.commitFailures = c.commitFailures[0], c.commitFailures[1:]
+	}
+	if failWith == http.StatusConflict {
+		c.respondErr(w, failWith, "CommitFailedException", "injected conflict")
+		return
+	}
+
+	for _, requirement := range req.Requirements {
+		if err := c.checkRequirement(current, requirement); err != nil {
+			c.respondErr(w, http.StatusConflict, "CommitFailedException", err.Error())
+			return
+		}
+	}
+
+	// Apply updates to a copy so that failures leave the table unchanged.
+	b, _ := json.Marshal(current)
+	var next tableMetadata
+	_ = json.Unmarshal(b, &next)
+	if next.Refs == nil {
+		next.Refs = map[string]snapshotRef{}
+	}
+
+	var lastAddedSchemaID int
+	for _, update := range req.Updates {
+		if err := c.applyUpdate(&next, update, &lastAddedSchemaID); err != nil {
+			c.respondErr(w, http.StatusBadRequest, "BadRequestException", err.Error())
+			return
+		}
+	}
+
+	metadataLocation, err := c.writeMetadata(&next)
+	if err != nil {
+		c.respondErr(w, http.StatusInternalServerError, "ServerError", err.Error())
+		return
+	}
+	c.tables[key] = &next
+
+	if failWith != 0 {
+		c.respondErr(w, failWith, "ServiceUnavailableException", "injected failure")
+		return
+	}
+	c.respond(w, http.StatusOK, loadTableResult{MetadataLocation: metadataLocation, Metadata: &next})
+}
+
+func TestFieldTypeJSON(t *testing.T) {
+	input := `{"type":"struct","schema-id":1,"fields":[{"id":1,"name":"a","required":true,"type":"long"},{"id":2,"name":"b","required":false,"type":{"type":"list","element-id":3,"element":{"type":"struct","fields":[{"id":4,"name":"c","required":false,"type":"string"}]},"element-required":false}}]}`
+
+	var s schema
+	require.NoError(t, json.Unmarshal([]byte(input), &s))
+	require.Equal(t, 1, s.ID)
+	require.Len(t, s.Fields, 2)
+	require.Equal(t, "long", s.Fields[0].Type.Primitive)
+	require.NotNil(t, s.Fields[1].Type.List)
+	require.Equal(t, "c", s.Fields[1].Type.List.Element.Struct.Fields[0].Name)
+
+	output, err := json.Marshal(&s)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(output))
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// fileIO provides access to the files of a table, which are addressed by their
+// full location.
+type fileIO interface {
+	WriteFile(ctx context.Context, location string, data []byte) error
+	ReadFile(ctx context.Context, location string) ([]byte, error)
+}
+
+// newFileIO returns a fileIO suitable for a table location, configured with
+// properties provided by the catalog such as vended credentials.
+func newFileIO(ctx context.Context, location string, props map[string]string) (fileIO, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("parsing table location: %w", err)
+	}
+	switch u.Scheme {
+	case "", "file":
+		return localFileIO{}, nil
+	case "s3", "s3a", "s3n":
+		return newS3FileIO(ctx, props)
+	}
+	return nil, fmt.Errorf("table location scheme %q is not supported", u.Scheme)
+}
+
+//------------------------------------------------------------------------------
+
+type localFileIO struct{}
+
+func localPath(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" {
+		return location, nil
+	}
+	return u.Path, nil
+}
+
+func (localFileIO) WriteFile(_ context.Context, location string, data []byte) error {
+	path, err := localPath(location)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (localFileIO) ReadFile(_ context.Context, location string) ([]byte, error) {
+	path, err := localPath(location)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+//------------------------------------------------------------------------------
+
+type s3FileIO struct {
+	client *s3.Client
+}
+
+// newS3FileIO creates an S3 client from the standard Iceberg FileIO properties,
+// falling back to the default AWS credentials chain when the catalog doesn't
+// vend credentials.
+func newS3FileIO(ctx context.Context, props map[string]string) (*s3FileIO, error) {
+	var opts []func(*config.LoadOptions) error
+	if region := props["s3.region"]; region != "" {
+		opts = append(opts, config.WithRegion(region))
+	} else if region := props["client.region"]; region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	if keyID := props["s3.access-key-id"]; keyID != "" {
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			keyID, props["s3.secret-access-key"], props["s3.session-token"],
+		)))
+	}
+
+	awsConf, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsConf, func(o *s3.Options) {
+		if endpoint := props["s3.endpoint"]; endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = props["s3.path-style-access"] == "true"
+	})
+	return &s3FileIO{client: client}, nil
+}
+
+func s3BucketKey(location string) (bucket, key string, err error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", "", err
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+func (s *s3FileIO) WriteFile(ctx context.Context, location string, data []byte) error {
+	bucket, key, err := s3BucketKey(location)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (s *s3FileIO) ReadFile(ctx context.Context, location string) ([]byte, error) {
+	bucket, key, err := s3BucketKey(location)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+	return io.ReadAll(obj.Body)
+}
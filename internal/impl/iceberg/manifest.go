@@ -0,0 +1,301 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// Manifests and manifest lists are Avro files with schemas annotated with field
+// IDs, as described in https://iceberg.apache.org/spec/#manifests.
+
+const (
+	manifestEntryStatusAdded = 1
+	manifestContentData      = 0
+)
+
+const manifestListSchema = `{
+  "type": "record",
+  "name": "manifest_file",
+  "fields": [
+    {"name": "manifest_path", "type": "string", "field-id": 500},
+    {"name": "manifest_length", "type": "long", "field-id": 501},
+    {"name": "partition_spec_id", "type": "int", "field-id": 502},
+    {"name": "content", "type": "int", "field-id": 517},
+    {"name": "sequence_number", "type": "long", "field-id": 515},
+    {"name": "min_sequence_number", "type": "long", "field-id": 516},
+    {"name": "added_snapshot_id", "type": "long", "field-id": 503},
+    {"name": "added_files_count", "type": "int", "field-id": 504},
+    {"name": "existing_files_count", "type": "int", "field-id": 505},
+    {"name": "deleted_files_count", "type": "int", "field-id": 506},
+    {"name": "added_rows_count", "type": "long", "field-id": 512},
+    {"name": "existing_rows_count", "type": "long", "field-id": 513},
+    {"name": "deleted_rows_count", "type": "long", "field-id": 514},
+    {"name": "partitions", "type": ["null", {"type": "array", "items": {
+      "type": "record",
+      "name": "r508",
+      "fields": [
+        {"name": "contains_null", "type": "boolean", "field-id": 509},
+        {"name": "contains_nan", "type": ["null", "boolean"], "default": null, "field-id": 518},
+        {"name": "lower_bound", "type": ["null", "bytes"], "default": null, "field-id": 510},
+        {"name": "upper_bound", "type": ["null", "bytes"], "default": null, "field-id": 511}
+      ]
+    }, "element-id": 508}], "default": null, "field-id": 507},
+    {"name": "key_metadata", "type": ["null", "bytes"], "default": null, "field-id": 519}
+  ]
+}`
+
+type fieldSummary struct {
+	ContainsNull bool    `avro:"contains_null"`
+	ContainsNaN  *bool   `avro:"contains_nan"`
+	LowerBound   *[]byte `avro:"lower_bound"`
+	UpperBound   *[]byte `avro:"upper_bound"`
+}
+
+// manifestFile is an entry of a manifest list.
+type manifestFile struct {
+	Path               string          `avro:"manifest_path"`
+	Length             int64           `avro:"manifest_length"`
+	PartitionSpecID    int32           `avro:"partition_spec_id"`
+	Content            int32           `avro:"content"`
+	SequenceNumber     int64           `avro:"sequence_number"`
+	MinSequenceNumber  int64           `avro:"min_sequence_number"`
+	AddedSnapshotID    int64           `avro:"added_snapshot_id"`
+	AddedFilesCount    int32           `avro:"added_files_count"`
+	ExistingFilesCount int32           `avro:"existing_files_count"`
+	DeletedFilesCount  int32           `avro:"deleted_files_count"`
+	AddedRowsCount     int64           `avro:"added_rows_count"`
+	ExistingRowsCount  int64           `avro:"existing_rows_count"`
+	DeletedRowsCount   int64           `avro:"deleted_rows_count"`
+	Partitions         *[]fieldSummary `avro:"partitions"`
+	KeyMetadata        *[]byte         `avro:"key_metadata"`
+}
+
+// readManifestList decodes the entries of a manifest list.
+func readManifestList(r io.Reader) ([]manifestFile, error) {
+	dec, err := ocf.NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	if v := string(dec.Metadata()["format-version"]); v != "" && v != "2" {
+		return nil, fmt.Errorf("manifest list format version %v is not supported", v)
+	}
+
+	var files []manifestFile
+	for dec.HasNext() {
+		var f manifestFile
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, dec.Error()
+}
+
+// writeManifestList encodes a manifest list for a snapshot.
+func writeManifestList(w io.Writer, snap *snapshot, files []manifestFile) error {
+	parentID := "null"
+	if snap.ParentSnapshotID != nil {
+		parentID = strconv.FormatInt(*snap.ParentSnapshotID, 10)
+	}
+
+	enc, err := ocf.NewEncoder(manifestListSchema, w,
+		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
+		ocf.WithCodec(ocf.Deflate),
+		ocf.WithMetadata(map[string][]byte{
+			"snapshot-id":        []byte(strconv.FormatInt(snap.SnapshotID, 10)),
+			"parent-snapshot-id": []byte(parentID),
+			"sequence-number":    []byte(strconv.FormatInt(snap.SequenceNumber, 10)),
+			"format-version":     []byte("2"),
+		}))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := enc.Encode(f); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+//------------------------------------------------------------------------------
+
+// dataFile is a parquet file that has been written to the table location and
+// is pending a commit.
+type dataFile struct {
+	path        string
+	recordCount int64
+	sizeBytes   int64
+	partition   []any
+}
+
+func avroPrimitive(icebergType string) (string, error) {
+	switch icebergType {
+	case "boolean":
+		return "boolean", nil
+	case "int", "date":
+		return "int", nil
+	case "long", "timestamp", "timestamptz":
+		return "long", nil
+	case "float":
+		return "float", nil
+	case "double":
+		return "double", nil
+	case "string":
+		return "string", nil
+	case "binary":
+		return "bytes", nil
+	}
+	return "", fmt.Errorf("partition values of type %v are not supported", icebergType)
+}
+
+func manifestEntrySchema(p *partitioner) (avro.Schema, error) {
+	partitionFields := []any{}
+	for _, f := range p.fields {
+		avroType, err := avroPrimitive(f.resultType)
+		if err != nil {
+			return nil, err
+		}
+		partitionFields = append(partitionFields, map[string]any{
+			"name":     f.field.Name,
+			"type":     []any{"null", avroType},
+			"default":  nil,
+			"field-id": f.field.FieldID,
+		})
+	}
+
+	schemaBytes, err := json.Marshal(map[string]any{
+		"type": "record",
+		"name": "manifest_entry",
+		"fields": []any{
+			map[string]any{"name": "status", "type": "int", "field-id": 0},
+			map[string]any{"name": "snapshot_id", "type": []any{"null", "long"}, "default": nil, "field-id": 1},
+			map[string]any{"name": "sequence_number", "type": []any{"null", "long"}, "default": nil, "field-id": 3},
+			map[string]any{"name": "file_sequence_number", "type": []any{"null", "long"}, "default": nil, "field-id": 4},
+			map[string]any{"name": "data_file", "field-id": 2, "type": map[string]any{
+				"type": "record",
+				"name": "r2",
+				"fields": []any{
+					map[string]any{"name": "content", "type": "int", "field-id": 134},
+					map[string]any{"name": "file_path", "type": "string", "field-id": 100},
+					map[string]any{"name": "file_format", "type": "string", "field-id": 101},
+					map[string]any{"name": "partition", "field-id": 102, "type": map[string]any{
+						"type":   "record",
+						"name":   "r102",
+						"fields": partitionFields,
+					}},
+					map[string]any{"name": "record_count", "type": "long", "field-id": 103},
+					map[string]any{"name": "file_size_in_bytes", "type": "long", "field-id": 104},
+				},
+			}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return avro.ParseBytesWithCache(schemaBytes, "", &avro.SchemaCache{})
+}
+
+func avroPartitionValue(v any) any {
+	if ts, ok := v.(time.Time); ok {
+		return ts.UnixMicro()
+	}
+	return v
+}
+
+// writeManifest encodes a manifest of data files added by a snapshot, where
+// sequence numbers are inherited from the manifest list.
+func writeManifest(w io.Writer, s *schema, spec *partitionSpec, p *partitioner, snapshotID int64, files []dataFile) error {
+	entrySchema, err := manifestEntrySchema(p)
+	if err != nil {
+		return err
+	}
+
+	schemaJSON, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	specJSON, err := json.Marshal(spec.Fields)
+	if err != nil {
+		return err
+	}
+
+	enc, err := ocf.NewEncoderWithSchema(entrySchema, w,
+		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
+		ocf.WithCodec(ocf.Deflate),
+		ocf.WithMetadata(map[string][]byte{
+			"schema":            schemaJSON,
+			"schema-id":         []byte(strconv.Itoa(s.ID)),
+			"partition-spec":    specJSON,
+			"partition-spec-id": []byte(strconv.Itoa(spec.ID)),
+			"format-version":    []byte("2"),
+			"content":           []byte("data"),
+		}))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		partition := make(map[string]any, len(p.fields))
+		for i, pf := range p.fields {
+			partition[pf.field.Name] = avroPartitionValue(f.partition[i])
+		}
+		if err := enc.Encode(map[string]any{
+			"status":               manifestEntryStatusAdded,
+			"snapshot_id":          snapshotID,
+			"sequence_number":      nil,
+			"file_sequence_number": nil,
+			"data_file": map[string]any{
+				"content":            manifestContentData,
+				"file_path":          f.path,
+				"file_format":        "PARQUET",
+				"partition":          partition,
+				"record_count":       f.recordCount,
+				"file_size_in_bytes": f.sizeBytes,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// manifestFileFor summarises a manifest of added data files for inclusion
+// within a manifest list.
+func manifestFileFor(path string, manifest *bytes.Buffer, specID int, snap *snapshot, files []dataFile) manifestFile {
+	mf := manifestFile{
+		Path:              path,
+		Length:            int64(manifest.Len()),
+		PartitionSpecID:   int32(specID),
+		Content:           manifestContentData,
+		SequenceNumber:    snap.SequenceNumber,
+		MinSequenceNumber: snap.SequenceNumber,
+		AddedSnapshotID:   snap.SnapshotID,
+		AddedFilesCount:   int32(len(files)),
+	}
+	for _, f := range files {
+		mf.AddedRowsCount += f.recordCount
+	}
+	return mf
+}
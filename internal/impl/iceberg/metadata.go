@@ -0,0 +1,258 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The subset of the Iceberg table metadata (format version 2) that is used for
+// appending data files to a table. The specification lives at
+// https://iceberg.apache.org/spec/#table-metadata-fields.
+
+const mainBranch = "main"
+
+// fieldType is either a primitive type name such as `long` or `string`, or one
+// of the nested types struct, list or map.
+type fieldType struct {
+	Primitive string
+	Struct    *structType
+	List      *listType
+	Map       *mapType
+}
+
+type structType struct {
+	Fields []*schemaField `json:"fields"`
+}
+
+type listType struct {
+	ElementID       int       `json:"element-id"`
+	Element         fieldType `json:"element"`
+	ElementRequired bool      `json:"element-required"`
+}
+
+type mapType struct {
+	KeyID         int       `json:"key-id"`
+	Key           fieldType `json:"key"`
+	ValueID       int       `json:"value-id"`
+	Value         fieldType `json:"value"`
+	ValueRequired bool      `json:"value-required"`
+}
+
+func (t fieldType) String() string {
+	switch {
+	case t.Struct != nil:
+		return "struct"
+	case t.List != nil:
+		return "list"
+	case t.Map != nil:
+		return "map"
+	}
+	return t.Primitive
+}
+
+func (t fieldType) MarshalJSON() ([]byte, error) {
+	switch {
+	case t.Struct != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*structType
+		}{"struct", t.Struct})
+	case t.List != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*listType
+		}{"list", t.List})
+	case t.Map != nil:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			*mapType
+		}{"map", t.Map})
+	}
+	if t.Primitive == "" {
+		return nil, errors.New("empty field type")
+	}
+	return json.Marshal(t.Primitive)
+}
+
+func (t *fieldType) UnmarshalJSON(b []byte) error {
+	*t = fieldType{}
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &t.Primitive)
+	}
+
+	var nested struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &nested); err != nil {
+		return err
+	}
+	switch nested.Type {
+	case "struct":
+		t.Struct = &structType{}
+		return json.Unmarshal(b, t.Struct)
+	case "list":
+		t.List = &listType{}
+		return json.Unmarshal(b, t.List)
+	case "map":
+		t.Map = &mapType{}
+		return json.Unmarshal(b, t.Map)
+	}
+	return fmt.Errorf("unrecognised nested type: %q", nested.Type)
+}
+
+type schemaField struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Required bool      `json:"required"`
+	Type     fieldType `json:"type"`
+	Doc      string    `json:"doc,omitempty"`
+}
+
+type schema struct {
+	ID                 int            `json:"schema-id"`
+	IdentifierFieldIDs []int          `json:"identifier-field-ids,omitempty"`
+	Fields             []*schemaField `json:"fields"`
+}
+
+func (s *schema) MarshalJSON() ([]byte, error) {
+	type schemaAlias schema
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*schemaAlias
+	}{"struct", (*schemaAlias)(s)})
+}
+
+// findField returns the field of a schema with a given ID, searching nested
+// types.
+func (s *schema) findField(id int) *schemaField {
+	var find func(fields []*schemaField) *schemaField
+	find = func(fields []*schemaField) *schemaField {
+		for _, f := range fields {
+			if f.ID == id {
+				return f
+			}
+			if f.Type.Struct != nil {
+				if found := find(f.Type.Struct.Fields); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	}
+	return find(s.Fields)
+}
+
+type partitionField struct {
+	SourceID  int    `json:"source-id"`
+	FieldID   int    `json:"field-id"`
+	Name      string `json:"name"`
+	Transform string `json:"transform"`
+}
+
+type partitionSpec struct {
+	ID     int              `json:"spec-id"`
+	Fields []partitionField `json:"fields"`
+}
+
+type sortOrder struct {
+	OrderID int               `json:"order-id"`
+	Fields  []json.RawMessage `json:"fields"`
+}
+
+type snapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id,omitempty"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	TimestampMs      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaID         *int              `json:"schema-id,omitempty"`
+}
+
+type snapshotRef struct {
+	SnapshotID int64  `json:"snapshot-id"`
+	Type       string `json:"type"`
+}
+
+type tableMetadata struct {
+	FormatVersion      int                    `json:"format-version"`
+	TableUUID          string                 `json:"table-uuid"`
+	Location           string                 `json:"location"`
+	LastSequenceNumber int64                  `json:"last-sequence-number"`
+	LastUpdatedMs      int64                  `json:"last-updated-ms"`
+	LastColumnID       int                    `json:"last-column-id"`
+	Schemas            []*schema              `json:"schemas"`
+	CurrentSchemaID    int                    `json:"current-schema-id"`
+	PartitionSpecs     []*partitionSpec       `json:"partition-specs"`
+	DefaultSpecID      int                    `json:"default-spec-id"`
+	LastPartitionID    int                    `json:"last-partition-id"`
+	Properties         map[string]string      `json:"properties,omitempty"`
+	CurrentSnapshotID  *int64                 `json:"current-snapshot-id,omitempty"`
+	Snapshots          []*snapshot            `json:"snapshots,omitempty"`
+	Refs               map[string]snapshotRef `json:"refs,omitempty"`
+	SortOrders         []sortOrder            `json:"sort-orders"`
+	DefaultSortOrderID int                    `json:"default-sort-order-id"`
+}
+
+func (m *tableMetadata) currentSchema() (*schema, error) {
+	for _, s := range m.Schemas {
+		if s.ID == m.CurrentSchemaID {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("current schema %v not found in table metadata", m.CurrentSchemaID)
+}
+
+func (m *tableMetadata) defaultSpec() (*partitionSpec, error) {
+	for _, s := range m.PartitionSpecs {
+		if s.ID == m.DefaultSpecID {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("default partition spec %v not found in table metadata", m.DefaultSpecID)
+}
+
+// mainSnapshot returns the snapshot at the head of the main branch, or nil if
+// the table is empty.
+func (m *tableMetadata) mainSnapshot() *snapshot {
+	var id int64
+	if ref, exists := m.Refs[mainBranch]; exists {
+		id = ref.SnapshotID
+	} else if m.CurrentSnapshotID != nil && *m.CurrentSnapshotID != -1 {
+		id = *m.CurrentSnapshotID
+	} else {
+		return nil
+	}
+	for _, s := range m.Snapshots {
+		if s.SnapshotID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// snapshotWithSummary returns a snapshot containing a given summary property,
+// or nil if there are none.
+func (m *tableMetadata) snapshotWithSummary(key, value string) *snapshot {
+	for _, s := range m.Snapshots {
+		if s.Summary[key] == value {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,791 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/parquet-go/parquet-go"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+
+	parquetimpl "github.com/redpanda-data/connect/v4/internal/impl/parquet"
+)
+
+const (
+	ioFieldCatalog                    = "catalog"
+	ioFieldCatalogURL                 = "url"
+	ioFieldCatalogWarehouse           = "warehouse"
+	ioFieldCatalogToken               = "token"
+	ioFieldCatalogOAuth2              = "oauth2"
+	ioFieldCatalogOAuth2ClientID      = "client_id"
+	ioFieldCatalogOAuth2ClientSecret  = "client_secret"
+	ioFieldCatalogOAuth2TokenURL      = "token_url"
+	ioFieldCatalogOAuth2Scopes        = "scopes"
+	ioFieldCatalogHeaders             = "headers"
+	ioFieldCatalogTLS                 = "tls"
+	ioFieldNamespace                  = "namespace"
+	ioFieldTable                      = "table"
+	ioFieldPartitionSpec              = "partition_spec"
+	ioFieldTableProperties            = "table_properties"
+	ioFieldSchemaEvolution            = "schema_evolution"
+	ioFieldSchemaEvolutionEnabled     = "enabled"
+	ioFieldSchemaEvolutionIgnoreNulls = "ignore_nulls"
+	ioFieldSchemaEvolutionProcessors  = "processors"
+	ioFieldMaxCommitAttempts          = "max_commit_attempts"
+	ioFieldBatching                   = "batching"
+
+	// A snapshot summary property containing a unique ID for each commit, used
+	// to determine whether a commit with an unknown outcome was applied.
+	commitIDSummaryKey = "redpanda-connect.commit-id"
+)
+
+func icebergOutputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Categories("Services").
+		Version("4.73.0").
+		Summary("Writes messages to an Apache Iceberg table via a REST catalog.").
+		Description(`
+Each batch of messages is written to the table location as one or more Parquet data files, one for each partition, and then committed to the table as an append snapshot via the REST catalog. A batch is only acknowledged once its commit succeeds, and commits that conflict with other writers are retried on top of the latest table state. Each snapshot carries a unique commit ID within its summary, which is used to detect whether a commit with an unknown outcome was applied, so that batches are not duplicated when a catalog fails to respond. However, a batch that is rejected after its data files are written will leave orphaned files within the table location, which can be removed with standard Iceberg table maintenance.
+
+Messages must be structured objects, and each field is written to the column of the same name. Fields that do not exist within the table schema result in the batch being rejected unless schema evolution is enabled, in which case new columns are added to the table with types inferred from the values of the fields, and the table is created if it does not already exist.
+
+Only format version 2 tables are supported, and the table location must either be a local filesystem path or an S3 bucket. Credentials for S3 are obtained from the catalog when it vends them, otherwise the default AWS credentials chain is used.
+
+== Types
+
+When schema evolution is enabled the types of new columns are inferred as follows:
+
+[%header,format=dsv]
+|===
+Message type:Iceberg type
+string:string
+bytes:binary
+boolean:boolean
+integer number:long
+decimal number:double
+timestamp:timestamptz
+object:struct
+array:list
+|===
+
+Values are coerced into the type of their column when written, and timestamp columns accept RFC 3339 strings. The `+"`decimal`, `fixed`, `time` and `map`"+` column types are not currently supported.
+`).
+		Fields(
+			service.NewObjectField(ioFieldCatalog,
+				service.NewURLField(ioFieldCatalogURL).
+					Description("The base URL of the REST catalog.").
+					Example("http://localhost:8181"),
+				service.NewStringField(ioFieldCatalogWarehouse).
+					Description("An optional warehouse to request from the catalog.").
+					Optional(),
+				service.NewStringField(ioFieldCatalogToken).
+					Description("An optional bearer token to authenticate with the catalog.").
+					Secret().
+					Optional(),
+				service.NewObjectField(ioFieldCatalogOAuth2,
+					service.NewStringField(ioFieldCatalogOAuth2ClientID).
+						Description("The client ID to authenticate with."),
+					service.NewStringField(ioFieldCatalogOAuth2ClientSecret).
+						Description("The client secret to authenticate with.").
+						Secret(),
+					service.NewURLField(ioFieldCatalogOAuth2TokenURL).
+						Description("The URL of the token endpoint, which defaults to the `v1/oauth/tokens` endpoint of the catalog.").
+						Optional(),
+					service.NewStringListField(ioFieldCatalogOAuth2Scopes).
+						Description("A list of scopes to request.").
+						Default([]string{"PRINCIPAL_ROLE:ALL"}),
+				).
+					Description("Optionally authenticate with the catalog using the OAuth2 client credentials flow.").
+					Optional(),
+				service.NewStringMapField(ioFieldCatalogHeaders).
+					Description("A map of headers to add to catalog requests.").
+					Default(map[string]any{}).
+					Advanced(),
+				service.NewTLSToggledField(ioFieldCatalogTLS),
+			).Description("The REST catalog that the table belongs to."),
+			service.NewStringField(ioFieldNamespace).
+				Description("The namespace of the table, where the levels of nested namespaces are separated by dots.").
+				Example("analytics").
+				Example("prod.analytics"),
+			service.NewStringField(ioFieldTable).
+				Description("The name of the table.").
+				Example("events"),
+			service.NewStringListField(ioFieldPartitionSpec).
+				Description("A list of partition fields to use when the table is created. Each field is either a column name for identity partitioning, or a transform of a column in the form `year(column)`, `month(column)`, `day(column)`, `hour(column)`, `bucket(N, column)`, `truncate(W, column)` or `void(column)`. Nested columns are referenced with dot separated paths. The partition spec of existing tables is always used.").
+				Example([]string{"day(created_at)", "region"}).
+				Example([]string{"bucket(16, user_id)"}).
+				Default([]string{}),
+			service.NewStringMapField(ioFieldTableProperties).
+				Description("A map of properties to set when the table is created.").
+				Default(map[string]any{}).
+				Advanced(),
+			service.NewObjectField(ioFieldSchemaEvolution,
+				service.NewBoolField(ioFieldSchemaEvolutionEnabled).
+					Description("Whether schema evolution is enabled."),
+				service.NewBoolField(ioFieldSchemaEvolutionIgnoreNulls).
+					Description("If `true`, then new fields that are `null` are ignored and schema evolution is not triggered. If `false` then null fields are added as `string` columns unless processors are specified to determine the type.").
+					Default(true).
+					Advanced(),
+				service.NewProcessorListField(ioFieldSchemaEvolutionProcessors).
+					Description(`
+A series of processors to execute when new columns are added to the table, in order to determine the type of the column. The input to these processors is an object with the value and the name of the new column, the original message and the table being written to. The metadata is unchanged from the original message that caused the schema to change. For example: `+"`"+`{"value": 42.3, "name": "new_data_field", "message": {"existing_data_field": 42, "new_data_field": 42.3}, "namespace": "analytics", "table": "events"}`+"`"+`.
+
+The output of these processors should be a single message containing the name of a primitive Iceberg type such as `+"`long`, `double`, `string` or `timestamptz`"+`. Types are only determined this way for fields that are not objects or arrays, which are added as structs and lists respectively.`).
+					Optional().
+					Advanced().
+					Example([]map[string]any{
+						{"mapping": `root = if this.name.has_suffix("_at") { "timestamptz" } else if this.value.type() == "number" { "double" } else { "string" }`},
+					}),
+			).
+				Description("Options to control schema evolution as new fields are added to messages.").
+				Optional(),
+			service.NewIntField(ioFieldMaxCommitAttempts).
+				Description("The maximum number of attempts to commit a batch when conflicting with other writers of the table.").
+				Default(10).
+				Advanced(),
+			service.NewBatchPolicyField(ioFieldBatching),
+			service.NewOutputMaxInFlightField().Default(4),
+		).
+		Example("Partitioned Events", "Write events to a table partitioned by the day of their timestamp, creating the table and adding columns as new fields are seen.", `
+output:
+  iceberg:
+    catalog:
+      url: http://localhost:8181
+      warehouse: my_warehouse
+    namespace: analytics
+    table: events
+    partition_spec: [ "day(created_at)" ]
+    schema_evolution:
+      enabled: true
+    batching:
+      count: 10000
+      period: 30s
+`)
+}
+
+func init() {
+	service.MustRegisterBatchOutput("iceberg", icebergOutputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (
+			output service.BatchOutput,
+			batchPolicy service.BatchPolicy,
+			maxInFlight int,
+			err error,
+		) {
+			if maxInFlight, err = conf.FieldMaxInFlight(); err != nil {
+				return
+			}
+			if batchPolicy, err = conf.FieldBatchPolicy(ioFieldBatching); err != nil {
+				return
+			}
+			output, err = newIcebergOutputFromConfig(conf, mgr)
+			return
+		})
+}
+
+func catalogFromParsed(conf *service.ParsedConfig) (*restCatalog, error) {
+	catalogURL, err := conf.FieldString(ioFieldCatalogURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var warehouse, token string
+	if conf.Contains(ioFieldCatalogWarehouse) {
+		if warehouse, err = conf.FieldString(ioFieldCatalogWarehouse); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(ioFieldCatalogToken) {
+		if token, err = conf.FieldString(ioFieldCatalogToken); err != nil {
+			return nil, err
+		}
+	}
+
+	headers, err := conf.FieldStringMap(ioFieldCatalogHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{}
+	tlsConf, tlsEnabled, err := conf.FieldTLSToggled(ioFieldCatalogTLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsEnabled {
+		client.Transport = &http.Transport{
+			TLSClientConfig: tlsConf,
+		}
+	}
+
+	if conf.Contains(ioFieldCatalogOAuth2) {
+		oConf := conf.Namespace(ioFieldCatalogOAuth2)
+
+		ccConf := clientcredentials.Config{
+			TokenURL: strings.TrimSuffix(catalogURL, "/") + "/v1/oauth/tokens",
+		}
+		if ccConf.ClientID, err = oConf.FieldString(ioFieldCatalogOAuth2ClientID); err != nil {
+			return nil, err
+		}
+		if ccConf.ClientSecret, err = oConf.FieldString(ioFieldCatalogOAuth2ClientSecret); err != nil {
+			return nil, err
+		}
+		if oConf.Contains(ioFieldCatalogOAuth2TokenURL) {
+			if ccConf.TokenURL, err = oConf.FieldString(ioFieldCatalogOAuth2TokenURL); err != nil {
+				return nil, err
+			}
+		}
+		if ccConf.Scopes, err = oConf.FieldStringList(ioFieldCatalogOAuth2Scopes); err != nil {
+			return nil, err
+		}
+		client = ccConf.Client(context.WithValue(context.Background(), oauth2.HTTPClient, client))
+	}
+
+	return newRESTCatalog(catalogURL, warehouse, token, headers, client), nil
+}
+
+//------------------------------------------------------------------------------
+
+// tableState is the last known state of the table along with everything
+// derived from it that is needed for writing data files.
+type tableState struct {
+	metadata    *tableMetadata
+	io          fileIO
+	schema      *schema
+	pSchema     *parquet.Schema
+	spec        *partitionSpec
+	partitioner *partitioner
+}
+
+func newTableState(metadata *tableMetadata, io fileIO) (*tableState, error) {
+	if metadata.FormatVersion != 2 {
+		return nil, fmt.Errorf("table format version %v is not supported", metadata.FormatVersion)
+	}
+
+	st := &tableState{metadata: metadata, io: io}
+
+	var err error
+	if st.schema, err = metadata.currentSchema(); err != nil {
+		return nil, err
+	}
+	if st.pSchema, err = parquetSchema(st.schema); err != nil {
+		return nil, err
+	}
+	if st.spec, err = metadata.defaultSpec(); err != nil {
+		return nil, err
+	}
+	if st.partitioner, err = newPartitioner(st.spec, st.schema); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (st *tableState) location() string {
+	return strings.TrimSuffix(st.metadata.Location, "/")
+}
+
+type icebergOutput struct {
+	catalog           *restCatalog
+	ident             tableIdentifier
+	partitionExprs    []partitionExpr
+	tableProperties   map[string]string
+	evolver           *schemaEvolver
+	maxCommitAttempts int
+
+	log *service.Logger
+
+	stateMut sync.Mutex
+	state    *tableState
+}
+
+func newIcebergOutputFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*icebergOutput, error) {
+	o := &icebergOutput{log: mgr.Logger()}
+
+	var err error
+	if o.catalog, err = catalogFromParsed(conf.Namespace(ioFieldCatalog)); err != nil {
+		return nil, err
+	}
+
+	namespace, err := conf.FieldString(ioFieldNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if o.ident.Name, err = conf.FieldString(ioFieldTable); err != nil {
+		return nil, err
+	}
+	if namespace == "" || o.ident.Name == "" {
+		return nil, errors.New("a namespace and table must be specified")
+	}
+	o.ident.Namespace = strings.Split(namespace, ".")
+
+	partitionStrs, err := conf.FieldStringList(ioFieldPartitionSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range partitionStrs {
+		e, err := parsePartitionExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		o.partitionExprs = append(o.partitionExprs, e)
+	}
+
+	if o.tableProperties, err = conf.FieldStringMap(ioFieldTableProperties); err != nil {
+		return nil, err
+	}
+
+	if conf.Contains(ioFieldSchemaEvolution, ioFieldSchemaEvolutionEnabled) {
+		seConf := conf.Namespace(ioFieldSchemaEvolution)
+		enabled, err := seConf.FieldBool(ioFieldSchemaEvolutionEnabled)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			o.evolver = &schemaEvolver{ident: o.ident}
+			if o.evolver.ignoreNulls, err = seConf.FieldBool(ioFieldSchemaEvolutionIgnoreNulls); err != nil {
+				return nil, err
+			}
+			if seConf.Contains(ioFieldSchemaEvolutionProcessors) {
+				if o.evolver.processors, err = seConf.FieldProcessorList(ioFieldSchemaEvolutionProcessors); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if o.maxCommitAttempts, err = conf.FieldInt(ioFieldMaxCommitAttempts); err != nil {
+		return nil, err
+	}
+	if o.maxCommitAttempts < 1 {
+		return nil, fmt.Errorf("%v must be at least 1", ioFieldMaxCommitAttempts)
+	}
+	return o, nil
+}
+
+func (o *icebergOutput) Connect(ctx context.Context) error {
+	if err := o.catalog.loadConfig(ctx); err != nil {
+		return err
+	}
+
+	o.stateMut.Lock()
+	defer o.stateMut.Unlock()
+
+	err := o.loadTable(ctx)
+	if errors.Is(err, errTableNotFound) {
+		if o.evolver == nil {
+			return fmt.Errorf("%w: enable schema evolution in order to create the table automatically", err)
+		}
+		// The table is created from the shape of the first batch.
+		o.log.Infof("Table %v does not exist and will be created from the first batch", o.ident)
+		return nil
+	}
+	return err
+}
+
+// loadTable requires the state lock to be held.
+func (o *icebergOutput) loadTable(ctx context.Context) error {
+	res, err := o.catalog.loadTable(ctx, o.ident)
+	if err != nil {
+		return err
+	}
+	return o.setState(ctx, res)
+}
+
+// setState requires the state lock to be held.
+func (o *icebergOutput) setState(ctx context.Context, res *loadTableResult) error {
+	io, err := newFileIO(ctx, res.Metadata.Location, res.Config)
+	if err != nil {
+		return err
+	}
+	st, err := newTableState(res.Metadata, io)
+	if err != nil {
+		return err
+	}
+	o.state = st
+	return nil
+}
+
+// updateMetadata replaces the state of the table with metadata returned by a
+// commit, requiring the state lock to be held.
+func (o *icebergOutput) updateMetadata(metadata *tableMetadata) error {
+	st, err := newTableState(metadata, o.state.io)
+	if err != nil {
+		return err
+	}
+	o.state = st
+	return nil
+}
+
+// createTable requires the state lock to be held.
+func (o *icebergOutput) createTable(ctx context.Context, batch service.MessageBatch, rows []map[string]any) error {
+	s, _, err := o.evolver.evolve(ctx, &schema{Fields: []*schemaField{}}, 0, batch, rows)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return errors.New("unable to create the table as the batch contains no fields with values")
+	}
+
+	spec, err := buildPartitionSpec(o.partitionExprs, s)
+	if err != nil {
+		return err
+	}
+
+	props := map[string]string{"format-version": "2"}
+	for k, v := range o.tableProperties {
+		props[k] = v
+	}
+
+	res, err := o.catalog.createTable(ctx, o.ident, createTableRequest{
+		Schema:        s,
+		PartitionSpec: spec,
+		Properties:    props,
+	})
+	if err != nil {
+		var cErr *catalogError
+		if errors.As(err, &cErr) && cErr.StatusCode == http.StatusConflict {
+			// Another writer created the table first.
+			return o.loadTable(ctx)
+		}
+		return err
+	}
+	o.log.Infof("Created table %v", o.ident)
+	return o.setState(ctx, res)
+}
+
+// prepareTable ensures that the table exists and that its schema contains all
+// fields of the batch when schema evolution is enabled, returning the state to
+// write the batch with.
+func (o *icebergOutput) prepareTable(ctx context.Context, batch service.MessageBatch, rows []map[string]any) (*tableState, error) {
+	o.stateMut.Lock()
+	defer o.stateMut.Unlock()
+
+	if o.state == nil {
+		err := o.loadTable(ctx)
+		if errors.Is(err, errTableNotFound) && o.evolver != nil {
+			err = o.createTable(ctx, batch, rows)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if o.evolver == nil {
+		return o.state, nil
+	}
+
+	for range o.maxCommitAttempts {
+		meta := o.state.metadata
+		next, lastColumnID, err := o.evolver.evolve(ctx, o.state.schema, meta.LastColumnID, batch, rows)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return o.state, nil
+		}
+
+		res, err := o.catalog.commitTable(ctx, o.ident, []tableRequirement{
+			assertTableUUID(meta.TableUUID),
+			assertCurrentSchemaID(meta.CurrentSchemaID),
+			assertLastAssignedFieldID(meta.LastColumnID),
+		}, []tableUpdate{
+			addSchemaUpdate(next, lastColumnID),
+			setCurrentSchemaUpdate(-1),
+		})
+		if err != nil {
+			if !errors.Is(err, errCommitConflict) && !errors.Is(err, errCommitStateUnknown) {
+				return nil, fmt.Errorf("evolving table schema: %w", err)
+			}
+			o.log.Debugf("Reloading table after failing to evolve schema: %v", err)
+			if err := o.loadTable(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		o.log.Infof("Evolved schema of table %v to include %v columns", o.ident, lastColumnID-meta.LastColumnID)
+		if err := o.updateMetadata(res.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("failed to evolve table schema after %v attempts", o.maxCommitAttempts)
+}
+
+//------------------------------------------------------------------------------
+
+func newFileName() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+func writeParquetWithoutPanic(st *tableState, rows []any) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("encoding panic: %v", r)
+		}
+	}()
+
+	var buf bytes.Buffer
+	pWtr := parquet.NewGenericWriter[any](&buf, st.pSchema, parquet.Compression(&parquet.Zstd))
+	if _, err = pWtr.Write(rows); err != nil {
+		return
+	}
+	if err = pWtr.Close(); err != nil {
+		return
+	}
+	return buf.Bytes(), nil
+}
+
+type partitionRows struct {
+	path   string
+	values []any
+	rows   []any
+}
+
+// writeDataFiles writes a parquet data file to the table location for each
+// partition of the rows.
+func (o *icebergOutput) writeDataFiles(ctx context.Context, st *tableState, rows []map[string]any) ([]dataFile, error) {
+	partitions := map[string]*partitionRows{}
+	var order []string
+
+	for i, row := range rows {
+		coerced, err := coerceStruct(st.schema.Fields, row)
+		if err != nil {
+			return nil, fmt.Errorf("message %v: %w", i, err)
+		}
+
+		values, path, err := st.partitioner.partition(coerced)
+		if err != nil {
+			return nil, fmt.Errorf("message %v: %w", i, err)
+		}
+
+		encoded, err := parquetimpl.CoerceEncodingTypes(coerced, st.pSchema)
+		if err != nil {
+			return nil, fmt.Errorf("message %v: %w", i, err)
+		}
+
+		p, exists := partitions[path]
+		if !exists {
+			p = &partitionRows{path: path, values: values}
+			partitions[path] = p
+			order = append(order, path)
+		}
+		p.rows = append(p.rows, encoded)
+	}
+
+	files := make([]dataFile, 0, len(order))
+	for _, path := range order {
+		p := partitions[path]
+
+		b, err := writeParquetWithoutPanic(st, p.rows)
+		if err != nil {
+			return nil, err
+		}
+
+		location := st.location() + "/data/"
+		if p.path != "" {
+			location += p.path + "/"
+		}
+		location += newFileName() + ".parquet"
+
+		if err := st.io.WriteFile(ctx, location, b); err != nil {
+			return nil, fmt.Errorf("writing data file: %w", err)
+		}
+		files = append(files, dataFile{
+			path:        location,
+			recordCount: int64(len(p.rows)),
+			sizeBytes:   int64(len(b)),
+			partition:   p.values,
+		})
+	}
+	return files, nil
+}
+
+func snapshotSummary(parent *snapshot, commitID string, files []dataFile) map[string]string {
+	var addedRecords, addedSize int64
+	for _, f := range files {
+		addedRecords += f.recordCount
+		addedSize += f.sizeBytes
+	}
+
+	summary := map[string]string{
+		"operation":          "append",
+		commitIDSummaryKey:   commitID,
+		"added-data-files":   strconv.Itoa(len(files)),
+		"added-records":      strconv.FormatInt(addedRecords, 10),
+		"added-files-size":   strconv.FormatInt(addedSize, 10),
+		"total-data-files":   strconv.Itoa(len(files)),
+		"total-records":      strconv.FormatInt(addedRecords, 10),
+		"total-files-size":   strconv.FormatInt(addedSize, 10),
+		"total-delete-files": "0",
+	}
+	if parent == nil {
+		return summary
+	}
+
+	// Totals are only carried over when the parent snapshot has them.
+	for k, added := range map[string]int64{
+		"total-data-files": int64(len(files)),
+		"total-records":    addedRecords,
+		"total-files-size": addedSize,
+	} {
+		total, err := strconv.ParseInt(parent.Summary[k], 10, 64)
+		if err != nil {
+			delete(summary, k)
+			continue
+		}
+		summary[k] = strconv.FormatInt(total+added, 10)
+	}
+	if v, exists := parent.Summary["total-delete-files"]; exists {
+		summary["total-delete-files"] = v
+	} else {
+		delete(summary, "total-delete-files")
+	}
+	return summary
+}
+
+// commit appends data files to the table by writing a manifest and then
+// committing a snapshot that references it, retrying on top of the latest
+// table state when conflicting with other writers.
+func (o *icebergOutput) commit(ctx context.Context, writeState *tableState, files []dataFile) error {
+	snapshotID := rand.Int64N(math.MaxInt64-1) + 1
+	commitID := newFileName()
+	schemaID := writeState.schema.ID
+
+	var manifest bytes.Buffer
+	if err := writeManifest(&manifest, writeState.schema, writeState.spec, writeState.partitioner, snapshotID, files); err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	manifestPath := writeState.location() + "/metadata/" + newFileName() + "-m0.avro"
+	if err := writeState.io.WriteFile(ctx, manifestPath, manifest.Bytes()); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	o.stateMut.Lock()
+	defer o.stateMut.Unlock()
+
+	var lastErr error
+	for attempt := range o.maxCommitAttempts {
+		st := o.state
+		meta := st.metadata
+
+		parent := meta.mainSnapshot()
+		snap := &snapshot{
+			SnapshotID:     snapshotID,
+			SequenceNumber: meta.LastSequenceNumber + 1,
+			TimestampMs:    time.Now().UnixMilli(),
+			Summary:        snapshotSummary(parent, commitID, files),
+			SchemaID:       &schemaID,
+		}
+		manifests := []manifestFile{
+			manifestFileFor(manifestPath, &manifest, writeState.spec.ID, snap, files),
+		}
+		if parent != nil {
+			snap.ParentSnapshotID = &parent.SnapshotID
+
+			parentList, err := st.io.ReadFile(ctx, parent.ManifestList)
+			if err != nil {
+				return fmt.Errorf("reading manifest list of snapshot %v: %w", parent.SnapshotID, err)
+			}
+			previous, err := readManifestList(bytes.NewReader(parentList))
+			if err != nil {
+				return fmt.Errorf("decoding manifest list of snapshot %v: %w", parent.SnapshotID, err)
+			}
+			manifests = append(manifests, previous...)
+		}
+
+		var manifestList bytes.Buffer
+		if err := writeManifestList(&manifestList, snap, manifests); err != nil {
+			return fmt.Errorf("encoding manifest list: %w", err)
+		}
+		snap.ManifestList = fmt.Sprintf("%v/metadata/snap-%v-%v-%v.avro", st.location(), snapshotID, attempt, newFileName())
+		if err := st.io.WriteFile(ctx, snap.ManifestList, manifestList.Bytes()); err != nil {
+			return fmt.Errorf("writing manifest list: %w", err)
+		}
+
+		var parentID *int64
+		if parent != nil {
+			parentID = &parent.SnapshotID
+		}
+
+		res, err := o.catalog.commitTable(ctx, o.ident, []tableRequirement{
+			assertTableUUID(meta.TableUUID),
+			assertRefSnapshotID(mainBranch, parentID),
+		}, []tableUpdate{
+			addSnapshotUpdate(snap),
+			setBranchUpdate(mainBranch, snapshotID),
+		})
+		if err == nil {
+			return o.updateMetadata(res.Metadata)
+		}
+		if !errors.Is(err, errCommitConflict) && !errors.Is(err, errCommitStateUnknown) {
+			return err
+		}
+
+		lastErr = err
+		o.log.Debugf("Reloading table after failed commit attempt: %v", err)
+		if err := o.loadTable(ctx); err != nil {
+			return err
+		}
+		if errors.Is(lastErr, errCommitStateUnknown) && o.state.metadata.snapshotWithSummary(commitIDSummaryKey, commitID) != nil {
+			// The commit was applied despite the error.
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to commit after %v attempts: %w", o.maxCommitAttempts, lastErr)
+}
+
+func (o *icebergOutput) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]any, len(batch))
+	for i, msg := range batch {
+		v, err := msg.AsStructured()
+		if err != nil {
+			return fmt.Errorf("message %v: %w", i, err)
+		}
+		var isObj bool
+		if rows[i], isObj = v.(map[string]any); !isObj {
+			return fmt.Errorf("message %v: unable to write message type %T as a table row", i, v)
+		}
+	}
+
+	st, err := o.prepareTable(ctx, batch, rows)
+	if err != nil {
+		return err
+	}
+
+	files, err := o.writeDataFiles(ctx, st, rows)
+	if err != nil {
+		return err
+	}
+	return o.commit(ctx, st, files)
+}
+
+func (*icebergOutput) Close(context.Context) error {
+	return nil
+}
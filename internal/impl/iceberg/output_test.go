@@ -0,0 +1,329 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func newTestOutput(t testing.TB, cat *testCatalog, confStr string) *icebergOutput {
+	t.Helper()
+
+	conf, err := icebergOutputConfig().ParseYAML(fmt.Sprintf(`
+catalog:
+  url: %v
+namespace: analytics
+table: events
+%v`, cat.server.URL, confStr), nil)
+	require.NoError(t, err)
+
+	o, err := newIcebergOutputFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, o.Connect(t.Context()))
+	t.Cleanup(func() {
+		require.NoError(t, o.Close(context.Background()))
+	})
+	return o
+}
+
+func testBatch(docs ...string) (batch service.MessageBatch) {
+	for _, d := range docs {
+		batch = append(batch, service.NewMessage([]byte(d)))
+	}
+	return
+}
+
+// readTable reads all rows of the current snapshot of a table along with the
+// partition values of the data files they belong to.
+func readTable(t testing.TB, cat *testCatalog) (rows, partitions []map[string]any) {
+	t.Helper()
+
+	m := cat.table("analytics", "events")
+	require.NotNil(t, m)
+
+	snap := m.mainSnapshot()
+	require.NotNil(t, snap)
+
+	listBytes, err := os.ReadFile(snap.ManifestList)
+	require.NoError(t, err)
+	manifests, err := readManifestList(bytes.NewReader(listBytes))
+	require.NoError(t, err)
+
+	for _, mf := range manifests {
+		assert.LessOrEqual(t, mf.SequenceNumber, snap.SequenceNumber)
+
+		manifestBytes, err := os.ReadFile(mf.Path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(manifestBytes)), mf.Length)
+
+		dec, err := ocf.NewDecoder(bytes.NewReader(manifestBytes))
+		require.NoError(t, err)
+		assert.Equal(t, "2", string(dec.Metadata()["format-version"]))
+
+		for dec.HasNext() {
+			var entry map[string]any
+			require.NoError(t, dec.Decode(&entry))
+
+			dataFile := entry["data_file"].(map[string]any)
+			assert.Equal(t, "PARQUET", dataFile["file_format"])
+
+			f, err := os.Open(dataFile["file_path"].(string))
+			require.NoError(t, err)
+
+			info, err := f.Stat()
+			require.NoError(t, err)
+			assert.Equal(t, info.Size(), dataFile["file_size_in_bytes"])
+
+			pRdr := parquet.NewGenericReader[any](f)
+			fileRows := make([]any, pRdr.NumRows())
+			_, err = pRdr.Read(fileRows)
+			if err != nil {
+				require.ErrorContains(t, err, "EOF")
+			}
+			assert.Equal(t, dataFile["record_count"], int64(len(fileRows)))
+			require.NoError(t, pRdr.Close())
+			require.NoError(t, f.Close())
+
+			// Partition values are nullable and therefore decoded as unions.
+			partition := map[string]any{}
+			for k, v := range dataFile["partition"].(map[string]any) {
+				for _, uv := range v.(map[string]any) {
+					partition[k] = uv
+				}
+			}
+			for _, r := range fileRows {
+				rows = append(rows, r.(map[string]any))
+				partitions = append(partitions, partition)
+			}
+		}
+	}
+	return
+}
+
+func sortedIDs(rows []map[string]any) (ids []int64) {
+	for _, r := range rows {
+		ids = append(ids, r["id"].(int64))
+	}
+	slices.Sort(ids)
+	return
+}
+
+func TestOutputCreateAndEvolve(t *testing.T) {
+	cat := newTestCatalog(t)
+	o := newTestOutput(t, cat, `
+partition_spec: [ "region" ]
+schema_evolution:
+  enabled: true
+`)
+
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(
+		`{"id":1,"region":"eu","ts":"2025-01-01T10:00:00Z","meta":{"tags":["a","b"]}}`,
+		`{"id":2,"region":"us","ts":"2025-01-01T11:00:00Z","meta":{"tags":[]}}`,
+		`{"id":3,"region":"eu","ts":"2025-01-02T10:00:00Z","ignored":null}`,
+	)))
+
+	m := cat.table("analytics", "events")
+	require.NotNil(t, m)
+
+	s, err := m.currentSchema()
+	require.NoError(t, err)
+	_, idField := findFieldByPath(s, []string{"id"})
+	require.NotNil(t, idField)
+	assert.Equal(t, "long", idField.Type.Primitive)
+	_, tsField := findFieldByPath(s, []string{"ts"})
+	require.NotNil(t, tsField)
+	assert.Equal(t, "string", tsField.Type.Primitive)
+	_, tagsField := findFieldByPath(s, []string{"meta", "tags"})
+	require.NotNil(t, tagsField)
+	require.NotNil(t, tagsField.Type.List)
+	assert.Equal(t, "string", tagsField.Type.List.Element.Primitive)
+	_, ignoredField := findFieldByPath(s, []string{"ignored"})
+	assert.Nil(t, ignoredField)
+
+	spec, err := m.defaultSpec()
+	require.NoError(t, err)
+	require.Len(t, spec.Fields, 1)
+	assert.Equal(t, "region", spec.Fields[0].Name)
+
+	rows, partitions := readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3}, sortedIDs(rows))
+	for i, r := range rows {
+		assert.Equal(t, r["region"], partitions[i]["region"])
+	}
+
+	// A new field evolves the schema and a second snapshot is appended.
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(
+		`{"id":4,"region":"eu","ts":"2025-01-03T10:00:00Z","score":1.5}`,
+	)))
+
+	m = cat.table("analytics", "events")
+	assert.Len(t, m.Schemas, 2)
+	assert.Equal(t, 1, m.CurrentSchemaID)
+	assert.Len(t, m.Snapshots, 2)
+	assert.Equal(t, int64(2), m.mainSnapshot().SequenceNumber)
+	assert.Equal(t, m.Snapshots[0].SnapshotID, *m.mainSnapshot().ParentSnapshotID)
+	assert.Equal(t, "4", m.mainSnapshot().Summary["total-records"])
+
+	rows, _ = readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3, 4}, sortedIDs(rows))
+	for _, r := range rows {
+		if r["id"] == int64(4) {
+			assert.Equal(t, 1.5, r["score"])
+		} else {
+			assert.Nil(t, r["score"])
+		}
+	}
+}
+
+func TestOutputTimestampPartitions(t *testing.T) {
+	cat := newTestCatalog(t)
+	o := newTestOutput(t, cat, `
+partition_spec: [ "day(ts)", "bucket(4, id)" ]
+schema_evolution:
+  enabled: true
+  processors:
+    - mapping: 'root = if this.name == "ts" { "timestamptz" } else { "long" }'
+`)
+
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(
+		`{"id":1,"ts":"2025-01-01T10:00:00Z"}`,
+		`{"id":2,"ts":"2025-01-02T11:00:00Z"}`,
+		`{"id":3,"ts":"2025-01-01T23:59:59Z"}`,
+	)))
+
+	rows, partitions := readTable(t, cat)
+	require.Len(t, rows, 3)
+
+	days := map[int64]int{}
+	for i, r := range rows {
+		days[r["id"].(int64)] = partitions[i]["ts_day"].(int)
+		assert.Contains(t, partitions[i], "id_bucket")
+	}
+	assert.Equal(t, map[int64]int{1: 20089, 2: 20090, 3: 20089}, days)
+
+	// Data files are written to a path for each partition.
+	entries, err := os.ReadDir(cat.table("analytics", "events").Location + "/data")
+	require.NoError(t, err)
+	var dirs []string
+	for _, e := range entries {
+		dirs = append(dirs, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"ts_day=2025-01-01", "ts_day=2025-01-02"}, dirs)
+}
+
+func TestOutputWithoutEvolution(t *testing.T) {
+	cat := newTestCatalog(t)
+
+	conf, err := icebergOutputConfig().ParseYAML(fmt.Sprintf(`
+catalog:
+  url: %v
+namespace: analytics
+table: events
+`, cat.server.URL), nil)
+	require.NoError(t, err)
+
+	o, err := newIcebergOutputFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	require.ErrorIs(t, o.Connect(t.Context()), errTableNotFound)
+
+	// Create the table with a writer that evolves the schema.
+	evolver := newTestOutput(t, cat, `
+schema_evolution:
+  enabled: true
+`)
+	require.NoError(t, evolver.WriteBatch(t.Context(), testBatch(`{"id":1,"name":"foo"}`)))
+
+	require.NoError(t, o.Connect(t.Context()))
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(`{"id":2}`, `{"id":3,"name":null,"other":null}`)))
+	require.ErrorIs(t, o.WriteBatch(t.Context(), testBatch(`{"id":4,"other":"bar"}`)), errUnknownField)
+	require.ErrorContains(t, o.WriteBatch(t.Context(), testBatch(`{"id":"nope"}`)), "id")
+
+	rows, _ := readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3}, sortedIDs(rows))
+}
+
+func TestOutputConcurrentWriters(t *testing.T) {
+	cat := newTestCatalog(t)
+	conf := `
+schema_evolution:
+  enabled: true
+`
+	a := newTestOutput(t, cat, conf)
+	require.NoError(t, a.WriteBatch(t.Context(), testBatch(`{"id":1}`)))
+
+	b := newTestOutput(t, cat, conf)
+	require.NoError(t, b.WriteBatch(t.Context(), testBatch(`{"id":2}`)))
+
+	// The state of the first writer is stale and its commit conflicts.
+	commitsBefore := cat.commits
+	require.NoError(t, a.WriteBatch(t.Context(), testBatch(`{"id":3}`)))
+	assert.Equal(t, 2, cat.commits-commitsBefore)
+
+	rows, _ := readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3}, sortedIDs(rows))
+
+	m := cat.table("analytics", "events")
+	require.Len(t, m.Snapshots, 3)
+	for i, s := range m.Snapshots {
+		assert.Equal(t, int64(i+1), s.SequenceNumber)
+	}
+}
+
+func TestOutputCommitFailures(t *testing.T) {
+	cat := newTestCatalog(t)
+	o := newTestOutput(t, cat, `
+schema_evolution:
+  enabled: true
+max_commit_attempts: 3
+`)
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(`{"id":1}`)))
+
+	// A commit that is applied but never acknowledged by the catalog is not
+	// retried, and therefore not duplicated.
+	cat.commitFailures = []int{http.StatusServiceUnavailable}
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(`{"id":2}`)))
+
+	// Conflicts are retried.
+	cat.commitFailures = []int{http.StatusConflict, http.StatusConflict}
+	require.NoError(t, o.WriteBatch(t.Context(), testBatch(`{"id":3}`)))
+
+	rows, _ := readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3}, sortedIDs(rows))
+
+	// Until they are exhausted, in which case the batch is rejected.
+	cat.commitFailures = []int{http.StatusConflict, http.StatusConflict, http.StatusConflict}
+	err := o.WriteBatch(t.Context(), testBatch(`{"id":4}`))
+	require.ErrorIs(t, err, errCommitConflict)
+	assert.True(t, strings.Contains(err.Error(), "3 attempts"))
+
+	rows, _ = readTable(t, cat)
+	assert.Equal(t, []int64{1, 2, 3}, sortedIDs(rows))
+	assert.Len(t, cat.table("analytics", "events").Snapshots, 3)
+}
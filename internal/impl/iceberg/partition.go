@@ -0,0 +1,366 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/spaolacci/murmur3"
+)
+
+// The first partition field ID, as IDs for partition fields are assigned
+// separately to schema field IDs.
+const firstPartitionFieldID = 1000
+
+// partitionTransform is a transform from the partition spec, such as `day` or
+// `bucket[16]`, as described in https://iceberg.apache.org/spec/#partition-transforms.
+type partitionTransform struct {
+	name  string
+	param int
+}
+
+var transformParamRegex = regexp.MustCompile(`^(bucket|truncate)\[(\d+)\]$`)
+
+func parseTransform(s string) (partitionTransform, error) {
+	switch s {
+	case "identity", "year", "month", "day", "hour", "void":
+		return partitionTransform{name: s}, nil
+	}
+	if m := transformParamRegex.FindStringSubmatch(s); m != nil {
+		param, err := strconv.Atoi(m[2])
+		if err != nil || param <= 0 {
+			return partitionTransform{}, fmt.Errorf("invalid %v transform parameter: %v", m[1], m[2])
+		}
+		return partitionTransform{name: m[1], param: param}, nil
+	}
+	return partitionTransform{}, fmt.Errorf("unsupported partition transform: %v", s)
+}
+
+func (t partitionTransform) String() string {
+	if t.param > 0 {
+		return fmt.Sprintf("%v[%v]", t.name, t.param)
+	}
+	return t.name
+}
+
+// resultType returns the primitive type of partition values produced by the
+// transform for a given source type.
+func (t partitionTransform) resultType(sourceType string) (string, error) {
+	isTime := sourceType == "date" || sourceType == "timestamp" || sourceType == "timestamptz"
+	switch t.name {
+	case "identity", "void":
+		switch sourceType {
+		case "boolean", "int", "long", "float", "double", "string", "binary", "date", "timestamp", "timestamptz":
+			return sourceType, nil
+		}
+	case "year", "month", "hour":
+		if isTime && (t.name != "hour" || sourceType != "date") {
+			return "int", nil
+		}
+	case "day":
+		if isTime {
+			return "date", nil
+		}
+	case "bucket":
+		switch sourceType {
+		case "int", "long", "string", "binary", "date", "timestamp", "timestamptz", "uuid":
+			return "int", nil
+		}
+	case "truncate":
+		switch sourceType {
+		case "int", "long", "string", "binary":
+			return sourceType, nil
+		}
+	}
+	return "", fmt.Errorf("partition transform %v cannot be applied to type %v", t, sourceType)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// apply transforms a value that has already been coerced into the source type.
+func (t partitionTransform) apply(v any, sourceType string) (any, error) {
+	if v == nil || t.name == "void" {
+		return nil, nil
+	}
+
+	switch t.name {
+	case "identity":
+		return v, nil
+
+	case "year", "month", "day", "hour":
+		var ts time.Time
+		switch tv := v.(type) {
+		case time.Time:
+			ts = tv.UTC()
+		case int32:
+			// Dates are stored as days from the unix epoch.
+			ts = time.Unix(int64(tv)*86400, 0).UTC()
+		default:
+			return nil, fmt.Errorf("expected a date or timestamp value, got %T", v)
+		}
+		switch t.name {
+		case "year":
+			return int32(ts.Year() - 1970), nil
+		case "month":
+			return int32((ts.Year()-1970)*12 + int(ts.Month()) - 1), nil
+		case "day":
+			return int32(floorDiv(ts.UnixMicro(), int64(24*time.Hour/time.Microsecond))), nil
+		default:
+			return int32(floorDiv(ts.UnixMicro(), int64(time.Hour/time.Microsecond))), nil
+		}
+
+	case "bucket":
+		var b []byte
+		switch tv := v.(type) {
+		case int32:
+			b = binary.LittleEndian.AppendUint64(nil, uint64(int64(tv)))
+		case int64:
+			b = binary.LittleEndian.AppendUint64(nil, uint64(tv))
+		case time.Time:
+			b = binary.LittleEndian.AppendUint64(nil, uint64(tv.UnixMicro()))
+		case string:
+			if sourceType == "uuid" {
+				id, err := uuid.FromString(tv)
+				if err != nil {
+					return nil, err
+				}
+				b = id.Bytes()
+			} else {
+				b = []byte(tv)
+			}
+		case []byte:
+			b = tv
+		default:
+			return nil, fmt.Errorf("unable to bucket value of type %T", v)
+		}
+		return int32((murmur3.Sum32(b) & 0x7fffffff) % uint32(t.param)), nil
+
+	case "truncate":
+		w := int64(t.param)
+		switch tv := v.(type) {
+		case int32:
+			return int32(int64(tv) - (((int64(tv) % w) + w) % w)), nil
+		case int64:
+			return tv - (((tv % w) + w) % w), nil
+		case string:
+			if runes := []rune(tv); len(runes) > t.param {
+				return string(runes[:t.param]), nil
+			}
+			return tv, nil
+		case []byte:
+			if len(tv) > t.param {
+				return tv[:t.param], nil
+			}
+			return tv, nil
+		}
+		return nil, fmt.Errorf("unable to truncate value of type %T", v)
+	}
+	return nil, fmt.Errorf("unsupported partition transform: %v", t)
+}
+
+// humanString formats a transformed value for use within data file paths.
+func (t partitionTransform) humanString(v any) string {
+	if v == nil {
+		return "null"
+	}
+	switch tv := v.(type) {
+	case int32:
+		switch t.name {
+		case "year":
+			return strconv.Itoa(1970 + int(tv))
+		case "month":
+			return fmt.Sprintf("%04d-%02d", 1970+int(tv)/12, int(tv)%12+1)
+		case "day":
+			return time.Unix(int64(tv)*86400, 0).UTC().Format(time.DateOnly)
+		case "hour":
+			return time.Unix(int64(tv)*3600, 0).UTC().Format("2006-01-02-15")
+		}
+	case time.Time:
+		return tv.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		return fmt.Sprintf("%x", tv)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+//------------------------------------------------------------------------------
+
+var partitionExprRegex = regexp.MustCompile(`^\s*(\w+)\s*\(\s*(?:(\d+)\s*,\s*)?([^(),\s]+)\s*\)\s*$`)
+
+// partitionExpr is a partition field as configured, in the form `column`,
+// `day(column)` or `bucket(16, column)`.
+type partitionExpr struct {
+	column    string
+	transform partitionTransform
+}
+
+func parsePartitionExpr(s string) (partitionExpr, error) {
+	m := partitionExprRegex.FindStringSubmatch(s)
+	if m == nil {
+		column := strings.TrimSpace(s)
+		if column == "" || strings.ContainsAny(column, "(), ") {
+			return partitionExpr{}, fmt.Errorf("invalid partition expression: %q", s)
+		}
+		return partitionExpr{column: column, transform: partitionTransform{name: "identity"}}, nil
+	}
+
+	name := m[1]
+	switch name {
+	case "years":
+		name = "year"
+	case "months":
+		name = "month"
+	case "days":
+		name = "day"
+	case "hours":
+		name = "hour"
+	}
+	if m[2] != "" {
+		name = fmt.Sprintf("%v[%v]", name, m[2])
+	}
+
+	t, err := parseTransform(name)
+	if err != nil {
+		return partitionExpr{}, fmt.Errorf("invalid partition expression %q: %w", s, err)
+	}
+	if t.param == 0 && (t.name == "bucket" || t.name == "truncate") {
+		return partitionExpr{}, fmt.Errorf("invalid partition expression %q: %v requires a width parameter", s, t.name)
+	}
+	return partitionExpr{column: m[3], transform: t}, nil
+}
+
+// partitionFieldName follows the naming convention used by the reference
+// implementation for partition fields.
+func (e partitionExpr) partitionFieldName() string {
+	column := strings.ReplaceAll(e.column, ".", "_")
+	switch e.transform.name {
+	case "identity":
+		return column
+	case "bucket":
+		return column + "_bucket"
+	case "truncate":
+		return column + "_trunc"
+	case "void":
+		return column + "_null"
+	}
+	return column + "_" + e.transform.name
+}
+
+// buildPartitionSpec resolves partition expressions against a schema.
+func buildPartitionSpec(exprs []partitionExpr, s *schema) (*partitionSpec, error) {
+	spec := &partitionSpec{Fields: []partitionField{}}
+	for i, e := range exprs {
+		_, f := findFieldByPath(s, strings.Split(e.column, "."))
+		if f == nil {
+			return nil, fmt.Errorf("partition column %v does not exist in the table schema", e.column)
+		}
+		if _, err := e.transform.resultType(f.Type.Primitive); err != nil {
+			return nil, fmt.Errorf("partition column %v: %w", e.column, err)
+		}
+		spec.Fields = append(spec.Fields, partitionField{
+			SourceID:  f.ID,
+			FieldID:   firstPartitionFieldID + i,
+			Name:      e.partitionFieldName(),
+			Transform: e.transform.String(),
+		})
+	}
+	return spec, nil
+}
+
+//------------------------------------------------------------------------------
+
+// boundPartitionField is a partition field resolved against a table schema so
+// that partition values can be calculated from rows.
+type boundPartitionField struct {
+	field      partitionField
+	path       []string
+	transform  partitionTransform
+	sourceType string
+	resultType string
+}
+
+// partitioner calculates the partition of rows for a partition spec.
+type partitioner struct {
+	specID int
+	fields []boundPartitionField
+}
+
+func newPartitioner(spec *partitionSpec, s *schema) (*partitioner, error) {
+	p := &partitioner{specID: spec.ID}
+	for _, f := range spec.Fields {
+		path, source := findFieldPathByID(s, f.SourceID)
+		if source == nil {
+			return nil, fmt.Errorf("partition field %v refers to column %v which does not exist in the schema", f.Name, f.SourceID)
+		}
+		t, err := parseTransform(f.Transform)
+		if err != nil {
+			return nil, err
+		}
+		resultType, err := t.resultType(source.Type.Primitive)
+		if err != nil {
+			return nil, fmt.Errorf("partition field %v: %w", f.Name, err)
+		}
+		p.fields = append(p.fields, boundPartitionField{
+			field:      f,
+			path:       path,
+			transform:  t,
+			sourceType: source.Type.Primitive,
+			resultType: resultType,
+		})
+	}
+	return p, nil
+}
+
+func (p *partitioner) unpartitioned() bool {
+	return len(p.fields) == 0
+}
+
+// partition returns the partition values of a row, which must already be
+// coerced into the table schema, along with a relative path that uniquely
+// identifies the partition.
+func (p *partitioner) partition(row map[string]any) (values []any, path string, err error) {
+	if p.unpartitioned() {
+		return nil, "", nil
+	}
+
+	segments := make([]string, 0, len(p.fields))
+	values = make([]any, 0, len(p.fields))
+	for _, f := range p.fields {
+		var v any = row
+		for _, name := range f.path {
+			obj, _ := v.(map[string]any)
+			v = obj[name]
+		}
+		if v, err = f.transform.apply(v, f.sourceType); err != nil {
+			return nil, "", fmt.Errorf("partition field %v: %w", f.field.Name, err)
+		}
+		values = append(values, v)
+		segments = append(segments, url.QueryEscape(f.field.Name)+"="+url.QueryEscape(f.transform.humanString(v)))
+	}
+	return values, strings.Join(segments, "/"), nil
+}
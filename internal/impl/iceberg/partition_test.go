@@ -0,0 +1,153 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionTransforms(t *testing.T) {
+	ts := time.Date(2017, 11, 16, 22, 31, 8, 0, time.UTC)
+	date := int32(ts.Unix() / 86400)
+
+	// A bucket count of the max int32 exposes the hash itself, which can be
+	// compared with the values listed in the specification.
+	maxBucket := math.MaxInt32
+	specHash := func(h int32) int32 {
+		return int32((uint32(h) & 0x7fffffff) % uint32(maxBucket))
+	}
+
+	tests := []struct {
+		name       string
+		transform  string
+		sourceType string
+		input      any
+		output     any
+	}{
+		{name: "bucket int", transform: "bucket[2147483647]", sourceType: "int", input: int32(34), output: specHash(2017239379)},
+		{name: "bucket long", transform: "bucket[2147483647]", sourceType: "long", input: int64(34), output: specHash(2017239379)},
+		{name: "bucket date", transform: "bucket[2147483647]", sourceType: "date", input: date, output: specHash(-653330422)},
+		{name: "bucket timestamp", transform: "bucket[2147483647]", sourceType: "timestamptz", input: ts, output: specHash(-2047944441)},
+		{name: "bucket string", transform: "bucket[2147483647]", sourceType: "string", input: "iceberg", output: specHash(1210000089)},
+		{name: "bucket uuid", transform: "bucket[2147483647]", sourceType: "uuid", input: "f79c3e09-677c-4bbd-a479-3f349cb785e7", output: specHash(1488055340)},
+		{name: "bucket small", transform: "bucket[16]", sourceType: "long", input: int64(34), output: int32(2017239379 % 16)},
+		{name: "truncate int", transform: "truncate[10]", sourceType: "int", input: int32(1), output: int32(0)},
+		{name: "truncate negative int", transform: "truncate[10]", sourceType: "int", input: int32(-1), output: int32(-10)},
+		{name: "truncate long", transform: "truncate[10]", sourceType: "long", input: int64(-11), output: int64(-20)},
+		{name: "truncate string", transform: "truncate[3]", sourceType: "string", input: "iceberg", output: "ice"},
+		{name: "truncate short string", transform: "truncate[10]", sourceType: "string", input: "ice", output: "ice"},
+		{name: "year", transform: "year", sourceType: "timestamptz", input: ts, output: int32(47)},
+		{name: "month", transform: "month", sourceType: "timestamptz", input: ts, output: int32(574)},
+		{name: "day", transform: "day", sourceType: "timestamptz", input: ts, output: date},
+		{name: "day of date", transform: "day", sourceType: "date", input: date, output: date},
+		{name: "hour", transform: "hour", sourceType: "timestamptz", input: ts, output: int32(date*24 + 22)},
+		{name: "day before epoch", transform: "day", sourceType: "timestamp", input: time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC), output: int32(-1)},
+		{name: "identity", transform: "identity", sourceType: "string", input: "foo", output: "foo"},
+		{name: "void", transform: "void", sourceType: "string", input: "foo", output: nil},
+		{name: "null", transform: "day", sourceType: "timestamptz", input: nil, output: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr, err := parseTransform(test.transform)
+			require.NoError(t, err)
+
+			_, err = tr.resultType(test.sourceType)
+			require.NoError(t, err)
+
+			output, err := tr.apply(test.input, test.sourceType)
+			require.NoError(t, err)
+			assert.Equal(t, test.output, output)
+		})
+	}
+}
+
+func TestPartitionHumanStrings(t *testing.T) {
+	ts := time.Date(2017, 11, 16, 22, 31, 8, 0, time.UTC)
+
+	for transform, exp := range map[string]string{
+		"year":  "2017",
+		"month": "2017-11",
+		"day":   "2017-11-16",
+		"hour":  "2017-11-16-22",
+	} {
+		tr, err := parseTransform(transform)
+		require.NoError(t, err)
+
+		v, err := tr.apply(ts, "timestamptz")
+		require.NoError(t, err)
+		assert.Equal(t, exp, tr.humanString(v), transform)
+	}
+}
+
+func TestPartitionTransformTypes(t *testing.T) {
+	for _, test := range []struct {
+		transform, sourceType string
+	}{
+		{"day", "string"},
+		{"hour", "date"},
+		{"truncate[4]", "double"},
+		{"bucket[4]", "boolean"},
+	} {
+		tr, err := parseTransform(test.transform)
+		require.NoError(t, err)
+
+		_, err = tr.resultType(test.sourceType)
+		assert.Error(t, err, "%v(%v)", test.transform, test.sourceType)
+	}
+
+	for _, s := range []string{"bucket[0]", "bucket", "foo", "truncate[-1]"} {
+		_, err := parseTransform(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParsePartitionExpr(t *testing.T) {
+	tests := []struct {
+		input     string
+		column    string
+		transform string
+		name      string
+		errs      bool
+	}{
+		{input: "region", column: "region", transform: "identity", name: "region"},
+		{input: "day(created_at)", column: "created_at", transform: "day", name: "created_at_day"},
+		{input: "days(created_at)", column: "created_at", transform: "day", name: "created_at_day"},
+		{input: "bucket(16, user.id)", column: "user.id", transform: "bucket[16]", name: "user_id_bucket"},
+		{input: " truncate( 4 ,name ) ", column: "name", transform: "truncate[4]", name: "name_trunc"},
+		{input: "void(foo)", column: "foo", transform: "void", name: "foo_null"},
+		{input: "bucket(user_id)", errs: true},
+		{input: "nope(user_id)", errs: true},
+		{input: "a, b", errs: true},
+		{input: "", errs: true},
+	}
+
+	for _, test := range tests {
+		e, err := parsePartitionExpr(test.input)
+		if test.errs {
+			assert.Error(t, err, test.input)
+			continue
+		}
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.column, e.column, test.input)
+		assert.Equal(t, test.transform, e.transform.String(), test.input)
+		assert.Equal(t, test.name, e.partitionFieldName(), test.input)
+	}
+}
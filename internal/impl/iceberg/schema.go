@@ -0,0 +1,486 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iceberg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+var primitiveTypes = []string{
+	"boolean", "int", "long", "float", "double", "string", "binary", "date", "timestamp", "timestamptz", "uuid",
+}
+
+func findField(fields []*schemaField, name string) *schemaField {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// findFieldByPath returns the field at a path of names through nested structs.
+func findFieldByPath(s *schema, path []string) ([]string, *schemaField) {
+	fields := s.Fields
+	var f *schemaField
+	for i, name := range path {
+		if f = findField(fields, name); f == nil {
+			return nil, nil
+		}
+		if i < len(path)-1 {
+			if f.Type.Struct == nil {
+				return nil, nil
+			}
+			fields = f.Type.Struct.Fields
+		}
+	}
+	return path, f
+}
+
+// findFieldPathByID returns the path of names to a field with a given ID, only
+// searching through nested structs.
+func findFieldPathByID(s *schema, id int) ([]string, *schemaField) {
+	var find func(fields []*schemaField, path []string) ([]string, *schemaField)
+	find = func(fields []*schemaField, path []string) ([]string, *schemaField) {
+		for _, f := range fields {
+			fPath := append(slices.Clone(path), f.Name)
+			if f.ID == id {
+				return fPath, f
+			}
+			if f.Type.Struct != nil {
+				if p, found := find(f.Type.Struct.Fields, fPath); found != nil {
+					return p, found
+				}
+			}
+		}
+		return nil, nil
+	}
+	return find(s.Fields, nil)
+}
+
+func cloneSchema(s *schema) (*schema, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var c schema
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+//------------------------------------------------------------------------------
+
+// schemaEvolver adds columns to a schema for fields of messages that are not
+// yet present, inferring their types from the values of the fields.
+type schemaEvolver struct {
+	ignoreNulls bool
+	processors  []*service.OwnedProcessor
+	ident       tableIdentifier
+}
+
+// evolve returns a new schema that includes all fields of a batch of rows, or
+// nil if the current schema already contains them. Column IDs are assigned
+// beyond the last column ID, and the new last column ID is returned.
+func (e *schemaEvolver) evolve(ctx context.Context, current *schema, lastColumnID int, batch service.MessageBatch, rows []map[string]any) (*schema, int, error) {
+	next, err := cloneSchema(current)
+	if err != nil {
+		return nil, 0, err
+	}
+	root := &structType{Fields: next.Fields}
+
+	var changed bool
+	for i, row := range rows {
+		rowChanged, err := e.evolveStruct(ctx, root, row, nil, batch[i], &lastColumnID)
+		if err != nil {
+			return nil, 0, err
+		}
+		changed = changed || rowChanged
+	}
+	if !changed {
+		return nil, 0, nil
+	}
+
+	next.Fields = root.Fields
+	next.ID = 0
+	return next, lastColumnID, nil
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (e *schemaEvolver) evolveStruct(ctx context.Context, st *structType, obj map[string]any, path []string, msg *service.Message, nextID *int) (changed bool, err error) {
+	for _, k := range sortedKeys(obj) {
+		v := obj[k]
+		fPath := append(slices.Clone(path), k)
+
+		f := findField(st.Fields, k)
+		if f == nil {
+			if v == nil && e.ignoreNulls {
+				continue
+			}
+			t, ok, err := e.inferType(ctx, v, fPath, msg, nextID)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+			*nextID++
+			st.Fields = append(st.Fields, &schemaField{ID: *nextID, Name: k, Type: t})
+			changed = true
+			continue
+		}
+
+		// Existing fields may still contain nested fields that are new.
+		nestedChanged, err := e.evolveNested(ctx, f.Type, v, fPath, msg, nextID)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || nestedChanged
+	}
+	return changed, nil
+}
+
+func (e *schemaEvolver) evolveNested(ctx context.Context, t fieldType, v any, path []string, msg *service.Message, nextID *int) (changed bool, err error) {
+	switch {
+	case t.Struct != nil:
+		if obj, ok := v.(map[string]any); ok {
+			return e.evolveStruct(ctx, t.Struct, obj, path, msg, nextID)
+		}
+	case t.List != nil:
+		arr, _ := v.([]any)
+		for _, ele := range arr {
+			eleChanged, err := e.evolveNested(ctx, t.List.Element, ele, path, msg, nextID)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || eleChanged
+		}
+	}
+	return changed, nil
+}
+
+// inferType returns the type of a new column from its value, or false if the
+// value does not contain enough information to infer a type, such as an empty
+// array.
+func (e *schemaEvolver) inferType(ctx context.Context, v any, path []string, msg *service.Message, nextID *int) (fieldType, bool, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		st := &structType{Fields: []*schemaField{}}
+		if _, err := e.evolveStruct(ctx, st, t, path, msg, nextID); err != nil {
+			return fieldType{}, false, err
+		}
+		return fieldType{Struct: st}, len(st.Fields) > 0, nil
+
+	case []any:
+		var elementType fieldType
+		var inferred bool
+		for _, ele := range t {
+			if ele == nil {
+				continue
+			}
+			if !inferred {
+				var err error
+				if elementType, inferred, err = e.inferType(ctx, ele, path, msg, nextID); err != nil {
+					return fieldType{}, false, err
+				}
+				continue
+			}
+			// Elements after the first may still contribute nested fields.
+			if _, err := e.evolveNested(ctx, elementType, ele, path, msg, nextID); err != nil {
+				return fieldType{}, false, err
+			}
+		}
+		if !inferred {
+			return fieldType{}, false, nil
+		}
+		*nextID++
+		return fieldType{List: &listType{ElementID: *nextID, Element: elementType}}, true, nil
+	}
+
+	primitive, err := e.primitiveType(ctx, v, path, msg)
+	if err != nil {
+		return fieldType{}, false, err
+	}
+	return fieldType{Primitive: primitive}, true, nil
+}
+
+func defaultPrimitiveType(v any) string {
+	switch t := v.(type) {
+	case []byte:
+		return "binary"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "timestamptz"
+	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
+		return "long"
+	case float32, float64:
+		return "double"
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			return "long"
+		}
+		return "double"
+	}
+	return "string"
+}
+
+func (e *schemaEvolver) primitiveType(ctx context.Context, v any, path []string, msg *service.Message) (string, error) {
+	if len(e.processors) == 0 {
+		return defaultPrimitiveType(v), nil
+	}
+
+	msg = msg.Copy()
+	original, err := msg.AsStructuredMut()
+	if err != nil {
+		return "", fmt.Errorf("unable to extract JSON data from message that caused schema evolution: %w", err)
+	}
+	msg.SetError(nil)
+	msg.SetStructuredMut(map[string]any{
+		"name":      strings.Join(path, "."),
+		"value":     v,
+		"message":   original,
+		"namespace": strings.Join(e.ident.Namespace, "."),
+		"table":     e.ident.Name,
+	})
+
+	batches, err := service.ExecuteProcessors(ctx, e.processors, service.MessageBatch{msg})
+	if err != nil {
+		return "", fmt.Errorf("failure to execute %s.%s prior to schema evolution: %w", ioFieldSchemaEvolution, ioFieldSchemaEvolutionProcessors, err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 1 {
+		return "", fmt.Errorf("expected a single message output from %s.%s", ioFieldSchemaEvolution, ioFieldSchemaEvolutionProcessors)
+	}
+	msg = batches[0][0]
+	if err := msg.GetError(); err != nil {
+		return "", fmt.Errorf("message failure executing %s.%s prior to schema evolution: %w", ioFieldSchemaEvolution, ioFieldSchemaEvolutionProcessors, err)
+	}
+
+	b, err := msg.AsBytes()
+	if err != nil {
+		return "", err
+	}
+	columnType := strings.ToLower(strings.TrimSpace(string(b)))
+	if !slices.Contains(primitiveTypes, columnType) {
+		return "", fmt.Errorf("invalid column type %q for new column %v, expected one of %v", columnType, strings.Join(path, "."), primitiveTypes)
+	}
+	return columnType, nil
+}
+
+//------------------------------------------------------------------------------
+
+var errUnknownField = errors.New("field does not exist in the table schema")
+
+// coerceStruct converts the fields of an object into the Go types expected by
+// the parquet writer for a list of columns.
+func coerceStruct(fields []*schemaField, obj map[string]any) (map[string]any, error) {
+	for k, v := range obj {
+		if v != nil && findField(fields, k) == nil {
+			return nil, fmt.Errorf("%v: %w", k, errUnknownField)
+		}
+	}
+
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		v := obj[f.Name]
+		if v == nil {
+			if f.Required {
+				return nil, fmt.Errorf("%v: a value is required", f.Name)
+			}
+			continue
+		}
+		cv, err := coerceValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", f.Name, err)
+		}
+		out[f.Name] = cv
+	}
+	return out, nil
+}
+
+func coerceValue(t fieldType, v any) (any, error) {
+	switch {
+	case t.Struct != nil:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", v)
+		}
+		return coerceStruct(t.Struct.Fields, obj)
+
+	case t.List != nil:
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an array, got %T", v)
+		}
+		out := make([]any, len(arr))
+		for i, ele := range arr {
+			if ele == nil {
+				if t.List.ElementRequired {
+					return nil, fmt.Errorf("[%v]: a value is required", i)
+				}
+				continue
+			}
+			var err error
+			if out[i], err = coerceValue(t.List.Element, ele); err != nil {
+				return nil, fmt.Errorf("[%v]: %w", i, err)
+			}
+		}
+		return out, nil
+
+	case t.Map != nil:
+		return nil, errors.New("map columns are not supported")
+	}
+
+	switch t.Primitive {
+	case "boolean":
+		return bloblang.ValueAsBool(v)
+	case "int":
+		i, err := bloblang.ValueAsInt64(v)
+		if err != nil {
+			return nil, err
+		}
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("value %v overflows int column", i)
+		}
+		return int32(i), nil
+	case "long":
+		return bloblang.ValueAsInt64(v)
+	case "float":
+		return bloblang.ValueAsFloat32(v)
+	case "double":
+		return bloblang.ValueAsFloat64(v)
+	case "string":
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case []byte:
+			return string(s), nil
+		}
+		return bloblang.ValueToString(v), nil
+	case "binary":
+		return bloblang.ValueAsBytes(v)
+	case "date":
+		ts, err := bloblang.ValueAsTimestamp(v)
+		if err != nil {
+			return nil, err
+		}
+		return int32(floorDiv(ts.Unix(), 86400)), nil
+	case "timestamp", "timestamptz":
+		return bloblang.ValueAsTimestamp(v)
+	case "uuid":
+		switch v.(type) {
+		case string, []byte:
+			return v, nil
+		}
+		return nil, fmt.Errorf("expected a uuid string, got %T", v)
+	}
+	return nil, fmt.Errorf("column type %v is not supported", t)
+}
+
+//------------------------------------------------------------------------------
+
+func parquetNode(t fieldType) (parquet.Node, error) {
+	switch {
+	case t.Struct != nil:
+		return parquetGroup(t.Struct.Fields)
+	case t.List != nil:
+		element, err := parquetNode(t.List.Element)
+		if err != nil {
+			return nil, err
+		}
+		element = parquet.FieldID(element, t.List.ElementID)
+		if !t.List.ElementRequired {
+			element = parquet.Optional(element)
+		}
+		return parquet.List(element), nil
+	case t.Map != nil:
+		return nil, errors.New("map columns are not supported")
+	}
+
+	switch t.Primitive {
+	case "boolean":
+		return parquet.Leaf(parquet.BooleanType), nil
+	case "int":
+		return parquet.Int(32), nil
+	case "long":
+		return parquet.Int(64), nil
+	case "float":
+		return parquet.Leaf(parquet.FloatType), nil
+	case "double":
+		return parquet.Leaf(parquet.DoubleType), nil
+	case "string":
+		return parquet.String(), nil
+	case "binary":
+		return parquet.Leaf(parquet.ByteArrayType), nil
+	case "date":
+		return parquet.Date(), nil
+	case "timestamp":
+		return parquet.TimestampAdjusted(parquet.Microsecond, false), nil
+	case "timestamptz":
+		return parquet.TimestampAdjusted(parquet.Microsecond, true), nil
+	case "uuid":
+		return parquet.UUID(), nil
+	}
+	return nil, fmt.Errorf("column type %v is not supported", t)
+}
+
+func parquetGroup(fields []*schemaField) (parquet.Group, error) {
+	group := parquet.Group{}
+	for _, f := range fields {
+		n, err := parquetNode(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", f.Name, err)
+		}
+		n = parquet.FieldID(n, f.ID)
+		if f.Required {
+			n = parquet.Required(n)
+		} else {
+			n = parquet.Optional(n)
+		}
+		group[f.Name] = n
+	}
+	return group, nil
+}
+
+// parquetSchema converts a table schema into a parquet schema where each node
+// carries the ID of the column it belongs to.
+func parquetSchema(s *schema) (*parquet.Schema, error) {
+	group, err := parquetGroup(s.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return parquet.NewSchema("table", group), nil
+}
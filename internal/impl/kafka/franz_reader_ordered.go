@@ -83,6 +83,7 @@ type FranzReaderOrdered struct {
 	readBackOff           backoff.BackOff
 	topicLagRefreshPeriod time.Duration
 	batchMaxSize          uint64
+	retryPolicy           *franzRetryPolicy
 
 	res     *service.Resources
 	log     *service.Logger
@@ -127,17 +128,27 @@ func NewFranzReaderOrderedFromConfig(conf *service.ParsedConfig, res *service.Re
 }
 
 type messageWithRecord struct {
-	m    *service.Message
-	r    *kgo.Record
-	size uint64
+	m         *service.Message
+	r         *kgo.Record
+	size      uint64
+	notBefore time.Time
 }
 
 type batchWithRecords struct {
-	b    []*messageWithRecord
-	size uint64
+	b         []*messageWithRecord
+	size      uint64
+	notBefore time.Time
 }
 
-func recordsToBatch(records []*kgo.Record, consumerLag *ConsumerLag) (batch batchWithRecords) {
+func (b *batchWithRecords) add(m *messageWithRecord) {
+	b.b = append(b.b, m)
+	b.size += m.size
+	if m.notBefore.After(b.notBefore) {
+		b.notBefore = m.notBefore
+	}
+}
+
+func recordsToBatch(records []*kgo.Record, consumerLag *ConsumerLag, retryPolicy *franzRetryPolicy) (batch batchWithRecords) {
 	batch.b = make([]*messageWithRecord, len(records))
 
 	for i, r := range records {
@@ -148,13 +159,24 @@ func recordsToBatch(records []*kgo.Record, consumerLag *ConsumerLag) (batch batc
 		}
 
 		rmsg := &messageWithRecord{
-			m:    msg,
-			r:    r,
-			size: uint64(len(r.Value) + len(r.Key)),
+			m:         msg,
+			r:         r,
+			size:      uint64(len(r.Value) + len(r.Key)),
+			notBefore: retryPolicy.notBefore(r),
 		}
 
 		batch.b[i] = rmsg
 		batch.size += rmsg.size
+		if rmsg.notBefore.After(batch.notBefore) {
+			batch.notBefore = rmsg.notBefore
+		}
+
+		if retryPolicy != nil {
+			// The contents are needed in order to produce the record to a
+			// retry topic should it be rejected.
+			rmsg.m = retryPolicy.withRecord(msg, r)
+			continue
+		}
 
 		// The record lives on for checkpointing, but we don't need the contents
 		// going forward so discard these. This looked fine to me but could
@@ -205,8 +227,7 @@ func (p *partitionCache) push(bufferSize, maxBatchSize uint64, batch *batchWithR
 				break
 			}
 
-			p.cache[indexEnd].b = append(p.cache[indexEnd].b, batch.b[0])
-			p.cache[indexEnd].size += nextMsgSize
+			p.cache[indexEnd].add(batch.b[0])
 
 			batch.b = batch.b[1:]
 			batch.size -= nextMsgSize
@@ -227,8 +248,7 @@ func (p *partitionCache) push(bufferSize, maxBatchSize uint64, batch *batchWithR
 				break
 			}
 
-			tmpBatch.b = append(tmpBatch.b, batch.b[0])
-			tmpBatch.size += nextMsgSize
+			tmpBatch.add(batch.b[0])
 
 			batch.b = batch.b[1:]
 			batch.size -= nextMsgSize
@@ -254,6 +274,12 @@ func (p *partitionCache) pop() *batchWithAckFn {
 		return nil
 	}
 
+	// Records consumed from retry topics are held back until their delay has
+	// passed.
+	if time.Now().Before(p.cache[0].notBefore) {
+		return nil
+	}
+
 	nextBatch := p.cache[0]
 	p.cache = p.cache[1:]
 
@@ -543,7 +569,7 @@ func (f *FranzReaderOrdered) Connect(ctx context.Context) error {
 					return
 				}
 
-				batch := recordsToBatch(p.Records, consumerLag)
+				batch := recordsToBatch(p.Records, consumerLag, f.retryPolicy)
 				if len(batch.b) == 0 {
 					return
 				}
@@ -605,8 +631,14 @@ func (f *FranzReaderOrdered) ReadBatch(ctx context.Context) (service.MessageBatc
 	for {
 		if mAck := f.partState.pop(); mAck != nil {
 			f.readBackOff.Reset()
-			return mAck.batch, func(context.Context, error) error {
-				// Res will always be nil because we initialize with service.AutoRetryNacks
+			return mAck.batch, func(_ context.Context, err error) error {
+				// Without a retry policy res will always be nil because we
+				// initialize with service.AutoRetryNacks
+				if err != nil && f.retryPolicy != nil {
+					if rerr := f.retryPolicy.rehome(f.shutSig, f.Client, mAck.batch, err); rerr != nil {
+						return rerr
+					}
+				}
 				mAck.onAck()
 				return nil
 			}, nil
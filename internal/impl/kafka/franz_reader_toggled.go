@@ -46,6 +46,7 @@ func FranzReaderToggledConfigFields() []*service.ConfigField {
 		).
 			Description("Configures partition consumers to allow parallel and therefore unordered processing of messages of any given partition. This allows for better utilization of processing threads and asynchronous publishing at the output level. The maximum parallelization of each partition is determined by the checkpoint_limit field.").
 			Advanced(),
+		FranzRetryPolicyConfigField(),
 	)
 }
 
@@ -59,6 +60,12 @@ func NewFranzReaderToggledFromConfig(conf *service.ParsedConfig, res *service.Re
 	if err != nil {
 		return nil, err
 	}
+
+	retryPolicy, err := franzRetryPolicyFromConfig(conf, res.Logger())
+	if err != nil {
+		return nil, err
+	}
+
 	if unordered {
 		f := FranzReaderUnordered{
 			res:     res,
@@ -67,6 +74,7 @@ func NewFranzReaderToggledFromConfig(conf *service.ParsedConfig, res *service.Re
 
 			clientOpts:         optsFn,
 			franzRecordToMsgFn: FranzRecordToMessageV1,
+			retryPolicy:        retryPolicy,
 		}
 
 		var err error
@@ -91,5 +99,10 @@ func NewFranzReaderToggledFromConfig(conf *service.ParsedConfig, res *service.Re
 		return &f, nil
 	}
 
-	return NewFranzReaderOrderedFromConfig(conf, res, optsFn)
+	f, err := NewFranzReaderOrderedFromConfig(conf, res, optsFn)
+	if err != nil {
+		return nil, err
+	}
+	f.retryPolicy = retryPolicy
+	return f, nil
 }
@@ -0,0 +1,280 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	krtxFieldTransactional        = "transactional"
+	krtxFieldTransactionalEnabled = "enabled"
+	krtxFieldTransactionalID      = "transactional_id"
+	krtxFieldTransactionalTimeout = "transaction_timeout"
+)
+
+// FranzReaderTransactionalConfigField returns a config field for enabling
+// exactly-once read-process-write pipelines, where consumer offsets are
+// committed within the transactions of an output sharing the client.
+func FranzReaderTransactionalConfigField() *service.ConfigField {
+	return service.NewObjectField(krtxFieldTransactional,
+		service.NewBoolField(krtxFieldTransactionalEnabled).
+			Description("Whether to consume within transactions.").
+			Default(false),
+		service.NewStringField(krtxFieldTransactionalID).
+			Description("The transactional ID of the producer. This ID must be unique to each instance of a pipeline, and must remain the same across restarts of that instance so that transactions left open by a prior instance are fenced off.").
+			Default(""),
+		service.NewDurationField(krtxFieldTransactionalTimeout).
+			Description("The maximum period of time that a transaction can remain open before it is aborted by the broker. This must be longer than the time it takes to process and deliver a batch.").
+			Default("1m"),
+	).
+		Description("Configures the input to consume within transactions, where the offsets of each batch are committed in the same transaction as the records produced by a `redpanda` output that references this input with its `transactional_input` field. When enabled a consumer group is required, and only one batch is processed at a time: the transaction is committed once the batch is delivered and aborted when it is rejected, in which case the batch is consumed again. This provides exactly-once delivery for pipelines that read from and write to Kafka, provided that downstream consumers read with the `read_committed` isolation level.").
+		Advanced().
+		Version("4.73.0")
+}
+
+// FranzReaderTransactional implements a kafka reader that consumes each batch
+// within a transaction, where the transactional client is shared with an
+// output in order to produce records within the same transaction.
+type FranzReaderTransactional struct {
+	clientOpts func() ([]kgo.Opt, error)
+	clientName string
+
+	consumerGroup         string
+	transactionalID       string
+	transactionTimeout    time.Duration
+	topicLagRefreshPeriod time.Duration
+
+	sessMut     sync.Mutex
+	session     *kgo.GroupTransactSession
+	consumerLag *ConsumerLag
+
+	// A token is held for the lifetime of each transaction, so that batches
+	// are consumed one at a time.
+	inFlight chan struct{}
+
+	res *service.Resources
+	log *service.Logger
+}
+
+// NewFranzReaderTransactionalFromConfig attempts to instantiate a new
+// FranzReaderTransactional reader from a parsed config. The client is shared
+// under the label of the input so that outputs can produce within its
+// transactions.
+func NewFranzReaderTransactionalFromConfig(conf *service.ParsedConfig, res *service.Resources, optsFn func() ([]kgo.Opt, error)) (*FranzReaderTransactional, error) {
+	f := FranzReaderTransactional{
+		clientOpts: optsFn,
+		clientName: res.Label(),
+		inFlight:   make(chan struct{}, 1),
+		res:        res,
+		log:        res.Logger(),
+	}
+	f.consumerGroup, _ = conf.FieldString(kroFieldConsumerGroup)
+	if f.consumerGroup == "" {
+		return nil, errors.New("a consumer group is required in order to consume within transactions")
+	}
+
+	var err error
+	if f.topicLagRefreshPeriod, err = conf.FieldDuration(kroFieldTopicLagRefreshPeriod); err != nil {
+		return nil, err
+	}
+
+	txnConf := conf.Namespace(krtxFieldTransactional)
+	if f.transactionalID, err = txnConf.FieldString(krtxFieldTransactionalID); err != nil {
+		return nil, err
+	}
+	if f.transactionalID == "" {
+		return nil, errors.New("a transactional_id is required in order to consume within transactions")
+	}
+	if f.transactionTimeout, err = txnConf.FieldDuration(krtxFieldTransactionalTimeout); err != nil {
+		return nil, err
+	}
+
+	if f.clientName == "" {
+		return nil, errors.New("a label must be set on inputs that consume within transactions in order for outputs to reference them")
+	}
+	return &f, nil
+}
+
+// Connect to the kafka seed brokers.
+func (f *FranzReaderTransactional) Connect(ctx context.Context) error {
+	f.sessMut.Lock()
+	defer f.sessMut.Unlock()
+
+	if f.session != nil {
+		return nil
+	}
+
+	clientOpts, err := f.clientOpts()
+	if err != nil {
+		return err
+	}
+	clientOpts = append(clientOpts,
+		kgo.ConsumerGroup(f.consumerGroup),
+		kgo.TransactionalID(f.transactionalID),
+		kgo.TransactionTimeout(f.transactionTimeout),
+		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
+		kgo.RequireStableFetchOffsets(),
+		kgo.DisableAutoCommit(),
+		kgo.WithLogger(&KGoLogger{f.log}),
+	)
+
+	session, err := kgo.NewGroupTransactSession(clientOpts...)
+	if err != nil {
+		return err
+	}
+	if err := session.Client().Ping(ctx); err != nil {
+		session.Close()
+		return err
+	}
+
+	if err := FranzSharedClientSet(f.clientName, &FranzSharedClientInfo{
+		Client: session.Client(),
+	}, f.res); err != nil {
+		session.Close()
+		return err
+	}
+
+	topicLagGauge := f.res.Metrics().NewGauge("redpanda_lag", "topic", "partition")
+	f.consumerLag = NewConsumerLag(session.Client(), f.consumerGroup, f.log, topicLagGauge, f.topicLagRefreshPeriod)
+	f.consumerLag.Start()
+
+	f.session = session
+	return nil
+}
+
+func (f *FranzReaderTransactional) getSession() *kgo.GroupTransactSession {
+	f.sessMut.Lock()
+	defer f.sessMut.Unlock()
+	return f.session
+}
+
+// disconnect requires the session lock to be held.
+func (f *FranzReaderTransactional) disconnect() {
+	if f.session == nil {
+		return
+	}
+	_, _ = FranzSharedClientPop(f.clientName, f.res)
+	f.consumerLag.Stop()
+	f.session.Close()
+	f.session = nil
+}
+
+// endTransaction commits the transaction of a batch when it was delivered and
+// otherwise aborts it, in which case the session rewinds to the last committed
+// offsets and the batch is consumed again.
+func (f *FranzReaderTransactional) endTransaction(ctx context.Context, session *kgo.GroupTransactSession, deliveryErr error) {
+	defer func() { <-f.inFlight }()
+
+	tryCommit := kgo.TryCommit
+	if deliveryErr != nil {
+		f.log.Warnf("Aborting transaction after failing to deliver batch: %v", deliveryErr)
+		tryCommit = kgo.TryAbort
+	}
+
+	committed, err := session.End(ctx, tryCommit)
+	if err != nil {
+		// Errors from ending a transaction are not retryable, and therefore
+		// we reset the client in order to start from the last commit.
+		f.log.Errorf("Failed to end transaction, reconnecting: %v", err)
+
+		f.sessMut.Lock()
+		if f.session == session {
+			f.disconnect()
+		}
+		f.sessMut.Unlock()
+		return
+	}
+	if deliveryErr == nil && !committed {
+		f.log.Warn("Transaction was aborted due to a consumer group rebalance, records will be consumed again")
+	}
+}
+
+// ReadBatch attempts to extract a batch of messages from the target topics,
+// beginning a transaction that lasts until the batch is acknowledged.
+func (f *FranzReaderTransactional) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	select {
+	case f.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	session := f.getSession()
+	if session == nil {
+		<-f.inFlight
+		return nil, nil, service.ErrNotConnected
+	}
+
+	for {
+		fetches := session.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			for _, kerr := range errs {
+				if errors.Is(kerr.Err, context.DeadlineExceeded) || errors.Is(kerr.Err, context.Canceled) {
+					continue
+				}
+				if errors.Is(kerr.Err, kgo.ErrClientClosed) {
+					<-f.inFlight
+					return nil, nil, service.ErrNotConnected
+				}
+				f.log.Errorf("Kafka poll error on topic %v, partition %v: %v", kerr.Topic, kerr.Partition, kerr.Err)
+			}
+		}
+		if ctx.Err() != nil {
+			<-f.inFlight
+			return nil, nil, ctx.Err()
+		}
+
+		var batch service.MessageBatch
+		fetches.EachRecord(func(r *kgo.Record) {
+			msg := FranzRecordToMessageV1(r)
+			msg.MetaSetMut("kafka_lag", f.consumerLag.Load(r.Topic, r.Partition))
+			batch = append(batch, msg)
+		})
+		if len(batch) == 0 {
+			continue
+		}
+
+		if err := session.Begin(); err != nil {
+			<-f.inFlight
+			f.sessMut.Lock()
+			if f.session == session {
+				f.disconnect()
+			}
+			f.sessMut.Unlock()
+			return nil, nil, err
+		}
+
+		return batch, func(ctx context.Context, err error) error {
+			f.endTransaction(ctx, session, err)
+			return nil
+		}, nil
+	}
+}
+
+// Close underlying connections.
+func (f *FranzReaderTransactional) Close(context.Context) error {
+	f.sessMut.Lock()
+	defer f.sessMut.Unlock()
+
+	f.disconnect()
+	return nil
+}
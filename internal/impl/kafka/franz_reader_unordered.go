@@ -92,6 +92,11 @@ type FranzReaderUnordered struct {
 	commitPeriod          time.Duration
 	batchPolicy           service.BatchPolicy
 	topicLagRefreshPeriod time.Duration
+	retryPolicy           *franzRetryPolicy
+
+	// The client is only accessed after the batch channel is stored, and is
+	// used to produce rejected records to retry topics.
+	client *kgo.Client
 
 	batchChan atomic.Value
 	res       *service.Resources
@@ -162,6 +167,15 @@ func (f *FranzReaderUnordered) recordToMessage(record *kgo.Record, consumerLag *
 		msg.MetaSetMut("kafka_lag", lag)
 	}
 
+	if f.retryPolicy != nil {
+		// The contents are needed in order to produce the record to a retry
+		// topic should it be rejected.
+		return &msgWithRecord{
+			msg: f.retryPolicy.withRecord(msg, record),
+			r:   record,
+		}
+	}
+
 	// The record lives on for checkpointing, but we don't need the contents
 	// going forward so discard these. This looked fine to me but could
 	// potentially be a source of problems so treat this as sus.
@@ -484,6 +498,7 @@ func (f *FranzReaderUnordered) Connect(ctx context.Context) error {
 		}
 	}
 	checkpoints := newCheckpointTracker(f.res, batchChan, commitFn, f.batchPolicy)
+	delayed := newDelayedRecords()
 
 	clientOpts, err := f.clientOpts()
 	if err != nil {
@@ -497,10 +512,12 @@ func (f *FranzReaderUnordered) Connect(ctx context.Context) error {
 					f.log.Errorf("Commit error on partition revoke: %v", commitErr)
 				}
 				checkpoints.removeTopicPartitions(rctx, m)
+				delayed.removeTopicPartitions(m)
 			}),
 			kgo.OnPartitionsLost(func(rctx context.Context, _ *kgo.Client, m map[string][]int32) {
 				// No point trying to commit our offsets, just clean up our topic map
 				checkpoints.removeTopicPartitions(rctx, m)
+				delayed.removeTopicPartitions(m)
 			}),
 			kgo.ConsumerGroup(f.consumerGroup),
 			kgo.AutoCommitMarks(),
@@ -588,12 +605,29 @@ func (f *FranzReaderUnordered) Connect(ctx context.Context) error {
 			}
 
 			pauseTopicPartitions := map[string][]int32{}
+			addRecord := func(record *kgo.Record) {
+				if checkpoints.addRecord(closeCtx, f.recordToMessage(record, consumerLag), f.checkpointLimit) {
+					pauseTopicPartitions[record.Topic] = append(pauseTopicPartitions[record.Topic], record.Partition)
+				}
+			}
+
+			// Records of retry topics that were held back are added once their
+			// delay has passed.
+			for _, record := range delayed.release() {
+				addRecord(record)
+			}
+
 			iter := fetches.RecordIter()
 			for !iter.Done() {
 				record := iter.Next()
-				if checkpoints.addRecord(closeCtx, f.recordToMessage(record, consumerLag), f.checkpointLimit) {
+
+				// Records consumed from retry topics are held back until their
+				// delay has passed, and their partition is paused meanwhile.
+				if delayed.hold(record, f.retryPolicy.notBefore(record)) {
 					pauseTopicPartitions[record.Topic] = append(pauseTopicPartitions[record.Topic], record.Partition)
+					continue
 				}
+				addRecord(record)
 			}
 
 			// Walk all the disabled topic partitions and check whether any of
@@ -601,6 +635,9 @@ func (f *FranzReaderUnordered) Connect(ctx context.Context) error {
 			resumeTopicPartitions := map[string][]int32{}
 			for pausedTopic, pausedPartitions := range cl.PauseFetchPartitions(pauseTopicPartitions) {
 				for _, pausedPartition := range pausedPartitions {
+					if delayed.holding(pausedTopic, pausedPartition) {
+						continue
+					}
 					if !checkpoints.pauseFetch(pausedTopic, pausedPartition, f.checkpointLimit) {
 						resumeTopicPartitions[pausedTopic] = append(resumeTopicPartitions[pausedTopic], pausedPartition)
 					}
@@ -612,6 +649,7 @@ func (f *FranzReaderUnordered) Connect(ctx context.Context) error {
 		}
 	}()
 
+	f.client = cl
 	f.storeBatchChan(batchChan)
 	return nil
 }
@@ -634,8 +672,15 @@ func (f *FranzReaderUnordered) ReadBatch(ctx context.Context) (service.MessageBa
 		return nil, nil, ctx.Err()
 	}
 
-	return mAck.batch, func(context.Context, error) error {
-		// Res will always be nil because we initialize with service.AutoRetryNacks
+	client := f.client
+	return mAck.batch, func(_ context.Context, err error) error {
+		// Without a retry policy res will always be nil because we initialize
+		// with service.AutoRetryNacks
+		if err != nil && f.retryPolicy != nil {
+			if rerr := f.retryPolicy.rehome(f.shutSig, client, mAck.batch, err); rerr != nil {
+				return rerr
+			}
+		}
 		mAck.onAck()
 		return nil
 	}, nil
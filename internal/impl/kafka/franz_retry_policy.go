@@ -0,0 +1,385 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/shutdown"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	frpField                = "retry_policy"
+	frpFieldEnabled         = "enabled"
+	frpFieldRetryTopics     = "retry_topics"
+	frpFieldRetryTopic      = "topic"
+	frpFieldRetryDelay      = "delay"
+	frpFieldDeadLetterTopic = "dead_letter_topic"
+
+	// Headers added to records that are produced to retry and dead letter
+	// topics.
+	frpHeaderAttempt           = "retry_attempt"
+	frpHeaderNotBefore         = "retry_not_before_ms"
+	frpHeaderError             = "retry_error"
+	frpHeaderOriginalTopic     = "retry_original_topic"
+	frpHeaderOriginalPartition = "retry_original_partition"
+	frpHeaderOriginalOffset    = "retry_original_offset"
+)
+
+// FranzRetryPolicyConfigField returns a config field for routing rejected
+// records to retry and dead letter topics.
+func FranzRetryPolicyConfigField() *service.ConfigField {
+	return service.NewObjectField(frpField,
+		service.NewBoolField(frpFieldEnabled).
+			Description("Whether rejected records are produced to retry and dead letter topics.").
+			Default(false),
+		service.NewObjectListField(frpFieldRetryTopics,
+			service.NewStringField(frpFieldRetryTopic).
+				Description("The topic to produce rejected records to."),
+			service.NewDurationField(frpFieldRetryDelay).
+				Description("The period of time to wait before records of the topic are processed again."),
+		).
+			Description("An ordered list of retry topics, where a record rejected for the first time is produced to the first topic, a record consumed from the first topic that is rejected again is produced to the second, and so on.").
+			Default([]any{}),
+		service.NewStringField(frpFieldDeadLetterTopic).
+			Description("The topic to produce records to that have been rejected by every retry topic, which must be set when the retry policy is enabled.").
+			Default(""),
+	).
+		Description("Routes batches that are rejected at the output level to retry topics, and ultimately a dead letter topic, rather than reattempting their delivery. The offsets of rejected records are committed once they have been produced, and so a poison record never blocks the consumption of its partition. Retry topics must also be consumed by this input in order for their records to be processed again.").
+		Optional().
+		Advanced()
+}
+
+type franzRetryTopic struct {
+	topic string
+	delay time.Duration
+}
+
+// franzRetryPolicy routes rejected records to retry and dead letter topics.
+type franzRetryPolicy struct {
+	retryTopics     []franzRetryTopic
+	deadLetterTopic string
+
+	log     *service.Logger
+	nowFn   func() time.Time
+	backOff func() backoff.BackOff
+}
+
+// franzRetryPolicyFromConfig returns a retry policy from a parsed config, or
+// nil when the policy is not enabled.
+func franzRetryPolicyFromConfig(conf *service.ParsedConfig, log *service.Logger) (*franzRetryPolicy, error) {
+	if !conf.Contains(frpField) {
+		return nil, nil
+	}
+	conf = conf.Namespace(frpField)
+
+	enabled, err := conf.FieldBool(frpFieldEnabled)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	p := &franzRetryPolicy{
+		log:   log,
+		nowFn: time.Now,
+		backOff: func() backoff.BackOff {
+			boff := backoff.NewExponentialBackOff()
+			boff.InitialInterval = time.Millisecond * 100
+			boff.MaxInterval = time.Second * 5
+			boff.MaxElapsedTime = 0
+			return boff
+		},
+	}
+
+	topicConfs, err := conf.FieldObjectList(frpFieldRetryTopics)
+	if err != nil {
+		return nil, err
+	}
+	for _, tc := range topicConfs {
+		var t franzRetryTopic
+		if t.topic, err = tc.FieldString(frpFieldRetryTopic); err != nil {
+			return nil, err
+		}
+		if t.delay, err = tc.FieldDuration(frpFieldRetryDelay); err != nil {
+			return nil, err
+		}
+		p.retryTopics = append(p.retryTopics, t)
+	}
+
+	if p.deadLetterTopic, err = conf.FieldString(frpFieldDeadLetterTopic); err != nil {
+		return nil, err
+	}
+	if p.deadLetterTopic == "" {
+		return nil, errors.New("a dead letter topic must be specified")
+	}
+	return p, nil
+}
+
+type frpRecordKey struct{}
+
+// withRecord stores the record of a message in its context so that it can be
+// produced to a retry topic when rejected.
+func (p *franzRetryPolicy) withRecord(msg *service.Message, r *kgo.Record) *service.Message {
+	return msg.WithContext(context.WithValue(msg.Context(), frpRecordKey{}, r))
+}
+
+// notBefore returns the time until which a record consumed from a retry topic
+// must not be processed, which is zero when the policy is nil.
+func (p *franzRetryPolicy) notBefore(r *kgo.Record) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	for _, h := range r.Headers {
+		if h.Key != frpHeaderNotBefore {
+			continue
+		}
+		if ms, err := strconv.ParseInt(string(h.Value), 10, 64); err == nil {
+			return time.UnixMilli(ms)
+		}
+	}
+	return time.Time{}
+}
+
+// delayedRecords holds back the records of retry topics until their delay has
+// passed. The partition of a held record is paused, and any later records of
+// that partition are held behind it, so that the records of other partitions
+// continue to be consumed.
+type delayedRecords struct {
+	mut        sync.Mutex
+	partitions map[string]map[int32][]delayedRecord
+}
+
+type delayedRecord struct {
+	r         *kgo.Record
+	notBefore time.Time
+}
+
+func newDelayedRecords() *delayedRecords {
+	return &delayedRecords{partitions: map[string]map[int32][]delayedRecord{}}
+}
+
+// hold returns true if a record must be held back, either because its delay has
+// not yet passed or because earlier records of its partition are being held.
+func (d *delayedRecords) hold(r *kgo.Record, notBefore time.Time) bool {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	held := d.partitions[r.Topic][r.Partition]
+	if len(held) == 0 && !time.Now().Before(notBefore) {
+		return false
+	}
+	if d.partitions[r.Topic] == nil {
+		d.partitions[r.Topic] = map[int32][]delayedRecord{}
+	}
+	d.partitions[r.Topic][r.Partition] = append(held, delayedRecord{r: r, notBefore: notBefore})
+	return true
+}
+
+// release returns the held records whose delay has passed, in the order that
+// they were consumed within each partition.
+func (d *delayedRecords) release() (records []*kgo.Record) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	now := time.Now()
+	for topic, partitions := range d.partitions {
+		for partition, held := range partitions {
+			for len(held) > 0 && !now.Before(held[0].notBefore) {
+				records = append(records, held[0].r)
+				held = held[1:]
+			}
+			if len(held) == 0 {
+				delete(partitions, partition)
+			} else {
+				partitions[partition] = held
+			}
+		}
+		if len(partitions) == 0 {
+			delete(d.partitions, topic)
+		}
+	}
+	return
+}
+
+// holding returns true if any records of a partition are being held.
+func (d *delayedRecords) holding(topic string, partition int32) bool {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return len(d.partitions[topic][partition]) > 0
+}
+
+// removeTopicPartitions drops the held records of partitions that are no
+// longer assigned to this consumer.
+func (d *delayedRecords) removeTopicPartitions(m map[string][]int32) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	for topic, lostPartitions := range m {
+		partitions, exists := d.partitions[topic]
+		if !exists {
+			continue
+		}
+		for _, partition := range lostPartitions {
+			delete(partitions, partition)
+		}
+		if len(partitions) == 0 {
+			delete(d.partitions, topic)
+		}
+	}
+}
+
+func frpHeaderValue(r *kgo.Record, key string) (string, bool) {
+	for _, h := range r.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// reroute returns a copy of a rejected record to be produced to the next
+// retry topic, or the dead letter topic once all retry topics are exhausted.
+func (p *franzRetryPolicy) reroute(r *kgo.Record, cause error) *kgo.Record {
+	var attempt int
+	if v, ok := frpHeaderValue(r, frpHeaderAttempt); ok {
+		attempt, _ = strconv.Atoi(v)
+	}
+
+	out := &kgo.Record{
+		Key:   r.Key,
+		Value: r.Value,
+	}
+	for _, h := range r.Headers {
+		switch h.Key {
+		case frpHeaderAttempt, frpHeaderNotBefore, frpHeaderError:
+			continue
+		}
+		out.Headers = append(out.Headers, h)
+	}
+
+	// The origin of a record is retained across retry topics.
+	if _, ok := frpHeaderValue(r, frpHeaderOriginalTopic); !ok {
+		out.Headers = append(out.Headers,
+			kgo.RecordHeader{Key: frpHeaderOriginalTopic, Value: []byte(r.Topic)},
+			kgo.RecordHeader{Key: frpHeaderOriginalPartition, Value: []byte(strconv.Itoa(int(r.Partition)))},
+			kgo.RecordHeader{Key: frpHeaderOriginalOffset, Value: []byte(strconv.FormatInt(r.Offset, 10))},
+		)
+	}
+	if cause != nil {
+		out.Headers = append(out.Headers, kgo.RecordHeader{Key: frpHeaderError, Value: []byte(cause.Error())})
+	}
+
+	if attempt < len(p.retryTopics) {
+		next := p.retryTopics[attempt]
+		out.Topic = next.topic
+		out.Headers = append(out.Headers,
+			kgo.RecordHeader{Key: frpHeaderAttempt, Value: []byte(strconv.Itoa(attempt + 1))},
+			kgo.RecordHeader{Key: frpHeaderNotBefore, Value: []byte(strconv.FormatInt(p.nowFn().Add(next.delay).UnixMilli(), 10))},
+		)
+		return out
+	}
+
+	out.Topic = p.deadLetterTopic
+	out.Headers = append(out.Headers, kgo.RecordHeader{Key: frpHeaderAttempt, Value: []byte(strconv.Itoa(attempt))})
+	return out
+}
+
+// frpRecordFromMessage creates a record from a message that does not carry
+// the record it was consumed from, which can happen when messages are created
+// by batch processors.
+func frpRecordFromMessage(msg *service.Message) (*kgo.Record, error) {
+	value, err := msg.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+	r := &kgo.Record{Value: value, Headers: ExtractHeaders(msg)}
+	if key, ok := msg.MetaGetMut("kafka_key"); ok {
+		if b, ok := key.([]byte); ok {
+			r.Key = b
+		}
+	}
+	r.Topic, _ = msg.MetaGet("kafka_topic")
+	if v, ok := msg.MetaGetMut("kafka_partition"); ok {
+		if partition, ok := v.(int); ok {
+			r.Partition = int32(partition)
+		}
+	}
+	if v, ok := msg.MetaGetMut("kafka_offset"); ok {
+		if offset, ok := v.(int); ok {
+			r.Offset = int64(offset)
+		}
+	}
+	return r, nil
+}
+
+// rehome produces the records of a rejected batch to the next retry topic or
+// the dead letter topic, reattempting until every record has been produced or
+// the reader is shut down.
+func (p *franzRetryPolicy) rehome(shutSig *shutdown.Signaller, client *kgo.Client, batch service.MessageBatch, cause error) error {
+	if client == nil {
+		return service.ErrNotConnected
+	}
+
+	pending := make([]*kgo.Record, 0, len(batch))
+	for _, msg := range batch {
+		r, ok := msg.Context().Value(frpRecordKey{}).(*kgo.Record)
+		if !ok {
+			var err error
+			if r, err = frpRecordFromMessage(msg); err != nil {
+				return err
+			}
+		}
+		pending = append(pending, p.reroute(r, cause))
+	}
+
+	ctx, done := shutSig.HardStopCtx(context.Background())
+	defer done()
+
+	boff := p.backOff()
+	for {
+		var failed []*kgo.Record
+		var firstErr error
+		for _, res := range client.ProduceSync(ctx, pending...) {
+			if res.Err == nil {
+				continue
+			}
+			if errors.Is(res.Err, kgo.ErrClientClosed) {
+				return res.Err
+			}
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+			failed = append(failed, res.Record)
+		}
+		if len(failed) == 0 {
+			return nil
+		}
+
+		p.log.Errorf("Failed to produce %v rejected records to retry topics: %v", len(failed), firstErr)
+		pending = failed
+		select {
+		case <-time.After(boff.NextBackOff()):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
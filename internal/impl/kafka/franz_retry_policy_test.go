@@ -0,0 +1,181 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func frpHeaderMap(r *kgo.Record) map[string]string {
+	m := map[string]string{}
+	for _, h := range r.Headers {
+		m[h.Key] = string(h.Value)
+	}
+	return m
+}
+
+func TestFranzRetryPolicyReroute(t *testing.T) {
+	now := time.UnixMilli(1_000_000)
+	p := &franzRetryPolicy{
+		retryTopics: []franzRetryTopic{
+			{topic: "foo_retry_1", delay: time.Minute},
+			{topic: "foo_retry_2", delay: time.Hour},
+		},
+		deadLetterTopic: "foo_dlq",
+		nowFn:           func() time.Time { return now },
+	}
+
+	r := &kgo.Record{
+		Topic:     "foo",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []kgo.RecordHeader{{Key: "custom", Value: []byte("bar")}},
+	}
+
+	r = p.reroute(r, errors.New("first"))
+	assert.Equal(t, "foo_retry_1", r.Topic)
+	assert.Equal(t, "key", string(r.Key))
+	assert.Equal(t, "value", string(r.Value))
+	assert.Equal(t, map[string]string{
+		"custom":                   "bar",
+		"retry_original_topic":     "foo",
+		"retry_original_partition": "3",
+		"retry_original_offset":    "42",
+		"retry_error":              "first",
+		"retry_attempt":            "1",
+		"retry_not_before_ms":      "1060000",
+	}, frpHeaderMap(r))
+	assert.Equal(t, now.Add(time.Minute), p.notBefore(r))
+
+	r.Partition, r.Offset = 0, 7
+	r = p.reroute(r, errors.New("second"))
+	assert.Equal(t, "foo_retry_2", r.Topic)
+	assert.Equal(t, map[string]string{
+		"custom":                   "bar",
+		"retry_original_topic":     "foo",
+		"retry_original_partition": "3",
+		"retry_original_offset":    "42",
+		"retry_error":              "second",
+		"retry_attempt":            "2",
+		"retry_not_before_ms":      "4600000",
+	}, frpHeaderMap(r))
+
+	r = p.reroute(r, errors.New("third"))
+	assert.Equal(t, "foo_dlq", r.Topic)
+	assert.Equal(t, map[string]string{
+		"custom":                   "bar",
+		"retry_original_topic":     "foo",
+		"retry_original_partition": "3",
+		"retry_original_offset":    "42",
+		"retry_error":              "third",
+		"retry_attempt":            "2",
+	}, frpHeaderMap(r))
+	assert.True(t, p.notBefore(r).IsZero())
+}
+
+func TestFranzRetryPolicyNotBefore(t *testing.T) {
+	var nilPolicy *franzRetryPolicy
+	r := &kgo.Record{Headers: []kgo.RecordHeader{{Key: frpHeaderNotBefore, Value: []byte("1500")}}}
+	assert.True(t, nilPolicy.notBefore(r).IsZero())
+
+	p := &franzRetryPolicy{}
+	assert.Equal(t, time.UnixMilli(1500), p.notBefore(r))
+	assert.True(t, p.notBefore(&kgo.Record{Headers: []kgo.RecordHeader{{Key: frpHeaderNotBefore, Value: []byte("nope")}}}).IsZero())
+}
+
+func TestFranzRetryPolicyDelayedRecords(t *testing.T) {
+	d := newDelayedRecords()
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+
+	delayedA := &kgo.Record{Topic: "foo", Partition: 0, Offset: 1}
+	behindA := &kgo.Record{Topic: "foo", Partition: 0, Offset: 2}
+	otherPartition := &kgo.Record{Topic: "foo", Partition: 1, Offset: 1}
+
+	// Records of a partition are held behind a delayed record, whereas other
+	// partitions are unaffected.
+	assert.True(t, d.hold(delayedA, future))
+	assert.True(t, d.hold(behindA, past))
+	assert.False(t, d.hold(otherPartition, past))
+	assert.True(t, d.holding("foo", 0))
+	assert.False(t, d.holding("foo", 1))
+	assert.Empty(t, d.release())
+
+	d.partitions["foo"][0][0].notBefore = past
+	assert.Equal(t, []*kgo.Record{delayedA, behindA}, d.release())
+	assert.False(t, d.holding("foo", 0))
+
+	assert.True(t, d.hold(delayedA, future))
+	d.removeTopicPartitions(map[string][]int32{"foo": {0}})
+	assert.False(t, d.holding("foo", 0))
+	assert.Empty(t, d.release())
+}
+
+func TestFranzRetryPolicyConfig(t *testing.T) {
+	spec := service.NewConfigSpec().Field(FranzRetryPolicyConfigField())
+
+	for _, test := range []struct {
+		name       string
+		config     string
+		enabled    bool
+		errContain string
+	}{
+		{name: "absent", config: `{}`},
+		{name: "disabled", config: `
+retry_policy:
+  enabled: false
+`},
+		{name: "enabled", config: `
+retry_policy:
+  enabled: true
+  retry_topics:
+    - topic: foo_retry
+      delay: 1m
+  dead_letter_topic: foo_dlq
+`, enabled: true},
+		{name: "missing dead letter topic", config: `
+retry_policy:
+  enabled: true
+`, errContain: "dead letter topic"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := spec.ParseYAML(test.config, nil)
+			require.NoError(t, err)
+
+			p, err := franzRetryPolicyFromConfig(conf, nil)
+			if test.errContain != "" {
+				require.ErrorContains(t, err, test.errContain)
+				return
+			}
+			require.NoError(t, err)
+			if !test.enabled {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			assert.Equal(t, []franzRetryTopic{{topic: "foo_retry", delay: time.Minute}}, p.retryTopics)
+			assert.Equal(t, "foo_dlq", p.deadLetterTopic)
+		})
+	}
+}
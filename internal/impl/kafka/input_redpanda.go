@@ -15,6 +15,7 @@
 package kafka
 
 import (
+	"errors"
 	"slices"
 	"time"
 
@@ -58,6 +59,58 @@ output:
             topic: foo_dlq
 ` + "```" + `
 
+== Retry Topics
+
+As an alternative to fallback outputs the field ` + "`retry_policy`" + ` can be used in order to route batches that are rejected at the output level to a tiered list of retry topics, followed by a dead letter topic once every retry topic has been attempted. Records are produced with the following headers, and the offsets of the rejected records are committed once they have been produced, which means that a poison record never blocks the consumption of its partition:
+
+` + "```text" + `
+- retry_attempt
+- retry_not_before_ms
+- retry_error
+- retry_original_topic
+- retry_original_partition
+- retry_original_offset
+` + "```" + `
+
+Retry topics need to be consumed by this input in order for their records to be processed again, and records of a retry topic are only processed once the delay of the topic has passed since they were rejected. Whilst records of a partition are held back the consumption of that partition is paused, and the consumption of other partitions continues. The field ` + "`auto_retry_nacks`" + ` has no effect when a retry policy is enabled.
+
+` + "```yaml" + `
+input:
+  redpanda:
+    seed_brokers: [ localhost:9092 ]
+    topics: [ foo, foo_retry_1m, foo_retry_10m ]
+    consumer_group: foo_group
+    retry_policy:
+      enabled: true
+      retry_topics:
+        - topic: foo_retry_1m
+          delay: 1m
+        - topic: foo_retry_10m
+          delay: 10m
+      dead_letter_topic: foo_dlq
+` + "```" + `
+
+== Exactly-Once Delivery
+
+When the field ` + "`transactional.enabled`" + ` is set to ` + "`true`" + ` each batch is consumed within a transaction, and a ` + "`redpanda`" + ` output that references this input by its label with the field ` + "`transactional_input`" + ` produces records within that same transaction. The offsets of the batch are committed as part of the transaction once the output delivers it, and so the records produced and the offsets consumed are either committed together or not at all. Batches that fail to be delivered abort the transaction and are consumed again, and therefore ` + "`auto_retry_nacks`" + ` has no effect in this mode. Only one batch is processed at a time.
+
+` + "```yaml" + `
+input:
+  label: source
+  redpanda:
+    seed_brokers: [ localhost:9092 ]
+    topics: [ foo ]
+    consumer_group: foo_group
+    transactional:
+      enabled: true
+      transactional_id: foo_to_bar
+
+output:
+  redpanda:
+    transactional_input: source
+    topic: bar
+` + "```" + `
+
 == Batching
 
 Records are processed and delivered from each partition in batches as received from brokers. These batch sizes are therefore dynamically sized in order to optimise throughput, but can be tuned with the config fields ` + "`fetch_max_partition_bytes` and `fetch_max_bytes`" + `. Batches can be further broken down using the ` + "xref:components:processors/split.adoc[`split`] processor" + `.
@@ -92,6 +145,7 @@ func redpandaInputConfigFields() []*service.ConfigField {
 		FranzConsumerFields(),
 		FranzReaderToggledConfigFields(),
 		[]*service.ConfigField{
+			FranzReaderTransactionalConfigField(),
 			service.NewAutoRetryNacksToggleField(),
 			service.NewForceTimelyNacksField(),
 		},
@@ -111,11 +165,42 @@ func init() {
 				return nil, err
 			}
 
+			transactional, err := conf.FieldBool(krtxFieldTransactional, krtxFieldTransactionalEnabled)
+			if err != nil {
+				return nil, err
+			}
+
+			var retryEnabled bool
+			if conf.Contains(frpField) {
+				if retryEnabled, err = conf.FieldBool(frpField, frpFieldEnabled); err != nil {
+					return nil, err
+				}
+			}
+
+			newReader := func(optsFn func() ([]kgo.Opt, error)) (service.BatchInput, error) {
+				return NewFranzReaderToggledFromConfig(conf, mgr, optsFn)
+			}
+			if transactional {
+				unordered, err := conf.FieldBool(krtFieldUnordered, krtFieldUnorderedEnabled)
+				if err != nil {
+					return nil, err
+				}
+				if unordered {
+					return nil, errors.New("unordered processing cannot be enabled when consuming within transactions")
+				}
+				if retryEnabled {
+					return nil, errors.New("a retry policy cannot be enabled when consuming within transactions")
+				}
+				newReader = func(optsFn func() ([]kgo.Opt, error)) (service.BatchInput, error) {
+					return NewFranzReaderTransactionalFromConfig(conf, mgr, optsFn)
+				}
+			}
+
 			var rdr service.BatchInput
 			if connDetails.IsConfigured() {
 				// We're using a custom connection from config.
 				clientOpts := append(connDetails.FranzOpts(), consumerOpts...)
-				if rdr, err = NewFranzReaderToggledFromConfig(conf, mgr, func() ([]kgo.Opt, error) {
+				if rdr, err = newReader(func() ([]kgo.Opt, error) {
 					return clientOpts, nil
 				}); err != nil {
 					return nil, err
@@ -124,7 +209,7 @@ func init() {
 				mgr.Logger().Info("Connection fields omitted, falling back to common redpanda config.")
 
 				// We're using a common redpanda block to determine the connection.
-				if rdr, err = NewFranzReaderToggledFromConfig(conf, mgr, func() (clientOpts []kgo.Opt, err error) {
+				if rdr, err = newReader(func() (clientOpts []kgo.Opt, err error) {
 					// Make multiple attempts here just to allow the redpanda logger
 					// to initialise in the background. Otherwise we get an annoying
 					// log.
@@ -144,8 +229,14 @@ func init() {
 				}
 			}
 
-			if rdr, err = service.AutoRetryNacksBatchedToggled(conf, rdr); err != nil {
-				return nil, err
+			// Rejected batches are consumed again after their transaction is
+			// aborted, and so retrying them within the input would produce
+			// duplicates. Likewise, a retry policy needs to observe rejections
+			// in order to produce them to retry topics.
+			if !transactional && !retryEnabled {
+				if rdr, err = service.AutoRetryNacksBatchedToggled(conf, rdr); err != nil {
+					return nil, err
+				}
 			}
 
 			if rdr, err = service.ForceTimelyNacksBatched(conf, rdr); err != nil {
@@ -178,3 +178,56 @@ redpanda:
 		})
 	}
 }
+
+func TestRedpandaInputTransactionalConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		conf   string
+		errStr string
+	}{
+		{
+			name: "no consumer group",
+			conf: `
+seed_brokers: [ localhost:9092 ]
+topics: [ foo:0 ]
+transactional:
+  enabled: true
+  transactional_id: foo
+`,
+			errStr: "a consumer group is required in order to consume within transactions",
+		},
+		{
+			name: "no transactional id",
+			conf: `
+seed_brokers: [ localhost:9092 ]
+topics: [ foo ]
+consumer_group: foo
+transactional:
+  enabled: true
+`,
+			errStr: "a transactional_id is required in order to consume within transactions",
+		},
+		{
+			name: "no label",
+			conf: `
+seed_brokers: [ localhost:9092 ]
+topics: [ foo ]
+consumer_group: foo
+transactional:
+  enabled: true
+  transactional_id: foo
+`,
+			errStr: "a label must be set on inputs that consume within transactions in order for outputs to reference them",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := redpandaInputConfig().ParseYAML(test.conf, nil)
+			require.NoError(t, err)
+
+			_, err = NewFranzReaderTransactionalFromConfig(conf, service.MockResources(), nil)
+			require.EqualError(t, err, test.errStr)
+		})
+	}
+}
@@ -632,3 +632,95 @@ input:
 		)
 	})
 }
+
+func TestRedpandaTransactionalIntegration(t *testing.T) {
+	integration.CheckSkip(t)
+
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+	pool.MaxWait = time.Minute
+
+	endpoints, err := redpandatest.StartRedpanda(t, pool, true, false)
+	require.NoError(t, err)
+
+	require.NoError(t, createKafkaTopic(t.Context(), endpoints.BrokerAddr, "source", 4))
+	require.NoError(t, createKafkaTopic(t.Context(), endpoints.BrokerAddr, "sink", 4))
+
+	const count = 1000
+
+	cl, err := kgo.NewClient(kgo.SeedBrokers(endpoints.BrokerAddr))
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	var records []*kgo.Record
+	for i := range count {
+		records = append(records, &kgo.Record{
+			Topic: "topic-source",
+			Key:   []byte(strconv.Itoa(i)),
+			Value: []byte(strconv.Itoa(i)),
+		})
+	}
+	require.NoError(t, cl.ProduceSync(t.Context(), records...).FirstErr())
+
+	// Some batches are rejected, aborting the transactions that contain the
+	// records already produced from them, which are then consumed again.
+	streamBuilder := service.NewStreamBuilder()
+	require.NoError(t, streamBuilder.SetYAML(fmt.Sprintf(`
+input:
+  label: source
+  redpanda:
+    seed_brokers: [ %v ]
+    topics: [ topic-source ]
+    consumer_group: transactional_cg
+    transactional:
+      enabled: true
+      transactional_id: source_to_sink
+
+output:
+  reject_errored:
+    redpanda:
+      transactional_input: source
+      topic: topic-sink
+      key: ${! @kafka_key }
+  processors:
+    - mapping: |
+        root = this
+        meta batch_num = counter()
+    - mapping: |
+        root = if @batch_num %% 10 == 0 && batch_index() == batch_size() - 1 { throw("rejected") } else { this }
+`, endpoints.BrokerAddr)))
+	require.NoError(t, streamBuilder.SetLoggerYAML(`level: OFF`))
+
+	stream, err := streamBuilder.Build()
+	require.NoError(t, err)
+
+	go func() {
+		assert.NoError(t, stream.Run(t.Context()))
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, stream.StopWithin(5*time.Second))
+	})
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(endpoints.BrokerAddr),
+		kgo.ConsumeTopics("topic-sink"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(consumer.Close)
+
+	seen := map[string]int{}
+	require.Eventually(t, func() bool {
+		ctx, done := context.WithTimeout(t.Context(), time.Second)
+		defer done()
+		consumer.PollFetches(ctx).EachRecord(func(r *kgo.Record) {
+			seen[string(r.Key)]++
+		})
+		return len(seen) == count
+	}, time.Minute, time.Millisecond*10)
+
+	for k, v := range seen {
+		assert.Equal(t, 1, v, k)
+	}
+}
@@ -25,7 +25,8 @@ import (
 )
 
 const (
-	roFieldMaxInFlight = "max_in_flight"
+	roFieldMaxInFlight        = "max_in_flight"
+	roFieldTransactionalInput = "transactional_input"
 )
 
 func redpandaOutputConfig() *service.ConfigSpec {
@@ -35,6 +36,10 @@ func redpandaOutputConfig() *service.ConfigSpec {
 		Summary("A Kafka output using the https://github.com/twmb/franz-go[Franz Kafka client library^].").
 		Description(`
 Writes a batch of messages to Kafka brokers and waits for acknowledgement before propagating it back to the input.
+
+== Exactly-Once Delivery
+
+When the field `+"`transactional_input`"+` references the label of a `+"`redpanda`"+` input with `+"`transactional.enabled`"+` set to `+"`true`"+`, records are produced with the client of that input within the transaction of the batch being processed. The transaction, including the consumer offsets of the batch, is committed by the input once the batch is delivered, which provides exactly-once delivery between the input and this output. In this mode the connection and producer fields of this output are ignored.
 `).
 		Fields(redpandaOutputConfigFields()...).
 		LintRule(FranzWriterConfigLints()).
@@ -74,6 +79,11 @@ func redpandaOutputConfigFields() []*service.ConfigField {
 			service.NewIntField(roFieldMaxInFlight).
 				Description("The maximum number of batches to be sending in parallel at any given time.").
 				Default(256),
+			service.NewStringField(roFieldTransactionalInput).
+				Description("The label of a `redpanda` input consuming within transactions, whose client is used to produce records within the transaction of each batch.").
+				Optional().
+				Advanced().
+				Version("4.73.0"),
 		},
 		FranzProducerFields(),
 	)
@@ -101,7 +111,25 @@ func init() {
 				return
 			}
 
-			if connDetails.IsConfigured() {
+			if conf.Contains(roFieldTransactionalInput) {
+				var inputLabel string
+				if inputLabel, err = conf.FieldString(roFieldTransactionalInput); err != nil {
+					return
+				}
+
+				// The client is owned by the input, which ends the transaction
+				// of each batch once it is acknowledged.
+				output, err = NewFranzWriterFromConfig(
+					conf,
+					NewFranzWriterHooks(
+						func(_ context.Context, fn FranzSharedClientUseFn) error {
+							return FranzSharedClientUse(inputLabel, mgr, fn)
+						},
+					).WithYieldClientFn(
+						func(context.Context) error { return nil },
+					),
+				)
+			} else if connDetails.IsConfigured() {
 				var client *kgo.Client
 				var clientMut sync.Mutex
 
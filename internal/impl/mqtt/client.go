@@ -21,6 +21,8 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 
@@ -42,6 +44,12 @@ const (
 	msFieldClientPassword          = "password"
 	msFieldClientKeepAlive         = "keepalive"
 	msFieldClientTLS               = "tls"
+	msFieldClientProtocolVersion   = "protocol_version"
+)
+
+const (
+	protocolVersion311 = "3.1.1"
+	protocolVersion5   = "5"
 )
 
 func clientFields() []*service.ConfigField {
@@ -97,6 +105,14 @@ func clientFields() []*service.ConfigField {
 			Default(30).
 			Advanced(),
 		service.NewTLSToggledField(msFieldClientTLS),
+		service.NewStringAnnotatedEnumField(msFieldClientProtocolVersion, map[string]string{
+			protocolVersion311: "MQTT 3.1.1, falling back to MQTT 3.1 when the broker does not support it.",
+			protocolVersion5:   "MQTT 5, which adds user properties, content types, correlation data and shared subscriptions.",
+		}).
+			Description("The version of the MQTT protocol to connect with.").
+			Default(protocolVersion311).
+			Advanced().
+			Version("4.73.0"),
 	}
 }
 
@@ -110,6 +126,7 @@ type clientOptsBuilder struct {
 	tlsEnabled     bool
 	tlsConf        *tls.Config
 	will           willOpt
+	v5             bool
 }
 
 func clientOptsFromParsed(conf *service.ParsedConfig) (opts clientOptsBuilder, err error) {
@@ -156,6 +173,11 @@ func clientOptsFromParsed(conf *service.ParsedConfig) (opts clientOptsBuilder, e
 	if opts.tlsConf, opts.tlsEnabled, err = conf.FieldTLSToggled(msFieldClientTLS); err != nil {
 		return
 	}
+	var version string
+	if version, err = conf.FieldString(msFieldClientProtocolVersion); err != nil {
+		return
+	}
+	opts.v5 = version == protocolVersion5
 	return
 }
 
@@ -181,6 +203,46 @@ func (b *clientOptsBuilder) apply(opts *mqtt.ClientOptions) *mqtt.ClientOptions
 	return opts
 }
 
+// autopahoConfig returns the configuration of an MQTT 5 connection manager,
+// which reconnects automatically whenever the connection is lost.
+func (b *clientOptsBuilder) autopahoConfig(log *service.Logger) autopaho.ClientConfig {
+	conf := autopaho.ClientConfig{
+		ServerUrls:      b.urls,
+		KeepAlive:       uint16(b.keepAlive),
+		ConnectTimeout:  b.connectTimeout,
+		ConnectUsername: b.username,
+		ConnectPassword: []byte(b.password),
+		OnConnectError: func(err error) {
+			log.Errorf("Failed to connect: %v", err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: b.clientID,
+			OnClientError: func(err error) {
+				log.Errorf("Connection lost due to: %v", err)
+			},
+			OnServerDisconnect: func(d *paho.Disconnect) {
+				if d.Properties != nil && d.Properties.ReasonString != "" {
+					log.Errorf("Disconnected by broker: %v", d.Properties.ReasonString)
+					return
+				}
+				log.Errorf("Disconnected by broker with reason code %v", d.ReasonCode)
+			},
+		},
+	}
+	if b.tlsEnabled {
+		conf.TlsCfg = b.tlsConf
+	}
+	if b.will.Enabled {
+		conf.WillMessage = &paho.WillMessage{
+			Retain:  b.will.Retained,
+			QoS:     b.will.QoS,
+			Topic:   b.will.Topic,
+			Payload: []byte(b.will.Payload),
+		}
+	}
+	return conf
+}
+
 func willOptFromParsed(conf *service.ParsedConfig) (opt willOpt, err error) {
 	if opt.Enabled, err = conf.FieldBool(msFieldClientWillEnabled); err != nil {
 		return
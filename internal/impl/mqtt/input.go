@@ -16,9 +16,12 @@ package mqtt
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 
 	"github.com/redpanda-data/benthos/v4/public/service"
@@ -46,17 +49,30 @@ This input adds the following metadata fields to each message:
 - mqtt_topic
 - mqtt_message_id
 
-You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].`).
+When the `+"`protocol_version`"+` is `+"`5`"+` the following metadata fields are also added when present on a message, along with each user property of the message as a metadata field of the same key:
+
+- mqtt_content_type
+- mqtt_correlation_data
+- mqtt_response_topic
+- mqtt_message_expiry
+
+You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+== Shared Subscriptions
+
+Topics of the form `+"`$share/<group>/<filter>`"+` are shared subscriptions, where messages matching the filter are distributed amongst the clients subscribed with the same group rather than being delivered to each of them. This allows consumption to be scaled horizontally by running multiple instances with distinct client IDs. Shared subscriptions are part of MQTT 5, although some brokers also support them for earlier versions.`).
 		Fields(clientFields()...).
 		Fields(
 			service.NewStringListField(miFieldTopics).
-				Description("A list of topics to consume from."),
+				Description("A list of topics to consume from. Topics may be shared subscriptions of the form `$share/<group>/<filter>`.").
+				Example([]string{"sensors/+/temperature"}).
+				Example([]string{"$share/connect/sensors/#"}),
 			service.NewIntField(miFieldQoS).
 				Description("The level of delivery guarantee to enforce. Has options 0, 1, 2.").
 				Advanced().
 				Default(1),
 			service.NewBoolField(miFieldCleanSession).
-				Description("Set whether the connection is non-persistent.").
+				Description("Set whether the connection is non-persistent. With MQTT 5 a persistent session never expires.").
 				Default(true).
 				Advanced(),
 			service.NewAutoRetryNacksToggleField(),
@@ -80,7 +96,9 @@ type mqttReader struct {
 	cleanSession  bool
 
 	client  mqtt.Client
-	msgChan chan mqtt.Message
+	client5 *autopaho.ConnectionManager
+	nacked5 chan struct{}
+	msgChan chan receivedMessage
 	cMut    sync.Mutex
 
 	interruptChan chan struct{}
@@ -114,16 +132,28 @@ func newMQTTReaderFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 	return m, nil
 }
 
-func (m *mqttReader) Connect(context.Context) error {
+// receivedMessage is a message consumed from either protocol version along
+// with the function that acknowledges it to the broker once it has been
+// delivered, or nacked without being retried.
+type receivedMessage struct {
+	msg *service.Message
+	ack func(err error)
+}
+
+func (m *mqttReader) Connect(ctx context.Context) error {
 	m.cMut.Lock()
 	defer m.cMut.Unlock()
 
-	if m.client != nil {
+	if m.client != nil || m.client5 != nil {
 		return nil
 	}
 
+	if m.clientBuilder.v5 {
+		return m.connect5(ctx)
+	}
+
 	var msgMut sync.Mutex
-	msgChan := make(chan mqtt.Message)
+	msgChan := make(chan receivedMessage)
 
 	closeMsgChan := func() bool {
 		msgMut.Lock()
@@ -150,10 +180,21 @@ func (m *mqttReader) Connect(context.Context) error {
 			}
 
 			tok := c.SubscribeMultiple(topics, func(_ mqtt.Client, msg mqtt.Message) {
+				message := service.NewMessage(msg.Payload())
+				message.MetaSetMut("mqtt_duplicate", msg.Duplicate())
+				message.MetaSetMut("mqtt_qos", int(msg.Qos()))
+				message.MetaSetMut("mqtt_retained", msg.Retained())
+				message.MetaSetMut("mqtt_topic", msg.Topic())
+				message.MetaSetMut("mqtt_message_id", int(msg.MessageID()))
+
 				msgMut.Lock()
 				if msgChan != nil {
 					select {
-					case msgChan <- msg:
+					case msgChan <- receivedMessage{msg: message, ack: func(err error) {
+						if err == nil {
+							msg.Ack()
+						}
+					}}:
 					case <-m.interruptChan:
 					}
 				}
@@ -196,9 +237,161 @@ func (m *mqttReader) Connect(context.Context) error {
 	return nil
 }
 
+// connect5 requires the connection lock to be held.
+func (m *mqttReader) connect5(ctx context.Context) error {
+	msgChan := make(chan receivedMessage)
+
+	sub := &paho.Subscribe{}
+	for _, topic := range m.topics {
+		sub.Subscriptions = append(sub.Subscriptions, paho.SubscribeOptions{Topic: topic, QoS: m.qos})
+	}
+
+	conf := m.clientBuilder.autopahoConfig(m.log)
+	conf.CleanStartOnInitialConnection = m.cleanSession
+	if !m.cleanSession {
+		conf.SessionExpiryInterval = 0xFFFFFFFF
+	}
+
+	// Topics are subscribed to again each time the connection is
+	// re-established, and the outcome of the first attempt is reported back to
+	// Connect.
+	subscribed := make(chan error, 1)
+	conf.OnConnectionUp = func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+		go func() {
+			subCtx, done := context.WithTimeout(context.Background(), m.clientBuilder.connectTimeout)
+			defer done()
+
+			_, err := cm.Subscribe(subCtx, sub)
+			if err != nil {
+				m.log.Errorf("Failed to subscribe to topics '%v': %v", m.topics, err)
+			}
+			select {
+			case subscribed <- err:
+			default:
+			}
+		}()
+	}
+
+	// Messages are acknowledged manually, and in the order they were received,
+	// once they've been delivered downstream.
+	acks := newV5AckTracker(m.log)
+	conf.EnableManualAcknowledgment = true
+	conf.OnPublishReceived = []func(paho.PublishReceived) (bool, error){
+		func(pr paho.PublishReceived) (bool, error) {
+			select {
+			case msgChan <- receivedMessage{msg: publishToMessage(pr.Packet), ack: acks.ackFn(pr.Client, pr.Packet)}:
+			case <-acks.nacked:
+			case <-m.interruptChan:
+			}
+			return true, nil
+		},
+	}
+
+	// The connection manager is shut down when its context is cancelled, and
+	// must therefore outlive this call.
+	client, err := autopaho.NewConnection(context.Background(), conf)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err = <-subscribed:
+		if err != nil {
+			err = fmt.Errorf("failed to subscribe to topics '%v': %w", m.topics, err)
+		}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+		return err
+	}
+
+	m.client5 = client
+	m.nacked5 = acks.nacked
+	m.msgChan = msgChan
+	return nil
+}
+
+// publishAcker acknowledges PUBLISH packets received with manual
+// acknowledgement enabled.
+type publishAcker interface {
+	Ack(pb *paho.Publish) error
+}
+
+// v5AckTracker acknowledges the messages of an MQTT 5 connection. Acks are
+// sent in the order that messages were received, and so a message that is
+// nacked holds back the acks of all messages received after it. The
+// connection is therefore abandoned after a nack, and the broker redelivers
+// the unacknowledged messages once it is re-established.
+type v5AckTracker struct {
+	nacked     chan struct{}
+	nackedOnce sync.Once
+	log        *service.Logger
+}
+
+func newV5AckTracker(log *service.Logger) *v5AckTracker {
+	return &v5AckTracker{
+		nacked: make(chan struct{}),
+		log:    log,
+	}
+}
+
+func (t *v5AckTracker) ackFn(client publishAcker, pb *paho.Publish) func(err error) {
+	return func(err error) {
+		if err != nil {
+			t.nackedOnce.Do(func() {
+				t.log.Warnf("Message was rejected, reconnecting in order for it to be redelivered: %v", err)
+				close(t.nacked)
+			})
+			return
+		}
+		select {
+		case <-t.nacked:
+			// The message is redelivered along with the nacked message.
+			return
+		default:
+		}
+		if err := client.Ack(pb); err != nil {
+			t.log.Errorf("Failed to acknowledge message: %v", err)
+		}
+	}
+}
+
+func publishToMessage(p *paho.Publish) *service.Message {
+	message := service.NewMessage(p.Payload)
+
+	props := p.Properties
+	if props == nil {
+		props = &paho.PublishProperties{}
+	}
+	for _, prop := range props.User {
+		message.MetaSetMut(prop.Key, prop.Value)
+	}
+
+	message.MetaSetMut("mqtt_duplicate", p.Duplicate())
+	message.MetaSetMut("mqtt_qos", int(p.QoS))
+	message.MetaSetMut("mqtt_retained", p.Retain)
+	message.MetaSetMut("mqtt_topic", p.Topic)
+	message.MetaSetMut("mqtt_message_id", int(p.PacketID))
+	if props.ContentType != "" {
+		message.MetaSetMut("mqtt_content_type", props.ContentType)
+	}
+	if props.CorrelationData != nil {
+		message.MetaSetMut("mqtt_correlation_data", string(props.CorrelationData))
+	}
+	if props.ResponseTopic != "" {
+		message.MetaSetMut("mqtt_response_topic", props.ResponseTopic)
+	}
+	if props.MessageExpiry != nil {
+		message.MetaSetMut("mqtt_message_expiry", int(*props.MessageExpiry))
+	}
+	return message
+}
+
 func (m *mqttReader) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
 	m.cMut.Lock()
-	msgChan := m.msgChan
+	msgChan, nacked := m.msgChan, m.nacked5
 	m.cMut.Unlock()
 
 	if msgChan == nil {
@@ -211,24 +404,26 @@ func (m *mqttReader) Read(ctx context.Context) (*service.Message, service.AckFun
 			m.cMut.Lock()
 			m.msgChan = nil
 			m.client = nil
+			m.client5 = nil
+			m.nacked5 = nil
 			m.cMut.Unlock()
 			return nil, nil, service.ErrNotConnected
 		}
 
-		message := service.NewMessage(msg.Payload())
-
-		message.MetaSetMut("mqtt_duplicate", msg.Duplicate())
-		message.MetaSetMut("mqtt_qos", int(msg.Qos()))
-		message.MetaSetMut("mqtt_retained", msg.Retained())
-		message.MetaSetMut("mqtt_topic", msg.Topic())
-		message.MetaSetMut("mqtt_message_id", int(msg.MessageID()))
-
-		return message, func(_ context.Context, res error) error {
-			if res == nil {
-				msg.Ack()
-			}
+		return msg.msg, func(_ context.Context, res error) error {
+			msg.ack(res)
 			return nil
 		}, nil
+	case <-nacked:
+		m.cMut.Lock()
+		if m.nacked5 == nacked {
+			_ = m.client5.Disconnect(ctx)
+			m.msgChan = nil
+			m.client5 = nil
+			m.nacked5 = nil
+		}
+		m.cMut.Unlock()
+		return nil, nil, service.ErrNotConnected
 	case <-ctx.Done():
 		return nil, nil, ctx.Err()
 	case <-m.interruptChan:
@@ -236,7 +431,7 @@ func (m *mqttReader) Read(ctx context.Context) (*service.Message, service.AckFun
 	}
 }
 
-func (m *mqttReader) Close(context.Context) (err error) {
+func (m *mqttReader) Close(ctx context.Context) (err error) {
 	m.cMut.Lock()
 	defer m.cMut.Unlock()
 
@@ -245,5 +440,12 @@ func (m *mqttReader) Close(context.Context) (err error) {
 		m.client = nil
 		close(m.interruptChan)
 	}
+	if m.client5 != nil {
+		// Unblock any message being handed over before disconnecting.
+		close(m.interruptChan)
+		err = m.client5.Disconnect(ctx)
+		m.client5 = nil
+		m.nacked5 = nil
+	}
 	return
 }
@@ -0,0 +1,112 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+type mockPublishAcker struct {
+	acked []uint16
+}
+
+func (m *mockPublishAcker) Ack(pb *paho.Publish) error {
+	m.acked = append(m.acked, pb.PacketID)
+	return nil
+}
+
+func TestInputPublishToMessage(t *testing.T) {
+	expiry := uint32(60)
+	msg := publishToMessage(&paho.Publish{
+		PacketID: 12,
+		QoS:      1,
+		Retain:   true,
+		Topic:    "foo/bar",
+		Payload:  []byte("hello"),
+		Properties: &paho.PublishProperties{
+			CorrelationData: []byte("abc"),
+			ContentType:     "application/json",
+			ResponseTopic:   "replies",
+			MessageExpiry:   &expiry,
+			User:            paho.UserProperties{{Key: "foo", Value: "bar"}},
+		},
+	})
+
+	b, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	for k, v := range map[string]any{
+		"foo":                   "bar",
+		"mqtt_duplicate":        false,
+		"mqtt_qos":              1,
+		"mqtt_retained":         true,
+		"mqtt_topic":            "foo/bar",
+		"mqtt_message_id":       12,
+		"mqtt_content_type":     "application/json",
+		"mqtt_correlation_data": "abc",
+		"mqtt_response_topic":   "replies",
+		"mqtt_message_expiry":   60,
+	} {
+		actual, exists := msg.MetaGetMut(k)
+		require.True(t, exists, k)
+		assert.Equal(t, v, actual, k)
+	}
+
+	// Absent properties are not added as metadata.
+	msg = publishToMessage(&paho.Publish{Topic: "foo"})
+	for _, k := range []string{"mqtt_content_type", "mqtt_correlation_data", "mqtt_response_topic", "mqtt_message_expiry"} {
+		_, exists := msg.MetaGetMut(k)
+		assert.False(t, exists, k)
+	}
+}
+
+func TestInputV5AckTracker(t *testing.T) {
+	client := &mockPublishAcker{}
+	acks := newV5AckTracker(service.MockResources().Logger())
+
+	acks.ackFn(client, &paho.Publish{PacketID: 1, QoS: 1})(nil)
+	assert.Equal(t, []uint16{1}, client.acked)
+
+	select {
+	case <-acks.nacked:
+		t.Fatal("connection abandoned without a nack")
+	default:
+	}
+
+	// A nacked message is left unacknowledged and the connection abandoned so
+	// that the broker redelivers it.
+	nackFn := acks.ackFn(client, &paho.Publish{PacketID: 2, QoS: 1})
+	nackFn(errors.New("nope"))
+	nackFn(errors.New("nope again"))
+	assert.Equal(t, []uint16{1}, client.acked)
+
+	select {
+	case <-acks.nacked:
+	default:
+		t.Fatal("connection not abandoned after a nack")
+	}
+
+	// Messages received after a nack are redelivered along with it.
+	acks.ackFn(client, &paho.Publish{PacketID: 3, QoS: 1})(nil)
+	assert.Equal(t, []uint16{1}, client.acked)
+}
@@ -16,11 +16,14 @@ package mqtt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 
 	"github.com/redpanda-data/benthos/v4/public/service"
@@ -32,15 +35,32 @@ const (
 	moFieldWriteTimeout         = "write_timeout"
 	moFieldRetained             = "retained"
 	moFieldRetainedInterpolated = "retained_interpolated"
+	moFieldUserProperties       = "user_properties"
+	moFieldContentType          = "content_type"
+	moFieldCorrelationData      = "correlation_data"
+	moFieldResponseTopic        = "response_topic"
+	moFieldMessageExpiry        = "message_expiry"
 )
 
+var outputV5Fields = []string{
+	moFieldUserProperties,
+	moFieldContentType,
+	moFieldCorrelationData,
+	moFieldResponseTopic,
+	moFieldMessageExpiry,
+}
+
 func outputConfigSpec() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Categories("Services").
 		Summary("Pushes messages to an MQTT broker.").
 		Description(`
-The `+"`topic`"+` field can be dynamically set using function interpolations described xref:configuration:interpolation.adoc#bloblang-queries[here]. When sending batched messages these interpolations are performed per message part.`+service.OutputPerformanceDocs(true, false)).
+The `+"`topic`"+` field can be dynamically set using function interpolations described xref:configuration:interpolation.adoc#bloblang-queries[here]. When sending batched messages these interpolations are performed per message part.
+
+== MQTT 5
+
+When the `+"`protocol_version`"+` is `+"`5`"+` metadata can be written as user properties with the `+"`user_properties`"+` field, and the content type, correlation data, response topic and expiry interval of messages can be set. These fields cannot be used with earlier protocol versions.`+service.OutputPerformanceDocs(true, false)).
 		Fields(clientFields()...).
 		Fields(
 			service.NewInterpolatedStringField(moFieldTopic).
@@ -61,6 +81,35 @@ The `+"`topic`"+` field can be dynamically set using function interpolations des
 				Advanced().
 				Optional().
 				Version("3.59.0"),
+			service.NewMetadataFilterField(moFieldUserProperties).
+				Description("Determine which (if any) metadata values should be added to messages as user properties. Requires MQTT 5.").
+				Optional().
+				Advanced().
+				Version("4.73.0"),
+			service.NewInterpolatedStringField(moFieldContentType).
+				Description("The content type of each message. Requires MQTT 5.").
+				Example("application/json").
+				Optional().
+				Advanced().
+				Version("4.73.0"),
+			service.NewInterpolatedStringField(moFieldCorrelationData).
+				Description("Correlation data used by the receiver of a request message in order to identify the request it responds to. Requires MQTT 5.").
+				Example(`${! @mqtt_correlation_data }`).
+				Optional().
+				Advanced().
+				Version("4.73.0"),
+			service.NewInterpolatedStringField(moFieldResponseTopic).
+				Description("The topic that the receiver of a request message should publish its response to. Requires MQTT 5.").
+				Example(`${! @mqtt_response_topic }`).
+				Optional().
+				Advanced().
+				Version("4.73.0"),
+			service.NewDurationField(moFieldMessageExpiry).
+				Description("The period of time after which messages are discarded by the broker when they have not yet been delivered to subscribers. Requires MQTT 5.").
+				Example("1h").
+				Optional().
+				Advanced().
+				Version("4.73.0"),
 			service.NewOutputMaxInFlightField(),
 		)
 }
@@ -86,7 +135,14 @@ type mqttWriter struct {
 	retainedInterp *service.InterpolatedString
 	qos            uint8
 
+	userProperties  *service.MetadataFilter
+	contentType     *service.InterpolatedString
+	correlationData *service.InterpolatedString
+	responseTopic   *service.InterpolatedString
+	messageExpiry   *uint32
+
 	client  mqtt.Client
+	client5 *autopaho.ConnectionManager
 	connMut sync.RWMutex
 }
 
@@ -119,14 +175,69 @@ func newMQTTWriterFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 		return nil, err
 	}
 	m.qos = uint8(tmpQoS)
+
+	if !m.clientBuilder.v5 {
+		for _, f := range outputV5Fields {
+			if conf.Contains(f) {
+				return nil, fmt.Errorf("field %v requires a protocol_version of %v", f, protocolVersion5)
+			}
+		}
+		return m, nil
+	}
+	if conf.Contains(moFieldUserProperties) {
+		if m.userProperties, err = conf.FieldMetadataFilter(moFieldUserProperties); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(moFieldContentType) {
+		if m.contentType, err = conf.FieldInterpolatedString(moFieldContentType); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(moFieldCorrelationData) {
+		if m.correlationData, err = conf.FieldInterpolatedString(moFieldCorrelationData); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(moFieldResponseTopic) {
+		if m.responseTopic, err = conf.FieldInterpolatedString(moFieldResponseTopic); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Contains(moFieldMessageExpiry) {
+		expiry, err := conf.FieldDuration(moFieldMessageExpiry)
+		if err != nil {
+			return nil, err
+		}
+		seconds := uint32(expiry / time.Second)
+		m.messageExpiry = &seconds
+	}
 	return m, nil
 }
 
-func (m *mqttWriter) Connect(context.Context) error {
+func (m *mqttWriter) Connect(ctx context.Context) error {
 	m.connMut.Lock()
 	defer m.connMut.Unlock()
 
-	if m.client != nil {
+	if m.client != nil || m.client5 != nil {
+		return nil
+	}
+
+	if m.clientBuilder.v5 {
+		conf := m.clientBuilder.autopahoConfig(m.log)
+		conf.CleanStartOnInitialConnection = true
+
+		// The connection manager is shut down when its context is cancelled,
+		// and must therefore outlive this call.
+		client, err := autopaho.NewConnection(context.Background(), conf)
+		if err != nil {
+			return err
+		}
+		if err := client.AwaitConnection(ctx); err != nil {
+			_ = client.Disconnect(context.Background())
+			return err
+		}
+		m.client5 = client
 		return nil
 	}
 
@@ -149,12 +260,12 @@ func (m *mqttWriter) Connect(context.Context) error {
 	return nil
 }
 
-func (m *mqttWriter) Write(_ context.Context, msg *service.Message) error {
+func (m *mqttWriter) Write(ctx context.Context, msg *service.Message) error {
 	m.connMut.RLock()
-	client := m.client
+	client, client5 := m.client, m.client5
 	m.connMut.RUnlock()
 
-	if client == nil {
+	if client == nil && client5 == nil {
 		return service.ErrNotConnected
 	}
 
@@ -178,6 +289,10 @@ func (m *mqttWriter) Write(_ context.Context, msg *service.Message) error {
 		return err
 	}
 
+	if client5 != nil {
+		return m.write5(ctx, client5, msg, topicStr, retained, mBytes)
+	}
+
 	mtok := client.Publish(topicStr, m.qos, retained, mBytes)
 	mtok.Wait()
 	sendErr := mtok.Error()
@@ -190,7 +305,67 @@ func (m *mqttWriter) Write(_ context.Context, msg *service.Message) error {
 	return sendErr
 }
 
-func (m *mqttWriter) Close(context.Context) error {
+func (m *mqttWriter) write5(ctx context.Context, client *autopaho.ConnectionManager, msg *service.Message, topic string, retained bool, payload []byte) error {
+	p, err := m.publish5(msg, topic, retained, payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, done := context.WithTimeout(ctx, m.writeTimeout)
+	defer done()
+
+	// The connection manager reconnects in the background, and so the client
+	// is kept for subsequent writes.
+	if _, err := client.Publish(ctx, p); err != nil {
+		if errors.Is(err, autopaho.ConnectionDownError) {
+			return service.ErrNotConnected
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *mqttWriter) publish5(msg *service.Message, topic string, retained bool, payload []byte) (*paho.Publish, error) {
+	p := &paho.Publish{
+		Topic:   topic,
+		QoS:     m.qos,
+		Retain:  retained,
+		Payload: payload,
+		Properties: &paho.PublishProperties{
+			MessageExpiry: m.messageExpiry,
+		},
+	}
+
+	var err error
+	if m.contentType != nil {
+		if p.Properties.ContentType, err = m.contentType.TryString(msg); err != nil {
+			return nil, fmt.Errorf("content type interpolation error: %w", err)
+		}
+	}
+	if m.correlationData != nil {
+		var data []byte
+		if data, err = m.correlationData.TryBytes(msg); err != nil {
+			return nil, fmt.Errorf("correlation data interpolation error: %w", err)
+		}
+		if len(data) > 0 {
+			p.Properties.CorrelationData = data
+		}
+	}
+	if m.responseTopic != nil {
+		if p.Properties.ResponseTopic, err = m.responseTopic.TryString(msg); err != nil {
+			return nil, fmt.Errorf("response topic interpolation error: %w", err)
+		}
+	}
+	if m.userProperties != nil {
+		_ = m.userProperties.Walk(msg, func(key, value string) error {
+			p.Properties.User.Add(key, value)
+			return nil
+		})
+	}
+	return p, nil
+}
+
+func (m *mqttWriter) Close(ctx context.Context) (err error) {
 	m.connMut.Lock()
 	defer m.connMut.Unlock()
 
@@ -198,5 +373,9 @@ func (m *mqttWriter) Close(context.Context) error {
 		m.client.Disconnect(0)
 		m.client = nil
 	}
-	return nil
+	if m.client5 != nil {
+		err = m.client5.Disconnect(ctx)
+		m.client5 = nil
+	}
+	return
 }
@@ -0,0 +1,97 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestOutputV5FieldsRequireVersion(t *testing.T) {
+	conf, err := outputConfigSpec().ParseYAML(`
+urls: [ tcp://localhost:1883 ]
+topic: foo
+content_type: application/json
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newMQTTWriterFromParsed(conf, service.MockResources())
+	require.ErrorContains(t, err, "content_type requires a protocol_version of 5")
+
+	conf, err = outputConfigSpec().ParseYAML(`
+urls: [ tcp://localhost:1883 ]
+topic: foo
+protocol_version: "5"
+content_type: application/json
+message_expiry: 1m
+user_properties:
+  include_prefixes: [ "" ]
+`, nil)
+	require.NoError(t, err)
+
+	w, err := newMQTTWriterFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+	require.NotNil(t, w.messageExpiry)
+	assert.Equal(t, uint32(60), *w.messageExpiry)
+}
+
+func TestOutputV5Publish(t *testing.T) {
+	conf, err := outputConfigSpec().ParseYAML(`
+urls: [ tcp://localhost:1883 ]
+topic: foo
+qos: 2
+protocol_version: "5"
+content_type: application/json
+correlation_data: ${! @id }
+response_topic: ${! @reply_to }
+message_expiry: 1h
+user_properties:
+  include_prefixes: [ "custom_" ]
+`, nil)
+	require.NoError(t, err)
+
+	w, err := newMQTTWriterFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	msg := service.NewMessage([]byte("hello"))
+	msg.MetaSetMut("id", "abc")
+	msg.MetaSetMut("reply_to", "replies")
+	msg.MetaSetMut("custom_foo", "bar")
+	msg.MetaSetMut("ignored", "baz")
+
+	p, err := w.publish5(msg, "foo", true, []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", p.Topic)
+	assert.Equal(t, byte(2), p.QoS)
+	assert.True(t, p.Retain)
+	assert.Equal(t, "hello", string(p.Payload))
+	assert.Equal(t, "application/json", p.Properties.ContentType)
+	assert.Equal(t, "abc", string(p.Properties.CorrelationData))
+	assert.Equal(t, "replies", p.Properties.ResponseTopic)
+	require.NotNil(t, p.Properties.MessageExpiry)
+	assert.Equal(t, uint32(3600), *p.Properties.MessageExpiry)
+	assert.Equal(t, paho.UserProperties{{Key: "custom_foo", Value: "bar"}}, p.Properties.User)
+
+	// Empty correlation data is omitted rather than sent.
+	p, err = w.publish5(service.NewMessage(nil), "foo", false, nil)
+	require.NoError(t, err)
+	assert.Nil(t, p.Properties.CorrelationData)
+	assert.Empty(t, p.Properties.User)
+}
@@ -30,6 +30,8 @@ const (
 	MessageOperationUpdate MessageOperation = "update"
 	// MessageOperationDelete represents delete statement in mysql binlog
 	MessageOperationDelete MessageOperation = "delete"
+	// MessageOperationDDL represents a schema change statement in mysql binlog
+	MessageOperationDDL MessageOperation = "ddl"
 )
 
 // MessageEvent represents a message from mysql cdc plugin
@@ -0,0 +1,241 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed as a Redpanda Enterprise file under the Redpanda Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// https://github.com/redpanda-data/connect/v4/blob/main/licenses/rcl.md
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+
+	"github.com/redpanda-data/connect/v4/internal/cdc"
+)
+
+// incrementalSnapshotter executes snapshots requested through the signal
+// table while the binlog is being streamed. Chunks of each table are queried
+// between watermarks that are written to the signal table, and the stream
+// emits the rows of a chunk once it reaches the high watermark.
+type incrementalSnapshotter struct {
+	db          *sql.DB
+	signalTable string
+	tables      []string
+	chunkSize   int
+	logger      *service.Logger
+
+	window   cdc.SnapshotWindow
+	requests chan *cdc.SnapshotRequest
+}
+
+func newIncrementalSnapshotter(logger *service.Logger, db *sql.DB, signalTable string, tables []string, chunkSize int) *incrementalSnapshotter {
+	return &incrementalSnapshotter{
+		db:          db,
+		signalTable: signalTable,
+		tables:      tables,
+		chunkSize:   chunkSize,
+		logger:      logger,
+		requests:    make(chan *cdc.SnapshotRequest, 16),
+	}
+}
+
+// handleSignal processes a row inserted into the signal table, and returns the
+// rows of a snapshot chunk that are to be emitted along with their table.
+func (s *incrementalSnapshotter) handleSignal(row map[string]any) (string, []map[string]any, error) {
+	signal, err := cdc.SignalFromRow(row)
+	if err != nil {
+		return "", nil, err
+	}
+	switch signal.Type {
+	case cdc.SignalExecuteSnapshot:
+		req, err := cdc.ParseSnapshotRequest(signal.Data)
+		if err != nil {
+			return "", nil, err
+		}
+		select {
+		case s.requests <- req:
+		default:
+			s.logger.Errorf("Dropping snapshot request %v as too many snapshots are pending", signal.ID)
+		}
+	case cdc.SignalSnapshotWindowOpen:
+		s.window.Open(signal.Data)
+	case cdc.SignalSnapshotWindowClose:
+		table, rows := s.window.Close(signal.Data)
+		return table, rows, nil
+	default:
+		s.logger.Warnf("Ignoring signal %v with unknown type: %v", signal.ID, signal.Type)
+	}
+	return "", nil, nil
+}
+
+func (s *incrementalSnapshotter) run(ctx context.Context) error {
+	for {
+		select {
+		case req := <-s.requests:
+			for _, table := range req.Tables {
+				if err := s.snapshotTable(ctx, table, req.Filters[table]); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					s.logger.Errorf("Incremental snapshot of table %v failed: %v", table, err)
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *incrementalSnapshotter) snapshotTable(ctx context.Context, table, filter string) error {
+	if !slices.Contains(s.tables, table) {
+		// The changes of other tables are not streamed, and so their rows
+		// cannot be deduplicated.
+		return fmt.Errorf("table %v is not part of the binlog stream", table)
+	}
+
+	keyCols, err := s.primaryKeyColumns(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Starting incremental snapshot of table %v", table)
+	var lastKey []any
+	var rowsCount int
+	for {
+		id := uuid.NewString()
+		closed := s.window.Prepare(id, table, keyCols)
+		if err := s.writeSignal(ctx, id, cdc.SignalSnapshotWindowOpen); err != nil {
+			return err
+		}
+		rows, err := s.queryChunk(ctx, table, keyCols, lastKey, filter)
+		if err != nil {
+			return err
+		}
+		s.window.SetRows(rows)
+		if err := s.writeSignal(ctx, id, cdc.SignalSnapshotWindowClose); err != nil {
+			return err
+		}
+		select {
+		case <-closed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		rowsCount += len(rows)
+		if len(rows) < s.chunkSize {
+			break
+		}
+		last := rows[len(rows)-1]
+		lastKey = make([]any, len(keyCols))
+		for i, col := range keyCols {
+			lastKey[i] = last[col]
+		}
+	}
+	s.logger.Infof("Finished incremental snapshot of table %v with %v rows", table, rowsCount)
+	return nil
+}
+
+// writeSignal writes a watermark to the signal table, where the data of the
+// signal identifies the chunk.
+func (s *incrementalSnapshotter) writeSignal(ctx context.Context, id, signalType string) error {
+	q := fmt.Sprintf("INSERT INTO `%s` (id, type, data) VALUES (?, ?, ?)", s.signalTable)
+	if _, err := s.db.ExecContext(ctx, q, id+"-"+signalType, signalType, id); err != nil {
+		return fmt.Errorf("failed to write %v signal: %w", signalType, err)
+	}
+	return nil
+}
+
+func (s *incrementalSnapshotter) primaryKeyColumns(ctx context.Context, table string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT COLUMN_NAME
+FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+WHERE TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY' AND TABLE_SCHEMA = DATABASE()
+ORDER BY ORDINAL_POSITION
+`, table)
+	if err != nil {
+		return nil, fmt.Errorf("get primary key: %v", err)
+	}
+	defer rows.Close()
+
+	var keyCols []string
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err != nil {
+			return nil, err
+		}
+		keyCols = append(keyCols, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate table: %s", err)
+	}
+	if len(keyCols) == 0 {
+		return nil, fmt.Errorf("unable to find primary key for table %s - does the table exist and does it have a primary key set?", table)
+	}
+	return keyCols, nil
+}
+
+func (s *incrementalSnapshotter) queryChunk(ctx context.Context, table string, keyCols []string, minExclusive []any, filter string) ([]map[string]any, error) {
+	var conditions []string
+	if minExclusive != nil {
+		placeholders := slices.Repeat([]string{"?"}, len(keyCols))
+		conditions = append(conditions, fmt.Sprintf("(%s) > (%s)", strings.Join(keyCols, ", "), strings.Join(placeholders, ", ")))
+	}
+	if filter != "" {
+		// Filters are written to the signal table by trusted users in the same
+		// way as a WHERE clause.
+		conditions = append(conditions, "("+filter+")")
+	}
+
+	queryParts := []string{"SELECT * FROM " + table}
+	if len(conditions) > 0 {
+		queryParts = append(queryParts, "WHERE "+strings.Join(conditions, " AND "))
+	}
+	queryParts = append(queryParts, buildOrderByClause(keyCols), fmt.Sprintf("LIMIT %d", s.chunkSize))
+	q := strings.Join(queryParts, " ")
+	s.logger.Tracef("Querying incremental snapshot: %s", q)
+
+	rows, err := s.db.QueryContext(ctx, q, minExclusive...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute incremental snapshot query: %w", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch column types: %s", err)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch columns: %s", err)
+	}
+	values, mappers := prepSnapshotScannerAndMappers(types)
+
+	var chunk []map[string]any
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		row := map[string]any{}
+		for idx, value := range values {
+			v, err := mappers[idx](value)
+			if err != nil {
+				return nil, err
+			}
+			row[columns[idx]] = v
+		}
+		chunk = append(chunk, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate incremental snapshot rows: %s", err)
+	}
+	return chunk, nil
+}
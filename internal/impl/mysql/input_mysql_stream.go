@@ -16,6 +16,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -45,6 +46,8 @@ const (
 	fieldCheckpointKey        = "checkpoint_key"
 	fieldCheckpointCache      = "checkpoint_cache"
 	fieldCheckpointLimit      = "checkpoint_limit"
+	fieldSignalTable          = "signal_table"
+	fieldIncludeDDL           = "include_ddl"
 	fieldAWSIAMAuth           = "aws"
 	// FieldAWSIAMAuthEnabled enabled field.
 	FieldAWSIAMAuthEnabled = "enabled"
@@ -71,6 +74,28 @@ var mysqlStreamConfigSpec = service.NewConfigSpec().
 	Version("4.45.0").
 	Summary("Enables MySQL streaming for RedPanda Connect.").
 	Description(`
+== Incremental Snapshots
+
+When `+"`"+fieldSignalTable+"`"+` is set, snapshots of tables can be requested while the binlog is being streamed, which is useful for snapshotting a table that has been added to `+"`"+fieldMySQLTables+"`"+` or repairing the drift of a downstream replica. The signal table must exist within the database of the DSN with the text columns `+"`id`, `type` and `data`"+`, where `+"`id`"+` is the primary key, and a snapshot is requested by inserting a row of the type `+"`execute-snapshot`"+` with the tables to snapshot and an optional filter for each table written as a SQL condition:
+
+`+"```sql"+`
+CREATE TABLE rpcn_signals (id VARCHAR(64) PRIMARY KEY, type VARCHAR(32) NOT NULL, data TEXT);
+
+INSERT INTO rpcn_signals (id, type, data) VALUES (
+  'snapshot-1',
+  'execute-snapshot',
+  '{"tables":["my_table"],"filters":{"my_table":"created_at > ''2025-01-01''"}}'
+);
+`+"```"+`
+
+Tables are read in chunks of `+"`"+fieldSnapshotMaxBatchSize+"`"+` rows, and each chunk is queried between a low and a high watermark that this input inserts into the signal table. Rows of a chunk that are changed between the watermarks are dropped from the chunk as the streamed change is more recent, and the remaining rows are emitted with the operation "read" once the high watermark is streamed. Requested tables must have a primary key and be listed in `+"`"+fieldMySQLTables+"`"+`. The progress of incremental snapshots is not persisted, and so snapshots that are interrupted by a restart need to be requested again. Rows of the signal table are never emitted, and can be deleted at any time.
+
+== Schema Changes
+
+The schema of each table is recorded in `+"`"+fieldCheckpointCache+"`"+` under the key `+"`"+fieldCheckpointKey+"`"+` suffixed with `+"`_schema_history`"+` whenever it is changed by a DDL statement in the binlog. When the input is restarted, rows that are replayed from the binlog are decoded with the schema that was valid at their binlog position rather than the current schema of the table. Schemas are read from the database when their DDL statement is streamed, and so a table that is changed again before the binlog catches up is recorded with its latest schema.
+
+When `+"`"+fieldIncludeDDL+"`"+` is set to true, DDL statements that change a streamed table are emitted as messages with the operation "ddl", which contain the fields `+"`statement`, `database` and `table`"+`.
+
 == Metadata
 
 This input adds the following metadata fields to each message:
@@ -107,6 +132,15 @@ This input adds the following metadata fields to each message:
 			Default(10),
 		service.NewBoolField(fieldStreamSnapshot).
 			Description("If set to true, the connector will query all the existing data as a part of snapshot process. Otherwise, it will start from the current binlog position."),
+		service.NewStringField(fieldSignalTable).
+			Description("The name of a table that is watched for incremental snapshot requests. When empty, incremental snapshots are disabled.").
+			Default("").
+			Example("rpcn_signals").
+			Advanced(),
+		service.NewBoolField(fieldIncludeDDL).
+			Description("If set to true, DDL statements that change the schema of a streamed table are emitted with the operation `ddl`.").
+			Default(false).
+			Advanced(),
 		service.NewAutoRetryNacksToggleField(),
 		service.NewIntField(fieldCheckpointLimit).
 			Description("The maximum number of messages that can be processed at a given time. Increasing this limit enables parallel processing and batching at the output level. Any given BinLog Position will not be acknowledged unless all messages under that offset are delivered in order to preserve at least once delivery guarantees.").
@@ -177,6 +211,14 @@ type mysqlStreamInput struct {
 	dsn            string
 	tables         []string
 	streamSnapshot bool
+	signalTable    string
+	includeDDL     bool
+
+	// schemaHistory and ddlTables are only accessed by the canal event handler.
+	schemaHistory schemaHistory
+	ddlTables     []string
+
+	incrementalSnapshotter *incrementalSnapshotter
 
 	batching                  service.BatchPolicy
 	batchPolicy               *service.Batcher
@@ -268,6 +310,19 @@ func newMySQLStreamInput(conf *service.ParsedConfig, res *service.Resources) (s
 		return nil, err
 	}
 
+	if i.signalTable, err = conf.FieldString(fieldSignalTable); err != nil {
+		return nil, err
+	}
+	if i.signalTable != "" {
+		if err = validateTableName(i.signalTable); err != nil {
+			return nil, err
+		}
+	}
+
+	if i.includeDDL, err = conf.FieldBool(fieldIncludeDDL); err != nil {
+		return nil, err
+	}
+
 	if i.fieldSnapshotMaxBatchSize, err = conf.FieldInt(fieldSnapshotMaxBatchSize); err != nil {
 		return nil, err
 	}
@@ -353,7 +408,7 @@ func (i *mysqlStreamInput) Connect(ctx context.Context) error {
 	canalConfig.ParseTime = true
 	// canalConfig.Logger
 
-	for _, table := range i.tables {
+	for _, table := range i.streamedTables() {
 		canalConfig.IncludeTableRegex = append(
 			canalConfig.IncludeTableRegex,
 			"^"+regexp.QuoteMeta(i.mysqlConfig.DBName+"."+table)+"$",
@@ -381,6 +436,15 @@ func (i *mysqlStreamInput) Connect(ctx context.Context) error {
 		snapshot = NewSnapshot(i.logger, db)
 	}
 
+	i.incrementalSnapshotter = nil
+	if i.signalTable != "" {
+		db, err := sql.Open("mysql", i.mysqlConfig.FormatDSN())
+		if err != nil {
+			return fmt.Errorf("failed to connect to MySQL server: %s", err)
+		}
+		i.incrementalSnapshotter = newIncrementalSnapshotter(i.logger, db, i.signalTable, i.tables, i.fieldSnapshotMaxBatchSize)
+	}
+
 	// Reset the shutSig
 	sig := shutdown.NewSignaller()
 	i.shutSig = sig
@@ -394,6 +458,12 @@ func (i *mysqlStreamInput) Connect(ctx context.Context) error {
 		})
 		wg.Go(func() error { return i.readMessages(ctx) })
 		wg.Go(func() error { return i.startMySQLSync(ctx, pos, snapshot) })
+		if incrementalSnapshotter := i.incrementalSnapshotter; incrementalSnapshotter != nil {
+			wg.Go(func() error {
+				defer incrementalSnapshotter.db.Close()
+				return incrementalSnapshotter.run(ctx)
+			})
+		}
 		if err := wg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
 			i.logger.Errorf("error during MySQL CDC: %s", err)
 		} else {
@@ -434,6 +504,9 @@ func (i *mysqlStreamInput) startMySQLSync(ctx context.Context, pos *position, sn
 	}
 	i.logger.Infof("starting MySQL CDC stream from binlog %s at offset %d", pos.Name, pos.Pos)
 	i.currentBinlogName = pos.Name
+	if err := i.loadSchemaHistory(ctx, *pos); err != nil {
+		return fmt.Errorf("unable to load schema history: %w", err)
+	}
 	i.canal.SetEventHandler(i)
 	if err := i.canal.RunFrom(*pos); err != nil {
 		return fmt.Errorf("failed to start streaming: %w", err)
@@ -786,8 +859,93 @@ func (i *mysqlStreamInput) setCachedBinlogPosition(ctx context.Context, binLogPo
 	return nil
 }
 
+func (i *mysqlStreamInput) schemaHistoryCacheKey() string {
+	return i.binLogCacheKey + "_schema_history"
+}
+
+func (i *mysqlStreamInput) getCachedSchemaHistory(ctx context.Context) (schemaHistory, error) {
+	var (
+		history  schemaHistory
+		cacheVal []byte
+		cErr     error
+	)
+	if err := i.res.AccessCache(ctx, i.binLogCache, func(c service.Cache) {
+		cacheVal, cErr = c.Get(ctx, i.schemaHistoryCacheKey())
+	}); err != nil {
+		return history, fmt.Errorf("unable to access cache for reading: %w", err)
+	}
+	if errors.Is(cErr, service.ErrKeyNotFound) {
+		return history, nil
+	} else if cErr != nil {
+		return history, fmt.Errorf("unable read schema history from cache: %w", cErr)
+	} else if cacheVal == nil {
+		return history, nil
+	}
+	if err := json.Unmarshal(cacheVal, &history); err != nil {
+		return history, fmt.Errorf("unable to parse schema history: %w", err)
+	}
+	return history, nil
+}
+
+func (i *mysqlStreamInput) setCachedSchemaHistory(ctx context.Context, history schemaHistory) error {
+	b, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("unable to serialize schema history: %w", err)
+	}
+	var cErr error
+	if err := i.res.AccessCache(ctx, i.binLogCache, func(c service.Cache) {
+		cErr = c.Set(ctx, i.schemaHistoryCacheKey(), b, nil)
+	}); err != nil {
+		return fmt.Errorf("unable to access cache for writing: %w", err)
+	}
+	if cErr != nil {
+		return fmt.Errorf("unable persist schema history to cache: %w", cErr)
+	}
+	return nil
+}
+
 // ---- cache methods end ----
 
+// loadSchemaHistory seeds the table cache of canal with the schemas that were
+// valid at the position the binlog is streamed from. Tables without a recorded
+// schema are recorded with their current schema.
+func (i *mysqlStreamInput) loadSchemaHistory(ctx context.Context, pos position) error {
+	history, err := i.getCachedSchemaHistory(ctx)
+	if err != nil {
+		return err
+	}
+	history.prune(pos)
+
+	db := i.mysqlConfig.DBName
+	for _, table := range i.streamedTables() {
+		if e, ok := history.at(table, pos); ok {
+			if e.Table != nil {
+				i.canal.SetTableCache([]byte(db), []byte(table), e.Table)
+			}
+			continue
+		}
+		t, err := i.canal.GetTable(db, table)
+		if errors.Is(err, schema.ErrTableNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to get schema of table %s: %w", table, err)
+		}
+		history.record(table, pos, t)
+	}
+
+	i.schemaHistory = history
+	return i.setCachedSchemaHistory(ctx, history)
+}
+
+func (i *mysqlStreamInput) streamedTables() []string {
+	if i.signalTable == "" {
+		return i.tables
+	}
+	// The signal table needs to be streamed in order for snapshot requests
+	// and watermarks to be observed.
+	return append(slices.Clone(i.tables), i.signalTable)
+}
+
 // --- MySQL Canal handler methods ----
 
 func (i *mysqlStreamInput) OnRotate(_ *replication.EventHeader, re *replication.RotateEvent) error {
@@ -795,6 +953,61 @@ func (i *mysqlStreamInput) OnRotate(_ *replication.EventHeader, re *replication.
 	return nil
 }
 
+// OnTableChanged is called for each table changed by a DDL statement, after
+// canal has cleared the schema of the table from its cache.
+func (i *mysqlStreamInput) OnTableChanged(header *replication.EventHeader, db, table string) error {
+	if db != i.mysqlConfig.DBName || !slices.Contains(i.streamedTables(), table) {
+		return nil
+	}
+	i.ddlTables = append(i.ddlTables, table)
+
+	pos := position{Name: i.currentBinlogName, Pos: header.LogPos}
+	if e, ok := i.schemaHistory.lookup(table, pos); ok {
+		// The statement is replayed, and so the current schema of the table
+		// might be more recent than the one that follows it.
+		if e.Table != nil {
+			i.canal.SetTableCache([]byte(db), []byte(table), e.Table)
+		}
+		return nil
+	}
+
+	t, err := i.canal.GetTable(db, table)
+	if err != nil && !errors.Is(err, schema.ErrTableNotExist) {
+		return fmt.Errorf("unable to get schema of table %s: %w", table, err)
+	}
+	i.schemaHistory.record(table, pos, t)
+
+	ctx, done := i.shutSig.HardStopCtx(context.Background())
+	defer done()
+	return i.setCachedSchemaHistory(ctx, i.schemaHistory)
+}
+
+// OnDDL is called for each DDL statement after the tables it changes have been
+// passed to OnTableChanged.
+func (i *mysqlStreamInput) OnDDL(header *replication.EventHeader, _ gomysql.Position, e *replication.QueryEvent) error {
+	tables := i.ddlTables
+	i.ddlTables = nil
+	if !i.includeDDL {
+		return nil
+	}
+	for _, table := range tables {
+		if !slices.Contains(i.tables, table) {
+			continue
+		}
+		i.rawMessageEvents <- MessageEvent{
+			Row: map[string]any{
+				"statement": string(e.Query),
+				"database":  i.mysqlConfig.DBName,
+				"table":     table,
+			},
+			Operation: MessageOperationDDL,
+			Table:     table,
+			Position:  &position{Name: i.currentBinlogName, Pos: header.LogPos},
+		}
+	}
+	return nil
+}
+
 func (i *mysqlStreamInput) OnRow(e *canal.RowsEvent) error {
 	switch e.Action {
 	case canal.InsertAction:
@@ -810,15 +1023,16 @@ func (i *mysqlStreamInput) OnRow(e *canal.RowsEvent) error {
 }
 
 func (i *mysqlStreamInput) onMessage(e *canal.RowsEvent, initValue, incrementValue int) error {
+	if i.incrementalSnapshotter != nil && e.Table.Name == i.signalTable {
+		return i.onSignal(e)
+	}
 	for pi := initValue; pi < len(e.Rows); pi += incrementValue {
-		message := map[string]any{}
-		for i, v := range e.Rows[pi] {
-			col := e.Table.Columns[i]
-			v, err := mapMessageColumn(v, col)
-			if err != nil {
-				return err
-			}
-			message[col.Name] = v
+		message, err := mapMessageRow(e, pi)
+		if err != nil {
+			return err
+		}
+		if i.incrementalSnapshotter != nil {
+			i.incrementalSnapshotter.window.Observe(e.Table.Name, message)
 		}
 		i.rawMessageEvents <- MessageEvent{
 			Row:       message,
@@ -830,6 +1044,48 @@ func (i *mysqlStreamInput) onMessage(e *canal.RowsEvent, initValue, incrementVal
 	return nil
 }
 
+// onSignal processes rows inserted into the signal table, which are never
+// emitted themselves, and emits the rows of incremental snapshot chunks that
+// are completed by them.
+func (i *mysqlStreamInput) onSignal(e *canal.RowsEvent) error {
+	if e.Action != canal.InsertAction {
+		return nil
+	}
+	for pi := range e.Rows {
+		signal, err := mapMessageRow(e, pi)
+		if err != nil {
+			return err
+		}
+		table, rows, err := i.incrementalSnapshotter.handleSignal(signal)
+		if err != nil {
+			i.logger.Errorf("Failed to process signal: %v", err)
+			continue
+		}
+		for _, row := range rows {
+			i.rawMessageEvents <- MessageEvent{
+				Row:       row,
+				Operation: MessageOperationRead,
+				Table:     table,
+				Position:  &position{Name: i.currentBinlogName, Pos: e.Header.LogPos},
+			}
+		}
+	}
+	return nil
+}
+
+func mapMessageRow(e *canal.RowsEvent, pi int) (map[string]any, error) {
+	message := map[string]any{}
+	for i, v := range e.Rows[pi] {
+		col := e.Table.Columns[i]
+		v, err := mapMessageColumn(v, col)
+		if err != nil {
+			return nil, err
+		}
+		message[col.Name] = v
+	}
+	return message, nil
+}
+
 func mapMessageColumn(v any, col schema.TableColumn) (any, error) {
 	if v == nil {
 		return v, nil
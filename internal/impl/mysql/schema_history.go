@@ -0,0 +1,86 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed as a Redpanda Enterprise file under the Redpanda Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// https://github.com/redpanda-data/connect/v4/blob/main/licenses/rcl.md
+
+package mysql
+
+import (
+	"slices"
+
+	"github.com/go-mysql-org/go-mysql/schema"
+)
+
+// schemaHistoryEntry is the schema of a table from a binlog position onwards.
+// A nil table means that the table was dropped at the position.
+type schemaHistoryEntry struct {
+	Position position      `json:"position"`
+	Table    *schema.Table `json:"table"`
+}
+
+// schemaHistory records the schema of tables at each schema change, so that
+// rows replayed from the binlog after a restart are decoded with the schema
+// that was valid at their position rather than the current schema of the
+// table.
+type schemaHistory struct {
+	Tables map[string][]schemaHistoryEntry `json:"tables"`
+}
+
+// record sets the schema of a table from a position onwards, where entries of
+// a table are kept ordered by their position.
+func (h *schemaHistory) record(table string, pos position, t *schema.Table) {
+	if h.Tables == nil {
+		h.Tables = map[string][]schemaHistoryEntry{}
+	}
+	entries := h.Tables[table]
+	idx, found := slices.BinarySearchFunc(entries, pos, func(e schemaHistoryEntry, p position) int {
+		return e.Position.Compare(p)
+	})
+	entry := schemaHistoryEntry{Position: pos, Table: t}
+	if found {
+		entries[idx] = entry
+	} else {
+		entries = slices.Insert(entries, idx, entry)
+	}
+	h.Tables[table] = entries
+}
+
+// lookup returns the schema change of a table that was recorded at exactly
+// the given position.
+func (h *schemaHistory) lookup(table string, pos position) (schemaHistoryEntry, bool) {
+	for _, e := range h.Tables[table] {
+		if e.Position.Compare(pos) == 0 {
+			return e, true
+		}
+	}
+	return schemaHistoryEntry{}, false
+}
+
+// at returns the schema of a table that is valid at the given position, which
+// is the most recent entry at or before the position.
+func (h *schemaHistory) at(table string, pos position) (schemaHistoryEntry, bool) {
+	entries := h.Tables[table]
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Position.Compare(pos) <= 0 {
+			return entries[i], true
+		}
+	}
+	return schemaHistoryEntry{}, false
+}
+
+// prune removes the entries that are superseded at the given position, as the
+// binlog is never replayed from before it.
+func (h *schemaHistory) prune(pos position) {
+	for table, entries := range h.Tables {
+		var keepFrom int
+		for i, e := range entries {
+			if e.Position.Compare(pos) <= 0 {
+				keepFrom = i
+			}
+		}
+		h.Tables[table] = entries[keepFrom:]
+	}
+}
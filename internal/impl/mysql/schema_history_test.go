@@ -0,0 +1,63 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed as a Redpanda Enterprise file under the Redpanda Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// https://github.com/redpanda-data/connect/v4/blob/main/licenses/rcl.md
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-mysql-org/go-mysql/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaHistory(t *testing.T) {
+	v1 := &schema.Table{Name: "foo", Columns: []schema.TableColumn{{Name: "a"}}}
+	v2 := &schema.Table{Name: "foo", Columns: []schema.TableColumn{{Name: "a"}, {Name: "b"}}}
+
+	var h schemaHistory
+	h.record("foo", position{Name: "binlog.000002", Pos: 10}, v2)
+	h.record("foo", position{Name: "binlog.000001", Pos: 100}, v1)
+	h.record("foo", position{Name: "binlog.000003", Pos: 4}, nil)
+
+	_, ok := h.at("foo", position{Name: "binlog.000001", Pos: 99})
+	assert.False(t, ok)
+	e, ok := h.at("foo", position{Name: "binlog.000001", Pos: 100})
+	require.True(t, ok)
+	assert.Equal(t, v1, e.Table)
+	e, ok = h.at("foo", position{Name: "binlog.000002", Pos: 4})
+	require.True(t, ok)
+	assert.Equal(t, v1, e.Table)
+	e, ok = h.at("foo", position{Name: "binlog.000002", Pos: 11})
+	require.True(t, ok)
+	assert.Equal(t, v2, e.Table)
+	e, ok = h.at("foo", position{Name: "binlog.000003", Pos: 4})
+	require.True(t, ok)
+	assert.Nil(t, e.Table)
+	_, ok = h.at("bar", position{Name: "binlog.000003", Pos: 4})
+	assert.False(t, ok)
+
+	e, ok = h.lookup("foo", position{Name: "binlog.000002", Pos: 10})
+	require.True(t, ok)
+	assert.Equal(t, v2, e.Table)
+	_, ok = h.lookup("foo", position{Name: "binlog.000002", Pos: 11})
+	assert.False(t, ok)
+
+	b, err := json.Marshal(h)
+	require.NoError(t, err)
+	var decoded schemaHistory
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, h, decoded)
+
+	h.prune(position{Name: "binlog.000002", Pos: 20})
+	assert.Equal(t, []schemaHistoryEntry{
+		{Position: position{Name: "binlog.000002", Pos: 10}, Table: v2},
+		{Position: position{Name: "binlog.000003", Pos: 4}},
+	}, h.Tables["foo"])
+}
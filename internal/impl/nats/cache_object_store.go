@@ -0,0 +1,187 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Jeffail/shutdown"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsObjectStoreCacheConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Services").
+		Version("4.73.0").
+		Summary("Cache values as objects in a NATS object store bucket.").
+		Description(`
+Each key of the cache is the name of an object within the bucket. The object store has no atomic create operation, and so the ` + "`add`" + ` operation checks for an existing object before writing it, which is not safe against concurrent writers.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(objectStoreDocs()...)
+}
+
+func init() {
+	service.MustRegisterCache(
+		"nats_object_store", natsObjectStoreCacheConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Cache, error) {
+			return newObjectStoreCache(conf, mgr)
+		},
+	)
+}
+
+type objectStoreCache struct {
+	connDetails connectionDetails
+	bucket      string
+
+	log *service.Logger
+
+	shutSig *shutdown.Signaller
+
+	connMut     sync.RWMutex
+	natsConn    *nats.Conn
+	objectStore jetstream.ObjectStore
+}
+
+func newObjectStoreCache(conf *service.ParsedConfig, mgr *service.Resources) (*objectStoreCache, error) {
+	p := &objectStoreCache{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if p.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+
+	if p.bucket, err = conf.FieldString(osFieldBucket); err != nil {
+		return nil, err
+	}
+
+	err = p.connect(context.Background())
+	return p, err
+}
+
+func (p *objectStoreCache) disconnect() {
+	p.connMut.Lock()
+	defer p.connMut.Unlock()
+
+	if p.natsConn != nil {
+		p.natsConn.Close()
+		p.natsConn = nil
+	}
+	p.objectStore = nil
+}
+
+func (p *objectStoreCache) connect(ctx context.Context) error {
+	p.connMut.Lock()
+	defer p.connMut.Unlock()
+
+	if p.natsConn != nil {
+		return nil
+	}
+
+	var err error
+	if p.natsConn, err = p.connDetails.get(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			p.natsConn.Close()
+			p.natsConn = nil
+		}
+	}()
+
+	var js jetstream.JetStream
+	if js, err = jetstream.New(p.natsConn); err != nil {
+		return err
+	}
+
+	if p.objectStore, err = js.ObjectStore(ctx, p.bucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *objectStoreCache) Get(ctx context.Context, key string) ([]byte, error) {
+	p.connMut.RLock()
+	defer p.connMut.RUnlock()
+
+	value, err := p.objectStore.GetBytes(ctx, key)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrObjectNotFound) {
+			err = service.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func (p *objectStoreCache) Set(ctx context.Context, key string, value []byte, _ *time.Duration) error {
+	p.connMut.RLock()
+	defer p.connMut.RUnlock()
+
+	_, err := p.objectStore.Put(ctx, jetstream.ObjectMeta{Name: key}, bytes.NewReader(value))
+	return err
+}
+
+func (p *objectStoreCache) Add(ctx context.Context, key string, value []byte, _ *time.Duration) error {
+	p.connMut.RLock()
+	defer p.connMut.RUnlock()
+
+	_, err := p.objectStore.GetInfo(ctx, key)
+	if err == nil {
+		return service.ErrKeyAlreadyExists
+	}
+	if !errors.Is(err, jetstream.ErrObjectNotFound) {
+		return err
+	}
+	_, err = p.objectStore.Put(ctx, jetstream.ObjectMeta{Name: key}, bytes.NewReader(value))
+	return err
+}
+
+func (p *objectStoreCache) Delete(ctx context.Context, key string) error {
+	p.connMut.RLock()
+	defer p.connMut.RUnlock()
+
+	err := p.objectStore.Delete(ctx, key)
+	if errors.Is(err, jetstream.ErrObjectNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (p *objectStoreCache) Close(ctx context.Context) error {
+	go func() {
+		p.disconnect()
+		p.shutSig.TriggerHasStopped()
+	}()
+	select {
+	case <-p.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 
 const (
 	kvFieldBucket = "bucket"
+	osFieldBucket = "bucket"
 )
 
 const (
@@ -60,3 +61,15 @@ func kvDocs(extraFields ...*service.ConfigField) []*service.ConfigField {
 
 	return fields
 }
+
+func objectStoreDocs(extraFields ...*service.ConfigField) []*service.ConfigField {
+	fields := append(
+		connectionHeadFields(),
+		service.NewStringField(osFieldBucket).
+			Description("The name of the object store bucket.").Example("my_object_bucket"),
+	)
+	fields = append(fields, extraFields...)
+	fields = append(fields, connectionTailFields()...)
+
+	return fields
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Jeffail/shutdown"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	osiFieldWatch   = "watch"
+	osiFieldScanner = "scanner"
+)
+
+func natsObjectStoreInputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Services").
+		Version("4.73.0").
+		Summary("Reads objects from a NATS object store bucket.").
+		Description(`
+By default the objects that exist in the bucket when the input connects are read once, after which the input shuts down. When ` + "`watch`" + ` is enabled the input instead keeps running and also reads objects as they are added to or updated within the bucket. Deleted objects are ignored.
+
+The contents of each object are consumed through a ` + "`scanner`" + `, which by default emits the whole object as a single message.
+
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+` + "``` text" + `
+- nats_object_store_name
+- nats_object_store_bucket
+- nats_object_store_nuid
+- nats_object_store_size
+- nats_object_store_modified
+- nats_object_store_digest
+` + "```" + `
+
+Any headers of an object are also added to its messages as metadata.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(objectStoreDocs([]*service.ConfigField{
+			service.NewBoolField(osiFieldWatch).
+				Description("Whether to keep watching the bucket for new and updated objects after the existing objects have been read.").
+				Default(false),
+			service.NewScannerField(osiFieldScanner).
+				Description("The xref:components:scanners/about.adoc[scanner] by which the contents of each object are consumed.").
+				Default(map[string]any{"to_the_end": map[string]any{}}),
+			service.NewAutoRetryNacksToggleField(),
+		}...)...)
+}
+
+func init() {
+	service.MustRegisterBatchInput(
+		"nats_object_store", natsObjectStoreInputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+			reader, err := newObjectStoreReader(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacksBatchedToggled(conf, reader)
+		},
+	)
+}
+
+type objectStoreReader struct {
+	connDetails connectionDetails
+	bucket      string
+	watch       bool
+	scanner     *service.OwnedScannerBuilder
+
+	log *service.Logger
+
+	shutSig *shutdown.Signaller
+
+	connMut     sync.Mutex
+	natsConn    *nats.Conn
+	objectStore jetstream.ObjectStore
+	watcher     jetstream.ObjectWatcher
+
+	// Objects that are yet to be read when listing the bucket rather than
+	// watching it.
+	listed  bool
+	pending []*jetstream.ObjectInfo
+
+	objMut     sync.Mutex
+	objInfo    *jetstream.ObjectInfo
+	objScanner *service.OwnedScanner
+}
+
+func newObjectStoreReader(conf *service.ParsedConfig, mgr *service.Resources) (*objectStoreReader, error) {
+	r := &objectStoreReader{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if r.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+
+	if r.bucket, err = conf.FieldString(osFieldBucket); err != nil {
+		return nil, err
+	}
+
+	if r.watch, err = conf.FieldBool(osiFieldWatch); err != nil {
+		return nil, err
+	}
+
+	if r.scanner, err = conf.FieldScanner(osiFieldScanner); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *objectStoreReader) Connect(ctx context.Context) (err error) {
+	r.connMut.Lock()
+	defer r.connMut.Unlock()
+
+	if r.natsConn != nil {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			if r.watcher != nil {
+				_ = r.watcher.Stop()
+				r.watcher = nil
+			}
+			if r.natsConn != nil {
+				r.natsConn.Close()
+				r.natsConn = nil
+			}
+		}
+	}()
+
+	if r.natsConn, err = r.connDetails.get(ctx); err != nil {
+		return err
+	}
+
+	js, err := jetstream.New(r.natsConn)
+	if err != nil {
+		return err
+	}
+
+	if r.objectStore, err = js.ObjectStore(ctx, r.bucket); err != nil {
+		return err
+	}
+
+	if r.watch {
+		r.watcher, err = r.objectStore.Watch(ctx, jetstream.IgnoreDeletes())
+		return err
+	}
+
+	if !r.listed {
+		objects, err := r.objectStore.List(ctx)
+		if err != nil && !errors.Is(err, jetstream.ErrNoObjectsFound) {
+			return err
+		}
+		r.pending = objects
+		r.listed = true
+	}
+	return nil
+}
+
+func (r *objectStoreReader) disconnect() {
+	r.connMut.Lock()
+	defer r.connMut.Unlock()
+
+	if r.watcher != nil {
+		_ = r.watcher.Stop()
+		r.watcher = nil
+	}
+	if r.natsConn != nil {
+		r.natsConn.Close()
+		r.natsConn = nil
+	}
+	r.objectStore = nil
+}
+
+// nextObject blocks until the next object to read is available, returning
+// io.EOF once all objects of the bucket have been listed.
+func (r *objectStoreReader) nextObject(ctx context.Context) (jetstream.ObjectStore, *jetstream.ObjectInfo, error) {
+	r.connMut.Lock()
+	objectStore, watcher := r.objectStore, r.watcher
+	if objectStore != nil && watcher == nil {
+		defer r.connMut.Unlock()
+		if len(r.pending) == 0 {
+			return nil, nil, io.EOF
+		}
+		info := r.pending[0]
+		r.pending = r.pending[1:]
+		return objectStore, info, nil
+	}
+	r.connMut.Unlock()
+
+	if objectStore == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	for {
+		var info *jetstream.ObjectInfo
+		var open bool
+		select {
+		case info, open = <-watcher.Updates():
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+
+		if !open {
+			r.disconnect()
+			return nil, nil, service.ErrNotConnected
+		}
+
+		// A nil update marks the end of the initial objects of the bucket.
+		if info == nil {
+			continue
+		}
+		return objectStore, info, nil
+	}
+}
+
+func (r *objectStoreReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	r.objMut.Lock()
+	defer r.objMut.Unlock()
+
+	for {
+		if r.objScanner == nil {
+			objectStore, info, err := r.nextObject(ctx)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil, nil, service.ErrEndOfInput
+				}
+				return nil, nil, err
+			}
+
+			// The object is read lazily by the scanner and so the context of
+			// the read must outlive this call.
+			obj, err := objectStore.Get(context.Background(), info.Name)
+			if err != nil {
+				if errors.Is(err, jetstream.ErrObjectNotFound) {
+					r.log.Debugf("Skipping object %v as it was deleted before it could be read", info.Name)
+					continue
+				}
+				return nil, nil, err
+			}
+
+			details := service.NewScannerSourceDetails()
+			details.SetName(info.Name)
+			if r.objScanner, err = r.scanner.Create(obj, func(context.Context, error) error {
+				return nil
+			}, details); err != nil {
+				_ = obj.Close()
+				return nil, nil, err
+			}
+			r.objInfo = info
+
+			r.log.With(
+				metaObjectStoreBucket, info.Bucket,
+				metaObjectStoreName, info.Name,
+				metaObjectStoreSize, info.Size,
+			).Debugf("Reading object store object")
+		}
+
+		batch, ackFn, err := r.objScanner.NextBatch(ctx)
+		if err != nil {
+			_ = r.objScanner.Close(ctx)
+			r.objScanner, r.objInfo = nil, nil
+			if errors.Is(err, io.EOF) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		for _, msg := range batch {
+			objectInfoToMessage(r.objInfo, msg)
+		}
+		return batch, ackFn, nil
+	}
+}
+
+func (r *objectStoreReader) Close(ctx context.Context) error {
+	go func() {
+		r.objMut.Lock()
+		if r.objScanner != nil {
+			_ = r.objScanner.Close(context.Background())
+			r.objScanner, r.objInfo = nil, nil
+		}
+		r.objMut.Unlock()
+
+		r.disconnect()
+		r.shutSig.TriggerHasStopped()
+	}()
+	select {
+	case <-r.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestInputObjectStoreParse(t *testing.T) {
+	spec := natsObjectStoreInputConfig()
+	env := service.NewEnvironment()
+
+	conf, err := spec.ParseYAML(`
+urls: [ url1, url2 ]
+bucket: testbucket
+watch: true
+scanner:
+  lines: {}
+auth:
+  user_jwt: test auth inline user JWT
+  user_nkey_seed: test auth inline user NKey Seed
+`, env)
+	require.NoError(t, err)
+
+	r, err := newObjectStoreReader(conf, service.MockResources())
+	require.NoError(t, err)
+
+	assert.Equal(t, "url1,url2", r.connDetails.urls)
+	assert.Equal(t, "testbucket", r.bucket)
+	assert.True(t, r.watch)
+	assert.NotNil(t, r.scanner)
+	assert.Equal(t, "test auth inline user JWT", r.connDetails.authConf.UserJWT)
+	assert.Equal(t, "test auth inline user NKey Seed", r.connDetails.authConf.UserNkeySeed)
+}
+
+func TestOutputObjectStoreParse(t *testing.T) {
+	spec := natsObjectStoreOutputConfig()
+	env := service.NewEnvironment()
+
+	conf, err := spec.ParseYAML(`
+urls: [ url1 ]
+bucket: testbucket
+object_name: ${! meta("path") }
+headers:
+  Content-Type: application/json
+metadata:
+  include_prefixes: [ foo_ ]
+`, env)
+	require.NoError(t, err)
+
+	o, err := newObjectStoreOutput(conf, service.MockResources())
+	require.NoError(t, err)
+
+	assert.Equal(t, "url1", o.connDetails.urls)
+	assert.Equal(t, "testbucket", o.bucket)
+	assert.Contains(t, o.headers, "Content-Type")
+	assert.NotNil(t, o.metaFilter)
+
+	msg := service.NewMessage(nil)
+	msg.MetaSetMut("path", "foo/bar.json")
+	name, err := o.objectName.TryString(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "foo/bar.json", name)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/benthos/v4/public/service/integration"
+)
+
+func TestIntegrationNatsObjectStore(t *testing.T) {
+	integration.CheckSkip(t)
+	t.Parallel()
+
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+
+	pool.MaxWait = time.Second * 30
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "nats",
+		Tag:        "latest",
+		Cmd:        []string{"--js", "--trace"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, pool.Purge(resource))
+	})
+
+	var natsConn *nats.Conn
+	_ = resource.Expire(900)
+	require.NoError(t, pool.Retry(func() error {
+		natsConn, err = nats.Connect(fmt.Sprintf("tcp://localhost:%v", resource.GetPort("4222/tcp")))
+		return err
+	}))
+	t.Cleanup(func() {
+		natsConn.Close()
+	})
+
+	createBucket := func(t testing.TB, name string) jetstream.ObjectStore {
+		js, err := jetstream.New(natsConn)
+		require.NoError(t, err)
+
+		obs, err := js.CreateObjectStore(t.Context(), jetstream.ObjectStoreConfig{
+			Bucket: name,
+		})
+		require.NoError(t, err)
+		return obs
+	}
+
+	template := `
+output:
+  label: object_store_output
+  nats_object_store:
+    urls: [ tcp://localhost:$PORT ]
+    bucket: bucket-$ID
+    # Objects of the same name are replaced, and so every message must be
+    # written to a unique object.
+    object_name: ${! ksuid() }
+    metadata:
+      include_prefixes: [ foo ]
+
+input:
+  label: object_store_input
+  nats_object_store:
+    urls: [ tcp://localhost:$PORT ]
+    bucket: bucket-$ID
+    watch: true
+`
+	suite := integration.StreamTests(
+		integration.StreamTestOpenClose(),
+		integration.StreamTestMetadata(),
+		integration.StreamTestSendBatch(10),
+		integration.StreamTestStreamParallel(100),
+		integration.StreamTestStreamSequential(100),
+	)
+	suite.Run(
+		t, template,
+		integration.StreamTestOptPreTest(func(t testing.TB, _ context.Context, vars *integration.StreamTestConfigVars) {
+			createBucket(t, "bucket-"+vars.ID)
+		}),
+		integration.StreamTestOptSleepAfterInput(100*time.Millisecond),
+		integration.StreamTestOptSleepAfterOutput(100*time.Millisecond),
+		integration.StreamTestOptPort(resource.GetPort("4222/tcp")),
+	)
+
+	t.Run("cache", func(t *testing.T) {
+		template := `
+cache_resources:
+  - label: testcache
+    nats_object_store:
+      bucket: bucket-$ID
+      urls: [ tcp://localhost:$PORT ]`
+		suite := integration.CacheTests(
+			integration.CacheTestOpenClose(),
+			integration.CacheTestMissingKey(),
+			integration.CacheTestDoubleAdd(),
+			integration.CacheTestDelete(),
+			integration.CacheTestGetAndSet(50),
+		)
+		suite.Run(
+			t, template,
+			integration.CacheTestOptPreTest(func(t testing.TB, _ context.Context, vars *integration.CacheTestConfigVars) {
+				createBucket(t, "bucket-"+vars.ID)
+			}),
+			integration.CacheTestOptPort(resource.GetPort("4222/tcp")),
+		)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		u4, err := uuid.NewV4()
+		require.NoError(t, err)
+
+		obs := createBucket(t, "bucket-"+u4.String())
+		_, err = obs.Put(t.Context(), jetstream.ObjectMeta{
+			Name:    "blob",
+			Headers: nats.Header{"foo": []string{"bar"}},
+		}, strings.NewReader("first\nsecond"))
+		require.NoError(t, err)
+
+		conf, err := natsObjectStoreInputConfig().ParseYAML(fmt.Sprintf(`
+urls: [ tcp://localhost:%v ]
+bucket: bucket-%v
+scanner:
+  lines: {}
+`, resource.GetPort("4222/tcp"), u4.String()), nil)
+		require.NoError(t, err)
+
+		r, err := newObjectStoreReader(conf, service.MockResources())
+		require.NoError(t, err)
+		require.NoError(t, r.Connect(t.Context()))
+		t.Cleanup(func() {
+			require.NoError(t, r.Close(context.Background()))
+		})
+
+		var values []string
+		for {
+			batch, _, err := r.ReadBatch(t.Context())
+			if errors.Is(err, service.ErrEndOfInput) {
+				break
+			}
+			require.NoError(t, err)
+			for _, m := range batch {
+				b, err := m.AsBytes()
+				require.NoError(t, err)
+				values = append(values, string(b))
+
+				name, _ := m.MetaGet(metaObjectStoreName)
+				assert.Equal(t, "blob", name)
+				foo, _ := m.MetaGet("foo")
+				assert.Equal(t, "bar", foo)
+			}
+		}
+		assert.Equal(t, []string{"first", "second"}, values)
+	})
+
+	t.Run("processor", func(t *testing.T) {
+		u4, err := uuid.NewV4()
+		require.NoError(t, err)
+
+		obs := createBucket(t, "bucket-"+u4.String())
+		_, err = obs.PutString(t.Context(), "blob", "lawblog")
+		require.NoError(t, err)
+
+		conf, err := natsObjectStoreProcessorConfig().ParseYAML(fmt.Sprintf(`
+urls: [ tcp://localhost:%v ]
+bucket: bucket-%v
+object_name: ${! content() }
+`, resource.GetPort("4222/tcp"), u4.String()), nil)
+		require.NoError(t, err)
+
+		p, err := newObjectStoreProcessor(conf, service.MockResources())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, p.Close(context.Background()))
+		})
+
+		result, err := p.Process(t.Context(), service.NewMessage([]byte("blob")))
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+
+		b, err := result[0].AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, "lawblog", string(b))
+
+		size, _ := result[0].MetaGet(metaObjectStoreSize)
+		assert.Equal(t, "7", size)
+
+		_, err = p.Process(t.Context(), service.NewMessage([]byte("nope")))
+		require.ErrorIs(t, err, jetstream.ErrObjectNotFound)
+	})
+}
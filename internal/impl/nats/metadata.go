@@ -15,6 +15,8 @@
 package nats
 
 import (
+	"time"
+
 	"github.com/nats-io/nats.go/jetstream"
 
 	"github.com/redpanda-data/benthos/v4/public/service"
@@ -29,6 +31,15 @@ const (
 	metaKVCreated   = "nats_kv_created"
 )
 
+const (
+	metaObjectStoreName     = "nats_object_store_name"
+	metaObjectStoreBucket   = "nats_object_store_bucket"
+	metaObjectStoreNUID     = "nats_object_store_nuid"
+	metaObjectStoreSize     = "nats_object_store_size"
+	metaObjectStoreModified = "nats_object_store_modified"
+	metaObjectStoreDigest   = "nats_object_store_digest"
+)
+
 func newMessageFromKVEntry(entry jetstream.KeyValueEntry) *service.Message {
 	msg := service.NewMessage(entry.Value())
 	msg.MetaSetMut(metaKVKey, entry.Key())
@@ -40,3 +51,17 @@ func newMessageFromKVEntry(entry jetstream.KeyValueEntry) *service.Message {
 
 	return msg
 }
+
+func objectInfoToMessage(info *jetstream.ObjectInfo, msg *service.Message) {
+	for k := range info.Headers {
+		if v := info.Headers.Get(k); v != "" {
+			msg.MetaSetMut(k, v)
+		}
+	}
+	msg.MetaSetMut(metaObjectStoreName, info.Name)
+	msg.MetaSetMut(metaObjectStoreBucket, info.Bucket)
+	msg.MetaSetMut(metaObjectStoreNUID, info.NUID)
+	msg.MetaSetMut(metaObjectStoreSize, info.Size)
+	msg.MetaSetMut(metaObjectStoreModified, info.ModTime.Format(time.RFC3339))
+	msg.MetaSetMut(metaObjectStoreDigest, info.Digest)
+}
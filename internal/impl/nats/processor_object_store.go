@@ -0,0 +1,214 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Jeffail/shutdown"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	ospFieldObjectName = "object_name"
+	ospFieldTimeout    = "timeout"
+)
+
+func natsObjectStoreProcessorConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Services").
+		Version("4.73.0").
+		Summary("Fetches objects from a NATS object store bucket by name.").
+		Description(`
+Each message is replaced with the contents of the object named by ` + "`object_name`" + `, which supports
+xref:configuration:interpolation.adoc#bloblang-queries[interpolation functions].
+
+== Metadata
+
+This processor adds the following metadata fields to each message:
+
+` + "``` text" + `
+- nats_object_store_name
+- nats_object_store_bucket
+- nats_object_store_nuid
+- nats_object_store_size
+- nats_object_store_modified
+- nats_object_store_digest
+` + "```" + `
+
+Any headers of the object are also added to the message as metadata.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(objectStoreDocs([]*service.ConfigField{
+			service.NewInterpolatedStringField(ospFieldObjectName).
+				Description("The name of the object to fetch for each message.").
+				Example("foo").
+				Example(`${! meta("nats_object_store_name") }`).
+				Example(`${! json("path") }`),
+			service.NewDurationField(ospFieldTimeout).
+				Description("The maximum period to wait on fetching an object before aborting and returning an error.").
+				Advanced().Default("5s"),
+		}...)...)
+}
+
+func init() {
+	service.MustRegisterProcessor(
+		"nats_object_store", natsObjectStoreProcessorConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return newObjectStoreProcessor(conf, mgr)
+		},
+	)
+}
+
+type objectStoreProcessor struct {
+	connDetails connectionDetails
+	bucket      string
+	objectName  *service.InterpolatedString
+	timeout     time.Duration
+
+	log *service.Logger
+
+	shutSig *shutdown.Signaller
+
+	connMut     sync.Mutex
+	natsConn    *nats.Conn
+	objectStore jetstream.ObjectStore
+}
+
+func newObjectStoreProcessor(conf *service.ParsedConfig, mgr *service.Resources) (*objectStoreProcessor, error) {
+	p := &objectStoreProcessor{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if p.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+
+	if p.bucket, err = conf.FieldString(osFieldBucket); err != nil {
+		return nil, err
+	}
+
+	if p.objectName, err = conf.FieldInterpolatedString(ospFieldObjectName); err != nil {
+		return nil, err
+	}
+
+	if p.timeout, err = conf.FieldDuration(ospFieldTimeout); err != nil {
+		return nil, err
+	}
+
+	err = p.Connect(context.Background())
+	return p, err
+}
+
+func (p *objectStoreProcessor) disconnect() {
+	p.connMut.Lock()
+	defer p.connMut.Unlock()
+
+	if p.natsConn != nil {
+		p.natsConn.Close()
+		p.natsConn = nil
+	}
+	p.objectStore = nil
+}
+
+func (p *objectStoreProcessor) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	p.connMut.Lock()
+	objectStore := p.objectStore
+	p.connMut.Unlock()
+
+	name, err := p.objectName.TryString(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := context.WithTimeout(ctx, p.timeout)
+	defer done()
+
+	obj, err := objectStore.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := obj.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	m := msg.Copy()
+	m.SetBytes(data)
+	objectInfoToMessage(info, m)
+	return service.MessageBatch{m}, nil
+}
+
+func (p *objectStoreProcessor) Connect(ctx context.Context) (err error) {
+	p.connMut.Lock()
+	defer p.connMut.Unlock()
+
+	if p.natsConn != nil {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			if p.natsConn != nil {
+				p.natsConn.Close()
+			}
+		}
+	}()
+
+	if p.natsConn, err = p.connDetails.get(ctx); err != nil {
+		return err
+	}
+
+	js, err := jetstream.New(p.natsConn)
+	if err != nil {
+		return err
+	}
+
+	p.objectStore, err = js.ObjectStore(ctx, p.bucket)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *objectStoreProcessor) Close(ctx context.Context) error {
+	go func() {
+		p.disconnect()
+		p.shutSig.TriggerHasStopped()
+	}()
+	select {
+	case <-p.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
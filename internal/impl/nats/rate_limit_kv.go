@@ -0,0 +1,248 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Jeffail/shutdown"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	kvrlFieldCount    = "count"
+	kvrlFieldInterval = "interval"
+	kvrlFieldKey      = "key"
+
+	// The number of times an access is attempted when racing with other
+	// instances to update the window.
+	kvrlMaxAttempts = 10
+)
+
+func natsKVRateLimitConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Categories("Services").
+		Version("4.73.0").
+		Summary("A rate limit implementation using a NATS key-value bucket.").
+		Description(`
+It works by using a fixed window algorithm to limit the number of requests to a given count within a given time period. The current window is stored as a key within the bucket and is updated with optimistic concurrency control, and therefore the rate limit is shared across all instances of Redpanda Connect that use the same bucket and key, which must all have a consistent count and interval.
+
+Windows are calculated using the local clock of each instance, and therefore instances sharing a rate limit should have reasonably synchronised clocks.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(kvDocs(
+			service.NewIntField(kvrlFieldCount).
+				Description("The maximum number of requests to allow for a given period of time.").
+				Default(1000).
+				LintRule(`root = if this <= 0 { [ "count must be larger than zero" ] }`),
+			service.NewDurationField(kvrlFieldInterval).
+				Description("The time window to limit requests by.").
+				Default("1s"),
+			service.NewStringField(kvrlFieldKey).
+				Description("The key within the bucket to use for the rate limit."),
+		)...)
+}
+
+func init() {
+	service.MustRegisterRateLimit(
+		"nats_kv", natsKVRateLimitConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.RateLimit, error) {
+			return newKVRateLimit(conf, mgr)
+		},
+	)
+}
+
+// kvRateLimitWindow is the state of a fixed window as stored within a bucket.
+type kvRateLimitWindow struct {
+	// The unix time in milliseconds at which the window ends.
+	End   int64 `json:"end"`
+	Count int   `json:"count"`
+}
+
+// access attempts to consume from a window at a given time, returning the
+// updated window if successful or the time to wait until the window ends.
+func (w kvRateLimitWindow) access(now time.Time, size int, period time.Duration) (kvRateLimitWindow, time.Duration) {
+	nowMillis := now.UnixMilli()
+	if nowMillis >= w.End {
+		return kvRateLimitWindow{End: nowMillis + period.Milliseconds(), Count: 1}, 0
+	}
+	if w.Count >= size {
+		return w, time.Duration(w.End-nowMillis) * time.Millisecond
+	}
+	w.Count++
+	return w, 0
+}
+
+type kvRateLimit struct {
+	connDetails connectionDetails
+	bucket      string
+	key         string
+	size        int
+	period      time.Duration
+
+	log *service.Logger
+
+	shutSig *shutdown.Signaller
+
+	connMut  sync.RWMutex
+	natsConn *nats.Conn
+	kv       jetstream.KeyValue
+}
+
+func newKVRateLimit(conf *service.ParsedConfig, mgr *service.Resources) (*kvRateLimit, error) {
+	r := &kvRateLimit{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if r.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+
+	if r.bucket, err = conf.FieldString(kvFieldBucket); err != nil {
+		return nil, err
+	}
+
+	if r.key, err = conf.FieldString(kvrlFieldKey); err != nil {
+		return nil, err
+	}
+
+	if r.size, err = conf.FieldInt(kvrlFieldCount); err != nil {
+		return nil, err
+	}
+	if r.size <= 0 {
+		return nil, errors.New("count must be larger than zero")
+	}
+
+	if r.period, err = conf.FieldDuration(kvrlFieldInterval); err != nil {
+		return nil, err
+	}
+
+	err = r.connect(context.Background())
+	return r, err
+}
+
+func (r *kvRateLimit) disconnect() {
+	r.connMut.Lock()
+	defer r.connMut.Unlock()
+
+	if r.natsConn != nil {
+		r.natsConn.Close()
+		r.natsConn = nil
+	}
+	r.kv = nil
+}
+
+func (r *kvRateLimit) connect(ctx context.Context) error {
+	r.connMut.Lock()
+	defer r.connMut.Unlock()
+
+	if r.natsConn != nil {
+		return nil
+	}
+
+	var err error
+	if r.natsConn, err = r.connDetails.get(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			r.natsConn.Close()
+			r.natsConn = nil
+		}
+	}()
+
+	var js jetstream.JetStream
+	if js, err = jetstream.New(r.natsConn); err != nil {
+		return err
+	}
+
+	if r.kv, err = js.KeyValue(ctx, r.bucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *kvRateLimit) Access(ctx context.Context) (time.Duration, error) {
+	r.connMut.RLock()
+	defer r.connMut.RUnlock()
+
+	if r.kv == nil {
+		return 0, service.ErrNotConnected
+	}
+
+	for range kvrlMaxAttempts {
+		var current kvRateLimitWindow
+		var revision uint64
+
+		entry, err := r.kv.Get(ctx, r.key)
+		if err == nil {
+			if err := json.Unmarshal(entry.Value(), &current); err != nil {
+				return 0, fmt.Errorf("failed to parse rate limit window: %w", err)
+			}
+			revision = entry.Revision()
+		} else if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			return 0, fmt.Errorf("accessing nats_kv rate limit: %w", err)
+		}
+
+		next, wait := current.access(time.Now(), r.size, r.period)
+		if wait > 0 {
+			return wait, nil
+		}
+
+		nextBytes, err := json.Marshal(next)
+		if err != nil {
+			return 0, err
+		}
+
+		if revision == 0 {
+			_, err = r.kv.Create(ctx, r.key, nextBytes)
+		} else {
+			_, err = r.kv.Update(ctx, r.key, nextBytes, revision)
+		}
+		if err == nil {
+			return 0, nil
+		}
+		if !errors.Is(err, jetstream.ErrKeyExists) {
+			return 0, fmt.Errorf("accessing nats_kv rate limit: %w", err)
+		}
+		// Another instance modified the window since we read it, try again.
+	}
+	return 0, fmt.Errorf("failed to access nats_kv rate limit after %v conflicting attempts", kvrlMaxAttempts)
+}
+
+func (r *kvRateLimit) Close(ctx context.Context) error {
+	go func() {
+		r.disconnect()
+		r.shutSig.TriggerHasStopped()
+	}()
+	select {
+	case <-r.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestKVRateLimitWindowAccess(t *testing.T) {
+	now := time.UnixMilli(10_000)
+
+	// No existing window.
+	w, wait := kvRateLimitWindow{}.access(now, 2, time.Second)
+	assert.Equal(t, time.Duration(0), wait)
+	assert.Equal(t, kvRateLimitWindow{End: 11_000, Count: 1}, w)
+
+	w, wait = w.access(now.Add(time.Millisecond*100), 2, time.Second)
+	assert.Equal(t, time.Duration(0), wait)
+	assert.Equal(t, kvRateLimitWindow{End: 11_000, Count: 2}, w)
+
+	w, wait = w.access(now.Add(time.Millisecond*400), 2, time.Second)
+	assert.Equal(t, time.Millisecond*600, wait)
+	assert.Equal(t, kvRateLimitWindow{End: 11_000, Count: 2}, w)
+
+	// The window has ended.
+	w, wait = w.access(now.Add(time.Second), 2, time.Second)
+	assert.Equal(t, time.Duration(0), wait)
+	assert.Equal(t, kvRateLimitWindow{End: 12_000, Count: 1}, w)
+}
+
+func TestKVRateLimitConfErrors(t *testing.T) {
+	conf, err := natsKVRateLimitConfig().ParseYAML(`
+urls: [ nats://localhost:4222 ]
+bucket: foo
+key: bar
+count: -1
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newKVRateLimit(conf, service.MockResources())
+	require.ErrorContains(t, err, "count must be larger than zero")
+
+	_, err = natsKVRateLimitConfig().ParseYAML(`
+urls: [ nats://localhost:4222 ]
+bucket: foo
+`, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,413 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/checkpoint"
+	"github.com/opensearch-project/opensearch-go/v3/opensearchapi"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	esoiFieldQuery           = "query"
+	esoiFieldSort            = "sort"
+	esoiFieldBatchSize       = "batch_size"
+	esoiFieldKeepAlive       = "keep_alive"
+	esoiFieldCheckpointCache = "checkpoint_cache"
+	esoiFieldCheckpointKey   = "checkpoint_key"
+)
+
+// InputSpec returns the config spec for an opensearch input.
+func InputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.73.0").
+		Categories("Services").
+		Summary(`Executes a query against an OpenSearch index and creates a message for each document found.`).
+		Description(`
+Results are paged through using a https://docs.opensearch.org/latest/search-plugins/searching-data/point-in-time/[point in time^] and `+"`search_after`"+`, which gives a consistent view of the index for the duration of the export. Once all documents have been consumed the input shuts down, allowing the pipeline to gracefully terminate (or the next input in a xref:components:inputs/sequence.adoc[sequence] to execute).
+
+== Checkpointing
+
+When a `+"`"+esoiFieldCheckpointCache+"`"+` is configured the sort values of the latest document to be delivered are stored in the cache once all prior documents have been acknowledged. When the input is restarted the export resumes after the stored sort values instead of starting from the beginning.
+
+Resuming is only reliable when the `+"`"+esoiFieldSort+"`"+` fields uniquely and consistently identify each document. The default sort on `+"`_id`"+` satisfies this, but sorting on a timestamp followed by a unique keyword field is typically more efficient for large indexes.
+
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```text"+`
+- opensearch_index
+- opensearch_id
+- opensearch_sort
+`+"```"+`
+
+The `+"`opensearch_sort`"+` field contains the sort values of the document as a JSON array.`).
+		Fields(
+			service.NewStringListField(esoFieldURLs).
+				Description("A list of URLs to connect to. If an item of the list contains commas it will be expanded into multiple URLs.").
+				Example([]string{"http://localhost:9200"}),
+			service.NewStringField(esoFieldIndex).
+				Description("The index to read documents from. Multiple comma separated indexes, aliases and wildcard expressions are supported.").
+				Example("things").
+				Example("logs-*"),
+			service.NewBloblangField(esoiFieldQuery).
+				Description("A xref:guides:bloblang/about.adoc[Bloblang mapping] that produces the https://docs.opensearch.org/latest/query-dsl/[query DSL^] object used to select documents. The mapping is executed once without an input message.").
+				Default(`root.match_all = {}`).
+				Example(`root.range.timestamp.gte = "now-1d/d"`).
+				Example(`root.bool.filter = [{"term": {"status": "active"}}]`),
+			service.NewAnyListField(esoiFieldSort).
+				Description("The sort to apply to the search, which determines the order of documents and the values used for `search_after` pagination. The sort must uniquely identify each document.").
+				Default([]any{map[string]any{"_id": "asc"}}).
+				Example([]any{map[string]any{"timestamp": "asc"}, map[string]any{"id": "asc"}}).
+				Advanced(),
+			service.NewIntField(esoiFieldBatchSize).
+				Description("The maximum number of documents to fetch in each search request, each page of documents is emitted as a batch.").
+				Default(1000).
+				LintRule(`root = if this < 1 { ["field must be greater than zero"] }`),
+			service.NewDurationField(esoiFieldKeepAlive).
+				Description("The period of time for which the point in time is kept alive between each search request. If it expires a new point in time is opened, which continues after the last document read but also sees changes made since the first one was opened.").
+				Default("5m").
+				Advanced(),
+			service.NewStringField(esoiFieldCheckpointCache).
+				Description("An optional xref:components:caches/about.adoc[cache resource] used to store the sort values of the latest acknowledged document, allowing interrupted exports to resume.").
+				Optional(),
+			service.NewStringField(esoiFieldCheckpointKey).
+				Description("The key to store the checkpoint under within the `"+esoiFieldCheckpointCache+"`.").
+				Default("opensearch_search_after").
+				Advanced(),
+			service.NewTLSToggledField(esoFieldTLS),
+			service.NewObjectField(esoFieldAuth,
+				service.NewBoolField(esoFieldAuthEnabled).
+					Description("Whether to use basic authentication in requests.").
+					Default(false),
+				service.NewStringField(esoFieldAuthUsername).
+					Description("A username to authenticate as.").
+					Default(""),
+				service.NewStringField(esoFieldAuthPassword).
+					Description("A password to authenticate with.").
+					Default("").Secret(),
+			).Description("Allows you to specify basic authentication.").
+				Advanced().
+				Optional(),
+			AWSField(),
+			service.NewAutoRetryNacksToggleField(),
+		).
+		Example("Re-indexing documents", "Here we copy all documents from one OpenSearch index to another, keeping their IDs.", `
+input:
+  opensearch:
+    urls: ['http://localhost:9200']
+    index: things
+    query: |
+      root.range.updated_at.gte = "now-7d/d"
+output:
+  opensearch:
+    urls: ['http://localhost:9200']
+    index: things_v2
+    action: index
+    id: ${! @opensearch_id }
+`)
+}
+
+func init() {
+	service.MustRegisterBatchInput("opensearch", InputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
+			in, err := InputFromParsed(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacksBatchedToggled(conf, in)
+		})
+}
+
+// Input implements service.BatchInput for opensearch.
+type Input struct {
+	log *service.Logger
+	mgr *service.Resources
+
+	clientOpts      opensearchapi.Config
+	indices         []string
+	query           any
+	sort            []any
+	batchSize       int
+	keepAlive       time.Duration
+	checkpointCache string
+	checkpointKey   string
+
+	checkpointer *checkpoint.Uncapped[json.RawMessage]
+
+	mut         sync.Mutex
+	client      *opensearchapi.Client
+	pitID       string
+	searchAfter json.RawMessage
+	done        bool
+}
+
+// InputFromParsed returns an opensearch input from a parsed config.
+func InputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*Input, error) {
+	e := &Input{
+		log:          mgr.Logger(),
+		mgr:          mgr,
+		checkpointer: checkpoint.NewUncapped[json.RawMessage](),
+	}
+	var err error
+	if e.clientOpts, err = esoClientOptsFromParsed(conf); err != nil {
+		return nil, err
+	}
+	index, err := conf.FieldString(esoFieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range strings.SplitSeq(index, ",") {
+		if idx = strings.TrimSpace(idx); idx != "" {
+			e.indices = append(e.indices, idx)
+		}
+	}
+	queryMapping, err := conf.FieldBloblang(esoiFieldQuery)
+	if err != nil {
+		return nil, err
+	}
+	if e.query, err = queryMapping.Query(nil); err != nil {
+		return nil, fmt.Errorf("executing %s mapping: %w", esoiFieldQuery, err)
+	}
+	if e.sort, err = conf.FieldAnyList(esoiFieldSort); err != nil {
+		return nil, err
+	}
+	if e.batchSize, err = conf.FieldInt(esoiFieldBatchSize); err != nil {
+		return nil, err
+	}
+	if e.keepAlive, err = conf.FieldDuration(esoiFieldKeepAlive); err != nil {
+		return nil, err
+	}
+	if conf.Contains(esoiFieldCheckpointCache) {
+		if e.checkpointCache, err = conf.FieldString(esoiFieldCheckpointCache); err != nil {
+			return nil, err
+		}
+		if !mgr.HasCache(e.checkpointCache) {
+			return nil, fmt.Errorf("cache resource %q was not found", e.checkpointCache)
+		}
+	}
+	if e.checkpointKey, err = conf.FieldString(esoiFieldCheckpointKey); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Connect attempts to connect to the server and opens a point in time.
+func (e *Input) Connect(ctx context.Context) error {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client != nil {
+		return nil
+	}
+
+	client, err := opensearchapi.NewClient(e.clientOpts)
+	if err != nil {
+		return err
+	}
+
+	// When reconnecting after the point in time was lost the search resumes
+	// after the last sort values that were read rather than the checkpoint.
+	if e.checkpointCache != "" && e.searchAfter == nil {
+		var cacheErr error
+		if err := e.mgr.AccessCache(ctx, e.checkpointCache, func(c service.Cache) {
+			var v []byte
+			if v, cacheErr = c.Get(ctx, e.checkpointKey); errors.Is(cacheErr, service.ErrKeyNotFound) {
+				cacheErr = nil
+			}
+			if len(v) > 0 {
+				e.searchAfter = v
+			}
+		}); err != nil {
+			return err
+		}
+		if cacheErr != nil {
+			return fmt.Errorf("reading checkpoint: %w", cacheErr)
+		}
+		if e.searchAfter != nil {
+			e.log.Infof("Resuming search after sort values %s", e.searchAfter)
+		}
+	}
+
+	pit, err := client.PointInTime.Create(ctx, opensearchapi.PointInTimeCreateReq{
+		Indices: e.indices,
+		Params:  opensearchapi.PointInTimeCreateParams{KeepAlive: e.keepAlive},
+	})
+	if err != nil {
+		return fmt.Errorf("creating point in time: %w", err)
+	}
+
+	e.client = client
+	e.pitID = pit.PitID
+	return nil
+}
+
+// searchHit is decoded manually rather than with opensearchapi.SearchHit so
+// that the sort values retain their exact representation, large integers lose
+// precision when decoded as floats.
+type searchHit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	Sort   json.RawMessage `json:"sort"`
+}
+
+// errPointInTimeMissing is returned by a search when its point in time has
+// expired or no longer exists.
+var errPointInTimeMissing = errors.New("point in time is missing")
+
+type searchResponse struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+func (e *Input) search(ctx context.Context) (*searchResponse, error) {
+	body := map[string]any{
+		"size":             e.batchSize,
+		"query":            e.query,
+		"sort":             e.sort,
+		"track_total_hits": false,
+		"pit": map[string]any{
+			"id":         e.pitID,
+			"keep_alive": strconv.FormatInt(e.keepAlive.Milliseconds(), 10) + "ms",
+		},
+	}
+	if e.searchAfter != nil {
+		body["search_after"] = e.searchAfter
+	}
+	reqBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding search request: %w", err)
+	}
+
+	req, err := opensearchapi.SearchReq{Body: bytes.NewReader(reqBytes)}.GetRequest()
+	if err != nil {
+		return nil, fmt.Errorf("building search request: %w", err)
+	}
+	res, err := e.client.Client.Perform(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("sending search request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading search response: %w", err)
+	}
+	if res.StatusCode >= 300 {
+		if bytes.Contains(resBytes, []byte("search_context_missing_exception")) {
+			return nil, fmt.Errorf("%w: %s", errPointInTimeMissing, resBytes)
+		}
+		return nil, fmt.Errorf("search request failed with status %v: %s", res.StatusCode, resBytes)
+	}
+
+	var result searchResponse
+	if err := json.Unmarshal(resBytes, &result); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+	return &result, nil
+}
+
+// ReadBatch reads the next page of documents from the point in time.
+func (e *Input) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+	if e.done {
+		return nil, nil, service.ErrEndOfInput
+	}
+
+	res, err := e.search(ctx)
+	if errors.Is(err, errPointInTimeMissing) {
+		// The point in time expired, most likely because the keep alive
+		// lapsed between reads, and so a new one is opened by reconnecting.
+		e.log.Warnf("Reopening point in time: %v", err)
+		e.client = nil
+		e.pitID = ""
+		return nil, nil, service.ErrNotConnected
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.PitID != "" {
+		e.pitID = res.PitID
+	}
+	if len(res.Hits.Hits) == 0 {
+		e.done = true
+		return nil, nil, service.ErrEndOfInput
+	}
+
+	batch := make(service.MessageBatch, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		msg := service.NewMessage(hit.Source)
+		msg.MetaSetMut("opensearch_index", hit.Index)
+		msg.MetaSetMut("opensearch_id", hit.ID)
+		msg.MetaSetMut("opensearch_sort", string(hit.Sort))
+		batch = append(batch, msg)
+	}
+	e.searchAfter = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+
+	release := e.checkpointer.Track(e.searchAfter, int64(len(batch)))
+	return batch, func(ctx context.Context, _ error) error {
+		highest := release()
+		if highest == nil || e.checkpointCache == "" {
+			return nil
+		}
+		var setErr error
+		if err := e.mgr.AccessCache(ctx, e.checkpointCache, func(c service.Cache) {
+			setErr = c.Set(ctx, e.checkpointKey, *highest, nil)
+		}); err != nil {
+			return err
+		}
+		return setErr
+	}, nil
+}
+
+// Close deletes the point in time.
+func (e *Input) Close(ctx context.Context) error {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.client == nil || e.pitID == "" {
+		return nil
+	}
+	closeCtx, done := context.WithTimeout(ctx, 10*time.Second)
+	defer done()
+	if _, err := e.client.PointInTime.Delete(closeCtx, opensearchapi.PointInTimeDeleteReq{
+		PitID: []string{e.pitID},
+	}); err != nil {
+		e.log.Warnf("Failed to delete point in time: %v", err)
+	}
+	e.pitID = ""
+	return nil
+}
@@ -17,6 +17,7 @@ package opensearch_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -158,6 +159,10 @@ func TestIntegrationOpensearch(t *testing.T) {
 	t.Run("TestOpenSearchBatchIDCollision", func(te *testing.T) {
 		testOpenSearchBatchIDCollision(urls, client, te)
 	})
+
+	t.Run("TestOpenSearchInput", func(te *testing.T) {
+		testOpenSearchInput(urls, client, te)
+	})
 }
 
 func testOpenSearchNoIndex(urls []string, client *os.Client, t *testing.T) {
@@ -572,3 +577,78 @@ action: update
 	assert.Equal(t, "updated", tmp.Source["user"])
 	assert.Equal(t, "goodbye", tmp.Source["message"])
 }
+
+func inputFromConf(t testing.TB, res *service.Resources, confStr string, args ...any) *opensearch.Input {
+	t.Helper()
+
+	pConf, err := opensearch.InputSpec().ParseYAML(fmt.Sprintf(confStr, args...), nil)
+	require.NoError(t, err)
+
+	i, err := opensearch.InputFromParsed(pConf, res)
+	require.NoError(t, err)
+
+	return i
+}
+
+func testOpenSearchInput(urls []string, client *os.Client, t *testing.T) {
+	ctx, done := context.WithTimeout(t.Context(), time.Second*30)
+	defer done()
+
+	o := outputFromConf(t, `
+index: input_index
+id: 'doc-${!json("n")}'
+urls: %v
+action: index
+`, urls)
+	require.NoError(t, o.Connect(ctx))
+
+	var batch service.MessageBatch
+	for i := range 25 {
+		batch = append(batch, service.NewMessage(fmt.Appendf(nil, `{"n":%v,"even":%v}`, i, i%2 == 0)))
+	}
+	require.NoError(t, o.WriteBatch(ctx, batch))
+	require.NoError(t, o.Close(ctx))
+
+	_, err := client.Do(ctx, osapi.IndicesRefreshReq{Indices: []string{"input_index"}}, nil)
+	require.NoError(t, err)
+
+	res := service.MockResources(service.MockResourcesOptAddCache("checkpoints"))
+	readAll := func(i *opensearch.Input, ackUntil int) (ids []string) {
+		require.NoError(t, i.Connect(ctx))
+		defer func() {
+			require.NoError(t, i.Close(ctx))
+		}()
+		for {
+			b, ackFn, err := i.ReadBatch(ctx)
+			if errors.Is(err, service.ErrEndOfInput) {
+				return
+			}
+			require.NoError(t, err)
+			for _, m := range b {
+				id, _ := m.MetaGet("opensearch_id")
+				ids = append(ids, id)
+			}
+			if len(ids) <= ackUntil {
+				require.NoError(t, ackFn(ctx, nil))
+			}
+		}
+	}
+
+	conf := `
+index: input_index
+urls: %v
+query: 'root.term.even = true'
+sort: [ { n: asc } ]
+batch_size: 5
+checkpoint_cache: checkpoints
+`
+	ids := readAll(inputFromConf(t, res, conf, urls), 10)
+	require.Len(t, ids, 13)
+	assert.Equal(t, "doc-0", ids[0])
+	assert.Equal(t, "doc-24", ids[12])
+
+	// Only the first two batches were acknowledged, so we resume from there.
+	ids = readAll(inputFromConf(t, res, conf, urls), 0)
+	require.Len(t, ids, 3)
+	assert.Equal(t, []string{"doc-20", "doc-22", "doc-24"}, ids)
+}
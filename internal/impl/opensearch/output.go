@@ -84,9 +84,7 @@ type esoConfig struct {
 	routingStr  *service.InterpolatedString
 }
 
-func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error) {
-	conf.clientOpts = opensearchapi.Config{}
-
+func esoClientOptsFromParsed(pConf *service.ParsedConfig) (opts opensearchapi.Config, err error) {
 	var tmpURLs []string
 	if tmpURLs, err = pConf.FieldStringList(esoFieldURLs); err != nil {
 		return
@@ -94,7 +92,7 @@ func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error
 	for _, u := range tmpURLs {
 		for splitURL := range strings.SplitSeq(u, ",") {
 			if splitURL != "" {
-				conf.clientOpts.Client.Addresses = append(conf.clientOpts.Client.Addresses, splitURL)
+				opts.Client.Addresses = append(opts.Client.Addresses, splitURL)
 			}
 		}
 	}
@@ -102,10 +100,10 @@ func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error
 	{
 		authConf := pConf.Namespace(esoFieldAuth)
 		if enabled, _ := authConf.FieldBool(esoFieldAuthEnabled); enabled {
-			if conf.clientOpts.Client.Username, err = authConf.FieldString(esoFieldAuthUsername); err != nil {
+			if opts.Client.Username, err = authConf.FieldString(esoFieldAuthUsername); err != nil {
 				return
 			}
-			if conf.clientOpts.Client.Password, err = authConf.FieldString(esoFieldAuthPassword); err != nil {
+			if opts.Client.Password, err = authConf.FieldString(esoFieldAuthPassword); err != nil {
 				return
 			}
 		}
@@ -116,11 +114,20 @@ func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error
 	if tlsConf, tlsEnabled, err = pConf.FieldTLSToggled(esoFieldTLS); err != nil {
 		return
 	} else if tlsEnabled {
-		conf.clientOpts.Client.Transport = &http.Transport{
+		opts.Client.Transport = &http.Transport{
 			TLSClientConfig: tlsConf,
 		}
 	}
 
+	err = AWSOptFn(pConf.Namespace(esoFieldAWS), &opts)
+	return
+}
+
+func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error) {
+	if conf.clientOpts, err = esoClientOptsFromParsed(pConf); err != nil {
+		return
+	}
+
 	if conf.actionStr, err = pConf.FieldInterpolatedString(esoFieldAction); err != nil {
 		return
 	}
@@ -136,10 +143,6 @@ func esoConfigFromParsed(pConf *service.ParsedConfig) (conf esoConfig, err error
 	if conf.routingStr, err = pConf.FieldInterpolatedString(esoFieldRouting); err != nil {
 		return
 	}
-
-	if err = AWSOptFn(pConf.Namespace(esoFieldAWS), &conf.clientOpts); err != nil {
-		return
-	}
 	return
 }
 
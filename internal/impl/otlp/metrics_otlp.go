@@ -0,0 +1,351 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	omFieldService          = "service"
+	omFieldHTTP             = "http"
+	omFieldGRPC             = "grpc"
+	omFieldAddress          = "address"
+	omFieldSecure           = "secure"
+	omFieldHeaders          = "headers"
+	omFieldTags             = "tags"
+	omFieldTemporality      = "temporality"
+	omFieldHistogramBuckets = "histogram_buckets"
+	omFieldExportInterval   = "export_interval"
+)
+
+func otlpMetricsSpec() *service.ConfigSpec {
+	collectorFields := func(defaultAddress string) []*service.ConfigField {
+		return []*service.ConfigField{
+			service.NewStringField(omFieldAddress).
+				Description("The endpoint of a collector to send metrics to.").
+				Default(defaultAddress),
+			service.NewBoolField(omFieldSecure).
+				Description("Connect to the collector with transport security.").
+				Default(false),
+			service.NewStringMapField(omFieldHeaders).
+				Description("A map of headers to add to every export request, such as authentication tokens.").
+				Default(map[string]any{}).
+				Advanced(),
+		}
+	}
+
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.73.0").
+		Summary("Send metrics to an https://opentelemetry.io/docs/collector/[Open Telemetry collector^] using the OTLP protocol.").
+		Description(`
+Metrics are aggregated in memory and pushed to each collector at the interval specified by `+"`export_interval`"+`. Timing metrics are exported as histograms with values converted from nanoseconds into seconds in order to better fit within bucket definitions.`).
+		Fields(
+			service.NewStringField(omFieldService).
+				Default("benthos").
+				Description("The name of the service in metrics."),
+			service.NewObjectListField(omFieldHTTP, collectorFields("localhost:4318")...).
+				Description("A list of http collectors.").
+				Default([]any{}),
+			service.NewObjectListField(omFieldGRPC, collectorFields("localhost:4317")...).
+				Description("A list of grpc collectors.").
+				Default([]any{}),
+			service.NewStringMapField(omFieldTags).
+				Description("A map of tags to add to the resource attributes of all metrics.").
+				Default(map[string]any{}).
+				Advanced(),
+			service.NewStringEnumField(omFieldTemporality, "cumulative", "delta").
+				Description("The aggregation temporality of exported counters and histograms. Some backends, such as Datadog and Dynatrace, expect delta temporality.").
+				Default("cumulative").
+				Advanced(),
+			service.NewFloatListField(omFieldHistogramBuckets).
+				Description("Timing metrics histogram buckets (in seconds).").
+				Default([]any{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0}).
+				Advanced(),
+			service.NewDurationField(omFieldExportInterval).
+				Description("The period of time between each export of metrics to the collectors.").
+				Default("60s").
+				Advanced(),
+		).
+		Example("Send to a local collector", "", `
+metrics:
+  otlp:
+    grpc:
+      - address: localhost:4317
+    tags:
+      deployment.environment: production
+`)
+}
+
+func init() {
+	service.MustRegisterMetricsExporter("otlp", otlpMetricsSpec(),
+		func(conf *service.ParsedConfig, log *service.Logger) (service.MetricsExporter, error) {
+			return newOtlpMetricsFromParsed(conf, log)
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type metricsCollector struct {
+	address string
+	secure  bool
+	headers map[string]string
+}
+
+func metricsCollectorsFromParsed(conf *service.ParsedConfig, name string) ([]metricsCollector, error) {
+	list, err := conf.FieldObjectList(name)
+	if err != nil {
+		return nil, err
+	}
+	collectors := make([]metricsCollector, 0, len(list))
+	for _, pc := range list {
+		var c metricsCollector
+		if c.address, err = pc.FieldString(omFieldAddress); err != nil {
+			return nil, err
+		}
+		if c.secure, err = pc.FieldBool(omFieldSecure); err != nil {
+			return nil, err
+		}
+		if c.headers, err = pc.FieldStringMap(omFieldHeaders); err != nil {
+			return nil, err
+		}
+		collectors = append(collectors, c)
+	}
+	return collectors, nil
+}
+
+func deltaTemporality(sdkmetric.InstrumentKind) metricdata.Temporality {
+	return metricdata.DeltaTemporality
+}
+
+type otlpMetrics struct {
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+	buckets  []float64
+	log      *service.Logger
+}
+
+func newOtlpMetricsFromParsed(conf *service.ParsedConfig, log *service.Logger) (*otlpMetrics, error) {
+	serviceName, err := conf.FieldString(omFieldService)
+	if err != nil {
+		return nil, err
+	}
+
+	httpCollectors, err := metricsCollectorsFromParsed(conf, omFieldHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcCollectors, err := metricsCollectorsFromParsed(conf, omFieldGRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := conf.FieldStringMap(omFieldTags)
+	if err != nil {
+		return nil, err
+	}
+
+	temporality, err := conf.FieldString(omFieldTemporality)
+	if err != nil {
+		return nil, err
+	}
+	temporalitySelector := sdkmetric.DefaultTemporalitySelector
+	if temporality == "delta" {
+		temporalitySelector = deltaTemporality
+	}
+
+	buckets, err := conf.FieldFloatList(omFieldHistogramBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := conf.FieldDuration(omFieldExportInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	var readers []sdkmetric.Reader
+	for _, c := range grpcCollectors {
+		clientOpts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(c.address),
+			otlpmetricgrpc.WithTemporalitySelector(temporalitySelector),
+		}
+		if !c.secure {
+			clientOpts = append(clientOpts, otlpmetricgrpc.WithInsecure())
+		}
+		if len(c.headers) > 0 {
+			clientOpts = append(clientOpts, otlpmetricgrpc.WithHeaders(c.headers))
+		}
+		exp, err := otlpmetricgrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating grpc exporter for %v: %w", c.address, err)
+		}
+		readers = append(readers, sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(interval)))
+	}
+	for _, c := range httpCollectors {
+		clientOpts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(c.address),
+			otlpmetrichttp.WithTemporalitySelector(temporalitySelector),
+		}
+		if !c.secure {
+			clientOpts = append(clientOpts, otlpmetrichttp.WithInsecure())
+		}
+		if len(c.headers) > 0 {
+			clientOpts = append(clientOpts, otlpmetrichttp.WithHeaders(c.headers))
+		}
+		exp, err := otlpmetrichttp.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating http exporter for %v: %w", c.address, err)
+		}
+		readers = append(readers, sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(interval)))
+	}
+	return newOtlpMetrics(log, readers, buckets, resourceAttributes(serviceName, conf.EngineVersion(), tags)), nil
+}
+
+func newOtlpMetrics(log *service.Logger, readers []sdkmetric.Reader, buckets []float64, attrs []attribute.KeyValue) *otlpMetrics {
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	}
+	for _, r := range readers {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
+	provider := sdkmetric.NewMeterProvider(opts...)
+	return &otlpMetrics{
+		provider: provider,
+		meter:    provider.Meter("github.com/redpanda-data/connect"),
+		buckets:  buckets,
+		log:      log,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func labelsOption(labels, values []string) metric.MeasurementOption {
+	if len(labels) != len(values) {
+		return metric.WithAttributeSet(*attribute.EmptySet())
+	}
+	kvs := make([]attribute.KeyValue, len(labels))
+	for i := range labels {
+		kvs[i] = attribute.String(labels[i], values[i])
+	}
+	return metric.WithAttributeSet(attribute.NewSet(kvs...))
+}
+
+type otlpCounter struct {
+	c   metric.Float64Counter
+	opt metric.MeasurementOption
+}
+
+func (o *otlpCounter) Incr(count int64) {
+	o.c.Add(context.Background(), float64(count), o.opt)
+}
+
+func (o *otlpCounter) IncrFloat64(count float64) {
+	o.c.Add(context.Background(), count, o.opt)
+}
+
+type otlpTimer struct {
+	h   metric.Float64Histogram
+	opt metric.MeasurementOption
+}
+
+func (o *otlpTimer) Timing(delta int64) {
+	o.h.Record(context.Background(), time.Duration(delta).Seconds(), o.opt)
+}
+
+type otlpGauge struct {
+	g   metric.Float64Gauge
+	opt metric.MeasurementOption
+}
+
+func (o *otlpGauge) Set(value int64) {
+	o.g.Record(context.Background(), float64(value), o.opt)
+}
+
+func (o *otlpGauge) SetFloat64(value float64) {
+	o.g.Record(context.Background(), value, o.opt)
+}
+
+type noopStat struct{}
+
+func (noopStat) Incr(int64)          {}
+func (noopStat) IncrFloat64(float64) {}
+func (noopStat) Timing(int64)        {}
+func (noopStat) Set(int64)           {}
+func (noopStat) SetFloat64(float64)  {}
+
+//------------------------------------------------------------------------------
+
+func (o *otlpMetrics) NewCounterCtor(name string, labelKeys ...string) service.MetricsExporterCounterCtor {
+	c, err := o.meter.Float64Counter(name)
+	if err != nil {
+		o.log.Errorf("Failed to create counter metric %v: %v", name, err)
+		return func(...string) service.MetricsExporterCounter { return noopStat{} }
+	}
+	return func(labelValues ...string) service.MetricsExporterCounter {
+		return &otlpCounter{c: c, opt: labelsOption(labelKeys, labelValues)}
+	}
+}
+
+func (o *otlpMetrics) NewTimerCtor(name string, labelKeys ...string) service.MetricsExporterTimerCtor {
+	h, err := o.meter.Float64Histogram(name,
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(o.buckets...))
+	if err != nil {
+		o.log.Errorf("Failed to create timer metric %v: %v", name, err)
+		return func(...string) service.MetricsExporterTimer { return noopStat{} }
+	}
+	return func(labelValues ...string) service.MetricsExporterTimer {
+		return &otlpTimer{h: h, opt: labelsOption(labelKeys, labelValues)}
+	}
+}
+
+func (o *otlpMetrics) NewGaugeCtor(name string, labelKeys ...string) service.MetricsExporterGaugeCtor {
+	g, err := o.meter.Float64Gauge(name)
+	if err != nil {
+		o.log.Errorf("Failed to create gauge metric %v: %v", name, err)
+		return func(...string) service.MetricsExporterGauge { return noopStat{} }
+	}
+	return func(labelValues ...string) service.MetricsExporterGauge {
+		return &otlpGauge{g: g, opt: labelsOption(labelKeys, labelValues)}
+	}
+}
+
+func (*otlpMetrics) HandlerFunc() http.HandlerFunc {
+	return nil
+}
+
+func (o *otlpMetrics) Close(ctx context.Context) error {
+	return o.provider.Shutdown(ctx)
+}
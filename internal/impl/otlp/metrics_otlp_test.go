@@ -0,0 +1,99 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestMetricsConfigParsing(t *testing.T) {
+	pConf, err := otlpMetricsSpec().ParseYAML(`
+http:
+  - address: foo:123
+    headers:
+      authorization: Bearer xyz
+grpc:
+  - secure: true
+temporality: delta
+`, nil)
+	require.NoError(t, err)
+
+	httpCollectors, err := metricsCollectorsFromParsed(pConf, omFieldHTTP)
+	require.NoError(t, err)
+	require.Len(t, httpCollectors, 1)
+	assert.Equal(t, "foo:123", httpCollectors[0].address)
+	assert.False(t, httpCollectors[0].secure)
+	assert.Equal(t, map[string]string{"authorization": "Bearer xyz"}, httpCollectors[0].headers)
+
+	grpcCollectors, err := metricsCollectorsFromParsed(pConf, omFieldGRPC)
+	require.NoError(t, err)
+	require.Len(t, grpcCollectors, 1)
+	assert.Equal(t, "localhost:4317", grpcCollectors[0].address)
+	assert.True(t, grpcCollectors[0].secure)
+}
+
+func TestMetricsRecording(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := newOtlpMetrics(service.MockResources().Logger(), []sdkmetric.Reader{reader}, []float64{0.1, 1}, resourceAttributes("foo", "1.2.3", nil))
+	t.Cleanup(func() {
+		require.NoError(t, m.Close(context.Background()))
+	})
+
+	m.NewCounterCtor("counter_a", "label_a")("value_a").Incr(3)
+	m.NewCounterCtor("counter_a", "label_a")("value_a").IncrFloat64(1.5)
+	m.NewTimerCtor("timer_a")().Timing(int64(time.Millisecond * 500))
+	m.NewGaugeCtor("gauge_a", "label_a", "label_b")("value_a", "value_b").Set(7)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+
+	serviceName, ok := rm.Resource.Set().Value("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "foo", serviceName.AsString())
+
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	counter, ok := metrics["counter_a"].(metricdata.Sum[float64])
+	require.True(t, ok)
+	require.Len(t, counter.DataPoints, 1)
+	assert.Equal(t, 4.5, counter.DataPoints[0].Value)
+	assert.Equal(t, attribute.NewSet(attribute.String("label_a", "value_a")), counter.DataPoints[0].Attributes)
+
+	timer, ok := metrics["timer_a"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, timer.DataPoints, 1)
+	assert.Equal(t, []float64{0.1, 1}, timer.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 1, 0}, timer.DataPoints[0].BucketCounts)
+	assert.Equal(t, 0.5, timer.DataPoints[0].Sum)
+
+	gauge, ok := metrics["gauge_a"].(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, 7.0, gauge.DataPoints[0].Value)
+}
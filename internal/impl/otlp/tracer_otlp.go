@@ -216,29 +216,34 @@ func newOtlp(config *otlp) (trace.TracerProvider, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	attrs := resourceAttributes(config.serviceName, config.engineVersion, config.tags)
+	opts = append(
+		opts,
+		tracesdk.WithIDGenerator(tracing.NewIDGenerator()),
+		tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	)
+
+	return tracesdk.NewTracerProvider(opts...), nil
+}
+
+func resourceAttributes(serviceName, engineVersion string, tags map[string]string) []attribute.KeyValue {
 	var attrs []attribute.KeyValue
 
-	for k, v := range config.tags {
+	for k, v := range tags {
 		attrs = append(attrs, attribute.String(k, v))
 	}
 
-	if _, ok := config.tags[string(semconv.ServiceNameKey)]; !ok {
-		attrs = append(attrs, semconv.ServiceNameKey.String(config.serviceName))
+	if _, ok := tags[string(semconv.ServiceNameKey)]; !ok {
+		attrs = append(attrs, semconv.ServiceNameKey.String(serviceName))
 
 		// Only set the default service version tag if the user doesn't provide
 		// a custom service name tag.
-		if _, ok := config.tags[string(semconv.ServiceVersionKey)]; !ok {
-			attrs = append(attrs, semconv.ServiceVersionKey.String(config.engineVersion))
+		if _, ok := tags[string(semconv.ServiceVersionKey)]; !ok {
+			attrs = append(attrs, semconv.ServiceVersionKey.String(engineVersion))
 		}
 	}
-
-	opts = append(
-		opts,
-		tracesdk.WithIDGenerator(tracing.NewIDGenerator()),
-		tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
-	)
-
-	return tracesdk.NewTracerProvider(opts...), nil
+	return attrs
 }
 
 func addGrpcCollectors(ctx context.Context, collectors []collector, opts []tracesdk.TracerProviderOption) ([]tracesdk.TracerProviderOption, error) {
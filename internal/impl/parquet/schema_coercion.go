@@ -28,6 +28,13 @@ type schemaVisitor interface {
 	visitLeaf(value any, schemaNode parquet.Node) (any, error)
 }
 
+// CoerceEncodingTypes converts the values of a structured row into the
+// representations expected by a parquet writer for the logical types of a
+// schema, such as timestamps and UUIDs.
+func CoerceEncodingTypes(row any, schema parquet.Node) (any, error) {
+	return visitWithSchema(encodingCoercionVisitor{}, scrubJSONNumbers(row), schema)
+}
+
 func visitWithSchema(visitor schemaVisitor, value any, schemaNode parquet.Node) (any, error) {
 	if schemaNode.Leaf() {
 		if schemaNode.Optional() && value == nil {
@@ -36,6 +43,21 @@ func visitWithSchema(visitor schemaVisitor, value any, schemaNode parquet.Node)
 		return visitor.visitLeaf(value, schemaNode)
 	}
 
+	if logicalType := schemaNode.Type().LogicalType(); logicalType != nil && logicalType.List != nil {
+		if elementNode, ok := listElementNode(schemaNode); ok {
+			if list, ok := value.([]any); ok {
+				for i := range list {
+					var err error
+					list[i], err = visitWithSchema(visitor, list[i], elementNode)
+					if err != nil {
+						return nil, fmt.Errorf("visiting [%d]: %w", i, err)
+					}
+				}
+				return list, nil
+			}
+		}
+	}
+
 	switch group := value.(type) {
 	case map[string]any:
 		for _, childSchemaNode := range schemaNode.Fields() {
@@ -68,6 +90,26 @@ func visitWithSchema(visitor schemaVisitor, value any, schemaNode parquet.Node)
 	}
 }
 
+// listElementNode returns the node of the elements of a LIST group. The
+// standard layout is a group containing a repeated group of a single element,
+// whereas legacy writers repeat the element itself, either as a primitive or
+// as a group. False is returned when the layout is not recognised.
+func listElementNode(schemaNode parquet.Node) (parquet.Node, bool) {
+	fields := schemaNode.Fields()
+	if len(fields) != 1 || !fields[0].Repeated() {
+		return nil, false
+	}
+	repeated := fields[0]
+	if repeated.Leaf() {
+		return repeated, true
+	}
+	elements := repeated.Fields()
+	if len(elements) != 1 || repeated.Name() == "array" {
+		return repeated, true
+	}
+	return elements[0], true
+}
+
 type encodingCoercionVisitor struct{}
 
 func (encodingCoercionVisitor) visitLeaf(value any, schemaNode parquet.Node) (any, error) {
@@ -76,26 +118,29 @@ func (encodingCoercionVisitor) visitLeaf(value any, schemaNode parquet.Node) (an
 		return value, nil
 	}
 	if logicalType.Timestamp != nil {
+		var ts time.Time
 		switch v := value.(type) {
 		case string:
-			ts, err := time.Parse(time.RFC3339, v)
-			if err != nil {
+			var err error
+			if ts, err = time.Parse(time.RFC3339, v); err != nil {
 				return nil, fmt.Errorf("parsing string RFC3339 timestamp: %w", err)
 			}
-			unit := logicalType.Timestamp.Unit
-			switch {
-			case unit.Millis != nil:
-				return ts.UnixMilli(), nil
-			case unit.Micros != nil:
-				return ts.UnixMicro(), nil
-			case unit.Nanos != nil:
-				return ts.UnixNano(), nil
-			default:
-				return nil, errors.New("unreachable branch while processing parquet timestamp")
-			}
+		case time.Time:
+			ts = v
 		default:
 			return nil, errors.New("TIMESTAMP values must be RFC3339-formatted strings")
 		}
+		unit := logicalType.Timestamp.Unit
+		switch {
+		case unit.Millis != nil:
+			return ts.UnixMilli(), nil
+		case unit.Micros != nil:
+			return ts.UnixMicro(), nil
+		case unit.Nanos != nil:
+			return ts.UnixNano(), nil
+		default:
+			return nil, errors.New("unreachable branch while processing parquet timestamp")
+		}
 	} else if logicalType.Json != nil {
 		jsonBytes, err := json.Marshal(value)
 		if err != nil {
@@ -0,0 +1,81 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyListNode is a LIST group with arbitrary children, which allows the
+// layouts of legacy writers to be constructed.
+type legacyListNode struct{ parquet.Group }
+
+func (legacyListNode) Type() parquet.Type { return parquet.List(parquet.String()).Type() }
+
+func TestCoerceEncodingTypesLists(t *testing.T) {
+	const ts = "2024-01-01T00:00:00Z"
+	const tsMillis = int64(1704067200000)
+	tsNode := parquet.Timestamp(parquet.Millisecond)
+
+	tests := []struct {
+		name     string
+		list     parquet.Node
+		value    []any
+		expected []any
+	}{
+		{
+			name:     "three level",
+			list:     parquet.List(tsNode),
+			value:    []any{ts, ts},
+			expected: []any{tsMillis, tsMillis},
+		},
+		{
+			name:     "two level group",
+			list:     legacyListNode{parquet.Group{"element": parquet.Repeated(parquet.Group{"ts": tsNode, "n": parquet.Int(64)})}},
+			value:    []any{map[string]any{"ts": ts, "n": 1}},
+			expected: []any{map[string]any{"ts": tsMillis, "n": 1}},
+		},
+		{
+			name:     "two level array group",
+			list:     legacyListNode{parquet.Group{"array": parquet.Repeated(parquet.Group{"ts": tsNode})}},
+			value:    []any{map[string]any{"ts": ts}},
+			expected: []any{map[string]any{"ts": tsMillis}},
+		},
+		{
+			name:     "repeated primitive",
+			list:     legacyListNode{parquet.Group{"ts": parquet.Repeated(tsNode)}},
+			value:    []any{ts, ts},
+			expected: []any{tsMillis, tsMillis},
+		},
+		{
+			name:     "unrecognised layout",
+			list:     legacyListNode{parquet.Group{"ts": tsNode, "n": parquet.Int(64)}},
+			value:    []any{map[string]any{"ts": ts}},
+			expected: []any{map[string]any{"ts": tsMillis}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			row, err := CoerceEncodingTypes(map[string]any{"foo": test.value}, parquet.Group{"foo": test.list})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"foo": test.expected}, row)
+		})
+	}
+}
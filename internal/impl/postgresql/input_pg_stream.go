@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Jeffail/checkpoint"
@@ -29,6 +30,9 @@ import (
 const (
 	fieldDSN                       = "dsn"
 	fieldIncludeTxnMarkers         = "include_transaction_markers"
+	fieldIncludeTruncates          = "include_truncates"
+	fieldIncludeLogicalMessages    = "include_logical_messages"
+	fieldStreamInProgressTxns      = "stream_in_progress_transactions"
 	fieldStreamSnapshot            = "stream_snapshot"
 	fieldSnapshotMemSafetyFactor   = "snapshot_memory_safety_factor"
 	fieldSnapshotBatchSize         = "snapshot_batch_size"
@@ -43,6 +47,7 @@ const (
 	fieldMaxParallelSnapshotTables = "max_parallel_snapshot_tables"
 	fieldUnchangedToastValue       = "unchanged_toast_value"
 	fieldHeartbeatInterval         = "heartbeat_interval"
+	fieldSignalTable               = "signal_table"
 	fieldAWSIAMAuth                = "aws"
 	// FieldAWSIAMAuthEnabled enabled field.
 	FieldAWSIAMAuthEnabled = "enabled"
@@ -76,12 +81,38 @@ func newPostgresCDCConfig() *service.ConfigSpec {
 		Description(`Streams changes from a PostgreSQL database for Change Data Capture (CDC).
 Additionally, if ` + "`" + fieldStreamSnapshot + "`" + ` is set to true, then the existing data in the database is also streamed too.
 
+== Incremental Snapshots
+
+When ` + "`" + fieldSignalTable + "`" + ` is set, snapshots of tables can be requested while changes are being streamed, which is useful for snapshotting a table that has been added to ` + "`" + fieldTables + "`" + ` or repairing the drift of a downstream replica. The signal table must exist within ` + "`" + fieldSchema + "`" + ` with the text columns ` + "`id`, `type` and `data`" + `, where ` + "`id`" + ` is the primary key, and a snapshot is requested by inserting a row of the type ` + "`execute-snapshot`" + ` with the tables to snapshot and an optional filter for each table written as a SQL condition:
+
+` + "```sql" + `
+CREATE TABLE rpcn_signals (id TEXT PRIMARY KEY, type TEXT NOT NULL, data TEXT);
+
+INSERT INTO rpcn_signals (id, type, data) VALUES (
+  'snapshot-1',
+  'execute-snapshot',
+  '{"tables":["my_table"],"filters":{"my_table":"created_at > ''2025-01-01''"}}'
+);
+` + "```" + `
+
+Tables are read in chunks of ` + "`" + fieldSnapshotBatchSize + "`" + ` rows, and each chunk is queried between a low and a high watermark that this input inserts into the signal table. Rows of a chunk that are changed between the watermarks are dropped from the chunk as the streamed change is more recent, and the remaining rows are emitted with the operation "read" once the high watermark is streamed. Requested tables must have a primary key and be listed in ` + "`" + fieldTables + "`" + `. The progress of incremental snapshots is not persisted, and so snapshots that are interrupted by a restart need to be requested again. Rows of the signal table are never emitted, and can be deleted at any time.
+
+== Streaming In-Progress Transactions
+
+By default PostgreSQL only sends the changes of a transaction once it commits, spilling large transactions to disk on the server until then. When ` + "`" + fieldStreamInProgressTxns + "`" + ` is set to true, transactions that exceed ` + "`logical_decoding_work_mem`" + ` are instead streamed using version 2 of the logical replication protocol while they are in progress, and so the changes of a multi-GB bulk update are emitted as they are decoded without being held in memory. This requires PostgreSQL 14 or later.
+
+The changes of a streamed transaction have the metadata field ` + "`xid`" + ` set, and may be interleaved with the changes of other transactions. Each streamed transaction ends with a message with the operation "commit" or "abort" and the same ` + "`xid`" + `, which is emitted regardless of ` + "`" + fieldIncludeTxnMarkers + "`" + `. Changes of subtransactions additionally have the metadata field ` + "`subxid`" + ` set, and an abort with a ` + "`subxid`" + ` only rolls back the changes of that subtransaction. Consumers must therefore buffer or be able to revert the changes of a streamed transaction until its outcome is known. A streamed transaction that is interrupted by a restart is streamed again from its beginning.
+
 == Metadata
 
 This input adds the following metadata fields to each message:
 - table (Name of the table that the message originated from)
-- operation (Type of operation that generated the message: "read", "insert", "update", or "delete". "read" is from messages that are read in the initial snapshot phase. This will also be "begin" and "commit" if ` + "`" + fieldIncludeTxnMarkers + "`" + ` is enabled)
+- operation (Type of operation that generated the message: "read", "insert", "update", or "delete". "read" is from messages that are read in the initial snapshot phase. This will also be "begin" and "commit" if ` + "`" + fieldIncludeTxnMarkers + "`" + ` is enabled, "truncate" if ` + "`" + fieldIncludeTruncates + "`" + ` is enabled, "message" if ` + "`" + fieldIncludeLogicalMessages + "`" + ` is enabled and "commit" or "abort" for transactions that are streamed while in progress)
 - lsn (the log sequence number in postgres)
+- prefix (the prefix of a logical decoding message, only set when the operation is "message")
+- transactional (whether a logical decoding message was emitted within a transaction, only set when the operation is "message")
+- xid (the transaction ID of a transaction that is streamed while in progress)
+- subxid (the subtransaction ID of a change or abort within a transaction that is streamed while in progress)
 		`).
 		Field(service.NewStringField(fieldDSN).
 			Description("The Data Source Name for the PostgreSQL database in the form of `postgres://[user[:password]@][netloc][:port][/dbname][?param1=value1&...]`. Please note that Postgres enforces SSL by default, you can override this with the parameter `sslmode=disable` if required.").
@@ -89,6 +120,18 @@ This input adds the following metadata fields to each message:
 		Field(service.NewBoolField(fieldIncludeTxnMarkers).
 			Description(`When set to true, empty messages with operation types BEGIN and COMMIT are generated for the beginning and end of each transaction. Messages with operation metadata set to "begin" or "commit" will have null message payloads.`).
 			Default(false)).
+		Field(service.NewBoolField(fieldIncludeTruncates).
+			Description(`When set to true, a message with the operation type "truncate" is generated for each table that is truncated. The payload of these messages is an object with the boolean fields ` + "`cascade` and `restart_identity`" + `, which reflect the options the tables were truncated with.`).
+			Default(false).
+			Advanced()).
+		Field(service.NewBoolField(fieldIncludeLogicalMessages).
+			Description(`When set to true, logical decoding messages emitted with ` + "`pg_logical_emit_message`" + ` are generated with the operation type "message", which can be used as an outbox or heartbeat channel. The payload of these messages is the raw content of the logical message and the metadata fields ` + "`prefix` and `transactional`" + ` are set. This requires PostgreSQL 15 or later.`).
+			Default(false).
+			Advanced()).
+		Field(service.NewBoolField(fieldStreamInProgressTxns).
+			Description(`When set to true, large transactions are streamed while they are in progress rather than once they commit, and are ended by a message with the operation "commit" or "abort". This requires PostgreSQL 14 or later.`).
+			Default(false).
+			Advanced()).
 		Field(service.NewBoolField(fieldStreamSnapshot).
 			Description("When set to true, the plugin will first stream a snapshot of all existing data in the database before streaming changes. In order to use this the tables that are being snapshot MUST have a primary key set so that reading from the table can be parallelized.").
 			Example(true).
@@ -140,6 +183,11 @@ This input adds the following metadata fields to each message:
 			Example("0s").
 			Example("24h").
 			Advanced()).
+		Field(service.NewStringField(fieldSignalTable).
+			Description("The name of a table within `" + fieldSchema + "` that is watched for incremental snapshot requests. When empty, incremental snapshots are disabled.").
+			Default("").
+			Example("rpcn_signals").
+			Advanced()).
 		Field(service.NewTLSField("tls")).
 		Description("Using this field overrides the SSL/TLS settings in the environment and DSN.").
 		Field(service.NewObjectField(fieldAWSIAMAuth,
@@ -194,6 +242,9 @@ func newPgStreamInput(conf *service.ParsedConfig, mgr *service.Resources) (s ser
 		tables                    []string
 		streamSnapshot            bool
 		includeTxnMarkers         bool
+		includeTruncates          bool
+		includeLogicalMessages    bool
+		streamInProgressTxns      bool
 		snapshotBatchSize         int
 		checkpointLimit           int
 		walMonitorInterval        time.Duration
@@ -202,6 +253,7 @@ func newPgStreamInput(conf *service.ParsedConfig, mgr *service.Resources) (s ser
 		batching                  service.BatchPolicy
 		unchangedToastValue       any
 		heartbeatInterval         time.Duration
+		signalTable               string
 		iamAuthEnabled            bool
 		iamAuthTokenBuilder       TokenBuilder
 	)
@@ -232,6 +284,18 @@ func newPgStreamInput(conf *service.ParsedConfig, mgr *service.Resources) (s ser
 		return nil, err
 	}
 
+	if includeTruncates, err = conf.FieldBool(fieldIncludeTruncates); err != nil {
+		return nil, err
+	}
+
+	if includeLogicalMessages, err = conf.FieldBool(fieldIncludeLogicalMessages); err != nil {
+		return nil, err
+	}
+
+	if streamInProgressTxns, err = conf.FieldBool(fieldStreamInProgressTxns); err != nil {
+		return nil, err
+	}
+
 	if schema, err = conf.FieldString(fieldSchema); err != nil {
 		return nil, err
 	}
@@ -278,6 +342,10 @@ func newPgStreamInput(conf *service.ParsedConfig, mgr *service.Resources) (s ser
 		return nil, err
 	}
 
+	if signalTable, err = conf.FieldString(fieldSignalTable); err != nil {
+		return nil, err
+	}
+
 	awsConf := conf.Namespace(fieldAWSIAMAuth)
 	iamAuthEnabled, _ = awsConf.FieldBool(FieldAWSIAMAuthEnabled)
 
@@ -316,17 +384,21 @@ func newPgStreamInput(conf *service.ParsedConfig, mgr *service.Resources) (s ser
 			DBTables:         tables,
 			RefreshAuthToken: iamAuthTokenBuilder,
 
-			IncludeTxnMarkers:        includeTxnMarkers,
-			ReplicationSlotName:      dbSlotName,
-			BatchSize:                snapshotBatchSize,
-			StreamOldData:            streamSnapshot,
-			TemporaryReplicationSlot: temporarySlot,
-			PgStandbyTimeout:         pgStandbyTimeout,
-			WalMonitorInterval:       walMonitorInterval,
-			MaxSnapshotWorkers:       maxParallelSnapshotTables,
-			Logger:                   logger,
-			UnchangedToastValue:      unchangedToastValue,
-			HeartbeatInterval:        heartbeatInterval,
+			IncludeTxnMarkers:            includeTxnMarkers,
+			IncludeTruncates:             includeTruncates,
+			IncludeLogicalMessages:       includeLogicalMessages,
+			StreamInProgressTransactions: streamInProgressTxns,
+			ReplicationSlotName:          dbSlotName,
+			BatchSize:                    snapshotBatchSize,
+			StreamOldData:                streamSnapshot,
+			TemporaryReplicationSlot:     temporarySlot,
+			PgStandbyTimeout:             pgStandbyTimeout,
+			WalMonitorInterval:           walMonitorInterval,
+			MaxSnapshotWorkers:           maxParallelSnapshotTables,
+			Logger:                       logger,
+			UnchangedToastValue:          unchangedToastValue,
+			HeartbeatInterval:            heartbeatInterval,
+			SignalTable:                  signalTable,
 		},
 		batching:        batching,
 		checkpointLimit: checkpointLimit,
@@ -460,16 +532,29 @@ func (p *pgStreamInput) processStream(pgStream *pglogicalstream.Stream, batcher
 				err   error
 			)
 			for _, msg := range batch {
-				if mb, err = json.Marshal(msg.Data); err != nil {
+				if raw, ok := msg.Data.([]byte); ok {
+					// The content of logical decoding messages is emitted as is.
+					mb = raw
+				} else if mb, err = json.Marshal(msg.Data); err != nil {
 					p.logger.Errorf("failure to marshal message: %s", err)
 					break
 				}
 				batchMsg := service.NewMessage(mb)
 				batchMsg.MetaSet("table", msg.Table)
 				batchMsg.MetaSet("operation", string(msg.Operation))
+				if msg.Operation == pglogicalstream.MessageOpType {
+					batchMsg.MetaSet("prefix", msg.Prefix)
+					batchMsg.MetaSet("transactional", strconv.FormatBool(msg.Transactional))
+				}
 				if msg.LSN != nil {
 					batchMsg.MetaSet("lsn", *msg.LSN)
 				}
+				if msg.Xid != 0 {
+					batchMsg.MetaSet("xid", strconv.FormatUint(uint64(msg.Xid), 10))
+				}
+				if msg.SubXid != 0 {
+					batchMsg.MetaSet("subxid", strconv.FormatUint(uint64(msg.SubXid), 10))
+				}
 				if batcher.Add(batchMsg) {
 					flush = true
 				}
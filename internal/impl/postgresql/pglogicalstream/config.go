@@ -40,6 +40,15 @@ type Config struct {
 	BatchSize int
 	// If true, include BEGIN and COMMIT messages in the stream
 	IncludeTxnMarkers bool
+	// If true, include a TRUNCATE message for each truncated table in the stream
+	IncludeTruncates bool
+	// If true, include logical decoding messages in the stream
+	IncludeLogicalMessages bool
+	// If true, transactions are streamed before they commit using protocol version 2
+	StreamInProgressTransactions bool
+	// SignalTable is the name of a table within DBSchema that is watched for
+	// incremental snapshot requests, or empty to disable signals
+	SignalTable string
 
 	Logger *service.Logger
 
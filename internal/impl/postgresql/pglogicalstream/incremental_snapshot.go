@@ -0,0 +1,280 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed as a Redpanda Enterprise file under the Redpanda Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// https://github.com/redpanda-data/connect/v4/blob/main/licenses/rcl.md
+
+package pglogicalstream
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+
+	"github.com/redpanda-data/connect/v4/internal/cdc"
+	"github.com/redpanda-data/connect/v4/internal/impl/postgresql/pglogicalstream/sanitize"
+)
+
+// incrementalSnapshotter executes snapshots requested through the signal
+// table while the replication stream is running. Chunks of each table are
+// queried between watermarks that are written to the signal table, and the
+// stream emits the rows of a chunk once it reaches the high watermark.
+type incrementalSnapshotter struct {
+	db          *sql.DB
+	signalTable TableFQN
+	tables      []TableFQN
+	chunkSize   int
+	logger      *service.Logger
+
+	window   cdc.SnapshotWindow
+	requests chan *cdc.SnapshotRequest
+}
+
+func newIncrementalSnapshotter(config *Config, signalTable TableFQN, tables []TableFQN, chunkSize int) (*incrementalSnapshotter, error) {
+	db, err := openPgConnectionFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &incrementalSnapshotter{
+		db:          db,
+		signalTable: signalTable,
+		tables:      tables,
+		chunkSize:   chunkSize,
+		logger:      config.Logger,
+		requests:    make(chan *cdc.SnapshotRequest, 16),
+	}, nil
+}
+
+// isSignalTable returns true if a message from the stream belongs to the
+// signal table.
+func (s *incrementalSnapshotter) isSignalTable(schema, table string) bool {
+	return sanitize.QuotePostgresIdentifier(schema) == s.signalTable.Schema &&
+		sanitize.QuotePostgresIdentifier(table) == s.signalTable.Table
+}
+
+// handleSignal processes a row inserted into the signal table, and returns the
+// rows of a snapshot chunk that are to be emitted along with their table.
+func (s *incrementalSnapshotter) handleSignal(row map[string]any) (string, []map[string]any, error) {
+	signal, err := cdc.SignalFromRow(row)
+	if err != nil {
+		return "", nil, err
+	}
+	switch signal.Type {
+	case cdc.SignalExecuteSnapshot:
+		req, err := cdc.ParseSnapshotRequest(signal.Data)
+		if err != nil {
+			return "", nil, err
+		}
+		select {
+		case s.requests <- req:
+		default:
+			s.logger.Errorf("Dropping snapshot request %v as too many snapshots are pending", signal.ID)
+		}
+	case cdc.SignalSnapshotWindowOpen:
+		s.window.Open(signal.Data)
+	case cdc.SignalSnapshotWindowClose:
+		table, rows := s.window.Close(signal.Data)
+		return table, rows, nil
+	default:
+		s.logger.Warnf("Ignoring signal %v with unknown type: %v", signal.ID, signal.Type)
+	}
+	return "", nil, nil
+}
+
+func (s *incrementalSnapshotter) run(ctx context.Context) error {
+	for {
+		select {
+		case req := <-s.requests:
+			for _, name := range req.Tables {
+				if err := s.snapshotTable(ctx, name, req.Filters[name]); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					s.logger.Errorf("Incremental snapshot of table %v failed: %v", name, err)
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *incrementalSnapshotter) snapshotTable(ctx context.Context, name, filter string) error {
+	normalized, err := sanitize.NormalizePostgresIdentifier(name)
+	if err != nil {
+		return fmt.Errorf("invalid table name: %w", err)
+	}
+	var table TableFQN
+	for _, t := range s.tables {
+		if t.Table == normalized {
+			table = t
+		}
+	}
+	if table.Table == "" {
+		// The changes of other tables are not streamed, and so their rows
+		// cannot be deduplicated.
+		return fmt.Errorf("table %v is not part of the replication stream", name)
+	}
+
+	keyCols, err := s.primaryKeyColumns(ctx, table)
+	if err != nil {
+		return err
+	}
+	unquotedTable, err := sanitize.UnquotePostgresIdentifier(table.Table)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Starting incremental snapshot of table %v", table)
+	var lastKey primaryKey
+	var rowsCount int
+	for {
+		id := uuid.NewString()
+		closed := s.window.Prepare(id, unquotedTable, keyCols)
+		if err := s.writeSignal(ctx, id, cdc.SignalSnapshotWindowOpen); err != nil {
+			return err
+		}
+		rows, err := s.queryChunk(ctx, table, keyCols, lastKey, filter)
+		if err != nil {
+			return err
+		}
+		s.window.SetRows(rows)
+		if err := s.writeSignal(ctx, id, cdc.SignalSnapshotWindowClose); err != nil {
+			return err
+		}
+		select {
+		case <-closed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		rowsCount += len(rows)
+		if len(rows) < s.chunkSize {
+			break
+		}
+		last := rows[len(rows)-1]
+		lastKey = make(primaryKey, len(keyCols))
+		for i, col := range keyCols {
+			lastKey[i] = last[col]
+		}
+	}
+	s.logger.Infof("Finished incremental snapshot of table %v with %v rows", table, rowsCount)
+	return nil
+}
+
+// writeSignal writes a watermark to the signal table, where the data of the
+// signal identifies the chunk.
+func (s *incrementalSnapshotter) writeSignal(ctx context.Context, id, signalType string) error {
+	q := fmt.Sprintf("INSERT INTO %s (id, type, data) VALUES ($1, $2, $3)", s.signalTable)
+	if _, err := s.db.ExecContext(ctx, q, id+"-"+signalType, signalType, id); err != nil {
+		return fmt.Errorf("failed to write %v signal: %w", signalType, err)
+	}
+	return nil
+}
+
+func (s *incrementalSnapshotter) primaryKeyColumns(ctx context.Context, table TableFQN) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT a.attname
+        FROM   pg_index i
+        JOIN   pg_attribute a ON a.attrelid = i.indrelid
+            AND a.attnum = ANY(i.indkey)
+        WHERE  i.indrelid = $1::regclass
+        AND    i.indisprimary
+        ORDER BY array_position(i.indkey, a.attnum);
+    `, table.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query primary key of table %v: %w", table, err)
+	}
+	defer rows.Close()
+
+	var keyCols []string
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err != nil {
+			return nil, err
+		}
+		keyCols = append(keyCols, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(keyCols) == 0 {
+		return nil, fmt.Errorf("no primary key found for table %s", table)
+	}
+	return keyCols, nil
+}
+
+func (s *incrementalSnapshotter) queryChunk(ctx context.Context, table TableFQN, keyCols []string, minExclusive primaryKey, filter string) ([]map[string]any, error) {
+	quotedKeyCols := make([]string, len(keyCols))
+	for i, col := range keyCols {
+		quotedKeyCols[i] = sanitize.QuotePostgresIdentifier(col)
+	}
+
+	pred := squirrel.And{}
+	if minExclusive != nil {
+		pred = append(pred, squirrel.ConcatExpr("("+strings.Join(quotedKeyCols, ", ")+")", " > ", &tuple{minExclusive}))
+	}
+	if filter != "" {
+		// Filters are written to the signal table by trusted users in the same
+		// way as a WHERE clause.
+		pred = append(pred, squirrel.Expr("("+filter+")"))
+	}
+
+	q, args, err := squirrel.Select("*").
+		From(table.String()).
+		Where(pred).
+		OrderBy(quotedKeyCols...).
+		Limit(uint64(s.chunkSize)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate SQL query for table scan: %w", err)
+	}
+	s.logger.Tracef("running incremental snapshot query: %s", q)
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table %v: %w", table, err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types for table %v: %w", table, err)
+	}
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column names for table %v: %w", table, err)
+	}
+	scanArgs, valueGetters := prepareScannersAndGetters(columnTypes)
+
+	var chunk []map[string]any
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, fmt.Errorf("failed to scan row for table %v: %w", table, err)
+		}
+		data := make(map[string]any, len(valueGetters))
+		for i, getter := range valueGetters {
+			if data[columnNames[i]], err = getter(scanArgs[i]); err != nil {
+				return nil, fmt.Errorf("unable to decode column %s: %w", columnNames[i], err)
+			}
+		}
+		chunk = append(chunk, data)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows of table %v: %w", table, err)
+	}
+	return chunk, nil
+}
+
+func (s *incrementalSnapshotter) close() error {
+	return s.db.Close()
+}
@@ -46,6 +46,9 @@ type Stream struct {
 	errors                chan error
 
 	includeTxnMarkers       bool
+	includeTruncates        bool
+	includeLogicalMessages  bool
+	streamInProgress        bool
 	slotName                string
 	tables                  []TableFQN
 	snapshotBatchSize       int
@@ -55,6 +58,11 @@ type Stream struct {
 	heartbeat               *heartbeat
 	maxSnapshotWorkers      int
 	unchangedToastValue     any
+	incrementalSnapshotter  *incrementalSnapshotter
+
+	// streamingXid is the xid of the transaction within the current stream
+	// start and stop block, and is only accessed by the replication loop.
+	streamingXid uint32
 }
 
 // NewPgStream creates a new instance of the Stream struct
@@ -109,18 +117,21 @@ func NewPgStream(ctx context.Context, config *Config) (*Stream, error) {
 		batchSize = config.BatchSize
 	}
 	stream := &Stream{
-		pgConn:                dbConn,
-		messages:              make(chan []StreamMessage),
-		errors:                make(chan error, 1),
-		slotName:              config.ReplicationSlotName,
-		snapshotBatchSize:     batchSize,
-		tables:                tables,
-		maxSnapshotWorkers:    config.MaxSnapshotWorkers,
-		logger:                config.Logger,
-		shutSig:               shutdown.NewSignaller(),
-		includeTxnMarkers:     config.IncludeTxnMarkers,
-		standbyMessageTimeout: config.PgStandbyTimeout,
-		unchangedToastValue:   config.UnchangedToastValue,
+		pgConn:                 dbConn,
+		messages:               make(chan []StreamMessage),
+		errors:                 make(chan error, 1),
+		slotName:               config.ReplicationSlotName,
+		snapshotBatchSize:      batchSize,
+		tables:                 tables,
+		maxSnapshotWorkers:     config.MaxSnapshotWorkers,
+		logger:                 config.Logger,
+		shutSig:                shutdown.NewSignaller(),
+		includeTxnMarkers:      config.IncludeTxnMarkers,
+		includeTruncates:       config.IncludeTruncates,
+		includeLogicalMessages: config.IncludeLogicalMessages,
+		streamInProgress:       config.StreamInProgressTransactions,
+		standbyMessageTimeout:  config.PgStandbyTimeout,
+		unchangedToastValue:    config.UnchangedToastValue,
 	}
 
 	monitor, err := NewMonitor(ctx, config, stream.logger, tables, stream.slotName)
@@ -151,26 +162,58 @@ func NewPgStream(ctx context.Context, config *Config) (*Stream, error) {
 		})
 	}
 
+	pubTables := tables
+	if config.SignalTable != "" {
+		normalized, err := sanitize.NormalizePostgresIdentifier(config.SignalTable)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signal table name %q: %w", config.SignalTable, err)
+		}
+		signalTable := TableFQN{Schema: schema, Table: normalized}
+		// The signal table needs to be replicated in order for snapshot
+		// requests and watermarks to be observed.
+		pubTables = append(slices.Clone(tables), signalTable)
+		if stream.incrementalSnapshotter, err = newIncrementalSnapshotter(config, signalTable, tables, batchSize); err != nil {
+			return nil, err
+		}
+		cleanups = append(cleanups, func() {
+			if err := stream.incrementalSnapshotter.close(); err != nil {
+				config.Logger.Warnf("unable to properly cleanup incremental snapshot connection on stream creation failure: %s", err)
+			}
+		})
+	}
+
 	var version int
 	if version, err = getPostgresVersion(config); err != nil {
 		return nil, err
 	}
 
+	protoVersion := "1"
+	if config.StreamInProgressTransactions {
+		if version < 14 {
+			return nil, fmt.Errorf("streaming in-progress transactions requires postgres 14 or later, found version %d", version)
+		}
+		protoVersion = "2"
+	}
 	pluginArguments := []string{
-		"proto_version '1'",
+		fmt.Sprintf("proto_version '%s'", protoVersion),
 		// Sprintf is safe because we validate ReplicationSlotName is alphanumeric in the config
 		fmt.Sprintf("publication_names 'pglog_stream_%s'", config.ReplicationSlotName),
 	}
+	if config.StreamInProgressTransactions {
+		pluginArguments = append(pluginArguments, "streaming 'on'")
+	}
 
 	if version > 14 {
 		pluginArguments = append(pluginArguments, "messages 'true'")
+	} else if config.IncludeLogicalMessages {
+		return nil, fmt.Errorf("logical decoding messages require postgres 15 or later, found version %d", version)
 	}
 
 	stream.decodingPluginArguments = pluginArguments
 
 	pubName := "pglog_stream_" + config.ReplicationSlotName
 	stream.logger.Infof("Creating publication %s for tables: %s", pubName, tables)
-	if err = CreatePublication(ctx, stream.pgConn, pubName, tables); err != nil {
+	if err = CreatePublication(ctx, stream.pgConn, pubName, pubTables); err != nil {
 		return nil, err
 	}
 	cleanups = append(cleanups, func() {
@@ -217,6 +260,7 @@ func NewPgStream(ctx context.Context, config *Config) (*Stream, error) {
 				stream.errors <- fmt.Errorf("logical replication stream error: %w", err)
 			}
 		}()
+		stream.startIncrementalSnapshots()
 		cleanups = nil
 		return stream, nil
 	}
@@ -308,11 +352,25 @@ func NewPgStream(ctx context.Context, config *Config) (*Stream, error) {
 		}
 	}()
 
+	stream.startIncrementalSnapshots()
 	// Success! No need to cleanup
 	cleanups = nil
 	return stream, nil
 }
 
+func (s *Stream) startIncrementalSnapshots() {
+	if s.incrementalSnapshotter == nil {
+		return
+	}
+	go func() {
+		ctx, done := s.shutSig.SoftStopCtx(context.Background())
+		defer done()
+		if err := s.incrementalSnapshotter.run(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Errorf("Incremental snapshots stopped: %v", err)
+		}
+	}()
+}
+
 // GetProgress returns the progress of the stream.
 // including the % of snapshot messages processed and the WAL lag in bytes.
 func (s *Stream) GetProgress() *Report {
@@ -490,37 +548,141 @@ const (
 
 // Handle handles the pgoutput output
 func (s *Stream) processChange(ctx context.Context, msgLSN LSN, xld XLogData, relations map[uint32]*RelationMessage, typeMap *pgtype.Map) (processChangeResult, error) {
-	logicalMsg, err := Parse(xld.WALData)
+	logicalMsg, subXid, err := s.parse(xld.WALData)
 	if err != nil {
 		return changeResultNoMessage, err
 	}
-	// parse changes inside the transaction
-	message, err := toStreamMessage(logicalMsg, relations, typeMap, s.unchangedToastValue)
-	if err != nil {
-		return changeResultNoMessage, err
-	}
-	if message == nil {
-		// In the case of heartbeats we can treat that the same as suppressed commit messages and advance the LSN that way.
-		// this is only needed for low frequency tables to continue to progress the LSN.
-		if logicalMsg, ok := logicalMsg.(*LogicalDecodingMessage); ok && logicalMsg.Prefix == "redpanda_connect_"+s.slotName {
-			return changeResultSuppressedCommitMessage, nil
-		}
+	switch streamMsg := logicalMsg.(type) {
+	case *StreamStartMessage:
+		s.streamingXid = streamMsg.Xid
 		return changeResultNoMessage, nil
+	case *StreamStopMessage:
+		s.streamingXid = 0
+		return changeResultNoMessage, nil
+	case *StreamCommitMessage:
+		// Consumers need to know the outcome of streamed transactions, and so
+		// the commit is emitted regardless of includeTxnMarkers.
+		return s.emitMessages(ctx, msgLSN, []StreamMessage{{Operation: CommitOpType, Xid: streamMsg.Xid}})
+	case *StreamAbortMessage:
+		message := StreamMessage{Operation: AbortOpType, Xid: streamMsg.Xid}
+		if streamMsg.SubXid != streamMsg.Xid {
+			message.SubXid = streamMsg.SubXid
+		}
+		return s.emitMessages(ctx, msgLSN, []StreamMessage{message})
 	}
 
-	if !s.includeTxnMarkers {
+	var messages []StreamMessage
+	if truncateMsg, ok := logicalMsg.(*TruncateMessage); ok {
+		if !s.includeTruncates {
+			return changeResultNoMessage, nil
+		}
+		if messages, err = truncateToStreamMessages(truncateMsg, relations); err != nil {
+			return changeResultNoMessage, err
+		}
+	} else {
+		// parse changes inside the transaction
+		message, err := toStreamMessage(logicalMsg, relations, typeMap, s.unchangedToastValue)
+		if err != nil {
+			return changeResultNoMessage, err
+		}
+		if message == nil {
+			return changeResultNoMessage, nil
+		}
+		if s.incrementalSnapshotter != nil {
+			if messages, ok := s.handleIncrementalSnapshot(message); ok {
+				if len(messages) == 0 {
+					return changeResultNoMessage, nil
+				}
+				return s.emitMessages(ctx, msgLSN, messages)
+			}
+		}
+
 		switch message.Operation {
 		case CommitOpType:
-			return changeResultSuppressedCommitMessage, nil
+			if !s.includeTxnMarkers {
+				return changeResultSuppressedCommitMessage, nil
+			}
 		case BeginOpType:
-			return changeResultNoMessage, nil
+			if !s.includeTxnMarkers {
+				return changeResultNoMessage, nil
+			}
+		case MessageOpType:
+			// In the case of heartbeats we can treat that the same as suppressed commit messages and advance the LSN that way.
+			// this is only needed for low frequency tables to continue to progress the LSN.
+			if message.Prefix == "redpanda_connect_"+s.slotName {
+				return changeResultSuppressedCommitMessage, nil
+			}
+			if !s.includeLogicalMessages {
+				return changeResultNoMessage, nil
+			}
 		}
+		messages = []StreamMessage{*message}
 	}
+	if len(messages) == 0 {
+		return changeResultNoMessage, nil
+	}
+	if s.streamingXid != 0 {
+		for i := range messages {
+			messages[i].Xid = s.streamingXid
+			if subXid != s.streamingXid {
+				messages[i].SubXid = subXid
+			}
+		}
+	}
+	return s.emitMessages(ctx, msgLSN, messages)
+}
+
+// parse decodes a message with the protocol version that the stream uses.
+// Changes of transactions that are streamed before they commit are returned
+// along with the xid of the (sub)transaction that made them.
+func (s *Stream) parse(data []byte) (Message, uint32, error) {
+	if !s.streamInProgress {
+		m, err := Parse(data)
+		return m, 0, err
+	}
+	return ParseV2(data, s.streamingXid != 0)
+}
 
+// handleIncrementalSnapshot observes a change for an ongoing incremental
+// snapshot, and returns true if the change belongs to the signal table along
+// with any snapshot rows that are to be emitted in its place.
+func (s *Stream) handleIncrementalSnapshot(message *StreamMessage) ([]StreamMessage, bool) {
+	row, _ := message.Data.(map[string]any)
+	if !s.incrementalSnapshotter.isSignalTable(message.Schema, message.Table) {
+		switch message.Operation {
+		case InsertOpType, UpdateOpType, DeleteOpType:
+			s.incrementalSnapshotter.window.Observe(message.Table, row)
+		}
+		return nil, false
+	}
+	if message.Operation != InsertOpType {
+		return nil, true
+	}
+
+	table, rows, err := s.incrementalSnapshotter.handleSignal(row)
+	if err != nil {
+		s.logger.Errorf("Failed to process signal: %v", err)
+		return nil, true
+	}
+	messages := make([]StreamMessage, len(rows))
+	for i, row := range rows {
+		messages[i] = StreamMessage{
+			Operation: ReadOpType,
+			Schema:    message.Schema,
+			Table:     table,
+			Data:      row,
+		}
+	}
+	return messages, true
+}
+
+func (s *Stream) emitMessages(ctx context.Context, msgLSN LSN, messages []StreamMessage) (processChangeResult, error) {
 	lsn := msgLSN.String()
-	message.LSN = &lsn
+	for i := range messages {
+		messages[i].LSN = &lsn
+	}
 	select {
-	case s.messages <- []StreamMessage{*message}:
+	case s.messages <- messages:
 		return changeResultEmittedMessage, nil
 	case <-ctx.Done():
 		return changeResultNoMessage, ctx.Err()
@@ -781,6 +943,12 @@ func (s *Stream) Stop(ctx context.Context) error {
 		}
 		return nil
 	})
+	wg.Go(func() error {
+		if s.incrementalSnapshotter != nil {
+			return s.incrementalSnapshotter.close()
+		}
+		return nil
+	})
 	select {
 	case <-ctx.Done():
 	case <-s.shutSig.HasStoppedChan():
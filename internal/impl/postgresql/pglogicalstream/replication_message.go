@@ -677,10 +677,147 @@ func (m *LogicalDecodingMessage) Decode(src []byte) (err error) {
 	return nil
 }
 
+// StreamStartMessage is a stream start message, which precedes a block of
+// changes of a transaction that is streamed before it commits.
+type StreamStartMessage struct {
+	baseMessage
+	// Xid of the transaction.
+	Xid uint32
+	// FirstSegment is true if this is the first block of the transaction.
+	FirstSegment bool
+}
+
+// Decode decodes the message from src.
+func (m *StreamStartMessage) Decode(src []byte) error {
+	if len(src) < 5 {
+		return m.lengthError("StreamStartMessage", 5, len(src))
+	}
+	var low, used int
+	m.Xid, used = m.decodeUint32(src)
+	low += used
+	m.FirstSegment = src[low] == 1
+
+	m.SetType(MessageTypeStreamStart)
+
+	return nil
+}
+
+// StreamStopMessage is a stream stop message, which ends a block of changes of
+// a transaction that is streamed before it commits.
+type StreamStopMessage struct {
+	baseMessage
+}
+
+// Decode decodes the message from src.
+func (m *StreamStopMessage) Decode([]byte) error {
+	m.SetType(MessageTypeStreamStop)
+	return nil
+}
+
+// StreamCommitMessage is a stream commit message, which commits a transaction
+// that has been streamed.
+type StreamCommitMessage struct {
+	baseMessage
+	// Xid of the transaction.
+	Xid uint32
+	// Flags currently unused (must be 0).
+	Flags uint8
+	// CommitLSN is the LSN of the commit.
+	CommitLSN LSN
+	// TransactionEndLSN is the end LSN of the transaction.
+	TransactionEndLSN LSN
+	// CommitTime is the commit timestamp of the transaction
+	CommitTime time.Time
+}
+
+// Decode decodes the message from src.
+func (m *StreamCommitMessage) Decode(src []byte) error {
+	if len(src) < 29 {
+		return m.lengthError("StreamCommitMessage", 29, len(src))
+	}
+	var low, used int
+	m.Xid, used = m.decodeUint32(src)
+	low += used
+	m.Flags = src[low]
+	low++
+	m.CommitLSN, used = m.decodeLSN(src[low:])
+	low += used
+	m.TransactionEndLSN, used = m.decodeLSN(src[low:])
+	low += used
+	m.CommitTime, _ = m.decodeTime(src[low:])
+
+	m.SetType(MessageTypeStreamCommit)
+
+	return nil
+}
+
+// StreamAbortMessage is a stream abort message, which aborts a transaction or
+// subtransaction that has been streamed.
+type StreamAbortMessage struct {
+	baseMessage
+	// Xid of the transaction.
+	Xid uint32
+	// SubXid of the subtransaction, which is the same as Xid if the
+	// transaction itself is aborted.
+	SubXid uint32
+}
+
+// Decode decodes the message from src.
+func (m *StreamAbortMessage) Decode(src []byte) error {
+	if len(src) < 8 {
+		return m.lengthError("StreamAbortMessage", 8, len(src))
+	}
+	var low, used int
+	m.Xid, used = m.decodeUint32(src)
+	low += used
+	m.SubXid, _ = m.decodeUint32(src[low:])
+
+	m.SetType(MessageTypeStreamAbort)
+
+	return nil
+}
+
 // Parse parse a logical replication message.
 func Parse(data []byte) (m Message, err error) {
-	var decoder MessageDecoder
+	return parse(MessageType(data[0]), data[1:])
+}
+
+// ParseV2 parses a logical replication message of protocol version 2, which
+// supports streaming transactions before they commit. When inStream is true
+// the message is within a stream start and stop block, and the xid of the
+// (sub)transaction that prefixes changes within the block is returned.
+func ParseV2(data []byte, inStream bool) (m Message, xid uint32, err error) {
 	msgType := MessageType(data[0])
+	src := data[1:]
+	switch msgType {
+	case MessageTypeStreamStart:
+		m, err = decode(new(StreamStartMessage), src)
+		return m, 0, err
+	case MessageTypeStreamStop:
+		m, err = decode(new(StreamStopMessage), src)
+		return m, 0, err
+	case MessageTypeStreamCommit:
+		m, err = decode(new(StreamCommitMessage), src)
+		return m, 0, err
+	case MessageTypeStreamAbort:
+		m, err = decode(new(StreamAbortMessage), src)
+		return m, 0, err
+	case MessageTypeRelation, MessageTypeType, MessageTypeInsert, MessageTypeUpdate,
+		MessageTypeDelete, MessageTypeTruncate, MessageTypeMessage:
+		if inStream {
+			if len(src) < 4 {
+				return nil, 0, fmt.Errorf("streamed %s message must have at least 4 bytes, got %d bytes", msgType, len(src))
+			}
+			xid = binary.BigEndian.Uint32(src)
+			src = src[4:]
+		}
+	}
+	m, err = parse(msgType, src)
+	return m, xid, err
+}
+
+func parse(msgType MessageType, src []byte) (Message, error) {
+	var decoder MessageDecoder
 	switch msgType {
 	case MessageTypeRelation:
 		decoder = new(RelationMessage)
@@ -703,11 +840,13 @@ func Parse(data []byte) (m Message, err error) {
 	if decoder == nil {
 		return nil, errMsgNotSupported
 	}
+	return decode(decoder, src)
+}
 
-	if err = decoder.Decode(data[1:]); err != nil {
+func decode(decoder MessageDecoder, src []byte) (Message, error) {
+	if err := decoder.Decode(src); err != nil {
 		return nil, err
 	}
-
 	return decoder.(Message), nil
 }
 
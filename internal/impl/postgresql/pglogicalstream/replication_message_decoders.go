@@ -9,6 +9,7 @@
 package pglogicalstream
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -157,10 +158,16 @@ func toStreamMessage(logicalMsg Message, relations map[uint32]*RelationMessage,
 			}
 		}
 		message.Data = values
-	case *TruncateMessage:
-	case *TypeMessage:
-	case *OriginMessage:
 	case *LogicalDecodingMessage:
+		message.Operation = MessageOpType
+		message.Prefix = logicalMsg.Prefix
+		message.Transactional = logicalMsg.Transactional
+		// The content references the buffer of the replication connection,
+		// which is reused for subsequent messages.
+		message.Data = bytes.Clone(logicalMsg.Content)
+	case *TruncateMessage, *TypeMessage, *OriginMessage:
+		// Truncates can affect multiple relations and are handled by
+		// truncateToStreamMessages.
 		return nil, nil
 	default:
 		return nil, nil
@@ -169,6 +176,28 @@ func toStreamMessage(logicalMsg Message, relations map[uint32]*RelationMessage,
 	return message, nil
 }
 
+// truncateToStreamMessages creates a message for each relation affected by a
+// truncate.
+func truncateToStreamMessages(logicalMsg *TruncateMessage, relations map[uint32]*RelationMessage) ([]StreamMessage, error) {
+	messages := make([]StreamMessage, 0, len(logicalMsg.RelationIDs))
+	for _, relationID := range logicalMsg.RelationIDs {
+		rel, ok := relations[relationID]
+		if !ok {
+			return nil, fmt.Errorf("unknown relation ID %d", relationID)
+		}
+		messages = append(messages, StreamMessage{
+			Operation: TruncateOpType,
+			Schema:    rel.Namespace,
+			Table:     rel.RelationName,
+			Data: map[string]any{
+				"cascade":          logicalMsg.Option&TruncateOptionCascade != 0,
+				"restart_identity": logicalMsg.Option&TruncateOptionRestartIdentity != 0,
+			},
+		})
+	}
+	return messages, nil
+}
+
 func decodeTextColumnData(mi *pgtype.Map, data []byte, dataType uint32) (any, error) {
 	if data == nil {
 		return nil, nil
@@ -834,3 +834,131 @@ func (s *logicalDecodingMessageSuite) Test() {
 
 	s.Equal(expected, logicalDecodingMsg)
 }
+
+func TestTruncateToStreamMessages(t *testing.T) {
+	relations := map[uint32]*RelationMessage{
+		1: {RelationID: 1, Namespace: "public", RelationName: "foo"},
+		2: {RelationID: 2, Namespace: "public", RelationName: "bar"},
+	}
+
+	messages, err := truncateToStreamMessages(&TruncateMessage{
+		RelationNum: 2,
+		Option:      TruncateOptionCascade,
+		RelationIDs: []uint32{1, 2},
+	}, relations)
+	require.NoError(t, err)
+	require.Equal(t, []StreamMessage{
+		{Operation: TruncateOpType, Schema: "public", Table: "foo", Data: map[string]any{"cascade": true, "restart_identity": false}},
+		{Operation: TruncateOpType, Schema: "public", Table: "bar", Data: map[string]any{"cascade": true, "restart_identity": false}},
+	}, messages)
+
+	_, err = truncateToStreamMessages(&TruncateMessage{RelationNum: 1, RelationIDs: []uint32{3}}, relations)
+	require.ErrorContains(t, err, "unknown relation ID 3")
+}
+
+func TestLogicalDecodingMessageToStreamMessage(t *testing.T) {
+	content := []byte(`{"id":1}`)
+	message, err := toStreamMessage(&LogicalDecodingMessage{
+		Transactional: true,
+		Prefix:        "outbox",
+		Content:       content,
+	}, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, &StreamMessage{
+		Operation:     MessageOpType,
+		Prefix:        "outbox",
+		Transactional: true,
+		Data:          []byte(`{"id":1}`),
+	}, message)
+
+	// The content must not share the buffer of the replication connection.
+	content[0] = 'x'
+	require.Equal(t, []byte(`{"id":1}`), message.Data)
+}
+
+func TestStreamMessageSuite(t *testing.T) {
+	suite.Run(t, new(streamMessageSuite))
+}
+
+type streamMessageSuite struct {
+	messageSuite
+}
+
+func (s *streamMessageSuite) TestStreamStart() {
+	xid := s.newXid()
+	msg := make([]byte, 1+4+1)
+	msg[0] = 'S'
+	bigEndian.PutUint32(msg[1:], xid)
+	msg[5] = 1
+
+	m, _, err := ParseV2(msg, false)
+	s.NoError(err)
+	expected := &StreamStartMessage{Xid: xid, FirstSegment: true}
+	expected.msgType = 'S'
+	s.Equal(expected, m)
+
+	m, _, err = ParseV2([]byte{'E'}, true)
+	s.NoError(err)
+	s.Equal(MessageTypeStreamStop, m.Type())
+}
+
+func (s *streamMessageSuite) TestStreamCommit() {
+	xid := s.newXid()
+	commitLSN := s.newLSN()
+	transactionEndLSN := s.newLSN()
+	commitTime, pgCommitTime := s.newTime()
+
+	msg := make([]byte, 1+4+1+8+8+8)
+	msg[0] = 'c'
+	bigEndian.PutUint32(msg[1:], xid)
+	bigEndian.PutUint64(msg[6:], uint64(commitLSN))
+	bigEndian.PutUint64(msg[14:], uint64(transactionEndLSN))
+	bigEndian.PutUint64(msg[22:], pgCommitTime)
+
+	m, _, err := ParseV2(msg, false)
+	s.NoError(err)
+	expected := &StreamCommitMessage{
+		Xid:               xid,
+		CommitLSN:         commitLSN,
+		TransactionEndLSN: transactionEndLSN,
+		CommitTime:        commitTime,
+	}
+	expected.msgType = 'c'
+	s.Equal(expected, m)
+}
+
+func (s *streamMessageSuite) TestStreamAbort() {
+	xid := s.newXid()
+	subXid := s.newXid()
+	msg := make([]byte, 1+4+4)
+	msg[0] = 'A'
+	bigEndian.PutUint32(msg[1:], xid)
+	bigEndian.PutUint32(msg[5:], subXid)
+
+	m, _, err := ParseV2(msg, false)
+	s.NoError(err)
+	expected := &StreamAbortMessage{Xid: xid, SubXid: subXid}
+	expected.msgType = 'A'
+	s.Equal(expected, m)
+}
+
+func (s *streamMessageSuite) TestStreamedChange() {
+	insert, expected := s.createInsertTestData()
+	xid := s.newXid()
+
+	// Changes within a stream block are prefixed with the xid.
+	msg := make([]byte, 0, len(insert)+4)
+	msg = append(msg, insert[0])
+	msg = bigEndian.AppendUint32(msg, xid)
+	msg = append(msg, insert[1:]...)
+
+	m, actualXid, err := ParseV2(msg, true)
+	s.NoError(err)
+	s.Equal(xid, actualXid)
+	s.Equal(expected, m)
+
+	m, actualXid, err = ParseV2(insert, false)
+	s.NoError(err)
+	s.Equal(uint32(0), actualXid)
+	s.Equal(expected, m)
+}
@@ -34,6 +34,13 @@ const (
 	BeginOpType OpType = "begin"
 	// CommitOpType is a database transaction commit
 	CommitOpType OpType = "commit"
+	// AbortOpType is a database transaction abort, which is only emitted for
+	// transactions that are streamed before they commit
+	AbortOpType OpType = "abort"
+	// TruncateOpType is a database table truncate
+	TruncateOpType OpType = "truncate"
+	// MessageOpType is a logical decoding message emitted with pg_logical_emit_message
+	MessageOpType OpType = "message"
 )
 
 // StreamMessage represents a single change from the database
@@ -44,4 +51,11 @@ type StreamMessage struct {
 	Table     string  `json:"table"`
 	// For deleted messages - there will be old changes if replica identity set to full or empty changes
 	Data any `json:"data"`
+	// For logical decoding messages - the prefix and whether the message was emitted within a transaction
+	Prefix        string `json:"prefix,omitempty"`
+	Transactional bool   `json:"transactional,omitempty"`
+	// For transactions that are streamed before they commit - the xid of the transaction and, for changes made
+	// within or aborts of a subtransaction, the xid of the subtransaction
+	Xid    uint32 `json:"xid,omitempty"`
+	SubXid uint32 `json:"subxid,omitempty"`
 }
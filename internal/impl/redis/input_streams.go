@@ -38,13 +38,26 @@ const (
 	siFieldStartFromOldest = "start_from_oldest"
 	siFieldCommitPeriod    = "commit_period"
 	siFieldTimeout         = "timeout"
+
+	siFieldAutoClaim                 = "auto_claim"
+	siFieldAutoClaimEnabled          = "enabled"
+	siFieldAutoClaimMinIdleTime      = "min_idle_time"
+	siFieldAutoClaimInterval         = "interval"
+	siFieldAutoClaimMaxDeliveries    = "max_deliveries"
+	siFieldAutoClaimDeadLetterStream = "dead_letter_stream"
 )
 
 func redisStreamsInputConfig() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Summary(`Pulls messages from Redis (v5.0+) streams with the XREADGROUP command. The `+"`client_id`"+` should be unique for each consumer of a group.`).
-		Description(`Redis stream entries are key/value pairs, as such it is necessary to specify the key that contains the body of the message. All other keys/value pairs are saved as metadata fields.`).
+		Description(`Redis stream entries are key/value pairs, as such it is necessary to specify the key that contains the body of the message. All other keys/value pairs are saved as metadata fields.
+
+== Pending Entry Recovery
+
+Entries that are delivered to a consumer of a group remain pending until they are acknowledged. When a consumer crashes, or is removed from the group, its pending entries are never delivered again unless claimed by another consumer. Enabling `+"`auto_claim`"+` causes the input to periodically claim entries of the group that have been pending for longer than `+"`min_idle_time`"+` with the XAUTOCLAIM command (Redis v6.2+), which are then consumed like any other entry. Reclaimed messages have the metadata field `+"`redis_stream_delivery_count`"+`, containing the number of times the entry has been delivered.
+
+Entries that have been delivered more than `+"`max_deliveries`"+` times are acknowledged without being consumed, and when a `+"`dead_letter_stream`"+` is set they are added to that stream first.`).
 		Categories("Services").
 		Fields(clientFields()...).
 		Fields(
@@ -79,6 +92,26 @@ func redisStreamsInputConfig() *service.ConfigSpec {
 				Description("The length of time to poll for new messages before reattempting.").
 				Advanced().
 				Default("1s"),
+			service.NewObjectField(siFieldAutoClaim,
+				service.NewBoolField(siFieldAutoClaimEnabled).
+					Description("Whether to claim entries left pending by other consumers of the group.").
+					Default(false),
+				service.NewDurationField(siFieldAutoClaimMinIdleTime).
+					Description("The minimum period of time that an entry must have been pending before it is claimed. This should be longer than the time it takes to process and acknowledge a message.").
+					Default("5m"),
+				service.NewDurationField(siFieldAutoClaimInterval).
+					Description("The period of time between attempts to claim pending entries.").
+					Default("30s"),
+				service.NewIntField(siFieldAutoClaimMaxDeliveries).
+					Description("The maximum number of times that an entry can be delivered before it is no longer consumed. Set to zero in order to consume entries regardless of their delivery count.").
+					Default(0),
+				service.NewStringField(siFieldAutoClaimDeadLetterStream).
+					Description("An optional stream to add entries to once they exceed `max_deliveries`. The fields of each entry are copied to the dead letter stream as they are.").
+					Default(""),
+			).
+				Description("Reclaim entries that have been left pending by consumers of the group that crashed or are no longer active.").
+				Advanced().
+				Version("4.73.0"),
 		)
 }
 
@@ -120,6 +153,14 @@ type redisStreamsReader struct {
 
 	backlogs map[string]string
 
+	autoClaim        bool
+	minIdleTime      time.Duration
+	claimInterval    time.Duration
+	maxDeliveries    int64
+	deadLetterStream string
+	lastClaim        time.Time
+	claimCursors     map[string]string
+
 	aMut    sync.Mutex
 	ackSend map[string][]string // Acks that can be sent
 
@@ -180,6 +221,30 @@ func newRedisStreamsReader(conf *service.ParsedConfig, mgr *service.Resources) (
 		return
 	}
 
+	claimConf := conf.Namespace(siFieldAutoClaim)
+	if r.autoClaim, err = claimConf.FieldBool(siFieldAutoClaimEnabled); err != nil {
+		return
+	}
+	if r.minIdleTime, err = claimConf.FieldDuration(siFieldAutoClaimMinIdleTime); err != nil {
+		return
+	}
+	if r.claimInterval, err = claimConf.FieldDuration(siFieldAutoClaimInterval); err != nil {
+		return
+	}
+	var tmpMaxDeliveries int
+	if tmpMaxDeliveries, err = claimConf.FieldInt(siFieldAutoClaimMaxDeliveries); err != nil {
+		return
+	}
+	if tmpMaxDeliveries < 0 {
+		err = errors.New("max_deliveries must not be negative")
+		return
+	}
+	r.maxDeliveries = int64(tmpMaxDeliveries)
+	if r.deadLetterStream, err = claimConf.FieldString(siFieldAutoClaimDeadLetterStream); err != nil {
+		return
+	}
+	r.claimCursors = make(map[string]string, len(r.streams))
+
 	r.ackSend = make(map[string][]string, len(r.streams))
 	r.backlogs = make(map[string]string, len(r.streams))
 	for _, str := range r.streams {
@@ -313,6 +378,17 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 		return msg, nil
 	}
 
+	if r.autoClaim && time.Since(r.lastClaim) >= r.claimInterval {
+		claimed, err := r.claim(ctx, client)
+		if err != nil {
+			r.log.Errorf("Failed to claim pending entries: %v", err)
+		}
+		if len(claimed) > 0 {
+			r.pendingMsgs = claimed[1:]
+			return claimed[0], nil
+		}
+	}
+
 	strs := make([]string, len(r.streams)*2)
 	for i, str := range r.streams {
 		strs[i] = str
@@ -356,35 +432,10 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 			}
 		}
 		for _, xmsg := range strRes.Messages {
-			body, exists := xmsg.Values[r.bodyKey]
-			if !exists {
+			nextMsg, ok := r.entryToMsg(strRes.Stream, xmsg)
+			if !ok {
 				continue
 			}
-			delete(xmsg.Values, r.bodyKey)
-
-			var bodyBytes []byte
-			switch t := body.(type) {
-			case string:
-				bodyBytes = []byte(t)
-			case []byte:
-				bodyBytes = t
-			}
-			if bodyBytes == nil {
-				continue
-			}
-
-			part := service.NewMessage(bodyBytes)
-			part.MetaSetMut("redis_stream", xmsg.ID)
-			for k, v := range xmsg.Values {
-				part.MetaSetMut(k, v)
-			}
-
-			nextMsg := pendingRedisStreamMsg{
-				payload: service.MessageBatch{},
-				stream:  strRes.Stream,
-				id:      xmsg.ID,
-			}
-			nextMsg.payload = append(nextMsg.payload, part)
 			if msg.payload == nil {
 				msg = nextMsg
 			} else {
@@ -400,6 +451,142 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 	return msg, nil
 }
 
+// entryToMsg converts a stream entry into a message, returning false when the
+// entry does not contain a body.
+func (r *redisStreamsReader) entryToMsg(stream string, xmsg redis.XMessage) (pendingRedisStreamMsg, bool) {
+	body, exists := xmsg.Values[r.bodyKey]
+	if !exists {
+		return pendingRedisStreamMsg{}, false
+	}
+	delete(xmsg.Values, r.bodyKey)
+
+	var bodyBytes []byte
+	switch t := body.(type) {
+	case string:
+		bodyBytes = []byte(t)
+	case []byte:
+		bodyBytes = t
+	}
+	if bodyBytes == nil {
+		return pendingRedisStreamMsg{}, false
+	}
+
+	part := service.NewMessage(bodyBytes)
+	part.MetaSetMut("redis_stream", xmsg.ID)
+	for k, v := range xmsg.Values {
+		part.MetaSetMut(k, v)
+	}
+
+	return pendingRedisStreamMsg{
+		payload: service.MessageBatch{part},
+		stream:  stream,
+		id:      xmsg.ID,
+	}, true
+}
+
+// claim takes ownership of entries of each stream that have been pending for
+// longer than the minimum idle time. A page of entries is claimed from each
+// stream per call, and when a stream has further pending entries the next
+// call is made without waiting for the claim interval.
+func (r *redisStreamsReader) claim(ctx context.Context, client redis.UniversalClient) ([]pendingRedisStreamMsg, error) {
+	var claimed []pendingRedisStreamMsg
+	var errs []error
+
+	exhausted := true
+	for _, stream := range r.streams {
+		cursor := r.claimCursors[stream]
+		if cursor == "" {
+			cursor = "0-0"
+		}
+
+		xmsgs, next, err := client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    r.consumerGroup,
+			Consumer: r.clientID,
+			MinIdle:  r.minIdleTime,
+			Start:    cursor,
+			Count:    r.limit,
+		}).Result()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stream %v: %w", stream, err))
+			continue
+		}
+		r.claimCursors[stream] = next
+		if next != "0-0" {
+			exhausted = false
+		}
+		if len(xmsgs) == 0 {
+			continue
+		}
+
+		// XAUTOCLAIM increments the delivery count of claimed entries, which
+		// we obtain from the pending entries list of this consumer.
+		pending, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   stream,
+			Group:    r.consumerGroup,
+			Start:    xmsgs[0].ID,
+			End:      xmsgs[len(xmsgs)-1].ID,
+			Count:    int64(len(xmsgs)),
+			Consumer: r.clientID,
+		}).Result()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stream %v: %w", stream, err))
+			continue
+		}
+		deliveries := make(map[string]int64, len(pending))
+		for _, p := range pending {
+			deliveries[p.ID] = p.RetryCount
+		}
+
+		for _, xmsg := range xmsgs {
+			count := deliveries[xmsg.ID]
+			if r.maxDeliveries > 0 && count > r.maxDeliveries {
+				if err := r.deadLetter(ctx, client, stream, xmsg, count); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+
+			values := make(map[string]any, len(xmsg.Values))
+			for k, v := range xmsg.Values {
+				values[k] = v
+			}
+			msg, ok := r.entryToMsg(stream, redis.XMessage{ID: xmsg.ID, Values: values})
+			if !ok {
+				continue
+			}
+			msg.payload[0].MetaSetMut("redis_stream_delivery_count", count)
+			claimed = append(claimed, msg)
+		}
+	}
+
+	if exhausted || len(errs) > 0 {
+		r.lastClaim = time.Now()
+	}
+	return claimed, errors.Join(errs...)
+}
+
+// deadLetter removes an entry that has exceeded the maximum number of
+// deliveries from the pending entries list, adding it to the dead letter
+// stream when configured.
+func (r *redisStreamsReader) deadLetter(ctx context.Context, client redis.UniversalClient, stream string, xmsg redis.XMessage, deliveries int64) error {
+	if r.deadLetterStream != "" {
+		if err := client.XAdd(ctx, &redis.XAddArgs{
+			Stream: r.deadLetterStream,
+			Values: xmsg.Values,
+		}).Err(); err != nil {
+			return fmt.Errorf("failed to add entry %v of stream %v to dead letter stream: %w", xmsg.ID, stream, err)
+		}
+		r.log.Warnf("Entry %v of stream %v was delivered %v times and has been moved to dead letter stream %v", xmsg.ID, stream, deliveries, r.deadLetterStream)
+	} else {
+		r.log.Errorf("Entry %v of stream %v was delivered %v times and is being dropped", xmsg.ID, stream, deliveries)
+	}
+	if err := client.XAck(ctx, stream, r.consumerGroup, xmsg.ID).Err(); err != nil {
+		return fmt.Errorf("failed to ack entry %v of stream %v: %w", xmsg.ID, stream, err)
+	}
+	return nil
+}
+
 func (r *redisStreamsReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
 	msg, err := r.read(ctx)
 	if err != nil {
@@ -16,6 +16,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"testing"
@@ -27,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
+	"github.com/redpanda-data/benthos/v4/public/service"
 	"github.com/redpanda-data/benthos/v4/public/service/integration"
 )
 
@@ -270,6 +272,95 @@ output:
 			integration.StreamTestOptPort(resource.GetPort("6379/tcp")),
 		)
 	})
+
+	// STREAMS AUTO CLAIM
+	t.Run("streams auto claim", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := t.Context()
+		require.NoError(t, client.XGroupCreateMkStream(ctx, "claim-stream", "claim-group", "0").Err())
+		for i := range 3 {
+			require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{
+				Stream: "claim-stream",
+				Values: map[string]any{"body": fmt.Sprintf("foo%v", i)},
+			}).Err())
+		}
+
+		// Entries are read by a consumer that never acknowledges them, where
+		// the last entry is redelivered until it exceeds max deliveries.
+		res, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    "claim-group",
+			Consumer: "crashed",
+			Streams:  []string{"claim-stream", ">"},
+			Count:    3,
+		}).Result()
+		require.NoError(t, err)
+		require.Len(t, res[0].Messages, 3)
+		for range 2 {
+			require.NoError(t, client.XClaim(ctx, &redis.XClaimArgs{
+				Stream:   "claim-stream",
+				Group:    "claim-group",
+				Consumer: "crashed",
+				Messages: []string{res[0].Messages[2].ID},
+			}).Err())
+		}
+		time.Sleep(time.Millisecond * 50)
+
+		conf, err := redisStreamsInputConfig().ParseYAML(fmt.Sprintf(`
+url: %v
+streams: [ claim-stream ]
+client_id: recovered
+consumer_group: claim-group
+commit_period: 10ms
+auto_claim:
+  enabled: true
+  min_idle_time: 10ms
+  interval: 10ms
+  max_deliveries: 2
+  dead_letter_stream: claim-dlq
+`, urlStr), nil)
+		require.NoError(t, err)
+
+		r, err := newRedisStreamsReader(conf, service.MockResources())
+		require.NoError(t, err)
+		require.NoError(t, r.Connect(ctx))
+		t.Cleanup(func() {
+			_ = r.Close(context.Background())
+		})
+
+		readCtx, done := context.WithTimeout(ctx, time.Second*10)
+		defer done()
+
+		var bodies []string
+		for len(bodies) < 2 {
+			batch, ackFn, err := r.ReadBatch(readCtx)
+			if errors.Is(err, context.Canceled) {
+				continue
+			}
+			require.NoError(t, err)
+			for _, msg := range batch {
+				b, err := msg.AsBytes()
+				require.NoError(t, err)
+				bodies = append(bodies, string(b))
+
+				count, exists := msg.MetaGetMut("redis_stream_delivery_count")
+				require.True(t, exists)
+				assert.Equal(t, int64(2), count)
+			}
+			require.NoError(t, ackFn(ctx, nil))
+		}
+		assert.ElementsMatch(t, []string{"foo0", "foo1"}, bodies)
+
+		dlq, err := client.XRange(ctx, "claim-dlq", "-", "+").Result()
+		require.NoError(t, err)
+		require.Len(t, dlq, 1)
+		assert.Equal(t, "foo2", dlq[0].Values["body"])
+
+		assert.Eventually(t, func() bool {
+			pending, err := client.XPending(ctx, "claim-stream", "claim-group").Result()
+			return err == nil && pending.Count == 0
+		}, time.Second*5, time.Millisecond*50)
+	})
 }
 
 func BenchmarkIntegrationRedis(b *testing.B) {
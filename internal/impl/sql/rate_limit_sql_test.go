@@ -0,0 +1,127 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+
+	_ "github.com/redpanda-data/connect/v4/public/components/sql"
+)
+
+func sqliteRateLimitResources(t *testing.T, dsn, extra string) *service.Resources {
+	t.Helper()
+
+	resBuilder := service.NewResourceBuilder()
+	require.NoError(t, resBuilder.AddRateLimitYAML(fmt.Sprintf(`
+label: foo
+sql:
+  driver: sqlite
+  dsn: %v
+  table: rate_limits
+  key: foo
+  init_statement: |
+    CREATE TABLE IF NOT EXISTS rate_limits (
+      limit_key varchar(255) primary key,
+      access_count integer not null,
+      window_end bigint not null
+    );
+%v
+`, dsn, extra)))
+
+	res, done, err := resBuilder.Build()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, done(t.Context()))
+	})
+	return res
+}
+
+func TestSQLRateLimitSQLite(t *testing.T) {
+	dsn := fmt.Sprintf("file:%v/rl.db", t.TempDir())
+
+	res := sqliteRateLimitResources(t, dsn, `
+  count: 10
+  interval: 200ms
+`)
+
+	access := func() (period time.Duration) {
+		require.NoError(t, res.AccessRateLimit(t.Context(), "foo", func(rl service.RateLimit) {
+			var err error
+			period, err = rl.Access(t.Context())
+			require.NoError(t, err)
+		}))
+		return
+	}
+
+	for range 10 {
+		assert.Equal(t, time.Duration(0), access())
+	}
+
+	period := access()
+	assert.Greater(t, period, time.Duration(0))
+	assert.LessOrEqual(t, period, time.Millisecond*200)
+
+	time.Sleep(period)
+	assert.Equal(t, time.Duration(0), access())
+}
+
+func TestSQLRateLimitSQLiteShared(t *testing.T) {
+	dsn := fmt.Sprintf("file:%v/rl.db?_pragma=busy_timeout(5000)", t.TempDir())
+
+	// Two instances sharing the same table and key.
+	resA := sqliteRateLimitResources(t, dsn, `
+  count: 20
+  interval: 1m
+`)
+	resB := sqliteRateLimitResources(t, dsn, `
+  count: 20
+  interval: 1m
+`)
+
+	var allowedMut sync.Mutex
+	var allowed int
+
+	var wg sync.WaitGroup
+	for _, res := range []*service.Resources{resA, resB} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 15 {
+				var period time.Duration
+				assert.NoError(t, res.AccessRateLimit(t.Context(), "foo", func(rl service.RateLimit) {
+					var err error
+					period, err = rl.Access(t.Context())
+					assert.NoError(t, err)
+				}))
+				if period == 0 {
+					allowedMut.Lock()
+					allowed++
+					allowedMut.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 20, allowed)
+}
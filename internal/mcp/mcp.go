@@ -23,6 +23,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -39,21 +40,49 @@ import (
 	_ "github.com/redpanda-data/connect/v4/public/components/all"
 )
 
+// gMux adapts a gorilla mux router to the service.HTTPMultiplexer interface.
+// Handlers registered for a pattern that already exists replace the previous
+// handler, which allows resources to re-register their endpoints when the
+// repository is reloaded.
 type gMux struct {
 	m *mux.Router
+
+	mu       sync.RWMutex
+	handlers map[string]func(http.ResponseWriter, *http.Request)
 }
 
 func (g *gMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
-	g.m.Path(pattern).HandlerFunc(handler) // TODO: PathPrefix?
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.handlers == nil {
+		g.handlers = map[string]func(http.ResponseWriter, *http.Request){}
+	}
+	if _, exists := g.handlers[pattern]; !exists {
+		g.m.Path(pattern).HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // TODO: PathPrefix?
+			g.mu.RLock()
+			h := g.handlers[pattern]
+			g.mu.RUnlock()
+			h(w, r)
+		})
+	}
+	g.handlers[pattern] = handler
 }
 
 // Server runs an mcp server against a target directory, with an optiona base
 // URL for an HTTP server.
 type Server struct {
-	base      *mcp.Server
-	mux       *mux.Router
-	rpJWT     *gateway.RPJWTMiddleware
-	cors      gateway.CORSConfig
+	base   *mcp.Server
+	mux    *mux.Router
+	rpJWT  *gateway.RPJWTMiddleware
+	cors   gateway.CORSConfig
+	logger *slog.Logger
+
+	repositoryDir string
+	load          func() (*tools.ResourcesWrapper, *service.Resources, error)
+	reloadMu      sync.Mutex
+
+	mu        sync.Mutex
+	wrapper   *tools.ResourcesWrapper
 	resources *service.Resources
 }
 
@@ -71,91 +100,113 @@ func NewServer(
 	s := mcp.NewServer(&mcp.Implementation{
 		Name:    "Redpanda Runtime",
 		Version: "1.0.0",
-	}, nil)
+	}, &mcp.ServerOptions{
+		// Subscriptions are tracked by the server itself, resources backed by
+		// inputs and caches publish updates to any subscribed sessions.
+		SubscribeHandler: func(context.Context, *mcp.SubscribeRequest) error {
+			return nil
+		},
+		UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error {
+			return nil
+		},
+	})
 
 	mux := mux.NewRouter()
+	httpMux := &gMux{m: mux}
 
 	env := service.GlobalEnvironment()
 
-	resWrapper := tools.NewResourcesWrapper(logger, s, filterFunc, tagFilterFunc)
-	resWrapper.SetEnvVarLookupFunc(envVarLookupFunc)
-	resWrapper.SetHTTPMultiplexer(&gMux{m: mux})
+	load := func() (*tools.ResourcesWrapper, *service.Resources, error) {
+		resWrapper := tools.NewResourcesWrapper(logger, s, filterFunc, tagFilterFunc)
+		resWrapper.SetEnvVarLookupFunc(envVarLookupFunc)
+		resWrapper.SetHTTPMultiplexer(httpMux)
 
-	repoScanner := repository.NewScanner(os.DirFS(repositoryDir))
+		repoScanner := repository.NewScanner(os.DirFS(repositoryDir))
 
-	repoScanner.OnTemplateFile(func(_ string, contents []byte) error {
-		return env.RegisterTemplateYAML(string(contents))
-	})
+		repoScanner.OnTemplateFile(func(_ string, contents []byte) error {
+			return env.RegisterTemplateYAML(string(contents))
+		})
 
-	repoScanner.OnResourceFile(func(resourceType, filename string, contents []byte) error {
-		switch resourceType {
-		case "starlark":
-			result, err := starlark.Eval(context.Background(), env, logger, filename, contents, envVarLookupFunc)
-			if err != nil {
-				return err
-			}
-			for _, v := range result.Processors {
-				cfg := map[string]any{
-					"label": v.Label,
-					v.Name:  v.SerializedConfig,
-					"meta": map[string]any{
-						"mcp": map[string]any{
-							"enabled":     true,
-							"description": v.Description,
+		repoScanner.OnPromptFile(func(_ string, contents []byte) error {
+			return resWrapper.AddPromptYAML(contents)
+		})
+
+		repoScanner.OnResourceFile(func(resourceType, filename string, contents []byte) error {
+			switch resourceType {
+			case "starlark":
+				result, err := starlark.Eval(context.Background(), env, logger, filename, contents, envVarLookupFunc)
+				if err != nil {
+					return err
+				}
+				for _, v := range result.Processors {
+					cfg := map[string]any{
+						"label": v.Label,
+						v.Name:  v.SerializedConfig,
+						"meta": map[string]any{
+							"mcp": map[string]any{
+								"enabled":     true,
+								"description": v.Description,
+							},
 						},
-					},
+					}
+					b, err := json.Marshal(&cfg)
+					if err != nil {
+						return err
+					}
+					if err := resWrapper.AddProcessorYAML(b); err != nil {
+						return err
+					}
 				}
-				b, err := json.Marshal(&cfg)
-				if err != nil {
+			case "input":
+				if err := resWrapper.AddInputYAML(contents); err != nil {
 					return err
 				}
-				if err := resWrapper.AddProcessorYAML(b); err != nil {
+			case "cache":
+				if err := resWrapper.AddCacheYAML(contents); err != nil {
 					return err
 				}
+			case "processor":
+				if err := resWrapper.AddProcessorYAML(contents); err != nil {
+					return err
+				}
+			case "output":
+				if err := resWrapper.AddOutputYAML(contents); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("resource type '%v' is not supported yet", resourceType)
 			}
-		case "input":
-			if err := resWrapper.AddInputYAML(contents); err != nil {
-				return err
-			}
-		case "cache":
-			if err := resWrapper.AddCacheYAML(contents); err != nil {
-				return err
-			}
-		case "processor":
-			if err := resWrapper.AddProcessorYAML(contents); err != nil {
-				return err
-			}
-		case "output":
-			if err := resWrapper.AddOutputYAML(contents); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("resource type '%v' is not supported yet", resourceType)
-		}
-		return nil
-	})
+			return nil
+		})
 
-	repoScanner.OnMetricsFile(func(_ string, contents []byte) error {
-		// TODO: Detect starlark here?
-		return resWrapper.SetMetricsYAML(contents)
-	})
+		repoScanner.OnMetricsFile(func(_ string, contents []byte) error {
+			// TODO: Detect starlark here?
+			return resWrapper.SetMetricsYAML(contents)
+		})
 
-	repoScanner.OnTracerFile(func(_ string, contents []byte) error {
-		// TODO: Detect starlark here?
-		return resWrapper.SetTracerYAML(contents)
-	})
+		repoScanner.OnTracerFile(func(_ string, contents []byte) error {
+			// TODO: Detect starlark here?
+			return resWrapper.SetTracerYAML(contents)
+		})
 
-	if err := repoScanner.Scan("."); err != nil {
-		return nil, err
+		if err := repoScanner.Scan("."); err != nil {
+			return nil, nil, err
+		}
+
+		resources, err := resWrapper.Build()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		license.RegisterService(resources, licenseConfig)
+		return resWrapper, resources, nil
 	}
 
-	resources, err := resWrapper.Build()
+	resWrapper, resources, err := load()
 	if err != nil {
 		return nil, err
 	}
 
-	license.RegisterService(resources, licenseConfig)
-
 	if auth != nil {
 		if err := license.CheckRunningEnterprise(resources); err != nil {
 			return nil, fmt.Errorf("unable to apply authorization policy: %w", err)
@@ -171,16 +222,22 @@ func NewServer(
 	cors := gateway.NewCORSConfigFromEnv()
 
 	return &Server{
-		base:      s,
-		mux:       mux,
-		rpJWT:     rpJWT,
-		cors:      cors,
-		resources: resources,
+		base:          s,
+		mux:           mux,
+		rpJWT:         rpJWT,
+		cors:          cors,
+		logger:        logger,
+		repositoryDir: repositoryDir,
+		load:          load,
+		wrapper:       resWrapper,
+		resources:     resources,
 	}, nil
 }
 
 // Resources returns the server's service resources for testing purposes.
 func (m *Server) Resources() *service.Resources {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.resources
 }
 
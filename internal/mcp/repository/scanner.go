@@ -27,6 +27,7 @@ type Scanner struct {
 	fs fs.FS
 
 	onTemplate func(filePath string, contents []byte) error
+	onPrompt   func(filePath string, contents []byte) error
 	onResource func(resourceType, filePath string, contents []byte) error
 	onMetrics  func(filePath string, contents []byte) error
 	onTracer   func(filePath string, contents []byte) error
@@ -45,6 +46,12 @@ func (s *Scanner) OnTemplateFile(fn func(filePath string, contents []byte) error
 	s.onTemplate = fn
 }
 
+// OnPromptFile registers a closure to be called for each prompt file
+// encountered by the scanner.
+func (s *Scanner) OnPromptFile(fn func(filePath string, contents []byte) error) {
+	s.onPrompt = fn
+}
+
 // OnResourceFile registers a closure to be called for each resource file
 // encountered by the scanner.
 func (s *Scanner) OnResourceFile(fn func(resourceType, filePath string, contents []byte) error) {
@@ -109,6 +116,17 @@ func (s *Scanner) Scan(root string) error {
 		}
 	}
 
+	if s.onPrompt != nil {
+		promptsDir := filepath.Join(root, "prompts")
+
+		// All prompts are defined in yaml files
+		if err := fs.WalkDir(s.fs, promptsDir, s.scanFnForExtensions(func(path string, contents []byte) error {
+			return s.onPrompt(path, contents)
+		}, yamlExtensions...)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
 	if s.onResource != nil {
 		// Scan each resource type for files
 		resourceDir := filepath.Join(root, "resources")
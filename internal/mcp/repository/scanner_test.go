@@ -33,6 +33,12 @@ func TestScannerHappy(t *testing.T) {
 		filepath.Clean("templates/notthis.txt"): &fstest.MapFile{
 			Data: []byte(`IGNORE ME`),
 		},
+		filepath.Clean("prompts/summarise.yaml"): &fstest.MapFile{
+			Data: []byte(`summarise prompt`),
+		},
+		filepath.Clean("prompts/notthis.md"): &fstest.MapFile{
+			Data: []byte(`IGNORE ME`),
+		},
 		filepath.Clean("resources/caches/foo.yaml"): &fstest.MapFile{
 			Data: []byte(`foo cache conf`),
 		},
@@ -61,6 +67,7 @@ func TestScannerHappy(t *testing.T) {
 
 	exp := map[string]string{
 		"templates/woof.yaml/template":                  "woof template",
+		"prompts/summarise.yaml/prompt":                 "summarise prompt",
 		"resources/caches/foo.yaml/cache":               "foo cache conf",
 		"resources/processors/deeper/bar.yml/processor": "bar proc conf",
 		"resources/inputs/baz.yml/input":                "baz input conf",
@@ -74,6 +81,10 @@ func TestScannerHappy(t *testing.T) {
 		act[filePath+"/template"] = string(contents)
 		return nil
 	})
+	s.OnPromptFile(func(filePath string, contents []byte) error {
+		act[filePath+"/prompt"] = string(contents)
+		return nil
+	})
 	s.OnResourceFile(func(resourceType, filePath string, contents []byte) error {
 		act[filePath+"/"+resourceType] = string(contents)
 		return nil
@@ -37,6 +37,15 @@ func Run(
 	if err != nil {
 		return err
 	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := srv.WatchRepository(ctx); err != nil {
+			logger.With("error", err).Error("Failed to watch repository, changes will not be reloaded")
+		}
+	}()
+
 	if addr == "" {
 		return srv.ServeStdio()
 	}
@@ -45,5 +54,5 @@ func Run(
 		return err
 	}
 	defer l.Close()
-	return srv.ServeHTTP(context.Background(), l)
+	return srv.ServeHTTP(ctx, l)
 }
@@ -0,0 +1,230 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	defaultInputResourceBufferSize = 10
+	inputResourceReadTimeout       = time.Second
+)
+
+type mcpResourceConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Description string `yaml:"description"`
+	MIMEType    string `yaml:"mime_type"`
+	// The number of most recent messages retained by input resources.
+	BufferSize int `yaml:"buffer_size"`
+}
+
+func (c mcpResourceConfig) description(fallback string) string {
+	if c.Description != "" {
+		return c.Description
+	}
+	return fallback
+}
+
+func resourceContents(uri, mimeType string, data []byte) *mcp.ResourceContents {
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	if utf8.Valid(data) {
+		return &mcp.ResourceContents{URI: uri, MIMEType: mimeType, Text: string(data)}
+	}
+	return &mcp.ResourceContents{URI: uri, MIMEType: mimeType, Blob: data}
+}
+
+func cacheResourcePrefix(label string) string {
+	return "cache://" + label + "/"
+}
+
+func cacheResourceURI(label, key string) string {
+	return cacheResourcePrefix(label) + url.PathEscape(key)
+}
+
+// addCacheResource exposes the items of a cache as an MCP resource template of
+// the form cache://<label>/{key}, updates are published when items are set
+// via the MCP tools of the cache.
+func (w *ResourcesWrapper) addCacheResource(res resFile) {
+	cfg := res.Meta.MCP.Resource
+	prefix := cacheResourcePrefix(res.Label)
+
+	w.logger.With("label", res.Label).Info("Registering cache resource")
+
+	w.addResourceTemplate(&mcp.ResourceTemplate{
+		Name:        res.Label,
+		Description: cfg.description(res.Meta.MCP.Description),
+		MIMEType:    cfg.MIMEType,
+		URITemplate: prefix + "{key}",
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		ctx, span := w.initSpan(ctx, res.Label)
+		defer span.End()
+
+		uri := request.Params.URI
+		attrString(span, "uri", uri)
+
+		key, err := url.PathUnescape(strings.TrimPrefix(uri, prefix))
+		if err != nil || key == "" || !strings.HasPrefix(uri, prefix) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+
+		var value []byte
+		var getErr error
+		if err := w.resources.AccessCache(ctx, res.Label, func(c service.Cache) {
+			value, getErr = c.Get(ctx, key)
+		}); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		if errors.Is(getErr, service.ErrKeyNotFound) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		if getErr != nil {
+			span.RecordError(getErr)
+			return nil, getErr
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{resourceContents(uri, cfg.MIMEType, value)},
+		}, nil
+	})
+}
+
+// inputResource retains the most recent messages consumed from an input so
+// that they can be read as an MCP resource.
+type inputResource struct {
+	label string
+	uri   string
+	cfg   mcpResourceConfig
+
+	mu       sync.Mutex
+	messages [][]byte
+}
+
+func (r *inputResource) push(msgs [][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msgs...)
+	if excess := len(r.messages) - r.cfg.BufferSize; excess > 0 {
+		r.messages = r.messages[excess:]
+	}
+}
+
+func (r *inputResource) snapshot() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]byte(nil), r.messages...)
+}
+
+// addInputResource exposes the most recent messages of an input as an MCP
+// resource of the form input://<label>. The input is only consumed when a
+// client reads the resource, at which point up to buffer_size new messages
+// are read and acknowledged, and the most recent buffer_size messages are
+// returned.
+func (w *ResourcesWrapper) addInputResource(res resFile) {
+	cfg := res.Meta.MCP.Resource
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultInputResourceBufferSize
+	}
+	r := &inputResource{
+		label: res.Label,
+		uri:   "input://" + res.Label,
+		cfg:   cfg,
+	}
+
+	w.logger.With("label", res.Label).Info("Registering input resource")
+
+	w.addResource(&mcp.Resource{
+		Name:        res.Label,
+		Description: cfg.description(res.Meta.MCP.Description),
+		MIMEType:    cfg.MIMEType,
+		URI:         r.uri,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		ctx, span := w.initSpan(ctx, res.Label)
+		defer span.End()
+
+		if err := w.readInputResource(ctx, r); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+
+		var contents []*mcp.ResourceContents
+		for _, m := range r.snapshot() {
+			contents = append(contents, resourceContents(request.Params.URI, cfg.MIMEType, m))
+		}
+		return &mcp.ReadResourceResult{Contents: contents}, nil
+	})
+}
+
+// readInputResource reads up to the buffer size of new messages from the
+// input of a resource, giving up on waiting for more once the read timeout
+// elapses. The input is only held for the duration of the read so that it
+// isn't contended with the input tool.
+func (w *ResourcesWrapper) readInputResource(ctx context.Context, r *inputResource) error {
+	readCtx, done := context.WithTimeout(ctx, inputResourceReadTimeout)
+	defer done()
+
+	var msgs [][]byte
+	var iErr error
+	if err := w.resources.AccessInput(ctx, r.label, func(i *service.ResourceInput) {
+		for len(msgs) < r.cfg.BufferSize {
+			batch, ackFn, err := i.ReadBatch(readCtx)
+			if err != nil {
+				iErr = err
+				return
+			}
+
+			for _, m := range batch {
+				mBytes, err := m.AsBytes()
+				if err != nil {
+					continue
+				}
+				// NOTE: We copy here because after acknowledgement we no
+				// longer own the message contents.
+				msgs = append(msgs, append([]byte(nil), mBytes...))
+			}
+
+			if err := ackFn(ctx, nil); err != nil {
+				iErr = err
+				return
+			}
+		}
+	}); err != nil {
+		return err
+	}
+	r.push(msgs)
+
+	if len(msgs) > 0 {
+		_ = w.svr.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: r.uri})
+	}
+	if iErr != nil && ctx.Err() == nil &&
+		(errors.Is(iErr, context.DeadlineExceeded) || errors.Is(iErr, service.ErrEndOfInput)) {
+		// Running out of messages to read is expected.
+		return nil
+	}
+	return iErr
+}
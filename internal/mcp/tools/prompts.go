@@ -0,0 +1,231 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+type promptArgument struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+func (a promptArgument) validate() error {
+	if a.Name == "" {
+		return errors.New("a prompt argument name is required")
+	}
+	switch a.Type {
+	case "", "string", "number", "boolean":
+		return nil
+	}
+	return fmt.Errorf("argument '%v' has unsupported type '%v', expected string, number or boolean", a.Name, a.Type)
+}
+
+// coerce converts the string value of a prompt argument, which is how all
+// arguments are provided by MCP clients, into the type declared for it.
+func (a promptArgument) coerce(v string) (any, error) {
+	switch a.Type {
+	case "", "string":
+		return v, nil
+	case "number":
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%v' must be a number: %w", a.Name, err)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%v' must be a boolean: %w", a.Name, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("argument '%v' has unsupported type '%v'", a.Name, a.Type)
+}
+
+type promptFile struct {
+	Name        string           `yaml:"name"`
+	Title       string           `yaml:"title"`
+	Description string           `yaml:"description"`
+	Tags        []string         `yaml:"tags"`
+	Arguments   []promptArgument `yaml:"arguments"`
+	Mapping     string           `yaml:"mapping"`
+	Template    string           `yaml:"template"`
+}
+
+// AddPromptYAML attempts to parse a prompt config and adds it to the MCP server
+// as a prompt. A prompt is rendered either with a Bloblang mapping, which is
+// executed against an object of the provided arguments, or with an
+// interpolated string template.
+func (w *ResourcesWrapper) AddPromptYAML(fileBytes []byte) error {
+	var p promptFile
+	if err := yaml.Unmarshal(fileBytes, &p); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		return errors.New("a prompt name is required")
+	}
+
+	if !w.labelFilter(p.Name) {
+		return nil
+	}
+	if !w.tagsFilter(p.Tags) {
+		return nil
+	}
+
+	var render func(args map[string]any) ([]*mcp.PromptMessage, error)
+	switch {
+	case p.Mapping != "" && p.Template != "":
+		return errors.New("a prompt must specify only one of mapping or template")
+	case p.Mapping != "":
+		exec, err := bloblang.Parse(p.Mapping)
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt mapping: %w", err)
+		}
+		render = func(args map[string]any) ([]*mcp.PromptMessage, error) {
+			v, err := exec.Query(args)
+			if err != nil {
+				return nil, err
+			}
+			return promptMessagesFromValue(v)
+		}
+	case p.Template != "":
+		tmpl, err := service.NewInterpolatedString(p.Template)
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt template: %w", err)
+		}
+		render = func(args map[string]any) ([]*mcp.PromptMessage, error) {
+			msg := service.NewMessage(nil)
+			msg.SetStructured(args)
+			text, err := tmpl.TryString(msg)
+			if err != nil {
+				return nil, err
+			}
+			return promptMessagesFromValue(text)
+		}
+	default:
+		return errors.New("a prompt must specify either a mapping or a template")
+	}
+
+	seen := map[string]struct{}{}
+	var arguments []*mcp.PromptArgument
+	for _, a := range p.Arguments {
+		if err := a.validate(); err != nil {
+			return err
+		}
+		if _, exists := seen[a.Name]; exists {
+			return fmt.Errorf("duplicate argument '%v' detected", a.Name)
+		}
+		seen[a.Name] = struct{}{}
+		arguments = append(arguments, &mcp.PromptArgument{
+			Name:        a.Name,
+			Description: a.Description,
+			Required:    a.Required,
+		})
+	}
+
+	w.logger.With("name", p.Name).Info("Registering prompt")
+
+	w.addPrompt(&mcp.Prompt{
+		Name:        p.Name,
+		Title:       p.Title,
+		Description: p.Description,
+		Arguments:   arguments,
+	}, func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		_, span := w.initSpan(ctx, p.Name)
+		defer span.End()
+
+		args := map[string]any{}
+		for _, a := range p.Arguments {
+			v, exists := request.Params.Arguments[a.Name]
+			if !exists {
+				if a.Required {
+					err := fmt.Errorf("required argument '%v' was missing", a.Name)
+					span.RecordError(err)
+					return nil, err
+				}
+				continue
+			}
+			attrString(span, a.Name, v)
+			typed, err := a.coerce(v)
+			if err != nil {
+				span.RecordError(err)
+				return nil, err
+			}
+			args[a.Name] = typed
+		}
+
+		messages, err := render(args)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		return &mcp.GetPromptResult{
+			Description: p.Description,
+			Messages:    messages,
+		}, nil
+	})
+
+	return nil
+}
+
+// promptMessagesFromValue converts the result of rendering a prompt into a
+// list of messages. A string results in a single user message, whereas an
+// array may contain strings or objects with a role and content.
+func promptMessagesFromValue(v any) ([]*mcp.PromptMessage, error) {
+	switch t := v.(type) {
+	case string:
+		return []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: t}}}, nil
+	case []byte:
+		return []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: string(t)}}}, nil
+	case []any:
+		var messages []*mcp.PromptMessage
+		for i, e := range t {
+			switch et := e.(type) {
+			case string:
+				messages = append(messages, &mcp.PromptMessage{Role: "user", Content: &mcp.TextContent{Text: et}})
+			case map[string]any:
+				role, _ := et["role"].(string)
+				if role == "" {
+					role = "user"
+				}
+				if role != "user" && role != "assistant" {
+					return nil, fmt.Errorf("message %v has unsupported role '%v'", i, role)
+				}
+				content, ok := et["content"].(string)
+				if !ok {
+					return nil, fmt.Errorf("message %v is missing a string content field", i)
+				}
+				messages = append(messages, &mcp.PromptMessage{Role: mcp.Role(role), Content: &mcp.TextContent{Text: content}})
+			default:
+				return nil, fmt.Errorf("message %v must be a string or an object, got %T", i, e)
+			}
+		}
+		return messages, nil
+	}
+	return nil, fmt.Errorf("prompt must render to a string or an array of messages, got %T", v)
+}
@@ -32,12 +32,21 @@ import (
 // ResourcesWrapper attempts to parse resource files, adds those resources to
 // a ResourcesBuilder as well as, where appropriate, adding them to an MCP
 // server as tools.
+//
+// Tools, prompts and resources are only added to the MCP server once the
+// resources have been successfully built.
 type ResourcesWrapper struct {
 	logger    *slog.Logger
 	svr       *mcp.Server
 	builder   *service.ResourceBuilder
 	resources *service.Resources
 	closeFn   func(context.Context) error
+
+	tools        []toolEntry
+	prompts      []promptEntry
+	mcpResources []resourceEntry
+	resTemplates []resourceTemplateEntry
+
 	// TODO: Remove labels in favour of tags
 	labelFilter func(label string) bool
 	tagsFilter  func(tags []string) bool
@@ -82,11 +91,94 @@ func (w *ResourcesWrapper) SetHTTPMultiplexer(mux service.HTTPMultiplexer) {
 // Build the underlying ResourcesBuilder, which allows the resources to be
 // executed.
 func (w *ResourcesWrapper) Build() (resources *service.Resources, err error) {
-	resources, w.closeFn, err = w.builder.Build()
+	if resources, w.closeFn, err = w.builder.Build(); err != nil {
+		return
+	}
 	w.resources = resources
+	for _, e := range w.tools {
+		w.svr.AddTool(e.tool, e.handler)
+	}
+	for _, e := range w.prompts {
+		w.svr.AddPrompt(e.prompt, e.handler)
+	}
+	for _, e := range w.mcpResources {
+		w.svr.AddResource(e.resource, e.handler)
+	}
+	for _, e := range w.resTemplates {
+		w.svr.AddResourceTemplate(e.template, e.handler)
+	}
 	return
 }
 
+type toolEntry struct {
+	tool    *mcp.Tool
+	handler mcp.ToolHandler
+}
+
+type promptEntry struct {
+	prompt  *mcp.Prompt
+	handler mcp.PromptHandler
+}
+
+type resourceEntry struct {
+	resource *mcp.Resource
+	handler  mcp.ResourceHandler
+}
+
+type resourceTemplateEntry struct {
+	template *mcp.ResourceTemplate
+	handler  mcp.ResourceHandler
+}
+
+func (w *ResourcesWrapper) addTool(t *mcp.Tool, h mcp.ToolHandler) {
+	w.tools = append(w.tools, toolEntry{tool: t, handler: h})
+}
+
+func (w *ResourcesWrapper) addPrompt(p *mcp.Prompt, h mcp.PromptHandler) {
+	w.prompts = append(w.prompts, promptEntry{prompt: p, handler: h})
+}
+
+func (w *ResourcesWrapper) addResource(r *mcp.Resource, h mcp.ResourceHandler) {
+	w.mcpResources = append(w.mcpResources, resourceEntry{resource: r, handler: h})
+}
+
+func (w *ResourcesWrapper) addResourceTemplate(t *mcp.ResourceTemplate, h mcp.ResourceHandler) {
+	w.resTemplates = append(w.resTemplates, resourceTemplateEntry{template: t, handler: h})
+}
+
+func staleNames[T any](prev, next []T, nameFn func(T) string) []string {
+	keep := map[string]struct{}{}
+	for _, v := range next {
+		keep[nameFn(v)] = struct{}{}
+	}
+	var stale []string
+	for _, v := range prev {
+		if _, exists := keep[nameFn(v)]; !exists {
+			stale = append(stale, nameFn(v))
+		}
+	}
+	return stale
+}
+
+// RemoveStale removes any tools, prompts and resources that were added to the
+// MCP server by this wrapper and are not also provided by next, which is the
+// wrapper replacing it. Items provided by both have already been replaced in
+// the server by the time next has been built.
+func (w *ResourcesWrapper) RemoveStale(next *ResourcesWrapper) {
+	if names := staleNames(w.tools, next.tools, func(e toolEntry) string { return e.tool.Name }); len(names) > 0 {
+		w.svr.RemoveTools(names...)
+	}
+	if names := staleNames(w.prompts, next.prompts, func(e promptEntry) string { return e.prompt.Name }); len(names) > 0 {
+		w.svr.RemovePrompts(names...)
+	}
+	if uris := staleNames(w.mcpResources, next.mcpResources, func(e resourceEntry) string { return e.resource.URI }); len(uris) > 0 {
+		w.svr.RemoveResources(uris...)
+	}
+	if tmpls := staleNames(w.resTemplates, next.resTemplates, func(e resourceTemplateEntry) string { return e.template.URITemplate }); len(tmpls) > 0 {
+		w.svr.RemoveResourceTemplates(tmpls...)
+	}
+}
+
 // Close all underlying resources and their connections.
 func (w *ResourcesWrapper) Close(ctx context.Context) error {
 	closeFn := w.closeFn
@@ -125,9 +217,10 @@ func (p mcpProperty) toSchemaProperty() map[string]any {
 }
 
 type mcpConfig struct {
-	Enabled     bool          `yaml:"enabled"`
-	Description string        `yaml:"description"`
-	Properties  []mcpProperty `yaml:"properties"`
+	Enabled     bool              `yaml:"enabled"`
+	Description string            `yaml:"description"`
+	Properties  []mcpProperty     `yaml:"properties"`
+	Resource    mcpResourceConfig `yaml:"resource"`
 }
 
 type meta struct {
@@ -182,13 +275,17 @@ func (w *ResourcesWrapper) AddCacheYAML(fileBytes []byte) error {
 		return err
 	}
 
+	if res.Meta.MCP.Resource.Enabled {
+		w.addCacheResource(res)
+	}
+
 	if !res.Meta.MCP.Enabled {
 		return nil
 	}
 
 	w.logger.With("label", res.Label).Info("Registering cache tools")
 
-	w.svr.AddTool(&mcp.Tool{
+	w.addTool(&mcp.Tool{
 		Name:        "get-" + res.Label,
 		Description: "Obtain an item from " + res.Meta.MCP.Description,
 		InputSchema: map[string]any{
@@ -246,7 +343,7 @@ func (w *ResourcesWrapper) AddCacheYAML(fileBytes []byte) error {
 		}, nil
 	})
 
-	w.svr.AddTool(&mcp.Tool{
+	w.addTool(&mcp.Tool{
 		Name:        "set-" + res.Label,
 		Description: "Set an item within " + res.Meta.MCP.Description,
 		InputSchema: map[string]any{
@@ -305,6 +402,12 @@ func (w *ResourcesWrapper) AddCacheYAML(fileBytes []byte) error {
 			return nil, setErr
 		}
 
+		if res.Meta.MCP.Resource.Enabled {
+			_ = w.svr.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{
+				URI: cacheResourceURI(res.Label, key),
+			})
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
@@ -336,13 +439,17 @@ func (w *ResourcesWrapper) AddInputYAML(fileBytes []byte) error {
 		return err
 	}
 
+	if res.Meta.MCP.Resource.Enabled {
+		w.addInputResource(res)
+	}
+
 	if !res.Meta.MCP.Enabled {
 		return nil
 	}
 
 	w.logger.With("label", res.Label).Info("Registering input tool")
 
-	w.svr.AddTool(&mcp.Tool{
+	w.addTool(&mcp.Tool{
 		Name:        res.Label,
 		Description: res.Meta.MCP.Description,
 		InputSchema: map[string]any{
@@ -470,7 +577,7 @@ func (w *ResourcesWrapper) AddProcessorYAML(fileBytes []byte) error {
 		inputSchema["required"] = required
 	}
 
-	w.svr.AddTool(&mcp.Tool{
+	w.addTool(&mcp.Tool{
 		Name:        res.Label,
 		Description: res.Meta.MCP.Description,
 		InputSchema: inputSchema,
@@ -599,7 +706,7 @@ func (w *ResourcesWrapper) AddOutputYAML(fileBytes []byte) error {
 		requiredProperties = append(requiredProperties, "value")
 	}
 
-	w.svr.AddTool(&mcp.Tool{
+	w.addTool(&mcp.Tool{
 		Name:        res.Label,
 		Description: res.Meta.MCP.Description,
 		InputSchema: map[string]any{
@@ -296,3 +296,213 @@ meta:
 
 	defer r.Close(ctx)
 }
+
+func TestResourcesWrappersPrompts(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{
+		Name:    "Testing",
+		Version: "1.0.0",
+	}, nil)
+
+	r := tools.NewResourcesWrapper(slog.New(discardHandler{}), s, nil, nil)
+
+	require.NoError(t, r.AddPromptYAML([]byte(`
+name: summarise
+description: Summarise a topic
+arguments:
+  - name: topic
+    type: string
+    required: true
+  - name: words
+    type: number
+mapping: |
+  root = [
+    { "role": "user", "content": "Summarise %v in %v words.".format(this.topic, this.words.or(50)) },
+    { "role": "assistant", "content": "Sure thing." },
+  ]
+`)))
+
+	require.NoError(t, r.AddPromptYAML([]byte(`
+name: greet
+description: Greet someone
+arguments:
+  - name: name
+    required: true
+template: 'Say hello to ${! this.name }'
+`)))
+
+	require.Error(t, r.AddPromptYAML([]byte(`
+name: broken
+arguments:
+  - name: nope
+    type: duration
+template: 'nope'
+`)))
+
+	_, err := r.Build()
+	require.NoError(t, err)
+
+	ctx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	// Use in-memory transport to test
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	// Start server in background
+	go func() {
+		_ = s.Run(ctx, serverTransport)
+	}()
+
+	// Connect client
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	// List prompts
+	result, err := session.ListPrompts(ctx, &mcp.ListPromptsParams{})
+	require.NoError(t, err)
+	require.Len(t, result.Prompts, 2)
+	assert.Equal(t, "greet", result.Prompts[0].Name)
+	assert.Equal(t, "summarise", result.Prompts[1].Name)
+	require.Len(t, result.Prompts[1].Arguments, 2)
+	assert.True(t, result.Prompts[1].Arguments[0].Required)
+
+	summary, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "summarise",
+		Arguments: map[string]string{"topic": "cats", "words": "10"},
+	})
+	require.NoError(t, err)
+	require.Len(t, summary.Messages, 2)
+	assert.Equal(t, mcp.Role("user"), summary.Messages[0].Role)
+	assert.Equal(t, "Summarise cats in 10 words.", summary.Messages[0].Content.(*mcp.TextContent).Text)
+	assert.Equal(t, mcp.Role("assistant"), summary.Messages[1].Role)
+
+	_, err = session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "summarise",
+		Arguments: map[string]string{"topic": "cats", "words": "lots"},
+	})
+	require.Error(t, err)
+
+	greeting, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "greet",
+		Arguments: map[string]string{"name": "Ash"},
+	})
+	require.NoError(t, err)
+	require.Len(t, greeting.Messages, 1)
+	assert.Equal(t, "Say hello to Ash", greeting.Messages[0].Content.(*mcp.TextContent).Text)
+
+	defer r.Close(ctx)
+}
+
+func TestResourcesWrappersCacheResource(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{
+		Name:    "Testing",
+		Version: "1.0.0",
+	}, nil)
+
+	r := tools.NewResourcesWrapper(slog.New(discardHandler{}), s, nil, nil)
+
+	require.NoError(t, r.AddCacheYAML([]byte(`
+label: foocache
+memory:
+  init_values:
+    greeting: hello world
+meta:
+  mcp:
+    description: my foo cache
+    resource:
+      enabled: true
+`)))
+
+	_, err := r.Build()
+	require.NoError(t, err)
+
+	ctx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	// Use in-memory transport to test
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	// Start server in background
+	go func() {
+		_ = s.Run(ctx, serverTransport)
+	}()
+
+	// Connect client
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	templates, err := session.ListResourceTemplates(ctx, &mcp.ListResourceTemplatesParams{})
+	require.NoError(t, err)
+	require.Len(t, templates.ResourceTemplates, 1)
+	assert.Equal(t, "cache://foocache/{key}", templates.ResourceTemplates[0].URITemplate)
+	assert.Equal(t, "my foo cache", templates.ResourceTemplates[0].Description)
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "cache://foocache/greeting"})
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	assert.Equal(t, "hello world", read.Contents[0].Text)
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "cache://foocache/nope"})
+	require.Error(t, err)
+
+	defer r.Close(ctx)
+}
+
+func TestResourcesWrappersInputResource(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{
+		Name:    "Testing",
+		Version: "1.0.0",
+	}, nil)
+
+	r := tools.NewResourcesWrapper(slog.New(discardHandler{}), s, nil, nil)
+
+	require.NoError(t, r.AddInputYAML([]byte(`
+label: fooinput
+generate:
+  count: 3
+  interval: ""
+  mapping: 'root = "msg " + count("fooinput").string()'
+meta:
+  mcp:
+    resource:
+      enabled: true
+      buffer_size: 2
+`)))
+
+	_, err := r.Build()
+	require.NoError(t, err)
+
+	ctx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = s.Run(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	readTexts := func() []string {
+		read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "input://fooinput"})
+		require.NoError(t, err)
+		var texts []string
+		for _, c := range read.Contents {
+			texts = append(texts, c.Text)
+		}
+		return texts
+	}
+
+	// Messages are only consumed when the resource is read, up to the buffer
+	// size at a time.
+	assert.Equal(t, []string{"msg 1", "msg 2"}, readTexts())
+	assert.Equal(t, []string{"msg 2", "msg 3"}, readTexts())
+	assert.Equal(t, []string{"msg 2", "msg 3"}, readTexts())
+
+	defer r.Close(ctx)
+}
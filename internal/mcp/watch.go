@@ -0,0 +1,127 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var (
+	reloadDebounce     = 250 * time.Millisecond
+	reloadCloseTimeout = 30 * time.Second
+)
+
+// Reload scans the repository directory again and replaces the tools, prompts
+// and resources of the server with the new set. Connected clients are sent
+// list changed notifications for anything that was added, replaced or
+// removed.
+//
+// The new resources are built before the previous resources are closed, and
+// if the repository fails to load then the previous resources remain active.
+func (m *Server) Reload(ctx context.Context) error {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	next, resources, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	prev := m.wrapper
+	m.wrapper = next
+	m.resources = resources
+	m.mu.Unlock()
+
+	prev.RemoveStale(next)
+
+	ctx, done := context.WithTimeout(ctx, reloadCloseTimeout)
+	defer done()
+	if err := prev.Close(ctx); err != nil {
+		return fmt.Errorf("failed to close previous resources: %w", err)
+	}
+	return nil
+}
+
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
+
+// WatchRepository watches the repository directory for changes and reloads
+// the server each time a change is detected. Blocks until the context is
+// cancelled.
+func (m *Server) WatchRepository(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, m.repositoryDir); err != nil {
+		return fmt.Errorf("failed to watch repository: %w", err)
+	}
+
+	// Editors tend to emit several events per save, so changes are debounced
+	// into a single reload.
+	debounce := time.NewTimer(0)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+
+	for {
+		select {
+		case event, open := <-watcher.Events:
+			if !open {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			debounce.Reset(reloadDebounce)
+		case err, open := <-watcher.Errors:
+			if !open {
+				return nil
+			}
+			m.logger.With("error", err).Warn("Repository watcher error")
+		case <-debounce.C:
+			// New directories need to be watched as well, adding a directory
+			// that is already watched is a no-op.
+			if err := addWatchDirs(watcher, m.repositoryDir); err != nil {
+				m.logger.With("error", err).Warn("Failed to watch repository")
+			}
+			if err := m.Reload(ctx); err != nil {
+				m.logger.With("error", err).Error("Failed to reload repository, keeping the previous tools")
+				continue
+			}
+			m.logger.Info("Reloaded repository")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
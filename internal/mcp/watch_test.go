@@ -0,0 +1,135 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcp_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/connect/v4/internal/license"
+	mcpinternal "github.com/redpanda-data/connect/v4/internal/mcp"
+)
+
+func writeProcessorTool(t *testing.T, dir, label string) {
+	t.Helper()
+
+	procsDir := filepath.Join(dir, "resources", "processors")
+	require.NoError(t, os.MkdirAll(procsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(procsDir, label+".yaml"), []byte(`
+label: `+label+`
+mapping: 'root = content().uppercase()'
+meta:
+  mcp:
+    enabled: true
+    description: The `+label+` tool
+`), 0o644))
+}
+
+func toolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestServerReload(t *testing.T) {
+	repoDir := t.TempDir()
+	writeProcessorTool(t, repoDir, "foo")
+	writeProcessorTool(t, repoDir, "bar")
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	envVarFunc := func(_ context.Context, key string) (string, bool) {
+		return os.LookupEnv(key)
+	}
+
+	server, err := mcpinternal.NewServer(repoDir, logger, envVarFunc, nil, nil, license.Config{}, nil)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	go func() {
+		_ = server.ServeHTTP(ctx, listener)
+	}()
+
+	var listChanged atomic.Int64
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			listChanged.Add(1)
+		},
+	})
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{
+		Endpoint: "http://" + listener.Addr().String() + "/mcp",
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.ElementsMatch(t, []string{"foo", "bar"}, toolNames(t, session))
+
+	// Replace foo with baz and reload explicitly.
+	require.NoError(t, os.Remove(filepath.Join(repoDir, "resources", "processors", "foo.yaml")))
+	writeProcessorTool(t, repoDir, "baz")
+	require.NoError(t, server.Reload(ctx))
+
+	assert.ElementsMatch(t, []string{"bar", "baz"}, toolNames(t, session))
+	assert.Eventually(t, func() bool {
+		return listChanged.Load() > 0
+	}, time.Second*5, time.Millisecond*50)
+
+	// A broken repository keeps the previous tools.
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "resources", "processors", "broken.yaml"), []byte(`
+label: broken
+not_a_processor: {}
+`), 0o644))
+	require.Error(t, server.Reload(ctx))
+	assert.ElementsMatch(t, []string{"bar", "baz"}, toolNames(t, session))
+	require.NoError(t, os.Remove(filepath.Join(repoDir, "resources", "processors", "broken.yaml")))
+
+	// Changes are picked up automatically while watching. The watcher starts
+	// asynchronously and so the new tool is written again on each poll, which
+	// is less frequent than reloads are debounced, until it is picked up.
+	go func() {
+		_ = server.WatchRepository(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		if len(toolNames(t, session)) == 3 {
+			return true
+		}
+		writeProcessorTool(t, repoDir, "buz")
+		return false
+	}, time.Second*10, time.Millisecond*500)
+	assert.ElementsMatch(t, []string{"bar", "baz", "buz"}, toolNames(t, session))
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb"
+	"github.com/redpanda-data/connect/v4/internal/rpcplugin/subprocess"
+)
+
+// CacheConfig is the configuration for a plugin cache.
+type CacheConfig struct {
+	// The name of the plugin
+	Name string
+	// The command to run the plugin process
+	Cmd []string
+	// The environment variables to set for the plugin process
+	//
+	// This does NOT inherit from the current process
+	Env map[string]string
+	// Directory for the process
+	Cwd string
+	// The configuration spec for the plugin
+	Spec *service.ConfigSpec
+}
+
+type cache struct {
+	cfgValue any
+	proc     *subprocess.Subprocess
+	client   runtimepb.CacheServiceClient
+}
+
+var _ service.Cache = (*cache)(nil)
+
+// RegisterCachePlugin creates a new cache plugin from the configuration.
+func RegisterCachePlugin(env *service.Environment, spec CacheConfig) error {
+	if len(spec.Cmd) == 0 {
+		return errors.New("plugin command is required")
+	}
+	ctor := func(parsed *service.ParsedConfig, res *service.Resources) (service.Cache, error) {
+		cfgValue, err := parsed.FieldAny()
+		if err != nil {
+			return nil, err
+		}
+		if spec.Env == nil {
+			spec.Env = make(map[string]string)
+		}
+		socketPath, err := newUnixSocketAddr()
+		if err != nil {
+			return nil, err
+		}
+		var cleanup []func() error
+		defer func() {
+			for _, fn := range cleanup {
+				err := fn()
+				if err != nil {
+					res.Logger().Warnf("failed to clean up creating %s: %v", spec.Name, err)
+				}
+			}
+		}()
+		// No I/O happens in NewClient, so we can do this before we start the subprocess.
+		// This simplifies the cleanup if there is a failure.
+		conn, err := grpc.NewClient(
+			socketPath,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		cleanup = append(cleanup, conn.Close)
+		spec.Env["REDPANDA_CONNECT_PLUGIN_ADDRESS"] = socketPath
+		proc, err := subprocess.New(
+			spec.Cmd,
+			spec.Env,
+			subprocess.WithLogger(res.Logger()),
+			subprocess.WithCwd(spec.Cwd),
+		)
+		if err != nil {
+			err = fmt.Errorf("invalid subprocess: %w", err)
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), maxStartupTime)
+		defer cancel()
+		client := runtimepb.NewCacheServiceClient(conn)
+		err = startCachePlugin(ctx, proc, client, cfgValue)
+		if err != nil {
+			return nil, fmt.Errorf("unable to restart plugin: %w", err)
+		}
+		c := &cache{
+			cfgValue: cfgValue,
+			proc:     proc,
+			client:   client,
+		}
+		cleanup = nil // Prevent cleanup from running.
+		return c, nil
+	}
+	return env.RegisterCache(spec.Name, spec.Spec, ctor)
+}
+
+func startCachePlugin(
+	ctx context.Context,
+	proc *subprocess.Subprocess,
+	client runtimepb.CacheServiceClient,
+	cfgValue any,
+) (err error) {
+	if err := proc.Start(); err != nil {
+		if errors.Is(err, subprocess.ErrProcessAlreadyStarted) {
+			return nil
+		}
+		return fmt.Errorf("unable to restart plugin: %w", err)
+	}
+	value, err := runtimepb.AnyToProto(cfgValue)
+	if err != nil {
+		_ = proc.Close(ctx)
+		return fmt.Errorf("unable to convert config to proto: %w", err)
+	}
+	// Retry to wait for the process to start
+	err = backoff.Retry(func() error {
+		resp, err := client.Init(ctx, &runtimepb.CacheInitRequest{
+			Config: value,
+		})
+		if err != nil {
+			if !proc.IsRunning() {
+				return backoff.Permanent(fmt.Errorf("plugin exited early: %w", err))
+			}
+			return err
+		}
+		if err := runtimepb.ProtoToError(resp.Error); err != nil {
+			return backoff.Permanent(err)
+		}
+		return nil
+	}, backoff.NewExponentialBackOff(exponentialBackoffOpts()...))
+	if err != nil {
+		_ = proc.Close(ctx)
+		return fmt.Errorf("unable to initialize plugin: %w", err)
+	}
+	return nil
+}
+
+// withRestart calls fn, and if the call fails because the plugin process has
+// crashed then it attempts to restart the process up to retryCount times.
+func (c *cache) withRestart(ctx context.Context, fn func() error) (err error) {
+	for range retryCount {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if c.proc.IsRunning() {
+			return fmt.Errorf("unable to reach plugin: %w", err)
+		}
+		// Otherwise we assume the process might have crashed, so attempt to restart it
+		if err := startCachePlugin(ctx, c.proc, c.client, c.cfgValue); err != nil {
+			return fmt.Errorf("unable to restart plugin: %w", err)
+		}
+	}
+	return fmt.Errorf("unable to reach plugin: %w", err)
+}
+
+func ttlToProto(ttl *time.Duration) *durationpb.Duration {
+	if ttl == nil {
+		return nil
+	}
+	return durationpb.New(*ttl)
+}
+
+// Get implements service.Cache.
+func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
+	var resp *runtimepb.CacheGetResponse
+	err := c.withRestart(ctx, func() (err error) {
+		resp, err = c.client.Get(ctx, &runtimepb.CacheGetRequest{Key: key})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := runtimepb.ProtoToError(resp.Error); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// Set implements service.Cache.
+func (c *cache) Set(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
+	var resp *runtimepb.CacheSetResponse
+	err := c.withRestart(ctx, func() (err error) {
+		resp, err = c.client.Set(ctx, &runtimepb.CacheSetRequest{
+			Key:   key,
+			Value: value,
+			Ttl:   ttlToProto(ttl),
+		})
+		return
+	})
+	if err != nil {
+		return err
+	}
+	return runtimepb.ProtoToError(resp.Error)
+}
+
+// Add implements service.Cache.
+func (c *cache) Add(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
+	var resp *runtimepb.CacheAddResponse
+	err := c.withRestart(ctx, func() (err error) {
+		resp, err = c.client.Add(ctx, &runtimepb.CacheAddRequest{
+			Key:   key,
+			Value: value,
+			Ttl:   ttlToProto(ttl),
+		})
+		return
+	})
+	if err != nil {
+		return err
+	}
+	return runtimepb.ProtoToError(resp.Error)
+}
+
+// Delete implements service.Cache.
+func (c *cache) Delete(ctx context.Context, key string) error {
+	var resp *runtimepb.CacheDeleteResponse
+	err := c.withRestart(ctx, func() (err error) {
+		resp, err = c.client.Delete(ctx, &runtimepb.CacheDeleteRequest{Key: key})
+		return
+	})
+	if err != nil {
+		return err
+	}
+	return runtimepb.ProtoToError(resp.Error)
+}
+
+// Close implements service.Cache.
+func (c *cache) Close(ctx context.Context) error {
+	resp, err := c.client.Close(ctx, &runtimepb.CacheCloseRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to close plugin: %w", err)
+	}
+	if err := runtimepb.ProtoToError(resp.Error); err != nil {
+		return fmt.Errorf("plugin close error: %w", err)
+	}
+	if err := c.proc.Close(ctx); err != nil {
+		return fmt.Errorf("unable to close plugin process: %w", err)
+	}
+	return nil
+}
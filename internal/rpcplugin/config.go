@@ -177,7 +177,7 @@ func (p ComponentType) Validate() error {
 		return errors.New("plugin type is required")
 	}
 	switch p {
-	case ComponentTypeInput, ComponentTypeProcessor, ComponentTypeOutput:
+	case ComponentTypeInput, ComponentTypeProcessor, ComponentTypeOutput, ComponentTypeCache, ComponentTypeRateLimit:
 		return nil
 	}
 	return fmt.Errorf("unexpected plugin type, valid options %v, got: %q", allComponentTypes, p)
@@ -188,9 +188,17 @@ const (
 	ComponentTypeInput     ComponentType = "input"
 	ComponentTypeProcessor ComponentType = "processor"
 	ComponentTypeOutput    ComponentType = "output"
+	ComponentTypeCache     ComponentType = "cache"
+	ComponentTypeRateLimit ComponentType = "rate_limit"
 )
 
-var allComponentTypes = []ComponentType{ComponentTypeInput, ComponentTypeProcessor, ComponentTypeOutput}
+var allComponentTypes = []ComponentType{
+	ComponentTypeInput,
+	ComponentTypeProcessor,
+	ComponentTypeOutput,
+	ComponentTypeCache,
+	ComponentTypeRateLimit,
+}
 
 // Config describes a dynamic plugin over gRPC.
 type Config struct {
@@ -314,6 +322,22 @@ func registerPlugin(env *service.Environment, cfg *Config) error {
 			Spec: spec,
 			Cwd:  cfg.Cwd,
 		})
+	case ComponentTypeCache:
+		return RegisterCachePlugin(env, CacheConfig{
+			Name: cfg.Name,
+			Cmd:  cfg.Cmd,
+			Env:  environMap(),
+			Spec: spec,
+			Cwd:  cfg.Cwd,
+		})
+	case ComponentTypeRateLimit:
+		return RegisterRateLimitPlugin(env, RateLimitConfig{
+			Name: cfg.Name,
+			Cmd:  cfg.Cmd,
+			Env:  environMap(),
+			Spec: spec,
+			Cwd:  cfg.Cwd,
+		})
 	default:
 		// Validated above
 		panic("unreachable")
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/connect/v4/public/plugin/go/rpcn"
+)
+
+type config struct{}
+
+func main() {
+	rpcn.CacheMain(func(cfg config) (service.Cache, error) {
+		return &myCache{cfg: cfg, items: map[string][]byte{}}, nil
+	})
+}
+
+type myCache struct {
+	cfg   config
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+var _ service.Cache = (*myCache)(nil)
+
+// Get implements service.Cache.
+func (c *myCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[key]
+	if !ok {
+		return nil, service.ErrKeyNotFound
+	}
+	return v, nil
+}
+
+// Set implements service.Cache.
+func (c *myCache) Set(_ context.Context, key string, value []byte, _ *time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	return nil
+}
+
+// Add implements service.Cache.
+func (c *myCache) Add(_ context.Context, key string, value []byte, _ *time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; ok {
+		return service.ErrKeyAlreadyExists
+	}
+	c.items[key] = value
+	return nil
+}
+
+// Delete implements service.Cache.
+func (c *myCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	return nil
+}
+
+// Close implements service.Cache.
+func (*myCache) Close(context.Context) error {
+	return nil
+}
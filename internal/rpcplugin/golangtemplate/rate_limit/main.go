@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/connect/v4/public/plugin/go/rpcn"
+)
+
+type config struct{}
+
+func main() {
+	rpcn.RateLimitMain(func(cfg config) (service.RateLimit, error) {
+		return &myRateLimit{cfg: cfg, interval: time.Second}, nil
+	})
+}
+
+type myRateLimit struct {
+	cfg      config
+	interval time.Duration
+
+	mu         sync.Mutex
+	lastAccess time.Time
+}
+
+var _ service.RateLimit = (*myRateLimit)(nil)
+
+// Access implements service.RateLimit.
+func (r *myRateLimit) Access(context.Context) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if remaining := r.interval - time.Since(r.lastAccess); remaining > 0 {
+		return remaining, nil
+	}
+	r.lastAccess = time.Now()
+	return 0, nil
+}
+
+// Close implements service.RateLimit.
+func (*myRateLimit) Close(context.Context) error {
+	return nil
+}
@@ -48,6 +48,12 @@ var golangOutputEmbeddedTemplate embed.FS
 //go:embed golangtemplate/processor
 var golangProcessorEmbeddedTemplate embed.FS
 
+//go:embed golangtemplate/cache
+var golangCacheEmbeddedTemplate embed.FS
+
+//go:embed golangtemplate/rate_limit
+var golangRateLimitEmbeddedTemplate embed.FS
+
 //go:embed pythontemplate/input
 var pythonInputEmbeddedTemplate embed.FS
 
@@ -57,6 +63,12 @@ var pythonOutputEmbeddedTemplate embed.FS
 //go:embed pythontemplate/processor
 var pythonProcessorEmbeddedTemplate embed.FS
 
+//go:embed pythontemplate/cache
+var pythonCacheEmbeddedTemplate embed.FS
+
+//go:embed pythontemplate/rate_limit
+var pythonRateLimitEmbeddedTemplate embed.FS
+
 // InitializeProject initializes a new plugin project in the specified directory.
 func InitializeProject(lang PluginLanguage, compType ComponentType, directory string) error {
 	abs, err := filepath.Abs(directory)
@@ -77,6 +89,10 @@ func InitializeProject(lang PluginLanguage, compType ComponentType, directory st
 			fs = golangOutputEmbeddedTemplate
 		case ComponentTypeProcessor:
 			fs = golangProcessorEmbeddedTemplate
+		case ComponentTypeCache:
+			fs = golangCacheEmbeddedTemplate
+		case ComponentTypeRateLimit:
+			fs = golangRateLimitEmbeddedTemplate
 		}
 	case PluginLanguagePython:
 		switch compType {
@@ -86,6 +102,10 @@ func InitializeProject(lang PluginLanguage, compType ComponentType, directory st
 			fs = pythonOutputEmbeddedTemplate
 		case ComponentTypeProcessor:
 			fs = pythonProcessorEmbeddedTemplate
+		case ComponentTypeCache:
+			fs = pythonCacheEmbeddedTemplate
+		case ComponentTypeRateLimit:
+			fs = pythonRateLimitEmbeddedTemplate
 		}
 	}
 	if fs == nil {
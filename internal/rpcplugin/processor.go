@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/cenkalti/backoff/v4"
 	"google.golang.org/grpc"
@@ -48,6 +49,13 @@ type processor struct {
 	cfgValue any
 	proc     *subprocess.Subprocess
 	client   runtimepb.BatchProcessorServiceClient
+
+	// Only set when the plugin supports ProcessBatchStream, limits the number
+	// of batches in flight on the stream.
+	inFlight chan struct{}
+
+	streamMu sync.Mutex
+	stream   *processorStream
 }
 
 var _ service.BatchProcessor = (*processor)(nil)
@@ -102,7 +110,7 @@ func RegisterProcessorPlugin(env *service.Environment, spec ProcessorConfig) err
 		ctx, cancel := context.WithTimeout(context.Background(), maxStartupTime)
 		defer cancel()
 		client := runtimepb.NewBatchProcessorServiceClient(conn)
-		err = startProcessorPlugin(ctx, proc, client, cfgValue)
+		caps, err := startProcessorPlugin(ctx, proc, client, cfgValue)
 		if err != nil {
 			return nil, fmt.Errorf("unable to restart plugin: %w", err)
 		}
@@ -111,6 +119,13 @@ func RegisterProcessorPlugin(env *service.Environment, spec ProcessorConfig) err
 			proc:     proc,
 			client:   client,
 		}
+		if caps.GetStreaming() {
+			maxInFlight := int(caps.GetMaxInFlight())
+			if maxInFlight <= 0 {
+				maxInFlight = defaultMaxInFlight
+			}
+			p.inFlight = make(chan struct{}, maxInFlight)
+		}
 		cleanup = nil // Prevent cleanup from running.
 		return p, nil
 	}
@@ -122,17 +137,17 @@ func startProcessorPlugin(
 	proc *subprocess.Subprocess,
 	client runtimepb.BatchProcessorServiceClient,
 	cfgValue any,
-) (err error) {
+) (caps *runtimepb.BatchProcessorCapabilities, err error) {
 	if err := proc.Start(); err != nil {
 		if errors.Is(err, subprocess.ErrProcessAlreadyStarted) {
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("unable to restart plugin: %w", err)
+		return nil, fmt.Errorf("unable to restart plugin: %w", err)
 	}
 	value, err := runtimepb.AnyToProto(cfgValue)
 	if err != nil {
 		_ = proc.Close(ctx)
-		return fmt.Errorf("unable to convert config to proto: %w", err)
+		return nil, fmt.Errorf("unable to convert config to proto: %w", err)
 	}
 	// Retry to wait for the process to start
 	err = backoff.Retry(func() error {
@@ -145,13 +160,14 @@ func startProcessorPlugin(
 			}
 			return err
 		}
+		caps = resp.Capabilities
 		return runtimepb.ProtoToError(resp.Error)
 	}, backoff.NewExponentialBackOff(exponentialBackoffOpts()...))
 	if err != nil {
 		_ = proc.Close(ctx)
-		return fmt.Errorf("unable to initialize plugin: %w", err)
+		return nil, fmt.Errorf("unable to initialize plugin: %w", err)
 	}
-	return nil
+	return caps, nil
 }
 
 // ProcessBatch implements service.BatchProcessor.
@@ -160,6 +176,13 @@ func (p *processor) ProcessBatch(ctx context.Context, batch service.MessageBatch
 	if err != nil {
 		return nil, fmt.Errorf("unable to convert batch to proto: %w", err)
 	}
+	if p.inFlight != nil {
+		resp, err := p.processStream(ctx, proto)
+		if err != nil {
+			return nil, err
+		}
+		return protoToBatches(resp.Batches, resp.Error)
+	}
 	var resp *runtimepb.BatchProcessorProcessBatchResponse
 	// If the plugin crashes attempt to restart the process up to retryCount times.
 	for range retryCount {
@@ -171,7 +194,7 @@ func (p *processor) ProcessBatch(ctx context.Context, batch service.MessageBatch
 				return nil, fmt.Errorf("unable to read from plugin: %w", err)
 			}
 			// Otherwise we assume the process might have crashed, so attempt to restart it
-			err = startProcessorPlugin(ctx, p.proc, p.client, p.cfgValue)
+			_, err = startProcessorPlugin(ctx, p.proc, p.client, p.cfgValue)
 			if err != nil {
 				return nil, fmt.Errorf("unable to restart plugin: %w", err)
 			}
@@ -179,11 +202,69 @@ func (p *processor) ProcessBatch(ctx context.Context, batch service.MessageBatch
 		}
 		break
 	}
-	if err := runtimepb.ProtoToError(resp.Error); err != nil {
+	return protoToBatches(resp.Batches, resp.Error)
+}
+
+// processStream sends a batch over the shared ProcessBatchStream call, which
+// allows multiple batches to be in flight with the plugin at once.
+func (p *processor) processStream(ctx context.Context, proto *runtimepb.MessageBatch) (*runtimepb.BatchProcessorProcessBatchStreamResponse, error) {
+	select {
+	case p.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.inFlight }()
+
+	var err error
+	// If the plugin crashes attempt to restart the process up to retryCount times.
+	for range retryCount {
+		var stream *processorStream
+		if stream, err = p.getStream(); err == nil {
+			var resp *runtimepb.BatchProcessorProcessBatchStreamResponse
+			if resp, err = stream.process(ctx, proto); err == nil {
+				return resp, nil
+			}
+			if ctx.Err() != nil {
+				return nil, err
+			}
+		}
+		if p.proc.IsRunning() {
+			return nil, fmt.Errorf("unable to read from plugin: %w", err)
+		}
+		// Otherwise we assume the process might have crashed, so attempt to restart it
+		if _, err = startProcessorPlugin(ctx, p.proc, p.client, p.cfgValue); err != nil {
+			return nil, fmt.Errorf("unable to restart plugin: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("unable to read from plugin: %w", err)
+}
+
+// getStream returns the current processing stream, opening a new one if the
+// previous stream has failed.
+func (p *processor) getStream() (*processorStream, error) {
+	p.streamMu.Lock()
+	defer p.streamMu.Unlock()
+	if p.stream != nil {
+		if p.stream.failed() == nil {
+			return p.stream, nil
+		}
+		p.stream.cancel()
+		p.stream = nil
+	}
+	stream, err := openProcessorStream(p.client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open processing stream: %w", err)
+	}
+	p.stream = stream
+	return stream, nil
+}
+
+func protoToBatches(protos []*runtimepb.MessageBatch, protoErr *runtimepb.Error) ([]service.MessageBatch, error) {
+	if err := runtimepb.ProtoToError(protoErr); err != nil {
 		return nil, err
 	}
-	batches := make([]service.MessageBatch, 0, len(resp.Batches))
-	for _, proto := range resp.Batches {
+	batches := make([]service.MessageBatch, 0, len(protos))
+	for _, proto := range protos {
 		batch, err := runtimepb.ProtoToMessageBatch(proto)
 		if err != nil {
 			return nil, fmt.Errorf("unable to convert batch from proto: %w", err)
@@ -195,6 +276,12 @@ func (p *processor) ProcessBatch(ctx context.Context, batch service.MessageBatch
 
 // Close implements service.BatchProcessor.
 func (p *processor) Close(ctx context.Context) error {
+	p.streamMu.Lock()
+	if p.stream != nil {
+		p.stream.close(ctx)
+		p.stream = nil
+	}
+	p.streamMu.Unlock()
 	resp, err := p.client.Close(ctx, &runtimepb.BatchProcessorCloseRequest{})
 	if err != nil {
 		return fmt.Errorf("unable to close plugin: %w", err)
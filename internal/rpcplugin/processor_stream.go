@@ -0,0 +1,169 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcplugin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb"
+)
+
+// defaultMaxInFlight is the number of batches the host keeps in flight on a
+// processor stream when the plugin does not specify a limit.
+const defaultMaxInFlight = 64
+
+var errStreamClosed = errors.New("plugin closed the processing stream")
+
+type processBatchStream = grpc.BidiStreamingClient[
+	runtimepb.BatchProcessorProcessBatchStreamRequest,
+	runtimepb.BatchProcessorProcessBatchStreamResponse,
+]
+
+// processorStream multiplexes concurrent calls to process a batch over a
+// single ProcessBatchStream call, correlating responses to requests by ID.
+type processorStream struct {
+	stream processBatchStream
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan *runtimepb.BatchProcessorProcessBatchStreamResponse
+	err     error
+}
+
+func openProcessorStream(client runtimepb.BatchProcessorServiceClient) (*processorStream, error) {
+	// The stream outlives any single call, so it gets its own context which is
+	// cancelled when the stream is closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.ProcessBatchStream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &processorStream{
+		stream:  stream,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		pending: map[uint64]chan *runtimepb.BatchProcessorProcessBatchStreamResponse{},
+	}
+	go s.recvLoop()
+	return s, nil
+}
+
+func (s *processorStream) recvLoop() {
+	defer close(s.done)
+	for {
+		resp, err := s.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errStreamClosed
+			}
+			s.mu.Lock()
+			s.err = err
+			for id, ch := range s.pending {
+				close(ch)
+				delete(s.pending, id)
+			}
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Lock()
+		ch, exists := s.pending[resp.GetId()]
+		delete(s.pending, resp.GetId())
+		s.mu.Unlock()
+		if exists {
+			ch <- resp
+		}
+	}
+}
+
+// failed returns the error that terminated the stream, or nil if the stream
+// is still healthy.
+func (s *processorStream) failed() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *processorStream) forget(id uint64) {
+	s.mu.Lock()
+	delete(s.pending, id)
+	s.mu.Unlock()
+}
+
+// process sends a batch to the plugin and blocks until either the
+// corresponding response arrives, the stream fails or the context is done.
+func (s *processorStream) process(ctx context.Context, batch *runtimepb.MessageBatch) (*runtimepb.BatchProcessorProcessBatchStreamResponse, error) {
+	ch := make(chan *runtimepb.BatchProcessorProcessBatchStreamResponse, 1)
+	s.mu.Lock()
+	if s.err != nil {
+		err := s.err
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.nextID++
+	id := s.nextID
+	s.pending[id] = ch
+	s.mu.Unlock()
+
+	s.sendMu.Lock()
+	err := s.stream.Send(&runtimepb.BatchProcessorProcessBatchStreamRequest{
+		Id:    id,
+		Batch: batch,
+	})
+	s.sendMu.Unlock()
+	if err != nil {
+		s.forget(id)
+		if errors.Is(err, io.EOF) {
+			// The real error is surfaced by Recv.
+			<-s.done
+			return nil, s.failed()
+		}
+		return nil, err
+	}
+
+	select {
+	case resp, open := <-ch:
+		if !open {
+			return nil, s.failed()
+		}
+		return resp, nil
+	case <-ctx.Done():
+		// The plugin may still respond, in which case the response is dropped.
+		s.forget(id)
+		return nil, ctx.Err()
+	}
+}
+
+// close half-closes the stream, giving the plugin an opportunity to flush any
+// outstanding responses before the stream is torn down.
+func (s *processorStream) close(ctx context.Context) {
+	s.sendMu.Lock()
+	_ = s.stream.CloseSend()
+	s.sendMu.Unlock()
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	}
+	s.cancel()
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcplugin
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb"
+)
+
+// reversingProcessor buffers a fixed number of stream requests and then
+// responds to them in reverse order, tagging each output with its request ID.
+type reversingProcessor struct {
+	runtimepb.UnimplementedBatchProcessorServiceServer
+
+	count int
+}
+
+func (r *reversingProcessor) ProcessBatchStream(stream runtimepb.BatchProcessorService_ProcessBatchStreamServer) error {
+	var reqs []*runtimepb.BatchProcessorProcessBatchStreamRequest
+	for len(reqs) < r.count {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, req)
+	}
+	for i := len(reqs) - 1; i >= 0; i-- {
+		id := reqs[i].GetId()
+		if err := stream.Send(&runtimepb.BatchProcessorProcessBatchStreamResponse{
+			Id: id,
+			Batches: []*runtimepb.MessageBatch{{
+				Messages: []*runtimepb.Message{{
+					Payload: &runtimepb.Message_Bytes{Bytes: []byte(strconv.FormatUint(id, 10))},
+				}},
+			}},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestProcessorStreamOutOfOrderResponses(t *testing.T) {
+	const inFlight = 5
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	runtimepb.RegisterBatchProcessorServiceServer(srv, &reversingProcessor{count: inFlight})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	stream, err := openProcessorStream(runtimepb.NewBatchProcessorServiceClient(conn))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	ids := make([]string, inFlight)
+	for i := range inFlight {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := stream.process(t.Context(), &runtimepb.MessageBatch{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			ids[i] = string(resp.GetBatches()[0].GetMessages()[0].GetBytes())
+		}()
+	}
+	wg.Wait()
+
+	// Every caller must receive the response for its own request.
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+	assert.Len(t, seen, inFlight)
+
+	// Once the plugin ends the stream all future calls fail.
+	<-stream.done
+	_, err = stream.process(t.Context(), &runtimepb.MessageBatch{})
+	require.ErrorIs(t, err, errStreamClosed)
+	stream.close(t.Context())
+}
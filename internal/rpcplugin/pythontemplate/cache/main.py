@@ -0,0 +1,35 @@
+import asyncio
+import logging
+from datetime import timedelta
+from redpanda_connect import Cache, KeyAlreadyExistsError, KeyNotFoundError, Value, cache_main
+
+class MyCache(Cache):
+    def __init__(self):
+        self.items: dict[str, bytes] = {}
+
+    async def get(self, key: str) -> bytes:
+        if key not in self.items:
+            raise KeyNotFoundError()
+        return self.items[key]
+
+    async def set(self, key: str, value: bytes, ttl: timedelta | None) -> None:
+        self.items[key] = value
+
+    async def add(self, key: str, value: bytes, ttl: timedelta | None) -> None:
+        if key in self.items:
+            raise KeyAlreadyExistsError()
+        self.items[key] = value
+
+    async def delete(self, key: str) -> None:
+        self.items.pop(key, None)
+
+    async def close(self) -> None:
+        pass
+
+def my_cache(config: Value) -> Cache:
+    _ = config
+    return MyCache()
+
+if __name__ == "__main__":
+    logging.basicConfig(level=logging.INFO)
+    asyncio.run(cache_main(my_cache))
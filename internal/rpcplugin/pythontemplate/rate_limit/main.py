@@ -0,0 +1,28 @@
+import asyncio
+import logging
+import time
+from datetime import timedelta
+from redpanda_connect import RateLimit, Value, rate_limit_main
+
+class MyRateLimit(RateLimit):
+    def __init__(self, interval: timedelta):
+        self.interval = interval
+        self.last_access = 0.0
+
+    async def access(self) -> timedelta:
+        remaining = self.interval.total_seconds() - (time.monotonic() - self.last_access)
+        if remaining > 0:
+            return timedelta(seconds=remaining)
+        self.last_access = time.monotonic()
+        return timedelta()
+
+    async def close(self) -> None:
+        pass
+
+def my_rate_limit(config: Value) -> RateLimit:
+    _ = config
+    return MyRateLimit(timedelta(seconds=1))
+
+if __name__ == "__main__":
+    logging.basicConfig(level=logging.INFO)
+    asyncio.run(rate_limit_main(my_rate_limit))
@@ -0,0 +1,742 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: redpanda/runtime/v1alpha1/cache.proto
+
+package runtimepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CacheInitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The parsed configuration from the user based on the register schema in
+	// `plugin.yaml`.
+	Config        *Value `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheInitRequest) Reset() {
+	*x = CacheInitRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheInitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheInitRequest) ProtoMessage() {}
+
+func (x *CacheInitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheInitRequest.ProtoReflect.Descriptor instead.
+func (*CacheInitRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CacheInitRequest) GetConfig() *Value {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CacheInitResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the cache configuration is invalid and an error should be
+	// surfaced at pipeline construction time.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheInitResponse) Reset() {
+	*x = CacheInitResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheInitResponse) ProtoMessage() {}
+
+func (x *CacheInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheInitResponse.ProtoReflect.Descriptor instead.
+func (*CacheInitResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CacheInitResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CacheGetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key of the item to fetch.
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheGetRequest) Reset() {
+	*x = CacheGetRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheGetRequest) ProtoMessage() {}
+
+func (x *CacheGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheGetRequest.ProtoReflect.Descriptor instead.
+func (*CacheGetRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CacheGetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type CacheGetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The value of the item.
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// If present, then the get attempt failed.
+	Error         *Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheGetResponse) Reset() {
+	*x = CacheGetResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheGetResponse) ProtoMessage() {}
+
+func (x *CacheGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheGetResponse.ProtoReflect.Descriptor instead.
+func (*CacheGetResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CacheGetResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CacheGetResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CacheSetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key of the item to set.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The value of the item to set.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// An optional time to live for the item, if omitted then the default TTL
+	// of the cache (if any) should be used.
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheSetRequest) Reset() {
+	*x = CacheSetRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheSetRequest) ProtoMessage() {}
+
+func (x *CacheSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheSetRequest.ProtoReflect.Descriptor instead.
+func (*CacheSetRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CacheSetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CacheSetRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CacheSetRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type CacheSetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the set attempt failed.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheSetResponse) Reset() {
+	*x = CacheSetResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheSetResponse) ProtoMessage() {}
+
+func (x *CacheSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheSetResponse.ProtoReflect.Descriptor instead.
+func (*CacheSetResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CacheSetResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CacheAddRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key of the item to add.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The value of the item to add.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// An optional time to live for the item, if omitted then the default TTL
+	// of the cache (if any) should be used.
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheAddRequest) Reset() {
+	*x = CacheAddRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheAddRequest) ProtoMessage() {}
+
+func (x *CacheAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheAddRequest.ProtoReflect.Descriptor instead.
+func (*CacheAddRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CacheAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CacheAddRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CacheAddRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type CacheAddResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the add attempt failed.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheAddResponse) Reset() {
+	*x = CacheAddResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheAddResponse) ProtoMessage() {}
+
+func (x *CacheAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheAddResponse.ProtoReflect.Descriptor instead.
+func (*CacheAddResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CacheAddResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CacheDeleteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key of the item to delete.
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheDeleteRequest) Reset() {
+	*x = CacheDeleteRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheDeleteRequest) ProtoMessage() {}
+
+func (x *CacheDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheDeleteRequest.ProtoReflect.Descriptor instead.
+func (*CacheDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CacheDeleteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type CacheDeleteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the delete attempt failed.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheDeleteResponse) Reset() {
+	*x = CacheDeleteResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheDeleteResponse) ProtoMessage() {}
+
+func (x *CacheDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheDeleteResponse.ProtoReflect.Descriptor instead.
+func (*CacheDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CacheDeleteResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CacheCloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheCloseRequest) Reset() {
+	*x = CacheCloseRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheCloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheCloseRequest) ProtoMessage() {}
+
+func (x *CacheCloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheCloseRequest.ProtoReflect.Descriptor instead.
+func (*CacheCloseRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{10}
+}
+
+type CacheCloseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the close attempt failed.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheCloseResponse) Reset() {
+	*x = CacheCloseResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheCloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheCloseResponse) ProtoMessage() {}
+
+func (x *CacheCloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_cache_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheCloseResponse.ProtoReflect.Descriptor instead.
+func (*CacheCloseResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CacheCloseResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+var File_redpanda_runtime_v1alpha1_cache_proto protoreflect.FileDescriptor
+
+const file_redpanda_runtime_v1alpha1_cache_proto_rawDesc = "" +
+	"\n" +
+	"%redpanda/runtime/v1alpha1/cache.proto\x12\x19redpanda.runtime.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a'redpanda/runtime/v1alpha1/message.proto\"L\n" +
+	"\x10CacheInitRequest\x128\n" +
+	"\x06config\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ValueR\x06config\"K\n" +
+	"\x11CacheInitResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"#\n" +
+	"\x0fCacheGetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"`\n" +
+	"\x10CacheGetResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x126\n" +
+	"\x05error\x18\x02 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"f\n" +
+	"\x0fCacheSetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"J\n" +
+	"\x10CacheSetResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"f\n" +
+	"\x0fCacheAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"J\n" +
+	"\x10CacheAddResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"&\n" +
+	"\x12CacheDeleteRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"M\n" +
+	"\x13CacheDeleteResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"\x13\n" +
+	"\x11CacheCloseRequest\"L\n" +
+	"\x12CacheCloseResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error2\xec\x04\n" +
+	"\fCacheService\x12c\n" +
+	"\x04Init\x12+.redpanda.runtime.v1alpha1.CacheInitRequest\x1a,.redpanda.runtime.v1alpha1.CacheInitResponse\"\x00\x12`\n" +
+	"\x03Get\x12*.redpanda.runtime.v1alpha1.CacheGetRequest\x1a+.redpanda.runtime.v1alpha1.CacheGetResponse\"\x00\x12`\n" +
+	"\x03Set\x12*.redpanda.runtime.v1alpha1.CacheSetRequest\x1a+.redpanda.runtime.v1alpha1.CacheSetResponse\"\x00\x12`\n" +
+	"\x03Add\x12*.redpanda.runtime.v1alpha1.CacheAddRequest\x1a+.redpanda.runtime.v1alpha1.CacheAddResponse\"\x00\x12i\n" +
+	"\x06Delete\x12-.redpanda.runtime.v1alpha1.CacheDeleteRequest\x1a..redpanda.runtime.v1alpha1.CacheDeleteResponse\"\x00\x12f\n" +
+	"\x05Close\x12,.redpanda.runtime.v1alpha1.CacheCloseRequest\x1a-.redpanda.runtime.v1alpha1.CacheCloseResponse\"\x00BBZ@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepbb\x06proto3"
+
+var (
+	file_redpanda_runtime_v1alpha1_cache_proto_rawDescOnce sync.Once
+	file_redpanda_runtime_v1alpha1_cache_proto_rawDescData []byte
+)
+
+func file_redpanda_runtime_v1alpha1_cache_proto_rawDescGZIP() []byte {
+	file_redpanda_runtime_v1alpha1_cache_proto_rawDescOnce.Do(func() {
+		file_redpanda_runtime_v1alpha1_cache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_cache_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_cache_proto_rawDesc)))
+	})
+	return file_redpanda_runtime_v1alpha1_cache_proto_rawDescData
+}
+
+var file_redpanda_runtime_v1alpha1_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_redpanda_runtime_v1alpha1_cache_proto_goTypes = []any{
+	(*CacheInitRequest)(nil),    // 0: redpanda.runtime.v1alpha1.CacheInitRequest
+	(*CacheInitResponse)(nil),   // 1: redpanda.runtime.v1alpha1.CacheInitResponse
+	(*CacheGetRequest)(nil),     // 2: redpanda.runtime.v1alpha1.CacheGetRequest
+	(*CacheGetResponse)(nil),    // 3: redpanda.runtime.v1alpha1.CacheGetResponse
+	(*CacheSetRequest)(nil),     // 4: redpanda.runtime.v1alpha1.CacheSetRequest
+	(*CacheSetResponse)(nil),    // 5: redpanda.runtime.v1alpha1.CacheSetResponse
+	(*CacheAddRequest)(nil),     // 6: redpanda.runtime.v1alpha1.CacheAddRequest
+	(*CacheAddResponse)(nil),    // 7: redpanda.runtime.v1alpha1.CacheAddResponse
+	(*CacheDeleteRequest)(nil),  // 8: redpanda.runtime.v1alpha1.CacheDeleteRequest
+	(*CacheDeleteResponse)(nil), // 9: redpanda.runtime.v1alpha1.CacheDeleteResponse
+	(*CacheCloseRequest)(nil),   // 10: redpanda.runtime.v1alpha1.CacheCloseRequest
+	(*CacheCloseResponse)(nil),  // 11: redpanda.runtime.v1alpha1.CacheCloseResponse
+	(*Value)(nil),               // 12: redpanda.runtime.v1alpha1.Value
+	(*Error)(nil),               // 13: redpanda.runtime.v1alpha1.Error
+	(*durationpb.Duration)(nil), // 14: google.protobuf.Duration
+}
+var file_redpanda_runtime_v1alpha1_cache_proto_depIdxs = []int32{
+	12, // 0: redpanda.runtime.v1alpha1.CacheInitRequest.config:type_name -> redpanda.runtime.v1alpha1.Value
+	13, // 1: redpanda.runtime.v1alpha1.CacheInitResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	13, // 2: redpanda.runtime.v1alpha1.CacheGetResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	14, // 3: redpanda.runtime.v1alpha1.CacheSetRequest.ttl:type_name -> google.protobuf.Duration
+	13, // 4: redpanda.runtime.v1alpha1.CacheSetResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	14, // 5: redpanda.runtime.v1alpha1.CacheAddRequest.ttl:type_name -> google.protobuf.Duration
+	13, // 6: redpanda.runtime.v1alpha1.CacheAddResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	13, // 7: redpanda.runtime.v1alpha1.CacheDeleteResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	13, // 8: redpanda.runtime.v1alpha1.CacheCloseResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	0,  // 9: redpanda.runtime.v1alpha1.CacheService.Init:input_type -> redpanda.runtime.v1alpha1.CacheInitRequest
+	2,  // 10: redpanda.runtime.v1alpha1.CacheService.Get:input_type -> redpanda.runtime.v1alpha1.CacheGetRequest
+	4,  // 11: redpanda.runtime.v1alpha1.CacheService.Set:input_type -> redpanda.runtime.v1alpha1.CacheSetRequest
+	6,  // 12: redpanda.runtime.v1alpha1.CacheService.Add:input_type -> redpanda.runtime.v1alpha1.CacheAddRequest
+	8,  // 13: redpanda.runtime.v1alpha1.CacheService.Delete:input_type -> redpanda.runtime.v1alpha1.CacheDeleteRequest
+	10, // 14: redpanda.runtime.v1alpha1.CacheService.Close:input_type -> redpanda.runtime.v1alpha1.CacheCloseRequest
+	1,  // 15: redpanda.runtime.v1alpha1.CacheService.Init:output_type -> redpanda.runtime.v1alpha1.CacheInitResponse
+	3,  // 16: redpanda.runtime.v1alpha1.CacheService.Get:output_type -> redpanda.runtime.v1alpha1.CacheGetResponse
+	5,  // 17: redpanda.runtime.v1alpha1.CacheService.Set:output_type -> redpanda.runtime.v1alpha1.CacheSetResponse
+	7,  // 18: redpanda.runtime.v1alpha1.CacheService.Add:output_type -> redpanda.runtime.v1alpha1.CacheAddResponse
+	9,  // 19: redpanda.runtime.v1alpha1.CacheService.Delete:output_type -> redpanda.runtime.v1alpha1.CacheDeleteResponse
+	11, // 20: redpanda.runtime.v1alpha1.CacheService.Close:output_type -> redpanda.runtime.v1alpha1.CacheCloseResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_redpanda_runtime_v1alpha1_cache_proto_init() }
+func file_redpanda_runtime_v1alpha1_cache_proto_init() {
+	if File_redpanda_runtime_v1alpha1_cache_proto != nil {
+		return
+	}
+	file_redpanda_runtime_v1alpha1_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_cache_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_cache_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_redpanda_runtime_v1alpha1_cache_proto_goTypes,
+		DependencyIndexes: file_redpanda_runtime_v1alpha1_cache_proto_depIdxs,
+		MessageInfos:      file_redpanda_runtime_v1alpha1_cache_proto_msgTypes,
+	}.Build()
+	File_redpanda_runtime_v1alpha1_cache_proto = out.File
+	file_redpanda_runtime_v1alpha1_cache_proto_goTypes = nil
+	file_redpanda_runtime_v1alpha1_cache_proto_depIdxs = nil
+}
@@ -35,6 +35,10 @@ func ProtoToError(err *Error) error {
 		return service.ErrNotConnected
 	case *Error_EndOfInput_:
 		return service.ErrEndOfInput
+	case *Error_KeyNotFound_:
+		return service.ErrKeyNotFound
+	case *Error_KeyAlreadyExists_:
+		return service.ErrKeyAlreadyExists
 	}
 	if msg == "" {
 		return nil
@@ -63,6 +67,18 @@ func ErrorToProto(err error) *Error {
 			Detail:  &Error_EndOfInput_{EndOfInput: &Error_EndOfInput{}},
 		}
 	}
+	if errors.Is(err, service.ErrKeyNotFound) {
+		return &Error{
+			Message: msg,
+			Detail:  &Error_KeyNotFound_{KeyNotFound: &Error_KeyNotFound{}},
+		}
+	}
+	if errors.Is(err, service.ErrKeyAlreadyExists) {
+		return &Error{
+			Message: msg,
+			Detail:  &Error_KeyAlreadyExists_{KeyAlreadyExists: &Error_KeyAlreadyExists{}},
+		}
+	}
 	var backoffErr *service.ErrBackOff
 	if errors.As(err, &backoffErr) {
 		return &Error{
@@ -0,0 +1,49 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimepb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestErrorRoundTrip(t *testing.T) {
+	assert.NoError(t, ProtoToError(ErrorToProto(nil)))
+
+	for _, sentinel := range []error{
+		service.ErrNotConnected,
+		service.ErrEndOfInput,
+		service.ErrKeyNotFound,
+		service.ErrKeyAlreadyExists,
+	} {
+		err := ProtoToError(ErrorToProto(sentinel))
+		assert.ErrorIs(t, err, sentinel)
+	}
+
+	err := ProtoToError(ErrorToProto(service.NewErrBackOff(errors.New("slow down"), time.Second)))
+	var backoffErr *service.ErrBackOff
+	require.ErrorAs(t, err, &backoffErr)
+	assert.Equal(t, time.Second, backoffErr.Wait)
+
+	err = ProtoToError(ErrorToProto(errors.New("boom")))
+	require.Error(t, err)
+	assert.Equal(t, "boom", err.Error())
+}
@@ -372,7 +372,7 @@ func (*Value_ListValue) isValue_Kind() {}
 // An error in the context of a data pipeline.
 type Error struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The error message. If non empty, then the error to be "valid" and
+	// The error message. If non empty, then the error is valid and
 	// if empty the error is ignored as if a success (due to proto3 empty
 	// semantics).
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -384,6 +384,8 @@ type Error struct {
 	//	*Error_Backoff
 	//	*Error_NotConnected_
 	//	*Error_EndOfInput_
+	//	*Error_KeyNotFound_
+	//	*Error_KeyAlreadyExists_
 	Detail        isError_Detail `protobuf_oneof:"detail"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -460,14 +462,34 @@ func (x *Error) GetEndOfInput() *Error_EndOfInput {
 	return nil
 }
 
+func (x *Error) GetKeyNotFound() *Error_KeyNotFound {
+	if x != nil {
+		if x, ok := x.Detail.(*Error_KeyNotFound_); ok {
+			return x.KeyNotFound
+		}
+	}
+	return nil
+}
+
+func (x *Error) GetKeyAlreadyExists() *Error_KeyAlreadyExists {
+	if x != nil {
+		if x, ok := x.Detail.(*Error_KeyAlreadyExists_); ok {
+			return x.KeyAlreadyExists
+		}
+	}
+	return nil
+}
+
 type isError_Detail interface {
 	isError_Detail()
 }
 
 type Error_Backoff struct {
-	// BackOff is an error that plugins can optionally wrap another error with which instructs upstream components to wait for a specified period of time before retrying the errored call.
+	// BackOff is an error that plugins can optionally wrap another error with
+	// which instructs upstream components to wait for a specified period of
+	// time before retrying the errored call.
 	//
-	// Only suppported by Connect methods in the Input and Output services.
+	// Only supported by Connect methods in the Input and Output services.
 	Backoff *durationpb.Duration `protobuf:"bytes,2,opt,name=backoff,proto3,oneof"`
 }
 
@@ -479,13 +501,26 @@ type Error_EndOfInput_ struct {
 	EndOfInput *Error_EndOfInput `protobuf:"bytes,4,opt,name=end_of_input,json=endOfInput,proto3,oneof"`
 }
 
+type Error_KeyNotFound_ struct {
+	KeyNotFound *Error_KeyNotFound `protobuf:"bytes,5,opt,name=key_not_found,json=keyNotFound,proto3,oneof"`
+}
+
+type Error_KeyAlreadyExists_ struct {
+	KeyAlreadyExists *Error_KeyAlreadyExists `protobuf:"bytes,6,opt,name=key_already_exists,json=keyAlreadyExists,proto3,oneof"`
+}
+
 func (*Error_Backoff) isError_Detail() {}
 
 func (*Error_NotConnected_) isError_Detail() {}
 
 func (*Error_EndOfInput_) isError_Detail() {}
 
-// Message represents a piece of data or an event that flows through the runtime.
+func (*Error_KeyNotFound_) isError_Detail() {}
+
+func (*Error_KeyAlreadyExists_) isError_Detail() {}
+
+// Message represents a piece of data or an event that flows through the
+// runtime.
 type Message struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Payload:
@@ -708,6 +743,81 @@ func (*Error_EndOfInput) Descriptor() ([]byte, []int) {
 	return file_redpanda_runtime_v1alpha1_message_proto_rawDescGZIP(), []int{3, 1}
 }
 
+// KeyNotFound is returned by caches when a requested key does not exist.
+type Error_KeyNotFound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error_KeyNotFound) Reset() {
+	*x = Error_KeyNotFound{}
+	mi := &file_redpanda_runtime_v1alpha1_message_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error_KeyNotFound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error_KeyNotFound) ProtoMessage() {}
+
+func (x *Error_KeyNotFound) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_message_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error_KeyNotFound.ProtoReflect.Descriptor instead.
+func (*Error_KeyNotFound) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_message_proto_rawDescGZIP(), []int{3, 2}
+}
+
+// KeyAlreadyExists is returned by caches when an add operation targets a
+// key that already exists.
+type Error_KeyAlreadyExists struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error_KeyAlreadyExists) Reset() {
+	*x = Error_KeyAlreadyExists{}
+	mi := &file_redpanda_runtime_v1alpha1_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error_KeyAlreadyExists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error_KeyAlreadyExists) ProtoMessage() {}
+
+func (x *Error_KeyAlreadyExists) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error_KeyAlreadyExists.ProtoReflect.Descriptor instead.
+func (*Error_KeyAlreadyExists) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_message_proto_rawDescGZIP(), []int{3, 3}
+}
+
 var File_redpanda_runtime_v1alpha1_message_proto protoreflect.FileDescriptor
 
 const file_redpanda_runtime_v1alpha1_message_proto_rawDesc = "" +
@@ -734,16 +844,20 @@ const file_redpanda_runtime_v1alpha1_message_proto_rawDesc = "" +
 	"\fstruct_value\x18\b \x01(\v2&.redpanda.runtime.v1alpha1.StructValueH\x00R\vstructValue\x12E\n" +
 	"\n" +
 	"list_value\x18\t \x01(\v2$.redpanda.runtime.v1alpha1.ListValueH\x00R\tlistValueB\x06\n" +
-	"\x04kind\"\xa7\x02\n" +
+	"\x04kind\"\x81\x04\n" +
 	"\x05Error\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x125\n" +
 	"\abackoff\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\abackoff\x12T\n" +
 	"\rnot_connected\x18\x03 \x01(\v2-.redpanda.runtime.v1alpha1.Error.NotConnectedH\x00R\fnotConnected\x12O\n" +
 	"\fend_of_input\x18\x04 \x01(\v2+.redpanda.runtime.v1alpha1.Error.EndOfInputH\x00R\n" +
-	"endOfInput\x1a\x0e\n" +
+	"endOfInput\x12R\n" +
+	"\rkey_not_found\x18\x05 \x01(\v2,.redpanda.runtime.v1alpha1.Error.KeyNotFoundH\x00R\vkeyNotFound\x12a\n" +
+	"\x12key_already_exists\x18\x06 \x01(\v21.redpanda.runtime.v1alpha1.Error.KeyAlreadyExistsH\x00R\x10keyAlreadyExists\x1a\x0e\n" +
 	"\fNotConnected\x1a\f\n" +
 	"\n" +
-	"EndOfInputB\b\n" +
+	"EndOfInput\x1a\r\n" +
+	"\vKeyNotFound\x1a\x12\n" +
+	"\x10KeyAlreadyExistsB\b\n" +
 	"\x06detail\"\xec\x01\n" +
 	"\aMessage\x12\x16\n" +
 	"\x05bytes\x18\x01 \x01(\fH\x00R\x05bytes\x12B\n" +
@@ -772,41 +886,45 @@ func file_redpanda_runtime_v1alpha1_message_proto_rawDescGZIP() []byte {
 }
 
 var file_redpanda_runtime_v1alpha1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_redpanda_runtime_v1alpha1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_redpanda_runtime_v1alpha1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_redpanda_runtime_v1alpha1_message_proto_goTypes = []any{
-	(NullValue)(0),                // 0: redpanda.runtime.v1alpha1.NullValue
-	(*StructValue)(nil),           // 1: redpanda.runtime.v1alpha1.StructValue
-	(*ListValue)(nil),             // 2: redpanda.runtime.v1alpha1.ListValue
-	(*Value)(nil),                 // 3: redpanda.runtime.v1alpha1.Value
-	(*Error)(nil),                 // 4: redpanda.runtime.v1alpha1.Error
-	(*Message)(nil),               // 5: redpanda.runtime.v1alpha1.Message
-	(*MessageBatch)(nil),          // 6: redpanda.runtime.v1alpha1.MessageBatch
-	nil,                           // 7: redpanda.runtime.v1alpha1.StructValue.FieldsEntry
-	(*Error_NotConnected)(nil),    // 8: redpanda.runtime.v1alpha1.Error.NotConnected
-	(*Error_EndOfInput)(nil),      // 9: redpanda.runtime.v1alpha1.Error.EndOfInput
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 11: google.protobuf.Duration
+	(NullValue)(0),                 // 0: redpanda.runtime.v1alpha1.NullValue
+	(*StructValue)(nil),            // 1: redpanda.runtime.v1alpha1.StructValue
+	(*ListValue)(nil),              // 2: redpanda.runtime.v1alpha1.ListValue
+	(*Value)(nil),                  // 3: redpanda.runtime.v1alpha1.Value
+	(*Error)(nil),                  // 4: redpanda.runtime.v1alpha1.Error
+	(*Message)(nil),                // 5: redpanda.runtime.v1alpha1.Message
+	(*MessageBatch)(nil),           // 6: redpanda.runtime.v1alpha1.MessageBatch
+	nil,                            // 7: redpanda.runtime.v1alpha1.StructValue.FieldsEntry
+	(*Error_NotConnected)(nil),     // 8: redpanda.runtime.v1alpha1.Error.NotConnected
+	(*Error_EndOfInput)(nil),       // 9: redpanda.runtime.v1alpha1.Error.EndOfInput
+	(*Error_KeyNotFound)(nil),      // 10: redpanda.runtime.v1alpha1.Error.KeyNotFound
+	(*Error_KeyAlreadyExists)(nil), // 11: redpanda.runtime.v1alpha1.Error.KeyAlreadyExists
+	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 13: google.protobuf.Duration
 }
 var file_redpanda_runtime_v1alpha1_message_proto_depIdxs = []int32{
 	7,  // 0: redpanda.runtime.v1alpha1.StructValue.fields:type_name -> redpanda.runtime.v1alpha1.StructValue.FieldsEntry
 	3,  // 1: redpanda.runtime.v1alpha1.ListValue.values:type_name -> redpanda.runtime.v1alpha1.Value
 	0,  // 2: redpanda.runtime.v1alpha1.Value.null_value:type_name -> redpanda.runtime.v1alpha1.NullValue
-	10, // 3: redpanda.runtime.v1alpha1.Value.timestamp_value:type_name -> google.protobuf.Timestamp
+	12, // 3: redpanda.runtime.v1alpha1.Value.timestamp_value:type_name -> google.protobuf.Timestamp
 	1,  // 4: redpanda.runtime.v1alpha1.Value.struct_value:type_name -> redpanda.runtime.v1alpha1.StructValue
 	2,  // 5: redpanda.runtime.v1alpha1.Value.list_value:type_name -> redpanda.runtime.v1alpha1.ListValue
-	11, // 6: redpanda.runtime.v1alpha1.Error.backoff:type_name -> google.protobuf.Duration
+	13, // 6: redpanda.runtime.v1alpha1.Error.backoff:type_name -> google.protobuf.Duration
 	8,  // 7: redpanda.runtime.v1alpha1.Error.not_connected:type_name -> redpanda.runtime.v1alpha1.Error.NotConnected
 	9,  // 8: redpanda.runtime.v1alpha1.Error.end_of_input:type_name -> redpanda.runtime.v1alpha1.Error.EndOfInput
-	3,  // 9: redpanda.runtime.v1alpha1.Message.structured:type_name -> redpanda.runtime.v1alpha1.Value
-	1,  // 10: redpanda.runtime.v1alpha1.Message.metadata:type_name -> redpanda.runtime.v1alpha1.StructValue
-	4,  // 11: redpanda.runtime.v1alpha1.Message.error:type_name -> redpanda.runtime.v1alpha1.Error
-	5,  // 12: redpanda.runtime.v1alpha1.MessageBatch.messages:type_name -> redpanda.runtime.v1alpha1.Message
-	3,  // 13: redpanda.runtime.v1alpha1.StructValue.FieldsEntry.value:type_name -> redpanda.runtime.v1alpha1.Value
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	10, // 9: redpanda.runtime.v1alpha1.Error.key_not_found:type_name -> redpanda.runtime.v1alpha1.Error.KeyNotFound
+	11, // 10: redpanda.runtime.v1alpha1.Error.key_already_exists:type_name -> redpanda.runtime.v1alpha1.Error.KeyAlreadyExists
+	3,  // 11: redpanda.runtime.v1alpha1.Message.structured:type_name -> redpanda.runtime.v1alpha1.Value
+	1,  // 12: redpanda.runtime.v1alpha1.Message.metadata:type_name -> redpanda.runtime.v1alpha1.StructValue
+	4,  // 13: redpanda.runtime.v1alpha1.Message.error:type_name -> redpanda.runtime.v1alpha1.Error
+	5,  // 14: redpanda.runtime.v1alpha1.MessageBatch.messages:type_name -> redpanda.runtime.v1alpha1.Message
+	3,  // 15: redpanda.runtime.v1alpha1.StructValue.FieldsEntry.value:type_name -> redpanda.runtime.v1alpha1.Value
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_redpanda_runtime_v1alpha1_message_proto_init() }
@@ -829,6 +947,8 @@ func file_redpanda_runtime_v1alpha1_message_proto_init() {
 		(*Error_Backoff)(nil),
 		(*Error_NotConnected_)(nil),
 		(*Error_EndOfInput_)(nil),
+		(*Error_KeyNotFound_)(nil),
+		(*Error_KeyAlreadyExists_)(nil),
 	}
 	file_redpanda_runtime_v1alpha1_message_proto_msgTypes[4].OneofWrappers = []any{
 		(*Message_Bytes)(nil),
@@ -840,7 +960,7 @@ func file_redpanda_runtime_v1alpha1_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_message_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_message_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
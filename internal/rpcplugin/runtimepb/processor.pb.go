@@ -81,9 +81,12 @@ func (x *BatchProcessorInitRequest) GetConfig() *Value {
 
 type BatchProcessorInitResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// If present, then the input configuration is invalid and an error should be surfaced
-	// at pipeline construction time.
-	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// If present, then the input configuration is invalid and an error should be
+	// surfaced at pipeline construction time.
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The optional capabilities of the plugin. Plugins that leave this unset
+	// will only be called using the unary ProcessBatch method.
+	Capabilities  *BatchProcessorCapabilities `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,6 +128,68 @@ func (x *BatchProcessorInitResponse) GetError() *Error {
 	return nil
 }
 
+func (x *BatchProcessorInitResponse) GetCapabilities() *BatchProcessorCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type BatchProcessorCapabilities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If true, then the plugin implements ProcessBatchStream.
+	Streaming bool `protobuf:"varint,1,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// The maximum number of batches that the host may have in flight on a
+	// single ProcessBatchStream call. If zero, then the host picks a default.
+	MaxInFlight   uint32 `protobuf:"varint,2,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProcessorCapabilities) Reset() {
+	*x = BatchProcessorCapabilities{}
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProcessorCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProcessorCapabilities) ProtoMessage() {}
+
+func (x *BatchProcessorCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProcessorCapabilities.ProtoReflect.Descriptor instead.
+func (*BatchProcessorCapabilities) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchProcessorCapabilities) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+func (x *BatchProcessorCapabilities) GetMaxInFlight() uint32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
 type BatchProcessorProcessBatchRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The input batch to the processor.
@@ -135,7 +200,7 @@ type BatchProcessorProcessBatchRequest struct {
 
 func (x *BatchProcessorProcessBatchRequest) Reset() {
 	*x = BatchProcessorProcessBatchRequest{}
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[2]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +212,7 @@ func (x *BatchProcessorProcessBatchRequest) String() string {
 func (*BatchProcessorProcessBatchRequest) ProtoMessage() {}
 
 func (x *BatchProcessorProcessBatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[2]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +225,7 @@ func (x *BatchProcessorProcessBatchRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchProcessorProcessBatchRequest.ProtoReflect.Descriptor instead.
 func (*BatchProcessorProcessBatchRequest) Descriptor() ([]byte, []int) {
-	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{2}
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BatchProcessorProcessBatchRequest) GetBatch() *MessageBatch {
@@ -183,7 +248,7 @@ type BatchProcessorProcessBatchResponse struct {
 
 func (x *BatchProcessorProcessBatchResponse) Reset() {
 	*x = BatchProcessorProcessBatchResponse{}
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[3]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -195,7 +260,7 @@ func (x *BatchProcessorProcessBatchResponse) String() string {
 func (*BatchProcessorProcessBatchResponse) ProtoMessage() {}
 
 func (x *BatchProcessorProcessBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[3]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -208,7 +273,7 @@ func (x *BatchProcessorProcessBatchResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchProcessorProcessBatchResponse.ProtoReflect.Descriptor instead.
 func (*BatchProcessorProcessBatchResponse) Descriptor() ([]byte, []int) {
-	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{3}
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BatchProcessorProcessBatchResponse) GetBatches() []*MessageBatch {
@@ -225,6 +290,125 @@ func (x *BatchProcessorProcessBatchResponse) GetError() *Error {
 	return nil
 }
 
+type BatchProcessorProcessBatchStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An identifier chosen by the host, which is unique amongst the requests
+	// that are in flight on the stream.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The input batch to the processor.
+	Batch         *MessageBatch `protobuf:"bytes,2,opt,name=batch,proto3" json:"batch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProcessorProcessBatchStreamRequest) Reset() {
+	*x = BatchProcessorProcessBatchStreamRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProcessorProcessBatchStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProcessorProcessBatchStreamRequest) ProtoMessage() {}
+
+func (x *BatchProcessorProcessBatchStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProcessorProcessBatchStreamRequest.ProtoReflect.Descriptor instead.
+func (*BatchProcessorProcessBatchStreamRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchProcessorProcessBatchStreamRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BatchProcessorProcessBatchStreamRequest) GetBatch() *MessageBatch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+type BatchProcessorProcessBatchStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The identifier of the request that this response corresponds to.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The resulting batch of messages. Returning multiple batches allows
+	// for splitting a single batch into multiple batches.
+	Batches []*MessageBatch `protobuf:"bytes,2,rep,name=batches,proto3" json:"batches,omitempty"`
+	// If present, then the processing failed.
+	Error         *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProcessorProcessBatchStreamResponse) Reset() {
+	*x = BatchProcessorProcessBatchStreamResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProcessorProcessBatchStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProcessorProcessBatchStreamResponse) ProtoMessage() {}
+
+func (x *BatchProcessorProcessBatchStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProcessorProcessBatchStreamResponse.ProtoReflect.Descriptor instead.
+func (*BatchProcessorProcessBatchStreamResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchProcessorProcessBatchStreamResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BatchProcessorProcessBatchStreamResponse) GetBatches() []*MessageBatch {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+func (x *BatchProcessorProcessBatchStreamResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
 type BatchProcessorCloseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -233,7 +417,7 @@ type BatchProcessorCloseRequest struct {
 
 func (x *BatchProcessorCloseRequest) Reset() {
 	*x = BatchProcessorCloseRequest{}
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[4]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -245,7 +429,7 @@ func (x *BatchProcessorCloseRequest) String() string {
 func (*BatchProcessorCloseRequest) ProtoMessage() {}
 
 func (x *BatchProcessorCloseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[4]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,7 +442,7 @@ func (x *BatchProcessorCloseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchProcessorCloseRequest.ProtoReflect.Descriptor instead.
 func (*BatchProcessorCloseRequest) Descriptor() ([]byte, []int) {
-	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{4}
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{7}
 }
 
 type BatchProcessorCloseResponse struct {
@@ -271,7 +455,7 @@ type BatchProcessorCloseResponse struct {
 
 func (x *BatchProcessorCloseResponse) Reset() {
 	*x = BatchProcessorCloseResponse{}
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[5]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -283,7 +467,7 @@ func (x *BatchProcessorCloseResponse) String() string {
 func (*BatchProcessorCloseResponse) ProtoMessage() {}
 
 func (x *BatchProcessorCloseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[5]
+	mi := &file_redpanda_runtime_v1alpha1_processor_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +480,7 @@ func (x *BatchProcessorCloseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchProcessorCloseResponse.ProtoReflect.Descriptor instead.
 func (*BatchProcessorCloseResponse) Descriptor() ([]byte, []int) {
-	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{5}
+	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *BatchProcessorCloseResponse) GetError() *Error {
@@ -312,20 +496,32 @@ const file_redpanda_runtime_v1alpha1_processor_proto_rawDesc = "" +
 	"\n" +
 	")redpanda/runtime/v1alpha1/processor.proto\x12\x19redpanda.runtime.v1alpha1\x1a'redpanda/runtime/v1alpha1/message.proto\"U\n" +
 	"\x19BatchProcessorInitRequest\x128\n" +
-	"\x06config\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ValueR\x06config\"T\n" +
+	"\x06config\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ValueR\x06config\"\xaf\x01\n" +
 	"\x1aBatchProcessorInitResponse\x126\n" +
-	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"b\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\x12Y\n" +
+	"\fcapabilities\x18\x02 \x01(\v25.redpanda.runtime.v1alpha1.BatchProcessorCapabilitiesR\fcapabilities\"^\n" +
+	"\x1aBatchProcessorCapabilities\x12\x1c\n" +
+	"\tstreaming\x18\x01 \x01(\bR\tstreaming\x12\"\n" +
+	"\rmax_in_flight\x18\x02 \x01(\rR\vmaxInFlight\"b\n" +
 	"!BatchProcessorProcessBatchRequest\x12=\n" +
 	"\x05batch\x18\x01 \x01(\v2'.redpanda.runtime.v1alpha1.MessageBatchR\x05batch\"\x9f\x01\n" +
 	"\"BatchProcessorProcessBatchResponse\x12A\n" +
 	"\abatches\x18\x01 \x03(\v2'.redpanda.runtime.v1alpha1.MessageBatchR\abatches\x126\n" +
-	"\x05error\x18\x02 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"\x1c\n" +
+	"\x05error\x18\x02 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"x\n" +
+	"'BatchProcessorProcessBatchStreamRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12=\n" +
+	"\x05batch\x18\x02 \x01(\v2'.redpanda.runtime.v1alpha1.MessageBatchR\x05batch\"\xb5\x01\n" +
+	"(BatchProcessorProcessBatchStreamResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12A\n" +
+	"\abatches\x18\x02 \x03(\v2'.redpanda.runtime.v1alpha1.MessageBatchR\abatches\x126\n" +
+	"\x05error\x18\x03 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"\x1c\n" +
 	"\x1aBatchProcessorCloseRequest\"U\n" +
 	"\x1bBatchProcessorCloseResponse\x126\n" +
-	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error2\x98\x03\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error2\xbe\x04\n" +
 	"\x15BatchProcessorService\x12u\n" +
 	"\x04Init\x124.redpanda.runtime.v1alpha1.BatchProcessorInitRequest\x1a5.redpanda.runtime.v1alpha1.BatchProcessorInitResponse\"\x00\x12\x8d\x01\n" +
-	"\fProcessBatch\x12<.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest\x1a=.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse\"\x00\x12x\n" +
+	"\fProcessBatch\x12<.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest\x1a=.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse\"\x00\x12\xa3\x01\n" +
+	"\x12ProcessBatchStream\x12B.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamRequest\x1aC.redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamResponse\"\x00(\x010\x01\x12x\n" +
 	"\x05Close\x125.redpanda.runtime.v1alpha1.BatchProcessorCloseRequest\x1a6.redpanda.runtime.v1alpha1.BatchProcessorCloseResponse\"\x00BBZ@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepbb\x06proto3"
 
 var (
@@ -340,36 +536,45 @@ func file_redpanda_runtime_v1alpha1_processor_proto_rawDescGZIP() []byte {
 	return file_redpanda_runtime_v1alpha1_processor_proto_rawDescData
 }
 
-var file_redpanda_runtime_v1alpha1_processor_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_redpanda_runtime_v1alpha1_processor_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_redpanda_runtime_v1alpha1_processor_proto_goTypes = []any{
-	(*BatchProcessorInitRequest)(nil),          // 0: redpanda.runtime.v1alpha1.BatchProcessorInitRequest
-	(*BatchProcessorInitResponse)(nil),         // 1: redpanda.runtime.v1alpha1.BatchProcessorInitResponse
-	(*BatchProcessorProcessBatchRequest)(nil),  // 2: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest
-	(*BatchProcessorProcessBatchResponse)(nil), // 3: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse
-	(*BatchProcessorCloseRequest)(nil),         // 4: redpanda.runtime.v1alpha1.BatchProcessorCloseRequest
-	(*BatchProcessorCloseResponse)(nil),        // 5: redpanda.runtime.v1alpha1.BatchProcessorCloseResponse
-	(*Value)(nil),                              // 6: redpanda.runtime.v1alpha1.Value
-	(*Error)(nil),                              // 7: redpanda.runtime.v1alpha1.Error
-	(*MessageBatch)(nil),                       // 8: redpanda.runtime.v1alpha1.MessageBatch
+	(*BatchProcessorInitRequest)(nil),                // 0: redpanda.runtime.v1alpha1.BatchProcessorInitRequest
+	(*BatchProcessorInitResponse)(nil),               // 1: redpanda.runtime.v1alpha1.BatchProcessorInitResponse
+	(*BatchProcessorCapabilities)(nil),               // 2: redpanda.runtime.v1alpha1.BatchProcessorCapabilities
+	(*BatchProcessorProcessBatchRequest)(nil),        // 3: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest
+	(*BatchProcessorProcessBatchResponse)(nil),       // 4: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse
+	(*BatchProcessorProcessBatchStreamRequest)(nil),  // 5: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamRequest
+	(*BatchProcessorProcessBatchStreamResponse)(nil), // 6: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamResponse
+	(*BatchProcessorCloseRequest)(nil),               // 7: redpanda.runtime.v1alpha1.BatchProcessorCloseRequest
+	(*BatchProcessorCloseResponse)(nil),              // 8: redpanda.runtime.v1alpha1.BatchProcessorCloseResponse
+	(*Value)(nil),                                    // 9: redpanda.runtime.v1alpha1.Value
+	(*Error)(nil),                                    // 10: redpanda.runtime.v1alpha1.Error
+	(*MessageBatch)(nil),                             // 11: redpanda.runtime.v1alpha1.MessageBatch
 }
 var file_redpanda_runtime_v1alpha1_processor_proto_depIdxs = []int32{
-	6, // 0: redpanda.runtime.v1alpha1.BatchProcessorInitRequest.config:type_name -> redpanda.runtime.v1alpha1.Value
-	7, // 1: redpanda.runtime.v1alpha1.BatchProcessorInitResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
-	8, // 2: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest.batch:type_name -> redpanda.runtime.v1alpha1.MessageBatch
-	8, // 3: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse.batches:type_name -> redpanda.runtime.v1alpha1.MessageBatch
-	7, // 4: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
-	7, // 5: redpanda.runtime.v1alpha1.BatchProcessorCloseResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
-	0, // 6: redpanda.runtime.v1alpha1.BatchProcessorService.Init:input_type -> redpanda.runtime.v1alpha1.BatchProcessorInitRequest
-	2, // 7: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatch:input_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest
-	4, // 8: redpanda.runtime.v1alpha1.BatchProcessorService.Close:input_type -> redpanda.runtime.v1alpha1.BatchProcessorCloseRequest
-	1, // 9: redpanda.runtime.v1alpha1.BatchProcessorService.Init:output_type -> redpanda.runtime.v1alpha1.BatchProcessorInitResponse
-	3, // 10: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatch:output_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse
-	5, // 11: redpanda.runtime.v1alpha1.BatchProcessorService.Close:output_type -> redpanda.runtime.v1alpha1.BatchProcessorCloseResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	9,  // 0: redpanda.runtime.v1alpha1.BatchProcessorInitRequest.config:type_name -> redpanda.runtime.v1alpha1.Value
+	10, // 1: redpanda.runtime.v1alpha1.BatchProcessorInitResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	2,  // 2: redpanda.runtime.v1alpha1.BatchProcessorInitResponse.capabilities:type_name -> redpanda.runtime.v1alpha1.BatchProcessorCapabilities
+	11, // 3: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest.batch:type_name -> redpanda.runtime.v1alpha1.MessageBatch
+	11, // 4: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse.batches:type_name -> redpanda.runtime.v1alpha1.MessageBatch
+	10, // 5: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	11, // 6: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamRequest.batch:type_name -> redpanda.runtime.v1alpha1.MessageBatch
+	11, // 7: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamResponse.batches:type_name -> redpanda.runtime.v1alpha1.MessageBatch
+	10, // 8: redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	10, // 9: redpanda.runtime.v1alpha1.BatchProcessorCloseResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	0,  // 10: redpanda.runtime.v1alpha1.BatchProcessorService.Init:input_type -> redpanda.runtime.v1alpha1.BatchProcessorInitRequest
+	3,  // 11: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatch:input_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchRequest
+	5,  // 12: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatchStream:input_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamRequest
+	7,  // 13: redpanda.runtime.v1alpha1.BatchProcessorService.Close:input_type -> redpanda.runtime.v1alpha1.BatchProcessorCloseRequest
+	1,  // 14: redpanda.runtime.v1alpha1.BatchProcessorService.Init:output_type -> redpanda.runtime.v1alpha1.BatchProcessorInitResponse
+	4,  // 15: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatch:output_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchResponse
+	6,  // 16: redpanda.runtime.v1alpha1.BatchProcessorService.ProcessBatchStream:output_type -> redpanda.runtime.v1alpha1.BatchProcessorProcessBatchStreamResponse
+	8,  // 17: redpanda.runtime.v1alpha1.BatchProcessorService.Close:output_type -> redpanda.runtime.v1alpha1.BatchProcessorCloseResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_redpanda_runtime_v1alpha1_processor_proto_init() }
@@ -384,7 +589,7 @@ func file_redpanda_runtime_v1alpha1_processor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_processor_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_processor_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -33,26 +33,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BatchProcessorService_Init_FullMethodName         = "/redpanda.runtime.v1alpha1.BatchProcessorService/Init"
-	BatchProcessorService_ProcessBatch_FullMethodName = "/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatch"
-	BatchProcessorService_Close_FullMethodName        = "/redpanda.runtime.v1alpha1.BatchProcessorService/Close"
+	BatchProcessorService_Init_FullMethodName               = "/redpanda.runtime.v1alpha1.BatchProcessorService/Init"
+	BatchProcessorService_ProcessBatch_FullMethodName       = "/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatch"
+	BatchProcessorService_ProcessBatchStream_FullMethodName = "/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatchStream"
+	BatchProcessorService_Close_FullMethodName              = "/redpanda.runtime.v1alpha1.BatchProcessorService/Close"
 )
 
 // BatchProcessorServiceClient is the client API for BatchProcessorService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// BatchProcessor is a Benthos processor implementation that works against batches
-// of messages, which allows windowed processing.
+// BatchProcessor is a Benthos processor implementation that works against
+// batches of messages, which allows windowed processing.
 //
 // Message batches must be created by upstream components (inputs, buffers, etc)
-// otherwise this processor will simply receive batches containing single messages.
+// otherwise this processor will simply receive batches containing single
+// messages.
 type BatchProcessorServiceClient interface {
-	// Init is the first method called for a batch processor and it passes the user's
-	// configuration to the input.
+	// Init is the first method called for a batch processor and it passes the
+	// user's configuration to the input.
 	//
-	// The schema for the processor configuration is specified in the `plugin.yaml` file
-	// provided to Redpanda Connect.
+	// The schema for the processor configuration is specified in the
+	// `plugin.yaml` file provided to Redpanda Connect.
 	Init(ctx context.Context, in *BatchProcessorInitRequest, opts ...grpc.CallOption) (*BatchProcessorInitResponse, error)
 	// Process a batch of messages into one or more resulting batches, or return
 	// an error if the entire batch could not be processed. If zero messages are
@@ -73,9 +75,18 @@ type BatchProcessorServiceClient interface {
 	// and CANNOT be custom instantiations of Message. In order to copy the
 	// provided messages use the Copy method.
 	ProcessBatch(ctx context.Context, in *BatchProcessorProcessBatchRequest, opts ...grpc.CallOption) (*BatchProcessorProcessBatchResponse, error)
+	// ProcessBatchStream is a streaming variant of ProcessBatch that allows the
+	// host to have multiple batches in flight with the plugin at once.
+	//
+	// Each request carries an ID that must be echoed in the corresponding
+	// response, responses may be sent in any order. The semantics of each
+	// individual batch are the same as ProcessBatch.
+	//
+	// This method is only used by the host if the plugin advertises support for
+	// it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.
+	ProcessBatchStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse], error)
 	// Close the component, blocks until either the underlying resources are
-	// cleaned up or the context is cancelled. Returns an error if the context
-	// is cancelled.
+	// cleaned up or the RPC deadline is reached.
 	Close(ctx context.Context, in *BatchProcessorCloseRequest, opts ...grpc.CallOption) (*BatchProcessorCloseResponse, error)
 }
 
@@ -107,6 +118,19 @@ func (c *batchProcessorServiceClient) ProcessBatch(ctx context.Context, in *Batc
 	return out, nil
 }
 
+func (c *batchProcessorServiceClient) ProcessBatchStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BatchProcessorService_ServiceDesc.Streams[0], BatchProcessorService_ProcessBatchStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BatchProcessorService_ProcessBatchStreamClient = grpc.BidiStreamingClient[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]
+
 func (c *batchProcessorServiceClient) Close(ctx context.Context, in *BatchProcessorCloseRequest, opts ...grpc.CallOption) (*BatchProcessorCloseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BatchProcessorCloseResponse)
@@ -121,17 +145,18 @@ func (c *batchProcessorServiceClient) Close(ctx context.Context, in *BatchProces
 // All implementations must embed UnimplementedBatchProcessorServiceServer
 // for forward compatibility.
 //
-// BatchProcessor is a Benthos processor implementation that works against batches
-// of messages, which allows windowed processing.
+// BatchProcessor is a Benthos processor implementation that works against
+// batches of messages, which allows windowed processing.
 //
 // Message batches must be created by upstream components (inputs, buffers, etc)
-// otherwise this processor will simply receive batches containing single messages.
+// otherwise this processor will simply receive batches containing single
+// messages.
 type BatchProcessorServiceServer interface {
-	// Init is the first method called for a batch processor and it passes the user's
-	// configuration to the input.
+	// Init is the first method called for a batch processor and it passes the
+	// user's configuration to the input.
 	//
-	// The schema for the processor configuration is specified in the `plugin.yaml` file
-	// provided to Redpanda Connect.
+	// The schema for the processor configuration is specified in the
+	// `plugin.yaml` file provided to Redpanda Connect.
 	Init(context.Context, *BatchProcessorInitRequest) (*BatchProcessorInitResponse, error)
 	// Process a batch of messages into one or more resulting batches, or return
 	// an error if the entire batch could not be processed. If zero messages are
@@ -152,9 +177,18 @@ type BatchProcessorServiceServer interface {
 	// and CANNOT be custom instantiations of Message. In order to copy the
 	// provided messages use the Copy method.
 	ProcessBatch(context.Context, *BatchProcessorProcessBatchRequest) (*BatchProcessorProcessBatchResponse, error)
+	// ProcessBatchStream is a streaming variant of ProcessBatch that allows the
+	// host to have multiple batches in flight with the plugin at once.
+	//
+	// Each request carries an ID that must be echoed in the corresponding
+	// response, responses may be sent in any order. The semantics of each
+	// individual batch are the same as ProcessBatch.
+	//
+	// This method is only used by the host if the plugin advertises support for
+	// it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.
+	ProcessBatchStream(grpc.BidiStreamingServer[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]) error
 	// Close the component, blocks until either the underlying resources are
-	// cleaned up or the context is cancelled. Returns an error if the context
-	// is cancelled.
+	// cleaned up or the RPC deadline is reached.
 	Close(context.Context, *BatchProcessorCloseRequest) (*BatchProcessorCloseResponse, error)
 	mustEmbedUnimplementedBatchProcessorServiceServer()
 }
@@ -172,6 +206,9 @@ func (UnimplementedBatchProcessorServiceServer) Init(context.Context, *BatchProc
 func (UnimplementedBatchProcessorServiceServer) ProcessBatch(context.Context, *BatchProcessorProcessBatchRequest) (*BatchProcessorProcessBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessBatch not implemented")
 }
+func (UnimplementedBatchProcessorServiceServer) ProcessBatchStream(grpc.BidiStreamingServer[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessBatchStream not implemented")
+}
 func (UnimplementedBatchProcessorServiceServer) Close(context.Context, *BatchProcessorCloseRequest) (*BatchProcessorCloseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Close not implemented")
 }
@@ -232,6 +269,13 @@ func _BatchProcessorService_ProcessBatch_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BatchProcessorService_ProcessBatchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BatchProcessorServiceServer).ProcessBatchStream(&grpc.GenericServerStream[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BatchProcessorService_ProcessBatchStreamServer = grpc.BidiStreamingServer[BatchProcessorProcessBatchStreamRequest, BatchProcessorProcessBatchStreamResponse]
+
 func _BatchProcessorService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BatchProcessorCloseRequest)
 	if err := dec(in); err != nil {
@@ -270,6 +314,13 @@ var BatchProcessorService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BatchProcessorService_Close_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessBatchStream",
+			Handler:       _BatchProcessorService_ProcessBatchStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "redpanda/runtime/v1alpha1/processor.proto",
 }
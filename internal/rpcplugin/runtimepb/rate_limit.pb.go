@@ -0,0 +1,390 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: redpanda/runtime/v1alpha1/rate_limit.proto
+
+package runtimepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RateLimitInitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The parsed configuration from the user based on the register schema in
+	// `plugin.yaml`.
+	Config        *Value `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitInitRequest) Reset() {
+	*x = RateLimitInitRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitInitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitInitRequest) ProtoMessage() {}
+
+func (x *RateLimitInitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitInitRequest.ProtoReflect.Descriptor instead.
+func (*RateLimitInitRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RateLimitInitRequest) GetConfig() *Value {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type RateLimitInitResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the rate limit configuration is invalid and an error
+	// should be surfaced at pipeline construction time.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitInitResponse) Reset() {
+	*x = RateLimitInitResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitInitResponse) ProtoMessage() {}
+
+func (x *RateLimitInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitInitResponse.ProtoReflect.Descriptor instead.
+func (*RateLimitInitResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RateLimitInitResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type RateLimitAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitAccessRequest) Reset() {
+	*x = RateLimitAccessRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitAccessRequest) ProtoMessage() {}
+
+func (x *RateLimitAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitAccessRequest.ProtoReflect.Descriptor instead.
+func (*RateLimitAccessRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{2}
+}
+
+type RateLimitAccessResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The period of time to wait before the resource can be accessed. If zero
+	// or absent then access is granted.
+	Wait *durationpb.Duration `protobuf:"bytes,1,opt,name=wait,proto3" json:"wait,omitempty"`
+	// If present, then the access attempt failed.
+	Error         *Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitAccessResponse) Reset() {
+	*x = RateLimitAccessResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitAccessResponse) ProtoMessage() {}
+
+func (x *RateLimitAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitAccessResponse.ProtoReflect.Descriptor instead.
+func (*RateLimitAccessResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RateLimitAccessResponse) GetWait() *durationpb.Duration {
+	if x != nil {
+		return x.Wait
+	}
+	return nil
+}
+
+func (x *RateLimitAccessResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type RateLimitCloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitCloseRequest) Reset() {
+	*x = RateLimitCloseRequest{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitCloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitCloseRequest) ProtoMessage() {}
+
+func (x *RateLimitCloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitCloseRequest.ProtoReflect.Descriptor instead.
+func (*RateLimitCloseRequest) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{4}
+}
+
+type RateLimitCloseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If present, then the close attempt failed.
+	Error         *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitCloseResponse) Reset() {
+	*x = RateLimitCloseResponse{}
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitCloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitCloseResponse) ProtoMessage() {}
+
+func (x *RateLimitCloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitCloseResponse.ProtoReflect.Descriptor instead.
+func (*RateLimitCloseResponse) Descriptor() ([]byte, []int) {
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RateLimitCloseResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+var File_redpanda_runtime_v1alpha1_rate_limit_proto protoreflect.FileDescriptor
+
+const file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDesc = "" +
+	"\n" +
+	"*redpanda/runtime/v1alpha1/rate_limit.proto\x12\x19redpanda.runtime.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a'redpanda/runtime/v1alpha1/message.proto\"P\n" +
+	"\x14RateLimitInitRequest\x128\n" +
+	"\x06config\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ValueR\x06config\"O\n" +
+	"\x15RateLimitInitResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"\x18\n" +
+	"\x16RateLimitAccessRequest\"\x80\x01\n" +
+	"\x17RateLimitAccessResponse\x12-\n" +
+	"\x04wait\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x04wait\x126\n" +
+	"\x05error\x18\x02 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error\"\x17\n" +
+	"\x15RateLimitCloseRequest\"P\n" +
+	"\x16RateLimitCloseResponse\x126\n" +
+	"\x05error\x18\x01 \x01(\v2 .redpanda.runtime.v1alpha1.ErrorR\x05error2\xe2\x02\n" +
+	"\x10RateLimitService\x12k\n" +
+	"\x04Init\x12/.redpanda.runtime.v1alpha1.RateLimitInitRequest\x1a0.redpanda.runtime.v1alpha1.RateLimitInitResponse\"\x00\x12q\n" +
+	"\x06Access\x121.redpanda.runtime.v1alpha1.RateLimitAccessRequest\x1a2.redpanda.runtime.v1alpha1.RateLimitAccessResponse\"\x00\x12n\n" +
+	"\x05Close\x120.redpanda.runtime.v1alpha1.RateLimitCloseRequest\x1a1.redpanda.runtime.v1alpha1.RateLimitCloseResponse\"\x00BBZ@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepbb\x06proto3"
+
+var (
+	file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescOnce sync.Once
+	file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescData []byte
+)
+
+func file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescGZIP() []byte {
+	file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescOnce.Do(func() {
+		file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDesc)))
+	})
+	return file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDescData
+}
+
+var file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_redpanda_runtime_v1alpha1_rate_limit_proto_goTypes = []any{
+	(*RateLimitInitRequest)(nil),    // 0: redpanda.runtime.v1alpha1.RateLimitInitRequest
+	(*RateLimitInitResponse)(nil),   // 1: redpanda.runtime.v1alpha1.RateLimitInitResponse
+	(*RateLimitAccessRequest)(nil),  // 2: redpanda.runtime.v1alpha1.RateLimitAccessRequest
+	(*RateLimitAccessResponse)(nil), // 3: redpanda.runtime.v1alpha1.RateLimitAccessResponse
+	(*RateLimitCloseRequest)(nil),   // 4: redpanda.runtime.v1alpha1.RateLimitCloseRequest
+	(*RateLimitCloseResponse)(nil),  // 5: redpanda.runtime.v1alpha1.RateLimitCloseResponse
+	(*Value)(nil),                   // 6: redpanda.runtime.v1alpha1.Value
+	(*Error)(nil),                   // 7: redpanda.runtime.v1alpha1.Error
+	(*durationpb.Duration)(nil),     // 8: google.protobuf.Duration
+}
+var file_redpanda_runtime_v1alpha1_rate_limit_proto_depIdxs = []int32{
+	6, // 0: redpanda.runtime.v1alpha1.RateLimitInitRequest.config:type_name -> redpanda.runtime.v1alpha1.Value
+	7, // 1: redpanda.runtime.v1alpha1.RateLimitInitResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	8, // 2: redpanda.runtime.v1alpha1.RateLimitAccessResponse.wait:type_name -> google.protobuf.Duration
+	7, // 3: redpanda.runtime.v1alpha1.RateLimitAccessResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	7, // 4: redpanda.runtime.v1alpha1.RateLimitCloseResponse.error:type_name -> redpanda.runtime.v1alpha1.Error
+	0, // 5: redpanda.runtime.v1alpha1.RateLimitService.Init:input_type -> redpanda.runtime.v1alpha1.RateLimitInitRequest
+	2, // 6: redpanda.runtime.v1alpha1.RateLimitService.Access:input_type -> redpanda.runtime.v1alpha1.RateLimitAccessRequest
+	4, // 7: redpanda.runtime.v1alpha1.RateLimitService.Close:input_type -> redpanda.runtime.v1alpha1.RateLimitCloseRequest
+	1, // 8: redpanda.runtime.v1alpha1.RateLimitService.Init:output_type -> redpanda.runtime.v1alpha1.RateLimitInitResponse
+	3, // 9: redpanda.runtime.v1alpha1.RateLimitService.Access:output_type -> redpanda.runtime.v1alpha1.RateLimitAccessResponse
+	5, // 10: redpanda.runtime.v1alpha1.RateLimitService.Close:output_type -> redpanda.runtime.v1alpha1.RateLimitCloseResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_redpanda_runtime_v1alpha1_rate_limit_proto_init() }
+func file_redpanda_runtime_v1alpha1_rate_limit_proto_init() {
+	if File_redpanda_runtime_v1alpha1_rate_limit_proto != nil {
+		return
+	}
+	file_redpanda_runtime_v1alpha1_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDesc), len(file_redpanda_runtime_v1alpha1_rate_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_redpanda_runtime_v1alpha1_rate_limit_proto_goTypes,
+		DependencyIndexes: file_redpanda_runtime_v1alpha1_rate_limit_proto_depIdxs,
+		MessageInfos:      file_redpanda_runtime_v1alpha1_rate_limit_proto_msgTypes,
+	}.Build()
+	File_redpanda_runtime_v1alpha1_rate_limit_proto = out.File
+	file_redpanda_runtime_v1alpha1_rate_limit_proto_goTypes = nil
+	file_redpanda_runtime_v1alpha1_rate_limit_proto_depIdxs = nil
+}
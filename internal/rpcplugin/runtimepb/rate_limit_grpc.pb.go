@@ -0,0 +1,241 @@
+// Copyright 2025 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: redpanda/runtime/v1alpha1/rate_limit.proto
+
+package runtimepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RateLimitService_Init_FullMethodName   = "/redpanda.runtime.v1alpha1.RateLimitService/Init"
+	RateLimitService_Access_FullMethodName = "/redpanda.runtime.v1alpha1.RateLimitService/Access"
+	RateLimitService_Close_FullMethodName  = "/redpanda.runtime.v1alpha1.RateLimitService/Close"
+)
+
+// RateLimitServiceClient is the client API for RateLimitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RateLimit is a strategy for limiting access to a shared resource, which can
+// be used by Redpanda Connect components in order to throttle their access.
+//
+// The Access method may be called concurrently.
+type RateLimitServiceClient interface {
+	// Init is the first method called for a rate limit and it passes the user's
+	// configuration to the rate limit.
+	//
+	// The schema for the rate limit configuration is specified in the
+	// `plugin.yaml` file provided to Redpanda Connect.
+	Init(ctx context.Context, in *RateLimitInitRequest, opts ...grpc.CallOption) (*RateLimitInitResponse, error)
+	// Access the rate limited resource. Returns a duration that the caller must
+	// wait before attempting access, a zero (or absent) duration indicates that
+	// access is granted immediately.
+	Access(ctx context.Context, in *RateLimitAccessRequest, opts ...grpc.CallOption) (*RateLimitAccessResponse, error)
+	// Close the component, blocks until either the underlying resources are
+	// cleaned up or the RPC deadline is reached.
+	Close(ctx context.Context, in *RateLimitCloseRequest, opts ...grpc.CallOption) (*RateLimitCloseResponse, error)
+}
+
+type rateLimitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRateLimitServiceClient(cc grpc.ClientConnInterface) RateLimitServiceClient {
+	return &rateLimitServiceClient{cc}
+}
+
+func (c *rateLimitServiceClient) Init(ctx context.Context, in *RateLimitInitRequest, opts ...grpc.CallOption) (*RateLimitInitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitInitResponse)
+	err := c.cc.Invoke(ctx, RateLimitService_Init_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateLimitServiceClient) Access(ctx context.Context, in *RateLimitAccessRequest, opts ...grpc.CallOption) (*RateLimitAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitAccessResponse)
+	err := c.cc.Invoke(ctx, RateLimitService_Access_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateLimitServiceClient) Close(ctx context.Context, in *RateLimitCloseRequest, opts ...grpc.CallOption) (*RateLimitCloseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitCloseResponse)
+	err := c.cc.Invoke(ctx, RateLimitService_Close_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RateLimitServiceServer is the server API for RateLimitService service.
+// All implementations must embed UnimplementedRateLimitServiceServer
+// for forward compatibility.
+//
+// RateLimit is a strategy for limiting access to a shared resource, which can
+// be used by Redpanda Connect components in order to throttle their access.
+//
+// The Access method may be called concurrently.
+type RateLimitServiceServer interface {
+	// Init is the first method called for a rate limit and it passes the user's
+	// configuration to the rate limit.
+	//
+	// The schema for the rate limit configuration is specified in the
+	// `plugin.yaml` file provided to Redpanda Connect.
+	Init(context.Context, *RateLimitInitRequest) (*RateLimitInitResponse, error)
+	// Access the rate limited resource. Returns a duration that the caller must
+	// wait before attempting access, a zero (or absent) duration indicates that
+	// access is granted immediately.
+	Access(context.Context, *RateLimitAccessRequest) (*RateLimitAccessResponse, error)
+	// Close the component, blocks until either the underlying resources are
+	// cleaned up or the RPC deadline is reached.
+	Close(context.Context, *RateLimitCloseRequest) (*RateLimitCloseResponse, error)
+	mustEmbedUnimplementedRateLimitServiceServer()
+}
+
+// UnimplementedRateLimitServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRateLimitServiceServer struct{}
+
+func (UnimplementedRateLimitServiceServer) Init(context.Context, *RateLimitInitRequest) (*RateLimitInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (UnimplementedRateLimitServiceServer) Access(context.Context, *RateLimitAccessRequest) (*RateLimitAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Access not implemented")
+}
+func (UnimplementedRateLimitServiceServer) Close(context.Context, *RateLimitCloseRequest) (*RateLimitCloseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Close not implemented")
+}
+func (UnimplementedRateLimitServiceServer) mustEmbedUnimplementedRateLimitServiceServer() {}
+func (UnimplementedRateLimitServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeRateLimitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RateLimitServiceServer will
+// result in compilation errors.
+type UnsafeRateLimitServiceServer interface {
+	mustEmbedUnimplementedRateLimitServiceServer()
+}
+
+func RegisterRateLimitServiceServer(s grpc.ServiceRegistrar, srv RateLimitServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRateLimitServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RateLimitService_ServiceDesc, srv)
+}
+
+func _RateLimitService_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateLimitServiceServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateLimitService_Init_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateLimitServiceServer).Init(ctx, req.(*RateLimitInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateLimitService_Access_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateLimitServiceServer).Access(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateLimitService_Access_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateLimitServiceServer).Access(ctx, req.(*RateLimitAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateLimitService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitCloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateLimitServiceServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateLimitService_Close_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateLimitServiceServer).Close(ctx, req.(*RateLimitCloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RateLimitService_ServiceDesc is the grpc.ServiceDesc for RateLimitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RateLimitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "redpanda.runtime.v1alpha1.RateLimitService",
+	HandlerType: (*RateLimitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _RateLimitService_Init_Handler,
+		},
+		{
+			MethodName: "Access",
+			Handler:    _RateLimitService_Access_Handler,
+		},
+		{
+			MethodName: "Close",
+			Handler:    _RateLimitService_Close_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "redpanda/runtime/v1alpha1/rate_limit.proto",
+}
@@ -36,11 +36,13 @@ import (
 	_ "github.com/redpanda-data/connect/v4/public/components/cypher"
 	_ "github.com/redpanda-data/connect/v4/public/components/dgraph"
 	_ "github.com/redpanda-data/connect/v4/public/components/discord"
+	_ "github.com/redpanda-data/connect/v4/public/components/disk"
 	_ "github.com/redpanda-data/connect/v4/public/components/elasticsearch/v8"
 	_ "github.com/redpanda-data/connect/v4/public/components/ffi"
 	_ "github.com/redpanda-data/connect/v4/public/components/gcp"
 	_ "github.com/redpanda-data/connect/v4/public/components/git"
 	_ "github.com/redpanda-data/connect/v4/public/components/hdfs"
+	_ "github.com/redpanda-data/connect/v4/public/components/iceberg"
 	_ "github.com/redpanda-data/connect/v4/public/components/influxdb"
 	_ "github.com/redpanda-data/connect/v4/public/components/io"
 	_ "github.com/redpanda-data/connect/v4/public/components/jaeger"
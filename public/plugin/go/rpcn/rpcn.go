@@ -27,7 +27,9 @@ package rpcn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -36,14 +38,19 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/redpanda-data/benthos/v4/public/service"
 	"github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb"
 )
 
 // ProcessorConstructor is the factory function to create a new batch processor.
+//
+// The returned processor may have ProcessBatch called concurrently, as the
+// host can keep multiple batches in flight with the plugin at once.
 type ProcessorConstructor[T any] func(config T) (processor service.BatchProcessor, err error)
 
 type processor struct {
@@ -55,8 +62,9 @@ type processor struct {
 
 // Init implements runtimepb.BatchProcessorServiceServer.
 func (p *processor) Init(_ context.Context, req *runtimepb.BatchProcessorInitRequest) (*runtimepb.BatchProcessorInitResponse, error) {
+	caps := &runtimepb.BatchProcessorCapabilities{Streaming: true}
 	if p.component != nil {
-		return &runtimepb.BatchProcessorInitResponse{Error: nil}, nil
+		return &runtimepb.BatchProcessorInitResponse{Error: nil, Capabilities: caps}, nil
 	}
 	config, err := runtimepb.ValueToAny(req.Config)
 	if err != nil {
@@ -67,33 +75,80 @@ func (p *processor) Init(_ context.Context, req *runtimepb.BatchProcessorInitReq
 		return &runtimepb.BatchProcessorInitResponse{Error: runtimepb.ErrorToProto(err)}, nil
 	}
 	p.component = component
-	return &runtimepb.BatchProcessorInitResponse{Error: nil}, nil
+	return &runtimepb.BatchProcessorInitResponse{Error: nil, Capabilities: caps}, nil
 }
 
-// ProcessBatch implements runtimepb.BatchProcessorServiceServer.
-func (p *processor) ProcessBatch(ctx context.Context, req *runtimepb.BatchProcessorProcessBatchRequest) (*runtimepb.BatchProcessorProcessBatchResponse, error) {
+func (p *processor) process(ctx context.Context, proto *runtimepb.MessageBatch) ([]*runtimepb.MessageBatch, error) {
 	if p.component == nil {
-		return &runtimepb.BatchProcessorProcessBatchResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+		return nil, service.ErrNotConnected
 	}
-	batch, err := runtimepb.ProtoToMessageBatch(req.Batch)
+	batch, err := runtimepb.ProtoToMessageBatch(proto)
 	if err != nil {
-		return &runtimepb.BatchProcessorProcessBatchResponse{Error: runtimepb.ErrorToProto(err)}, nil
+		return nil, err
 	}
 	batches, err := p.component.ProcessBatch(ctx, batch)
 	if err != nil {
-		return &runtimepb.BatchProcessorProcessBatchResponse{Error: runtimepb.ErrorToProto(err)}, nil
+		return nil, err
 	}
 	protos := make([]*runtimepb.MessageBatch, 0, len(batches))
 	for _, batch := range batches {
 		proto, err := runtimepb.MessageBatchToProto(batch)
 		if err != nil {
-			return &runtimepb.BatchProcessorProcessBatchResponse{Error: runtimepb.ErrorToProto(err)}, nil
+			return nil, err
 		}
 		protos = append(protos, proto)
 	}
+	return protos, nil
+}
+
+// ProcessBatch implements runtimepb.BatchProcessorServiceServer.
+func (p *processor) ProcessBatch(ctx context.Context, req *runtimepb.BatchProcessorProcessBatchRequest) (*runtimepb.BatchProcessorProcessBatchResponse, error) {
+	protos, err := p.process(ctx, req.Batch)
+	if err != nil {
+		return &runtimepb.BatchProcessorProcessBatchResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
 	return &runtimepb.BatchProcessorProcessBatchResponse{Batches: protos}, nil
 }
 
+// ProcessBatchStream implements runtimepb.BatchProcessorServiceServer.
+func (p *processor) ProcessBatchStream(stream runtimepb.BatchProcessorService_ProcessBatchStreamServer) error {
+	ctx := stream.Context()
+	var (
+		wg      sync.WaitGroup
+		sendMu  sync.Mutex
+		sendErr error
+	)
+	send := func(resp *runtimepb.BatchProcessorProcessBatchStreamResponse) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(resp)
+		}
+	}
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			// Flush the responses of any batches still in flight before
+			// returning, the host half-closes the stream on shutdown.
+			wg.Wait()
+			if errors.Is(err, io.EOF) {
+				return sendErr
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			protos, err := p.process(ctx, req.Batch)
+			if err != nil {
+				send(&runtimepb.BatchProcessorProcessBatchStreamResponse{Id: req.Id, Error: runtimepb.ErrorToProto(err)})
+				return
+			}
+			send(&runtimepb.BatchProcessorProcessBatchStreamResponse{Id: req.Id, Batches: protos})
+		}()
+	}
+}
+
 // Close implements runtimepb.BatchProcessorServiceServer.
 func (p *processor) Close(ctx context.Context, _ *runtimepb.BatchProcessorCloseRequest) (*runtimepb.BatchProcessorCloseResponse, error) {
 	if p.component == nil {
@@ -304,6 +359,175 @@ func GenericInputMain(ctor InputConstructor[any]) {
 	})
 }
 
+// CacheConstructor is the factory function to create a new cache.
+type CacheConstructor[T any] func(config T) (cache service.Cache, err error)
+
+type cache struct {
+	runtimepb.UnimplementedCacheServiceServer
+
+	ctor      CacheConstructor[any]
+	component service.Cache
+}
+
+// Init implements runtimepb.CacheServiceServer.
+func (c *cache) Init(_ context.Context, req *runtimepb.CacheInitRequest) (*runtimepb.CacheInitResponse, error) {
+	if c.component != nil {
+		return &runtimepb.CacheInitResponse{Error: nil}, nil
+	}
+	config, err := runtimepb.ValueToAny(req.Config)
+	if err != nil {
+		return &runtimepb.CacheInitResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	component, err := c.ctor(config)
+	if err != nil {
+		return &runtimepb.CacheInitResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	c.component = component
+	return &runtimepb.CacheInitResponse{Error: nil}, nil
+}
+
+func ttlFromProto(d *durationpb.Duration) *time.Duration {
+	if d == nil {
+		return nil
+	}
+	ttl := d.AsDuration()
+	return &ttl
+}
+
+// Get implements runtimepb.CacheServiceServer.
+func (c *cache) Get(ctx context.Context, req *runtimepb.CacheGetRequest) (*runtimepb.CacheGetResponse, error) {
+	if c.component == nil {
+		return &runtimepb.CacheGetResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+	}
+	value, err := c.component.Get(ctx, req.Key)
+	if err != nil {
+		return &runtimepb.CacheGetResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	return &runtimepb.CacheGetResponse{Value: value}, nil
+}
+
+// Set implements runtimepb.CacheServiceServer.
+func (c *cache) Set(ctx context.Context, req *runtimepb.CacheSetRequest) (*runtimepb.CacheSetResponse, error) {
+	if c.component == nil {
+		return &runtimepb.CacheSetResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+	}
+	err := c.component.Set(ctx, req.Key, req.Value, ttlFromProto(req.Ttl))
+	return &runtimepb.CacheSetResponse{Error: runtimepb.ErrorToProto(err)}, nil
+}
+
+// Add implements runtimepb.CacheServiceServer.
+func (c *cache) Add(ctx context.Context, req *runtimepb.CacheAddRequest) (*runtimepb.CacheAddResponse, error) {
+	if c.component == nil {
+		return &runtimepb.CacheAddResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+	}
+	err := c.component.Add(ctx, req.Key, req.Value, ttlFromProto(req.Ttl))
+	return &runtimepb.CacheAddResponse{Error: runtimepb.ErrorToProto(err)}, nil
+}
+
+// Delete implements runtimepb.CacheServiceServer.
+func (c *cache) Delete(ctx context.Context, req *runtimepb.CacheDeleteRequest) (*runtimepb.CacheDeleteResponse, error) {
+	if c.component == nil {
+		return &runtimepb.CacheDeleteResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+	}
+	err := c.component.Delete(ctx, req.Key)
+	return &runtimepb.CacheDeleteResponse{Error: runtimepb.ErrorToProto(err)}, nil
+}
+
+// Close implements runtimepb.CacheServiceServer.
+func (c *cache) Close(ctx context.Context, _ *runtimepb.CacheCloseRequest) (*runtimepb.CacheCloseResponse, error) {
+	if c.component == nil {
+		return &runtimepb.CacheCloseResponse{Error: nil}, nil
+	}
+	err := c.component.Close(ctx)
+	return &runtimepb.CacheCloseResponse{Error: runtimepb.ErrorToProto(err)}, nil
+}
+
+// CacheMain should be called in your main function to initialize the RPC plugin service and serve cache requests.
+// The configuration object given to the constructor is strongly typed, and deserialized using encoding/json rules.
+func CacheMain[T any](ctor CacheConstructor[T]) {
+	GenericCacheMain(func(config any) (service.Cache, error) {
+		typed, err := typedFromAny[T](config)
+		if err != nil {
+			return nil, err
+		}
+		return ctor(typed)
+	})
+}
+
+// GenericCacheMain is the same as CacheMain except that it does not give a strongly typed configuration object
+func GenericCacheMain(ctor CacheConstructor[any]) {
+	runMain(func(s *grpc.Server) {
+		runtimepb.RegisterCacheServiceServer(s, &cache{ctor: ctor})
+	})
+}
+
+// RateLimitConstructor is the factory function to create a new rate limit.
+type RateLimitConstructor[T any] func(config T) (rateLimit service.RateLimit, err error)
+
+type rateLimit struct {
+	runtimepb.UnimplementedRateLimitServiceServer
+
+	ctor      RateLimitConstructor[any]
+	component service.RateLimit
+}
+
+// Init implements runtimepb.RateLimitServiceServer.
+func (r *rateLimit) Init(_ context.Context, req *runtimepb.RateLimitInitRequest) (*runtimepb.RateLimitInitResponse, error) {
+	if r.component != nil {
+		return &runtimepb.RateLimitInitResponse{Error: nil}, nil
+	}
+	config, err := runtimepb.ValueToAny(req.Config)
+	if err != nil {
+		return &runtimepb.RateLimitInitResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	component, err := r.ctor(config)
+	if err != nil {
+		return &runtimepb.RateLimitInitResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	r.component = component
+	return &runtimepb.RateLimitInitResponse{Error: nil}, nil
+}
+
+// Access implements runtimepb.RateLimitServiceServer.
+func (r *rateLimit) Access(ctx context.Context, _ *runtimepb.RateLimitAccessRequest) (*runtimepb.RateLimitAccessResponse, error) {
+	if r.component == nil {
+		return &runtimepb.RateLimitAccessResponse{Error: runtimepb.ErrorToProto(service.ErrNotConnected)}, nil
+	}
+	wait, err := r.component.Access(ctx)
+	if err != nil {
+		return &runtimepb.RateLimitAccessResponse{Error: runtimepb.ErrorToProto(err)}, nil
+	}
+	return &runtimepb.RateLimitAccessResponse{Wait: durationpb.New(wait)}, nil
+}
+
+// Close implements runtimepb.RateLimitServiceServer.
+func (r *rateLimit) Close(ctx context.Context, _ *runtimepb.RateLimitCloseRequest) (*runtimepb.RateLimitCloseResponse, error) {
+	if r.component == nil {
+		return &runtimepb.RateLimitCloseResponse{Error: nil}, nil
+	}
+	err := r.component.Close(ctx)
+	return &runtimepb.RateLimitCloseResponse{Error: runtimepb.ErrorToProto(err)}, nil
+}
+
+// RateLimitMain should be called in your main function to initialize the RPC plugin service and serve rate limit requests.
+// The configuration object given to the constructor is strongly typed, and deserialized using encoding/json rules.
+func RateLimitMain[T any](ctor RateLimitConstructor[T]) {
+	GenericRateLimitMain(func(config any) (service.RateLimit, error) {
+		typed, err := typedFromAny[T](config)
+		if err != nil {
+			return nil, err
+		}
+		return ctor(typed)
+	})
+}
+
+// GenericRateLimitMain is the same as RateLimitMain except that it does not give a strongly typed configuration object
+func GenericRateLimitMain(ctor RateLimitConstructor[any]) {
+	runMain(func(s *grpc.Server) {
+		runtimepb.RegisterRateLimitServiceServer(s, &rateLimit{ctor: ctor})
+	})
+}
+
 func typedFromAny[T any](v any) (result T, err error) {
 	b, err := json.Marshal(v)
 	if err != nil {
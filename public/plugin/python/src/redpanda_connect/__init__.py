@@ -13,13 +13,16 @@
 # limitations under the License.
 
 """
-A Python package for writing Redpanda Connect components (inputs, processors and outputs).
+A Python package for writing Redpanda Connect components (inputs, processors, outputs, caches
+and rate limits).
 """
 
-from ._grpc import input_main, output_main, processor_main
+from ._grpc import cache_main, input_main, output_main, processor_main, rate_limit_main
 from .core import (
+    Cache,
     Message,
     MessageBatch,
+    RateLimit,
     Value,
     batch_input,
     batch_processor,
@@ -31,6 +34,8 @@ from .errors import (
     BackoffError,
     BaseError,
     EndOfInputError,
+    KeyAlreadyExistsError,
+    KeyNotFoundError,
     NotConnectedError,
 )
 
@@ -38,6 +43,8 @@ __all__ = [
     "input_main",
     "output_main",
     "processor_main",
+    "cache_main",
+    "rate_limit_main",
     "Message",
     "MessageBatch",
     "batch_input",
@@ -46,8 +53,12 @@ __all__ = [
     "input",
     "processor",
     "output",
+    "Cache",
+    "RateLimit",
     "BaseError",
     "BackoffError",
     "NotConnectedError",
     "EndOfInputError",
+    "KeyNotFoundError",
+    "KeyAlreadyExistsError",
 ]
@@ -20,7 +20,14 @@ from google.protobuf import duration_pb2, timestamp_pb2
 
 from ._proto.redpanda.runtime.v1alpha1 import message_pb2
 from .core import Message, Value
-from .errors import BackoffError, BaseError, EndOfInputError, NotConnectedError
+from .errors import (
+    BackoffError,
+    BaseError,
+    EndOfInputError,
+    KeyAlreadyExistsError,
+    KeyNotFoundError,
+    NotConnectedError,
+)
 
 
 def proto_to_value(proto: message_pb2.Value) -> Value:
@@ -103,6 +110,10 @@ def proto_to_error(proto: message_pb2.Error) -> BaseError | None:
         return BackoffError(proto.message, duration)
     elif detail == "end_of_input":
         return EndOfInputError()
+    elif detail == "key_not_found":
+        return KeyNotFoundError()
+    elif detail == "key_already_exists":
+        return KeyAlreadyExistsError()
     else:
         return BaseError(proto.message)
 
@@ -118,6 +129,14 @@ def error_to_proto(error: BaseError) -> message_pb2.Error:
         return message_pb2.Error(message=error.message, backoff=duration)
     if isinstance(error, EndOfInputError):
         return message_pb2.Error(message=error.message, end_of_input=message_pb2.Error.EndOfInput())
+    if isinstance(error, KeyNotFoundError):
+        return message_pb2.Error(
+            message=error.message, key_not_found=message_pb2.Error.KeyNotFound()
+        )
+    if isinstance(error, KeyAlreadyExistsError):
+        return message_pb2.Error(
+            message=error.message, key_already_exists=message_pb2.Error.KeyAlreadyExists()
+        )
     return message_pb2.Error(message=error.message)
 
 
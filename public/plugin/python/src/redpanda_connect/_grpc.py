@@ -18,28 +18,37 @@ import os
 import signal
 import sys
 from datetime import timedelta
-from typing import Callable, final, override
+from typing import AsyncIterator, Callable, final, override
 
 import grpc  # pyright: ignore[reportMissingTypeStubs]
 import grpc.aio  # pyright: ignore[reportMissingTypeStubs]
 
 from ._convert import batch_to_proto, error_to_proto, proto_to_batch, proto_to_error, proto_to_value
 from ._proto.redpanda.runtime.v1alpha1 import (
+    cache_pb2,
+    cache_pb2_grpc,
     input_pb2,
     input_pb2_grpc,
+    message_pb2,
     output_pb2,
     output_pb2_grpc,
     processor_pb2,
     processor_pb2_grpc,
+    rate_limit_pb2,
+    rate_limit_pb2_grpc,
 )
 from .core import (
     AckFn,
+    Cache,
+    CacheConstructor,
     Input,
     InputConstructor,
     Output,
     OutputConstructor,
     Processor,
     ProcessorConstructor,
+    RateLimit,
+    RateLimitConstructor,
 )
 from .errors import BaseError
 
@@ -191,12 +200,31 @@ class _ProcessorService(processor_pb2_grpc.BatchProcessorServiceServicer):
         resp = processor_pb2.BatchProcessorInitResponse()
         try:
             self.component = self.ctor(proto_to_value(request.config))
+            resp.capabilities.streaming = True
         except BaseError as e:
             resp.error.CopyFrom(error_to_proto(e))
         except Exception as e:
             resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to initialize output: {e}")))
         return resp
 
+    async def _process(
+        self,
+        batch: message_pb2.MessageBatch,
+        resp: processor_pb2.BatchProcessorProcessBatchResponse
+        | processor_pb2.BatchProcessorProcessBatchStreamResponse,
+    ) -> None:
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Processor not initialized")))
+            return
+        try:
+            batches = await self.component.process(proto_to_batch(batch))
+            for b in batches:
+                resp.batches.append(batch_to_proto(b))
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to process batch: {e}")))
+
     @override
     async def ProcessBatch(
         self,
@@ -207,19 +235,42 @@ class _ProcessorService(processor_pb2_grpc.BatchProcessorServiceServicer):
         ],
     ) -> processor_pb2.BatchProcessorProcessBatchResponse:
         resp = processor_pb2.BatchProcessorProcessBatchResponse()
-        if self.component is None:
-            resp.error.CopyFrom(error_to_proto(BaseError("Processor not initialized")))
-            return resp
-        try:
-            batches = await self.component.process(proto_to_batch(request.batch))
-            for batch in batches:
-                resp.batches.append(batch_to_proto(batch))
-        except BaseError as e:
-            resp.error.CopyFrom(error_to_proto(e))
-        except Exception as e:
-            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to initialize output: {e}")))
+        await self._process(request.batch, resp)
         return resp
 
+    @override
+    async def ProcessBatchStream(
+        self,
+        request_iterator: AsyncIterator[processor_pb2.BatchProcessorProcessBatchStreamRequest],
+        context: grpc.aio.ServicerContext[
+            processor_pb2.BatchProcessorProcessBatchStreamRequest,
+            processor_pb2.BatchProcessorProcessBatchStreamResponse,
+        ],
+    ) -> AsyncIterator[processor_pb2.BatchProcessorProcessBatchStreamResponse]:
+        # Batches are processed concurrently and responses are yielded in the order that
+        # they complete, the host correlates them with requests using the ID.
+        responses: asyncio.Queue[processor_pb2.BatchProcessorProcessBatchStreamResponse | None] = (
+            asyncio.Queue()
+        )
+
+        async def handle(request: processor_pb2.BatchProcessorProcessBatchStreamRequest):
+            resp = processor_pb2.BatchProcessorProcessBatchStreamResponse(id=request.id)
+            await self._process(request.batch, resp)
+            await responses.put(resp)
+
+        async def read_requests():
+            try:
+                async with asyncio.TaskGroup() as tg:
+                    async for request in request_iterator:
+                        _ = tg.create_task(handle(request))
+            finally:
+                await responses.put(None)
+
+        reader = asyncio.create_task(read_requests())
+        while (resp := await responses.get()) is not None:
+            yield resp
+        await reader
+
     @override
     async def Close(
         self,
@@ -343,6 +394,200 @@ class _OutputService(output_pb2_grpc.BatchOutputServiceServicer):
         return resp
 
 
+@final
+class _CacheService(cache_pb2_grpc.CacheServiceServicer):
+    ctor: CacheConstructor
+    component: Cache | None = None
+    close_event: asyncio.Event
+
+    def __init__(self, ctor: CacheConstructor, close_event: asyncio.Event):
+        super().__init__()
+        self.ctor = ctor
+        self.close_event = close_event
+
+    @override
+    async def Init(
+        self,
+        request: cache_pb2.CacheInitRequest,
+        context: grpc.aio.ServicerContext[cache_pb2.CacheInitRequest, cache_pb2.CacheInitResponse],
+    ) -> cache_pb2.CacheInitResponse:
+        resp = cache_pb2.CacheInitResponse()
+        try:
+            self.component = self.ctor(proto_to_value(request.config))
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to initialize cache: {e}")))
+        return resp
+
+    @override
+    async def Get(
+        self,
+        request: cache_pb2.CacheGetRequest,
+        context: grpc.aio.ServicerContext[cache_pb2.CacheGetRequest, cache_pb2.CacheGetResponse],
+    ) -> cache_pb2.CacheGetResponse:
+        resp = cache_pb2.CacheGetResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Cache not initialized")))
+            return resp
+        try:
+            resp.value = await self.component.get(request.key)
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to get cache item: {e}")))
+        return resp
+
+    @override
+    async def Set(
+        self,
+        request: cache_pb2.CacheSetRequest,
+        context: grpc.aio.ServicerContext[cache_pb2.CacheSetRequest, cache_pb2.CacheSetResponse],
+    ) -> cache_pb2.CacheSetResponse:
+        resp = cache_pb2.CacheSetResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Cache not initialized")))
+            return resp
+        ttl = request.ttl.ToTimedelta() if request.HasField("ttl") else None
+        try:
+            await self.component.set(request.key, request.value, ttl)
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to set cache item: {e}")))
+        return resp
+
+    @override
+    async def Add(
+        self,
+        request: cache_pb2.CacheAddRequest,
+        context: grpc.aio.ServicerContext[cache_pb2.CacheAddRequest, cache_pb2.CacheAddResponse],
+    ) -> cache_pb2.CacheAddResponse:
+        resp = cache_pb2.CacheAddResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Cache not initialized")))
+            return resp
+        ttl = request.ttl.ToTimedelta() if request.HasField("ttl") else None
+        try:
+            await self.component.add(request.key, request.value, ttl)
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to add cache item: {e}")))
+        return resp
+
+    @override
+    async def Delete(
+        self,
+        request: cache_pb2.CacheDeleteRequest,
+        context: grpc.aio.ServicerContext[
+            cache_pb2.CacheDeleteRequest, cache_pb2.CacheDeleteResponse
+        ],
+    ) -> cache_pb2.CacheDeleteResponse:
+        resp = cache_pb2.CacheDeleteResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Cache not initialized")))
+            return resp
+        try:
+            await self.component.delete(request.key)
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to delete cache item: {e}")))
+        return resp
+
+    @override
+    async def Close(
+        self,
+        request: cache_pb2.CacheCloseRequest,
+        context: grpc.aio.ServicerContext[
+            cache_pb2.CacheCloseRequest, cache_pb2.CacheCloseResponse
+        ],
+    ) -> cache_pb2.CacheCloseResponse:
+        self.close_event.set()
+        resp = cache_pb2.CacheCloseResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Cache not initialized")))
+            return resp
+        try:
+            await self.component.close()
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to close cache: {e}")))
+        return resp
+
+
+@final
+class _RateLimitService(rate_limit_pb2_grpc.RateLimitServiceServicer):
+    ctor: RateLimitConstructor
+    component: RateLimit | None = None
+    close_event: asyncio.Event
+
+    def __init__(self, ctor: RateLimitConstructor, close_event: asyncio.Event):
+        super().__init__()
+        self.ctor = ctor
+        self.close_event = close_event
+
+    @override
+    async def Init(
+        self,
+        request: rate_limit_pb2.RateLimitInitRequest,
+        context: grpc.aio.ServicerContext[
+            rate_limit_pb2.RateLimitInitRequest, rate_limit_pb2.RateLimitInitResponse
+        ],
+    ) -> rate_limit_pb2.RateLimitInitResponse:
+        resp = rate_limit_pb2.RateLimitInitResponse()
+        try:
+            self.component = self.ctor(proto_to_value(request.config))
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to initialize rate limit: {e}")))
+        return resp
+
+    @override
+    async def Access(
+        self,
+        request: rate_limit_pb2.RateLimitAccessRequest,
+        context: grpc.aio.ServicerContext[
+            rate_limit_pb2.RateLimitAccessRequest, rate_limit_pb2.RateLimitAccessResponse
+        ],
+    ) -> rate_limit_pb2.RateLimitAccessResponse:
+        resp = rate_limit_pb2.RateLimitAccessResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Rate limit not initialized")))
+            return resp
+        try:
+            resp.wait.FromTimedelta(await self.component.access())
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to access rate limit: {e}")))
+        return resp
+
+    @override
+    async def Close(
+        self,
+        request: rate_limit_pb2.RateLimitCloseRequest,
+        context: grpc.aio.ServicerContext[
+            rate_limit_pb2.RateLimitCloseRequest, rate_limit_pb2.RateLimitCloseResponse
+        ],
+    ) -> rate_limit_pb2.RateLimitCloseResponse:
+        self.close_event.set()
+        resp = rate_limit_pb2.RateLimitCloseResponse()
+        if self.component is None:
+            resp.error.CopyFrom(error_to_proto(BaseError("Rate limit not initialized")))
+            return resp
+        try:
+            await self.component.close()
+        except BaseError as e:
+            resp.error.CopyFrom(error_to_proto(e))
+        except Exception as e:
+            resp.error.CopyFrom(error_to_proto(BaseError(f"Failed to close rate limit: {e}")))
+        return resp
+
+
 async def _serve_component(register: Callable[[grpc.aio.Server, asyncio.Event], None]):
     version = os.environ.get("REDPANDA_CONNECT_PLUGIN_VERSION", "1")
     if version != "1":
@@ -418,3 +663,31 @@ async def output_main(ctor: OutputConstructor):
         output_pb2_grpc.add_BatchOutputServiceServicer_to_server(output_service, server)
 
     await _serve_component(register)
+
+
+async def cache_main(ctor: CacheConstructor):
+    """
+    cache_main is the entry point for the cache plugin. It should be called in __main__
+    and will block until plugin shutdown.
+    """
+    logging.basicConfig(encoding="utf-8", level=logging.DEBUG)
+
+    def register(server: grpc.aio.Server, close_event: asyncio.Event):
+        cache_service = _CacheService(ctor, close_event)
+        cache_pb2_grpc.add_CacheServiceServicer_to_server(cache_service, server)
+
+    await _serve_component(register)
+
+
+async def rate_limit_main(ctor: RateLimitConstructor):
+    """
+    rate_limit_main is the entry point for the rate limit plugin. It should be called in
+    __main__ and will block until plugin shutdown.
+    """
+    logging.basicConfig(encoding="utf-8", level=logging.DEBUG)
+
+    def register(server: grpc.aio.Server, close_event: asyncio.Event):
+        rate_limit_service = _RateLimitService(ctor, close_event)
+        rate_limit_pb2_grpc.add_RateLimitServiceServicer_to_server(rate_limit_service, server)
+
+    await _serve_component(register)
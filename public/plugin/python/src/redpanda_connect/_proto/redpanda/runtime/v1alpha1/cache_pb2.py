@@ -0,0 +1,43 @@
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(_runtime_version.Domain.PUBLIC, 5, 29, 0, '', 'redpanda/runtime/v1alpha1/cache.proto')
+_sym_db = _symbol_database.Default()
+from google.protobuf import duration_pb2 as google_dot_protobuf_dot_duration__pb2
+from ....redpanda.runtime.v1alpha1 import message_pb2 as redpanda_dot_runtime_dot_v1alpha1_dot_message__pb2
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n%redpanda/runtime/v1alpha1/cache.proto\x12\x19redpanda.runtime.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\'redpanda/runtime/v1alpha1/message.proto"D\n\x10CacheInitRequest\x120\n\x06config\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Value"D\n\x11CacheInitResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"\x1e\n\x0fCacheGetRequest\x12\x0b\n\x03key\x18\x01 \x01(\t"R\n\x10CacheGetResponse\x12\r\n\x05value\x18\x01 \x01(\x0c\x12/\n\x05error\x18\x02 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"U\n\x0fCacheSetRequest\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c\x12&\n\x03ttl\x18\x03 \x01(\x0b2\x19.google.protobuf.Duration"C\n\x10CacheSetResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"U\n\x0fCacheAddRequest\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c\x12&\n\x03ttl\x18\x03 \x01(\x0b2\x19.google.protobuf.Duration"C\n\x10CacheAddResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"!\n\x12CacheDeleteRequest\x12\x0b\n\x03key\x18\x01 \x01(\t"F\n\x13CacheDeleteResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"\x13\n\x11CacheCloseRequest"E\n\x12CacheCloseResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error2\xec\x04\n\x0cCacheService\x12c\n\x04Init\x12+.redpanda.runtime.v1alpha1.CacheInitRequest\x1a,.redpanda.runtime.v1alpha1.CacheInitResponse"\x00\x12`\n\x03Get\x12*.redpanda.runtime.v1alpha1.CacheGetRequest\x1a+.redpanda.runtime.v1alpha1.CacheGetResponse"\x00\x12`\n\x03Set\x12*.redpanda.runtime.v1alpha1.CacheSetRequest\x1a+.redpanda.runtime.v1alpha1.CacheSetResponse"\x00\x12`\n\x03Add\x12*.redpanda.runtime.v1alpha1.CacheAddRequest\x1a+.redpanda.runtime.v1alpha1.CacheAddResponse"\x00\x12i\n\x06Delete\x12-.redpanda.runtime.v1alpha1.CacheDeleteRequest\x1a..redpanda.runtime.v1alpha1.CacheDeleteResponse"\x00\x12f\n\x05Close\x12,.redpanda.runtime.v1alpha1.CacheCloseRequest\x1a-.redpanda.runtime.v1alpha1.CacheCloseResponse"\x00BBZ@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepbb\x06proto3')
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'redpanda.runtime.v1alpha1.cache_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+    _globals['DESCRIPTOR']._loaded_options = None
+    _globals['DESCRIPTOR']._serialized_options = b'Z@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb'
+    _globals['_CACHEINITREQUEST']._serialized_start = 141
+    _globals['_CACHEINITREQUEST']._serialized_end = 209
+    _globals['_CACHEINITRESPONSE']._serialized_start = 211
+    _globals['_CACHEINITRESPONSE']._serialized_end = 279
+    _globals['_CACHEGETREQUEST']._serialized_start = 281
+    _globals['_CACHEGETREQUEST']._serialized_end = 311
+    _globals['_CACHEGETRESPONSE']._serialized_start = 313
+    _globals['_CACHEGETRESPONSE']._serialized_end = 395
+    _globals['_CACHESETREQUEST']._serialized_start = 397
+    _globals['_CACHESETREQUEST']._serialized_end = 482
+    _globals['_CACHESETRESPONSE']._serialized_start = 484
+    _globals['_CACHESETRESPONSE']._serialized_end = 551
+    _globals['_CACHEADDREQUEST']._serialized_start = 553
+    _globals['_CACHEADDREQUEST']._serialized_end = 638
+    _globals['_CACHEADDRESPONSE']._serialized_start = 640
+    _globals['_CACHEADDRESPONSE']._serialized_end = 707
+    _globals['_CACHEDELETEREQUEST']._serialized_start = 709
+    _globals['_CACHEDELETEREQUEST']._serialized_end = 742
+    _globals['_CACHEDELETERESPONSE']._serialized_start = 744
+    _globals['_CACHEDELETERESPONSE']._serialized_end = 814
+    _globals['_CACHECLOSEREQUEST']._serialized_start = 816
+    _globals['_CACHECLOSEREQUEST']._serialized_end = 835
+    _globals['_CACHECLOSERESPONSE']._serialized_start = 837
+    _globals['_CACHECLOSERESPONSE']._serialized_end = 906
+    _globals['_CACHESERVICE']._serialized_start = 909
+    _globals['_CACHESERVICE']._serialized_end = 1529
\ No newline at end of file
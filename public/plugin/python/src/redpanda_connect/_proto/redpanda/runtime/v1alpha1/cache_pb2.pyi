@@ -0,0 +1,254 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2025 Redpanda Data, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import builtins
+import google.protobuf.descriptor
+import google.protobuf.duration_pb2
+import google.protobuf.message
+from .... import redpanda
+import typing
+DESCRIPTOR: google.protobuf.descriptor.FileDescriptor
+
+@typing.final
+class CacheInitRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    CONFIG_FIELD_NUMBER: builtins.int
+
+    @property
+    def config(self) -> redpanda.runtime.v1alpha1.message_pb2.Value:
+        """The parsed configuration from the user based on the register schema in
+        `plugin.yaml`.
+        """
+
+    def __init__(self, *, config: redpanda.runtime.v1alpha1.message_pb2.Value | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['config', b'config']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['config', b'config']) -> None:
+        ...
+global___CacheInitRequest = CacheInitRequest
+
+@typing.final
+class CacheInitResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the cache configuration is invalid and an error should be
+        surfaced at pipeline construction time.
+        """
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___CacheInitResponse = CacheInitResponse
+
+@typing.final
+class CacheGetRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    KEY_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    'The key of the item to fetch.'
+
+    def __init__(self, *, key: builtins.str=...) -> None:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['key', b'key']) -> None:
+        ...
+global___CacheGetRequest = CacheGetRequest
+
+@typing.final
+class CacheGetResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    VALUE_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
+    value: builtins.bytes
+    'The value of the item.'
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the get attempt failed."""
+
+    def __init__(self, *, value: builtins.bytes=..., error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error', 'value', b'value']) -> None:
+        ...
+global___CacheGetResponse = CacheGetResponse
+
+@typing.final
+class CacheSetRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    KEY_FIELD_NUMBER: builtins.int
+    VALUE_FIELD_NUMBER: builtins.int
+    TTL_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    'The key of the item to set.'
+    value: builtins.bytes
+    'The value of the item to set.'
+
+    @property
+    def ttl(self) -> google.protobuf.duration_pb2.Duration:
+        """An optional time to live for the item, if omitted then the default TTL
+        of the cache (if any) should be used.
+        """
+
+    def __init__(self, *, key: builtins.str=..., value: builtins.bytes=..., ttl: google.protobuf.duration_pb2.Duration | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['ttl', b'ttl']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['key', b'key', 'ttl', b'ttl', 'value', b'value']) -> None:
+        ...
+global___CacheSetRequest = CacheSetRequest
+
+@typing.final
+class CacheSetResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the set attempt failed."""
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___CacheSetResponse = CacheSetResponse
+
+@typing.final
+class CacheAddRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    KEY_FIELD_NUMBER: builtins.int
+    VALUE_FIELD_NUMBER: builtins.int
+    TTL_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    'The key of the item to add.'
+    value: builtins.bytes
+    'The value of the item to add.'
+
+    @property
+    def ttl(self) -> google.protobuf.duration_pb2.Duration:
+        """An optional time to live for the item, if omitted then the default TTL
+        of the cache (if any) should be used.
+        """
+
+    def __init__(self, *, key: builtins.str=..., value: builtins.bytes=..., ttl: google.protobuf.duration_pb2.Duration | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['ttl', b'ttl']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['key', b'key', 'ttl', b'ttl', 'value', b'value']) -> None:
+        ...
+global___CacheAddRequest = CacheAddRequest
+
+@typing.final
+class CacheAddResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the add attempt failed."""
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___CacheAddResponse = CacheAddResponse
+
+@typing.final
+class CacheDeleteRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    KEY_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    'The key of the item to delete.'
+
+    def __init__(self, *, key: builtins.str=...) -> None:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['key', b'key']) -> None:
+        ...
+global___CacheDeleteRequest = CacheDeleteRequest
+
+@typing.final
+class CacheDeleteResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the delete attempt failed."""
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___CacheDeleteResponse = CacheDeleteResponse
+
+@typing.final
+class CacheCloseRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    def __init__(self) -> None:
+        ...
+global___CacheCloseRequest = CacheCloseRequest
+
+@typing.final
+class CacheCloseResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the close attempt failed."""
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___CacheCloseResponse = CacheCloseResponse
\ No newline at end of file
@@ -0,0 +1,127 @@
+"""Client and server classes corresponding to protobuf-defined services."""
+import grpc
+import warnings
+from ....redpanda.runtime.v1alpha1 import cache_pb2 as redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2
+GRPC_GENERATED_VERSION = '1.71.0'
+GRPC_VERSION = grpc.__version__
+_version_not_supported = False
+try:
+    from grpc._utilities import first_version_is_lower
+    _version_not_supported = first_version_is_lower(GRPC_VERSION, GRPC_GENERATED_VERSION)
+except ImportError:
+    _version_not_supported = True
+if _version_not_supported:
+    raise RuntimeError(f'The grpc package installed is at version {GRPC_VERSION},' + f' but the generated code in redpanda/runtime/v1alpha1/cache_pb2_grpc.py depends on' + f' grpcio>={GRPC_GENERATED_VERSION}.' + f' Please upgrade your grpc module to grpcio>={GRPC_GENERATED_VERSION}' + f' or downgrade your generated code using grpcio-tools<={GRPC_VERSION}.')
+
+class CacheServiceStub(object):
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+
+    def __init__(self, channel):
+        """Constructor.
+
+        Args:
+            channel: A grpc.Channel.
+        """
+        self.Init = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Init', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitResponse.FromString, _registered_method=True)
+        self.Get = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Get', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetResponse.FromString, _registered_method=True)
+        self.Set = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Set', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetResponse.FromString, _registered_method=True)
+        self.Add = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Add', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddResponse.FromString, _registered_method=True)
+        self.Delete = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Delete', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteResponse.FromString, _registered_method=True)
+        self.Close = channel.unary_unary('/redpanda.runtime.v1alpha1.CacheService/Close', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseResponse.FromString, _registered_method=True)
+
+class CacheServiceServicer(object):
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+
+    def Init(self, request, context):
+        """Init is the first method called for a cache and it passes the user's
+        configuration to the cache.
+
+        The schema for the cache configuration is specified in the `plugin.yaml`
+        file provided to Redpanda Connect.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Get(self, request, context):
+        """Get a cache item by key. If the key does not exist then
+        Error.KeyNotFound should be returned.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Set(self, request, context):
+        """Set a cache item, overwriting any existing value for the key."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Add(self, request, context):
+        """Add a cache item only if the key does not already exist. If the key
+        already exists then Error.KeyAlreadyExists should be returned.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Delete(self, request, context):
+        """Delete a cache item by key. Deleting a key that does not exist should
+        not return an error.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Close(self, request, context):
+        """Close the component, blocks until either the underlying resources are
+        cleaned up or the RPC deadline is reached.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+def add_CacheServiceServicer_to_server(servicer, server):
+    rpc_method_handlers = {'Init': grpc.unary_unary_rpc_method_handler(servicer.Init, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitResponse.SerializeToString), 'Get': grpc.unary_unary_rpc_method_handler(servicer.Get, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetResponse.SerializeToString), 'Set': grpc.unary_unary_rpc_method_handler(servicer.Set, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetResponse.SerializeToString), 'Add': grpc.unary_unary_rpc_method_handler(servicer.Add, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddResponse.SerializeToString), 'Delete': grpc.unary_unary_rpc_method_handler(servicer.Delete, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteResponse.SerializeToString), 'Close': grpc.unary_unary_rpc_method_handler(servicer.Close, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseResponse.SerializeToString)}
+    generic_handler = grpc.method_handlers_generic_handler('redpanda.runtime.v1alpha1.CacheService', rpc_method_handlers)
+    server.add_generic_rpc_handlers((generic_handler,))
+    server.add_registered_method_handlers('redpanda.runtime.v1alpha1.CacheService', rpc_method_handlers)
+
+class CacheService(object):
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+
+    @staticmethod
+    def Init(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Init', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheInitResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Get(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Get', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheGetResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Set(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Set', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheSetResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Add(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Add', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheAddResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Delete(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Delete', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheDeleteResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Close(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.CacheService/Close', redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_cache__pb2.CacheCloseResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
\ No newline at end of file
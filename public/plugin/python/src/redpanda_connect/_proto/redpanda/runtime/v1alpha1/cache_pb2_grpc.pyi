@@ -0,0 +1,118 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2025 Redpanda Data, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import abc
+import collections.abc
+import grpc
+import grpc.aio
+from .... import redpanda
+import typing
+_T = typing.TypeVar('_T')
+
+class _MaybeAsyncIterator(collections.abc.AsyncIterator[_T], collections.abc.Iterator[_T], metaclass=abc.ABCMeta):
+    ...
+
+class _ServicerContext(grpc.ServicerContext, grpc.aio.ServicerContext):
+    ...
+
+class CacheServiceStub:
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+
+    def __init__(self, channel: typing.Union[grpc.Channel, grpc.aio.Channel]) -> None:
+        ...
+    Init: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheInitRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheInitResponse]
+    "Init is the first method called for a cache and it passes the user's\n    configuration to the cache.\n\n    The schema for the cache configuration is specified in the `plugin.yaml`\n    file provided to Redpanda Connect.\n    "
+    Get: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheGetRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheGetResponse]
+    'Get a cache item by key. If the key does not exist then\n    Error.KeyNotFound should be returned.\n    '
+    Set: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheSetRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheSetResponse]
+    'Set a cache item, overwriting any existing value for the key.'
+    Add: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheAddRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheAddResponse]
+    'Add a cache item only if the key does not already exist. If the key\n    already exists then Error.KeyAlreadyExists should be returned.\n    '
+    Delete: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteResponse]
+    'Delete a cache item by key. Deleting a key that does not exist should\n    not return an error.\n    '
+    Close: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheCloseRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheCloseResponse]
+    'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
+
+class CacheServiceAsyncStub:
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+    Init: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheInitRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheInitResponse]
+    "Init is the first method called for a cache and it passes the user's\n    configuration to the cache.\n\n    The schema for the cache configuration is specified in the `plugin.yaml`\n    file provided to Redpanda Connect.\n    "
+    Get: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheGetRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheGetResponse]
+    'Get a cache item by key. If the key does not exist then\n    Error.KeyNotFound should be returned.\n    '
+    Set: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheSetRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheSetResponse]
+    'Set a cache item, overwriting any existing value for the key.'
+    Add: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheAddRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheAddResponse]
+    'Add a cache item only if the key does not already exist. If the key\n    already exists then Error.KeyAlreadyExists should be returned.\n    '
+    Delete: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteResponse]
+    'Delete a cache item by key. Deleting a key that does not exist should\n    not return an error.\n    '
+    Close: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.cache_pb2.CacheCloseRequest, redpanda.runtime.v1alpha1.cache_pb2.CacheCloseResponse]
+    'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
+
+class CacheServiceServicer(metaclass=abc.ABCMeta):
+    """Cache is a key/value store that can be used by Redpanda Connect components
+    for applications such as deduplication or data joins.
+
+    All methods of a cache may be called concurrently.
+    """
+
+    @abc.abstractmethod
+    def Init(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheInitRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheInitResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheInitResponse]]:
+        """Init is the first method called for a cache and it passes the user's
+        configuration to the cache.
+
+        The schema for the cache configuration is specified in the `plugin.yaml`
+        file provided to Redpanda Connect.
+        """
+
+    @abc.abstractmethod
+    def Get(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheGetRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheGetResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheGetResponse]]:
+        """Get a cache item by key. If the key does not exist then
+        Error.KeyNotFound should be returned.
+        """
+
+    @abc.abstractmethod
+    def Set(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheSetRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheSetResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheSetResponse]]:
+        """Set a cache item, overwriting any existing value for the key."""
+
+    @abc.abstractmethod
+    def Add(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheAddRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheAddResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheAddResponse]]:
+        """Add a cache item only if the key does not already exist. If the key
+        already exists then Error.KeyAlreadyExists should be returned.
+        """
+
+    @abc.abstractmethod
+    def Delete(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheDeleteResponse]]:
+        """Delete a cache item by key. Deleting a key that does not exist should
+        not return an error.
+        """
+
+    @abc.abstractmethod
+    def Close(self, request: redpanda.runtime.v1alpha1.cache_pb2.CacheCloseRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.cache_pb2.CacheCloseResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.cache_pb2.CacheCloseResponse]]:
+        """Close the component, blocks until either the underlying resources are
+        cleaned up or the RPC deadline is reached.
+        """
+
+def add_CacheServiceServicer_to_server(servicer: CacheServiceServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None:
+    ...
\ No newline at end of file
@@ -175,12 +175,32 @@ class Error(google.protobuf.message.Message):
         """
         DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+        def __init__(self) -> None:
+            ...
+
+    @typing.final
+    class KeyNotFound(google.protobuf.message.Message):
+        """KeyNotFound is returned by caches when a requested key does not exist."""
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        def __init__(self) -> None:
+            ...
+
+    @typing.final
+    class KeyAlreadyExists(google.protobuf.message.Message):
+        """KeyAlreadyExists is returned by caches when an add operation targets a
+        key that already exists.
+        """
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
         def __init__(self) -> None:
             ...
     MESSAGE_FIELD_NUMBER: builtins.int
     BACKOFF_FIELD_NUMBER: builtins.int
     NOT_CONNECTED_FIELD_NUMBER: builtins.int
     END_OF_INPUT_FIELD_NUMBER: builtins.int
+    KEY_NOT_FOUND_FIELD_NUMBER: builtins.int
+    KEY_ALREADY_EXISTS_FIELD_NUMBER: builtins.int
     message: builtins.str
     'The error message. If non empty, then the error is valid and\n    if empty the error is ignored as if a success (due to proto3 empty\n    semantics).\n    '
 
@@ -201,16 +221,24 @@ class Error(google.protobuf.message.Message):
     def end_of_input(self) -> global___Error.EndOfInput:
         ...
 
-    def __init__(self, *, message: builtins.str=..., backoff: google.protobuf.duration_pb2.Duration | None=..., not_connected: global___Error.NotConnected | None=..., end_of_input: global___Error.EndOfInput | None=...) -> None:
+    @property
+    def key_not_found(self) -> global___Error.KeyNotFound:
+        ...
+
+    @property
+    def key_already_exists(self) -> global___Error.KeyAlreadyExists:
+        ...
+
+    def __init__(self, *, message: builtins.str=..., backoff: google.protobuf.duration_pb2.Duration | None=..., not_connected: global___Error.NotConnected | None=..., end_of_input: global___Error.EndOfInput | None=..., key_not_found: global___Error.KeyNotFound | None=..., key_already_exists: global___Error.KeyAlreadyExists | None=...) -> None:
         ...
 
-    def HasField(self, field_name: typing.Literal['backoff', b'backoff', 'detail', b'detail', 'end_of_input', b'end_of_input', 'not_connected', b'not_connected']) -> builtins.bool:
+    def HasField(self, field_name: typing.Literal['backoff', b'backoff', 'detail', b'detail', 'end_of_input', b'end_of_input', 'key_already_exists', b'key_already_exists', 'key_not_found', b'key_not_found', 'not_connected', b'not_connected']) -> builtins.bool:
         ...
 
-    def ClearField(self, field_name: typing.Literal['backoff', b'backoff', 'detail', b'detail', 'end_of_input', b'end_of_input', 'message', b'message', 'not_connected', b'not_connected']) -> None:
+    def ClearField(self, field_name: typing.Literal['backoff', b'backoff', 'detail', b'detail', 'end_of_input', b'end_of_input', 'key_already_exists', b'key_already_exists', 'key_not_found', b'key_not_found', 'message', b'message', 'not_connected', b'not_connected']) -> None:
         ...
 
-    def WhichOneof(self, oneof_group: typing.Literal['detail', b'detail']) -> typing.Literal['backoff', 'not_connected', 'end_of_input'] | None:
+    def WhichOneof(self, oneof_group: typing.Literal['detail', b'detail']) -> typing.Literal['backoff', 'not_connected', 'end_of_input', 'key_not_found', 'key_already_exists'] | None:
         ...
 global___Error = Error
 
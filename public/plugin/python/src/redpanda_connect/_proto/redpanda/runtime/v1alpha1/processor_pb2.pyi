@@ -47,6 +47,7 @@ global___BatchProcessorInitRequest = BatchProcessorInitRequest
 class BatchProcessorInitResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
     ERROR_FIELD_NUMBER: builtins.int
+    CAPABILITIES_FIELD_NUMBER: builtins.int
 
     @property
     def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
@@ -54,16 +55,39 @@ class BatchProcessorInitResponse(google.protobuf.message.Message):
         surfaced at pipeline construction time.
         """
 
-    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+    @property
+    def capabilities(self) -> global___BatchProcessorCapabilities:
+        """The optional capabilities of the plugin. Plugins that leave this unset
+        will only be called using the unary ProcessBatch method.
+        """
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=..., capabilities: global___BatchProcessorCapabilities | None=...) -> None:
         ...
 
-    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+    def HasField(self, field_name: typing.Literal['capabilities', b'capabilities', 'error', b'error']) -> builtins.bool:
         ...
 
-    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+    def ClearField(self, field_name: typing.Literal['capabilities', b'capabilities', 'error', b'error']) -> None:
         ...
 global___BatchProcessorInitResponse = BatchProcessorInitResponse
 
+@typing.final
+class BatchProcessorCapabilities(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    STREAMING_FIELD_NUMBER: builtins.int
+    MAX_IN_FLIGHT_FIELD_NUMBER: builtins.int
+    streaming: builtins.bool
+    'If true, then the plugin implements ProcessBatchStream.'
+    max_in_flight: builtins.int
+    'The maximum number of batches that the host may have in flight on a\n    single ProcessBatchStream call. If zero, then the host picks a default.\n    '
+
+    def __init__(self, *, streaming: builtins.bool=..., max_in_flight: builtins.int=...) -> None:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['max_in_flight', b'max_in_flight', 'streaming', b'streaming']) -> None:
+        ...
+global___BatchProcessorCapabilities = BatchProcessorCapabilities
+
 @typing.final
 class BatchProcessorProcessBatchRequest(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
@@ -109,6 +133,57 @@ class BatchProcessorProcessBatchResponse(google.protobuf.message.Message):
         ...
 global___BatchProcessorProcessBatchResponse = BatchProcessorProcessBatchResponse
 
+@typing.final
+class BatchProcessorProcessBatchStreamRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ID_FIELD_NUMBER: builtins.int
+    BATCH_FIELD_NUMBER: builtins.int
+    id: builtins.int
+    'An identifier chosen by the host, which is unique amongst the requests\n    that are in flight on the stream.\n    '
+
+    @property
+    def batch(self) -> redpanda.runtime.v1alpha1.message_pb2.MessageBatch:
+        """The input batch to the processor."""
+
+    def __init__(self, *, id: builtins.int=..., batch: redpanda.runtime.v1alpha1.message_pb2.MessageBatch | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['batch', b'batch']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['batch', b'batch', 'id', b'id']) -> None:
+        ...
+global___BatchProcessorProcessBatchStreamRequest = BatchProcessorProcessBatchStreamRequest
+
+@typing.final
+class BatchProcessorProcessBatchStreamResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ID_FIELD_NUMBER: builtins.int
+    BATCHES_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
+    id: builtins.int
+    'The identifier of the request that this response corresponds to.'
+
+    @property
+    def batches(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[redpanda.runtime.v1alpha1.message_pb2.MessageBatch]:
+        """The resulting batch of messages. Returning multiple batches allows
+        for splitting a single batch into multiple batches.
+        """
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the processing failed."""
+
+    def __init__(self, *, id: builtins.int=..., batches: collections.abc.Iterable[redpanda.runtime.v1alpha1.message_pb2.MessageBatch] | None=..., error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['batches', b'batches', 'error', b'error', 'id', b'id']) -> None:
+        ...
+global___BatchProcessorProcessBatchStreamResponse = BatchProcessorProcessBatchStreamResponse
+
 @typing.final
 class BatchProcessorCloseRequest(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
@@ -30,6 +30,7 @@ class BatchProcessorServiceStub(object):
         """
         self.Init = channel.unary_unary('/redpanda.runtime.v1alpha1.BatchProcessorService/Init', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitResponse.FromString, _registered_method=True)
         self.ProcessBatch = channel.unary_unary('/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatch', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchResponse.FromString, _registered_method=True)
+        self.ProcessBatchStream = channel.stream_stream('/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatchStream', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamResponse.FromString, _registered_method=True)
         self.Close = channel.unary_unary('/redpanda.runtime.v1alpha1.BatchProcessorService/Close', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseResponse.FromString, _registered_method=True)
 
 class BatchProcessorServiceServicer(object):
@@ -76,6 +77,21 @@ class BatchProcessorServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def ProcessBatchStream(self, request_iterator, context):
+        """ProcessBatchStream is a streaming variant of ProcessBatch that allows the
+        host to have multiple batches in flight with the plugin at once.
+
+        Each request carries an ID that must be echoed in the corresponding
+        response, responses may be sent in any order. The semantics of each
+        individual batch are the same as ProcessBatch.
+
+        This method is only used by the host if the plugin advertises support for
+        it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def Close(self, request, context):
         """Close the component, blocks until either the underlying resources are
         cleaned up or the RPC deadline is reached.
@@ -85,7 +101,7 @@ class BatchProcessorServiceServicer(object):
         raise NotImplementedError('Method not implemented!')
 
 def add_BatchProcessorServiceServicer_to_server(servicer, server):
-    rpc_method_handlers = {'Init': grpc.unary_unary_rpc_method_handler(servicer.Init, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitResponse.SerializeToString), 'ProcessBatch': grpc.unary_unary_rpc_method_handler(servicer.ProcessBatch, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchResponse.SerializeToString), 'Close': grpc.unary_unary_rpc_method_handler(servicer.Close, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseResponse.SerializeToString)}
+    rpc_method_handlers = {'Init': grpc.unary_unary_rpc_method_handler(servicer.Init, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorInitResponse.SerializeToString), 'ProcessBatch': grpc.unary_unary_rpc_method_handler(servicer.ProcessBatch, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchResponse.SerializeToString), 'ProcessBatchStream': grpc.stream_stream_rpc_method_handler(servicer.ProcessBatchStream, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamResponse.SerializeToString), 'Close': grpc.unary_unary_rpc_method_handler(servicer.Close, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseResponse.SerializeToString)}
     generic_handler = grpc.method_handlers_generic_handler('redpanda.runtime.v1alpha1.BatchProcessorService', rpc_method_handlers)
     server.add_generic_rpc_handlers((generic_handler,))
     server.add_registered_method_handlers('redpanda.runtime.v1alpha1.BatchProcessorService', rpc_method_handlers)
@@ -107,6 +123,10 @@ class BatchProcessorService(object):
     def ProcessBatch(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
         return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatch', redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
 
+    @staticmethod
+    def ProcessBatchStream(request_iterator, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.stream_stream(request_iterator, target, '/redpanda.runtime.v1alpha1.BatchProcessorService/ProcessBatchStream', redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorProcessBatchStreamResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
     @staticmethod
     def Close(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
         return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.BatchProcessorService/Close', redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_processor__pb2.BatchProcessorCloseResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
\ No newline at end of file
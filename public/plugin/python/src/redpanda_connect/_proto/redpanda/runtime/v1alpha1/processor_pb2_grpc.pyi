@@ -44,6 +44,8 @@ class BatchProcessorServiceStub:
     "Init is the first method called for a batch processor and it passes the\n    user's configuration to the input.\n\n    The schema for the processor configuration is specified in the\n    `plugin.yaml` file provided to Redpanda Connect.\n    "
     ProcessBatch: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchResponse]
     'Process a batch of messages into one or more resulting batches, or return\n    an error if the entire batch could not be processed. If zero messages are\n    returned and the error is nil then all messages are filtered.\n\n    The provided MessageBatch should NOT be modified, in order to return a\n    mutated batch a copy of the slice should be created instead.\n\n    When an error is returned all of the input messages will continue down\n    the pipeline but will be marked with the error with *message.SetError,\n    and metrics and logs will be emitted.\n\n    In order to add errors to individual messages of the batch for downstream\n    handling use message.SetError(err) and return it in the resulting batch\n    with a nil error.\n\n    The Message types returned MUST be derived from the provided messages,\n    and CANNOT be custom instantiations of Message. In order to copy the\n    provided messages use the Copy method.\n    '
+    ProcessBatchStream: grpc.StreamStreamMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamResponse]
+    'ProcessBatchStream is a streaming variant of ProcessBatch that allows the\n    host to have multiple batches in flight with the plugin at once.\n\n    Each request carries an ID that must be echoed in the corresponding\n    response, responses may be sent in any order. The semantics of each\n    individual batch are the same as ProcessBatch.\n\n    This method is only used by the host if the plugin advertises support for\n    it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.\n    '
     Close: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseResponse]
     'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
 
@@ -59,6 +61,8 @@ class BatchProcessorServiceAsyncStub:
     "Init is the first method called for a batch processor and it passes the\n    user's configuration to the input.\n\n    The schema for the processor configuration is specified in the\n    `plugin.yaml` file provided to Redpanda Connect.\n    "
     ProcessBatch: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchResponse]
     'Process a batch of messages into one or more resulting batches, or return\n    an error if the entire batch could not be processed. If zero messages are\n    returned and the error is nil then all messages are filtered.\n\n    The provided MessageBatch should NOT be modified, in order to return a\n    mutated batch a copy of the slice should be created instead.\n\n    When an error is returned all of the input messages will continue down\n    the pipeline but will be marked with the error with *message.SetError,\n    and metrics and logs will be emitted.\n\n    In order to add errors to individual messages of the batch for downstream\n    handling use message.SetError(err) and return it in the resulting batch\n    with a nil error.\n\n    The Message types returned MUST be derived from the provided messages,\n    and CANNOT be custom instantiations of Message. In order to copy the\n    provided messages use the Copy method.\n    '
+    ProcessBatchStream: grpc.aio.StreamStreamMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamResponse]
+    'ProcessBatchStream is a streaming variant of ProcessBatch that allows the\n    host to have multiple batches in flight with the plugin at once.\n\n    Each request carries an ID that must be echoed in the corresponding\n    response, responses may be sent in any order. The semantics of each\n    individual batch are the same as ProcessBatch.\n\n    This method is only used by the host if the plugin advertises support for\n    it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.\n    '
     Close: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseRequest, redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseResponse]
     'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
 
@@ -102,6 +106,19 @@ class BatchProcessorServiceServicer(metaclass=abc.ABCMeta):
         provided messages use the Copy method.
         """
 
+    @abc.abstractmethod
+    def ProcessBatchStream(self, request_iterator: _MaybeAsyncIterator[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamRequest], context: _ServicerContext) -> typing.Union[collections.abc.Iterator[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamResponse], collections.abc.AsyncIterator[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorProcessBatchStreamResponse]]:
+        """ProcessBatchStream is a streaming variant of ProcessBatch that allows the
+        host to have multiple batches in flight with the plugin at once.
+
+        Each request carries an ID that must be echoed in the corresponding
+        response, responses may be sent in any order. The semantics of each
+        individual batch are the same as ProcessBatch.
+
+        This method is only used by the host if the plugin advertises support for
+        it within the BatchProcessorInitResponse, otherwise ProcessBatch is used.
+        """
+
     @abc.abstractmethod
     def Close(self, request: redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.processor_pb2.BatchProcessorCloseResponse]]:
         """Close the component, blocks until either the underlying resources are
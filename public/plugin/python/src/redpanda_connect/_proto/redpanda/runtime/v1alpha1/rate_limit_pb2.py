@@ -0,0 +1,31 @@
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(_runtime_version.Domain.PUBLIC, 5, 29, 0, '', 'redpanda/runtime/v1alpha1/rate_limit.proto')
+_sym_db = _symbol_database.Default()
+from google.protobuf import duration_pb2 as google_dot_protobuf_dot_duration__pb2
+from ....redpanda.runtime.v1alpha1 import message_pb2 as redpanda_dot_runtime_dot_v1alpha1_dot_message__pb2
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n*redpanda/runtime/v1alpha1/rate_limit.proto\x12\x19redpanda.runtime.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\'redpanda/runtime/v1alpha1/message.proto"H\n\x14RateLimitInitRequest\x120\n\x06config\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Value"H\n\x15RateLimitInitResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"\x18\n\x16RateLimitAccessRequest"s\n\x17RateLimitAccessResponse\x12\'\n\x04wait\x18\x01 \x01(\x0b2\x19.google.protobuf.Duration\x12/\n\x05error\x18\x02 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error"\x17\n\x15RateLimitCloseRequest"I\n\x16RateLimitCloseResponse\x12/\n\x05error\x18\x01 \x01(\x0b2 .redpanda.runtime.v1alpha1.Error2\xe2\x02\n\x10RateLimitService\x12k\n\x04Init\x12/.redpanda.runtime.v1alpha1.RateLimitInitRequest\x1a0.redpanda.runtime.v1alpha1.RateLimitInitResponse"\x00\x12q\n\x06Access\x121.redpanda.runtime.v1alpha1.RateLimitAccessRequest\x1a2.redpanda.runtime.v1alpha1.RateLimitAccessResponse"\x00\x12n\n\x05Close\x120.redpanda.runtime.v1alpha1.RateLimitCloseRequest\x1a1.redpanda.runtime.v1alpha1.RateLimitCloseResponse"\x00BBZ@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepbb\x06proto3')
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'redpanda.runtime.v1alpha1.rate_limit_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+    _globals['DESCRIPTOR']._loaded_options = None
+    _globals['DESCRIPTOR']._serialized_options = b'Z@github.com/redpanda-data/connect/v4/internal/rpcplugin/runtimepb'
+    _globals['_RATELIMITINITREQUEST']._serialized_start = 146
+    _globals['_RATELIMITINITREQUEST']._serialized_end = 218
+    _globals['_RATELIMITINITRESPONSE']._serialized_start = 220
+    _globals['_RATELIMITINITRESPONSE']._serialized_end = 292
+    _globals['_RATELIMITACCESSREQUEST']._serialized_start = 294
+    _globals['_RATELIMITACCESSREQUEST']._serialized_end = 318
+    _globals['_RATELIMITACCESSRESPONSE']._serialized_start = 320
+    _globals['_RATELIMITACCESSRESPONSE']._serialized_end = 435
+    _globals['_RATELIMITCLOSEREQUEST']._serialized_start = 437
+    _globals['_RATELIMITCLOSEREQUEST']._serialized_end = 460
+    _globals['_RATELIMITCLOSERESPONSE']._serialized_start = 462
+    _globals['_RATELIMITCLOSERESPONSE']._serialized_end = 535
+    _globals['_RATELIMITSERVICE']._serialized_start = 538
+    _globals['_RATELIMITSERVICE']._serialized_end = 892
\ No newline at end of file
@@ -0,0 +1,127 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2025 Redpanda Data, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import builtins
+import google.protobuf.descriptor
+import google.protobuf.duration_pb2
+import google.protobuf.message
+from .... import redpanda
+import typing
+DESCRIPTOR: google.protobuf.descriptor.FileDescriptor
+
+@typing.final
+class RateLimitInitRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    CONFIG_FIELD_NUMBER: builtins.int
+
+    @property
+    def config(self) -> redpanda.runtime.v1alpha1.message_pb2.Value:
+        """The parsed configuration from the user based on the register schema in
+        `plugin.yaml`.
+        """
+
+    def __init__(self, *, config: redpanda.runtime.v1alpha1.message_pb2.Value | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['config', b'config']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['config', b'config']) -> None:
+        ...
+global___RateLimitInitRequest = RateLimitInitRequest
+
+@typing.final
+class RateLimitInitResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the rate limit configuration is invalid and an error
+        should be surfaced at pipeline construction time.
+        """
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___RateLimitInitResponse = RateLimitInitResponse
+
+@typing.final
+class RateLimitAccessRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    def __init__(self) -> None:
+        ...
+global___RateLimitAccessRequest = RateLimitAccessRequest
+
+@typing.final
+class RateLimitAccessResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    WAIT_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def wait(self) -> google.protobuf.duration_pb2.Duration:
+        """The period of time to wait before the resource can be accessed. If zero
+        or absent then access is granted.
+        """
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the access attempt failed."""
+
+    def __init__(self, *, wait: google.protobuf.duration_pb2.Duration | None=..., error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error', 'wait', b'wait']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error', 'wait', b'wait']) -> None:
+        ...
+global___RateLimitAccessResponse = RateLimitAccessResponse
+
+@typing.final
+class RateLimitCloseRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    def __init__(self) -> None:
+        ...
+global___RateLimitCloseRequest = RateLimitCloseRequest
+
+@typing.final
+class RateLimitCloseResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    ERROR_FIELD_NUMBER: builtins.int
+
+    @property
+    def error(self) -> redpanda.runtime.v1alpha1.message_pb2.Error:
+        """If present, then the close attempt failed."""
+
+    def __init__(self, *, error: redpanda.runtime.v1alpha1.message_pb2.Error | None=...) -> None:
+        ...
+
+    def HasField(self, field_name: typing.Literal['error', b'error']) -> builtins.bool:
+        ...
+
+    def ClearField(self, field_name: typing.Literal['error', b'error']) -> None:
+        ...
+global___RateLimitCloseResponse = RateLimitCloseResponse
\ No newline at end of file
@@ -0,0 +1,91 @@
+"""Client and server classes corresponding to protobuf-defined services."""
+import grpc
+import warnings
+from ....redpanda.runtime.v1alpha1 import rate_limit_pb2 as redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2
+GRPC_GENERATED_VERSION = '1.71.0'
+GRPC_VERSION = grpc.__version__
+_version_not_supported = False
+try:
+    from grpc._utilities import first_version_is_lower
+    _version_not_supported = first_version_is_lower(GRPC_VERSION, GRPC_GENERATED_VERSION)
+except ImportError:
+    _version_not_supported = True
+if _version_not_supported:
+    raise RuntimeError(f'The grpc package installed is at version {GRPC_VERSION},' + f' but the generated code in redpanda/runtime/v1alpha1/rate_limit_pb2_grpc.py depends on' + f' grpcio>={GRPC_GENERATED_VERSION}.' + f' Please upgrade your grpc module to grpcio>={GRPC_GENERATED_VERSION}' + f' or downgrade your generated code using grpcio-tools<={GRPC_VERSION}.')
+
+class RateLimitServiceStub(object):
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+
+    def __init__(self, channel):
+        """Constructor.
+
+        Args:
+            channel: A grpc.Channel.
+        """
+        self.Init = channel.unary_unary('/redpanda.runtime.v1alpha1.RateLimitService/Init', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitResponse.FromString, _registered_method=True)
+        self.Access = channel.unary_unary('/redpanda.runtime.v1alpha1.RateLimitService/Access', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessResponse.FromString, _registered_method=True)
+        self.Close = channel.unary_unary('/redpanda.runtime.v1alpha1.RateLimitService/Close', request_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseRequest.SerializeToString, response_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseResponse.FromString, _registered_method=True)
+
+class RateLimitServiceServicer(object):
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+
+    def Init(self, request, context):
+        """Init is the first method called for a rate limit and it passes the user's
+        configuration to the rate limit.
+
+        The schema for the rate limit configuration is specified in the
+        `plugin.yaml` file provided to Redpanda Connect.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Access(self, request, context):
+        """Access the rate limited resource. Returns a duration that the caller must
+        wait before attempting access, a zero (or absent) duration indicates that
+        access is granted immediately.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Close(self, request, context):
+        """Close the component, blocks until either the underlying resources are
+        cleaned up or the RPC deadline is reached.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+def add_RateLimitServiceServicer_to_server(servicer, server):
+    rpc_method_handlers = {'Init': grpc.unary_unary_rpc_method_handler(servicer.Init, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitResponse.SerializeToString), 'Access': grpc.unary_unary_rpc_method_handler(servicer.Access, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessResponse.SerializeToString), 'Close': grpc.unary_unary_rpc_method_handler(servicer.Close, request_deserializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseRequest.FromString, response_serializer=redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseResponse.SerializeToString)}
+    generic_handler = grpc.method_handlers_generic_handler('redpanda.runtime.v1alpha1.RateLimitService', rpc_method_handlers)
+    server.add_generic_rpc_handlers((generic_handler,))
+    server.add_registered_method_handlers('redpanda.runtime.v1alpha1.RateLimitService', rpc_method_handlers)
+
+class RateLimitService(object):
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+
+    @staticmethod
+    def Init(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.RateLimitService/Init', redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitInitResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Access(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.RateLimitService/Access', redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitAccessResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
+
+    @staticmethod
+    def Close(request, target, options=(), channel_credentials=None, call_credentials=None, insecure=False, compression=None, wait_for_ready=None, timeout=None, metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/redpanda.runtime.v1alpha1.RateLimitService/Close', redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseRequest.SerializeToString, redpanda_dot_runtime_dot_v1alpha1_dot_rate__limit__pb2.RateLimitCloseResponse.FromString, options, channel_credentials, insecure, call_credentials, compression, wait_for_ready, timeout, metadata, _registered_method=True)
\ No newline at end of file
@@ -0,0 +1,91 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2025 Redpanda Data, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import abc
+import collections.abc
+import grpc
+import grpc.aio
+from .... import redpanda
+import typing
+_T = typing.TypeVar('_T')
+
+class _MaybeAsyncIterator(collections.abc.AsyncIterator[_T], collections.abc.Iterator[_T], metaclass=abc.ABCMeta):
+    ...
+
+class _ServicerContext(grpc.ServicerContext, grpc.aio.ServicerContext):
+    ...
+
+class RateLimitServiceStub:
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+
+    def __init__(self, channel: typing.Union[grpc.Channel, grpc.aio.Channel]) -> None:
+        ...
+    Init: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitResponse]
+    "Init is the first method called for a rate limit and it passes the user's\n    configuration to the rate limit.\n\n    The schema for the rate limit configuration is specified in the\n    `plugin.yaml` file provided to Redpanda Connect.\n    "
+    Access: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessResponse]
+    'Access the rate limited resource. Returns a duration that the caller must\n    wait before attempting access, a zero (or absent) duration indicates that\n    access is granted immediately.\n    '
+    Close: grpc.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseResponse]
+    'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
+
+class RateLimitServiceAsyncStub:
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+    Init: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitResponse]
+    "Init is the first method called for a rate limit and it passes the user's\n    configuration to the rate limit.\n\n    The schema for the rate limit configuration is specified in the\n    `plugin.yaml` file provided to Redpanda Connect.\n    "
+    Access: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessResponse]
+    'Access the rate limited resource. Returns a duration that the caller must\n    wait before attempting access, a zero (or absent) duration indicates that\n    access is granted immediately.\n    '
+    Close: grpc.aio.UnaryUnaryMultiCallable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseRequest, redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseResponse]
+    'Close the component, blocks until either the underlying resources are\n    cleaned up or the RPC deadline is reached.\n    '
+
+class RateLimitServiceServicer(metaclass=abc.ABCMeta):
+    """RateLimit is a strategy for limiting access to a shared resource, which can
+    be used by Redpanda Connect components in order to throttle their access.
+
+    The Access method may be called concurrently.
+    """
+
+    @abc.abstractmethod
+    def Init(self, request: redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitInitResponse]]:
+        """Init is the first method called for a rate limit and it passes the user's
+        configuration to the rate limit.
+
+        The schema for the rate limit configuration is specified in the
+        `plugin.yaml` file provided to Redpanda Connect.
+        """
+
+    @abc.abstractmethod
+    def Access(self, request: redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitAccessResponse]]:
+        """Access the rate limited resource. Returns a duration that the caller must
+        wait before attempting access, a zero (or absent) duration indicates that
+        access is granted immediately.
+        """
+
+    @abc.abstractmethod
+    def Close(self, request: redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseRequest, context: _ServicerContext) -> typing.Union[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseResponse, collections.abc.Awaitable[redpanda.runtime.v1alpha1.rate_limit_pb2.RateLimitCloseResponse]]:
+        """Close the component, blocks until either the underlying resources are
+        cleaned up or the RPC deadline is reached.
+        """
+
+def add_RateLimitServiceServicer_to_server(servicer: RateLimitServiceServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None:
+    ...
\ No newline at end of file
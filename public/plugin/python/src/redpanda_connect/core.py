@@ -387,3 +387,76 @@ def output(max_in_flight: int = 1) -> Callable[[OutputFunc], OutputConstructor]:
         return batching_output(inner_wrapped)
 
     return wrapped
+
+
+class Cache(Protocol):
+    """
+    A cache is a key/value store that can be used by other components for applications
+    such as deduplication or data joins.
+    """
+
+    async def get(self, key: str) -> bytes:
+        """
+        Get an item from the cache by key. If the key does not exist then a
+        KeyNotFoundError should be raised.
+        """
+        ...
+
+    async def set(self, key: str, value: bytes, ttl: timedelta | None) -> None:
+        """
+        Set an item in the cache, overwriting any existing value. The ttl is optional
+        and when None the default TTL of the cache (if any) should be used.
+        """
+        ...
+
+    async def add(self, key: str, value: bytes, ttl: timedelta | None) -> None:
+        """
+        Add an item to the cache only if the key does not already exist. If the key
+        already exists then a KeyAlreadyExistsError should be raised.
+        """
+        ...
+
+    async def delete(self, key: str) -> None:
+        """
+        Delete an item from the cache by key.
+        """
+        ...
+
+    async def close(self) -> None:
+        """
+        Close the cache and frees up any resources.
+        """
+        ...
+
+
+CacheConstructor: TypeAlias = Callable[[Value], Cache]
+"""
+A cache constructor recieves the configuration specified in the configuration,
+then returns a properly configured cache component.
+"""
+
+
+class RateLimit(Protocol):
+    """
+    A rate limit is a strategy for limiting access to a shared resource.
+    """
+
+    async def access(self) -> timedelta:
+        """
+        Access the rate limited resource, returning the duration that the caller must
+        wait before attempting access. A zero duration means access is granted.
+        """
+        ...
+
+    async def close(self) -> None:
+        """
+        Close the rate limit and frees up any resources.
+        """
+        ...
+
+
+RateLimitConstructor: TypeAlias = Callable[[Value], RateLimit]
+"""
+A rate limit constructor recieves the configuration specified in the configuration,
+then returns a properly configured rate limit component.
+"""
@@ -50,3 +50,17 @@ class EndOfInputError(BaseError):
 
     def __init__(self) -> None:
         super().__init__("End of input reached.")
+
+
+class KeyNotFoundError(BaseError):
+    """Raised by caches when a key does not exist."""
+
+    def __init__(self) -> None:
+        super().__init__("Key does not exist.")
+
+
+class KeyAlreadyExistsError(BaseError):
+    """Raised by caches when adding a key that already exists."""
+
+    def __init__(self) -> None:
+        super().__init__("Key already exists.")